@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/plan"
+)
+
+// planRunner performs plan steps; tests replace it.
+var planRunner plan.Runner = plan.BD{}
+
+// runApply implements `mg apply PLAN`, applying a declarative change plan
+// step by step. Progress is journaled beside the plan so a failed run can be
+// continued with --resume or undone with --rollback. Exit status is 1 when a
+// step fails and 2 on usage, parse or validation failure.
+func runApply(args []string, stdout, stderr io.Writer) int {
+	var file string
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		file, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	dryRun := fs.Bool("dry-run", false, "Print the changes without applying them")
+	resume := fs.Bool("resume", false, "Continue a failed run from its journal")
+	rollback := fs.Bool("rollback", false, "Undo the steps recorded in the journal")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if file == "" && fs.NArg() == 1 {
+		file = fs.Arg(0)
+	}
+	if file == "" || (*resume && *rollback) {
+		fmt.Fprintf(stderr, "usage: mg apply PLAN.yaml [--dry-run | --resume | --rollback]\n")
+		return 2
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	journalPath := plan.JournalPath(file)
+	save := func(j *plan.Journal) error { return j.Save(journalPath) }
+	journal, err := plan.LoadJournal(journalPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if *rollback {
+		if journal == nil {
+			fmt.Fprintf(stderr, "Error: no journal at %s\n", journalPath)
+			return 2
+		}
+		warnings, err := journal.Rollback(planRunner, save, func(s plan.Step) {
+			fmt.Fprintf(stdout, "undone  %s\n", s.Diff)
+		})
+		for _, w := range warnings {
+			fmt.Fprintf(stdout, "warning %s\n", w)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, "Rolled back.")
+		return 0
+	}
+
+	if journal != nil {
+		switch {
+		case journal.RolledBack:
+			fmt.Fprintf(stderr, "Error: %s records a rolled-back run; delete it to apply the plan again\n", journalPath)
+			return 2
+		case journal.Complete():
+			fmt.Fprintf(stderr, "Error: plan already applied (%s); use --rollback to undo it, or delete the journal to apply again\n", journalPath)
+			return 2
+		case !*resume && !*dryRun:
+			fmt.Fprintf(stderr, "Error: %s has %d of %d steps left from a previous run; use --resume or --rollback\n",
+				journalPath, journal.Pending(), len(journal.Steps))
+			return 2
+		case journal.Hash != plan.HashPlan(raw):
+			fmt.Fprintf(stderr, "Error: %s changed since the journal was written; roll back or delete the journal first\n", file)
+			return 2
+		}
+		if *dryRun {
+			writePlanSteps(stdout, journal.Steps)
+			fmt.Fprintf(stdout, "\n%d of %d steps left. Re-run with --resume to apply them.\n", journal.Pending(), len(journal.Steps))
+			return 0
+		}
+		return runPlan(journal, save, journalPath, stdout, stderr)
+	}
+	if *resume {
+		fmt.Fprintf(stderr, "Error: no journal at %s\n", journalPath)
+		return 2
+	}
+
+	p, err := plan.Parse(bytes.NewReader(raw))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error reading %s: %v\n", file, err)
+		return 2
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 2
+	}
+	source := resolveSource(cwd, *path)
+	if source.Mode == SourceJSONL && source.Path == "" {
+		fmt.Fprintf(stderr, "No .beads/issues.jsonl found and bd not on PATH.\n")
+		return 2
+	}
+	applyConfig(loadConfig(source.ProjectDir, fs, stderr))
+	issues, _, err := loadIssues(source)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading issues: %v\n", err)
+		return 2
+	}
+	return applyPlan(file, raw, p, issues, *dryRun, stdout, stderr)
+}
+
+// applyPlan validates and compiles p against issues, then prints the diff
+// (dry run) or starts a journaled run.
+func applyPlan(file string, raw []byte, p *plan.Plan, issues []data.Issue, dryRun bool, stdout, stderr io.Writer) int {
+	existing := make(map[string]*data.Issue, len(issues))
+	for i := range issues {
+		existing[issues[i].ID] = &issues[i]
+	}
+	if err := p.Validate(existing); err != nil {
+		fmt.Fprintf(stderr, "Invalid plan %s:\n%v\n", file, err)
+		return 2
+	}
+	steps := plan.Compile(p, existing)
+	if len(steps) == 0 {
+		fmt.Fprintln(stdout, "Nothing to change.")
+		return 0
+	}
+	if dryRun {
+		writePlanSteps(stdout, steps)
+		fmt.Fprintf(stdout, "\n%d change(s). Re-run without --dry-run to apply them.\n", len(steps))
+		return 0
+	}
+
+	journalPath := plan.JournalPath(file)
+	journal := plan.NewJournal(file, raw, p, steps)
+	save := func(j *plan.Journal) error { return j.Save(journalPath) }
+	if err := save(journal); err != nil {
+		fmt.Fprintf(stderr, "Error writing journal: %v\n", err)
+		return 2
+	}
+	return runPlan(journal, save, journalPath, stdout, stderr)
+}
+
+func runPlan(journal *plan.Journal, save func(*plan.Journal) error, journalPath string, stdout, stderr io.Writer) int {
+	err := journal.Run(planRunner, save, func(s plan.Step) {
+		if s.Result != "" {
+			fmt.Fprintf(stdout, "done    %s -> %s\n", s.Diff, s.Result)
+		} else {
+			fmt.Fprintf(stdout, "done    %s\n", s.Diff)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		fmt.Fprintf(stderr, "%d step(s) left. Fix the problem and re-run with --resume, or undo with --rollback.\n", journal.Pending())
+		return 1
+	}
+	fmt.Fprintf(stdout, "Applied %d change(s). Journal: %s\n", len(journal.Steps), journalPath)
+	return 0
+}
+
+// writePlanSteps prints one diff line per step, marking those already run.
+func writePlanSteps(w io.Writer, steps []plan.Step) {
+	for _, s := range steps {
+		mark := "  "
+		if s.Done {
+			mark = "✓ "
+		}
+		fmt.Fprintln(w, mark+s.Diff)
+	}
+}
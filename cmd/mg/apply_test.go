@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/plan"
+)
+
+const testPlan = `
+create:
+  - alias: api
+    title: Build API
+update:
+  - id: mg-1
+    depends_on: [api]
+`
+
+// stubRunner hands out mg-100 for creates and fails on failOp.
+type stubRunner struct {
+	failOp plan.Op
+	calls  int
+}
+
+func (s *stubRunner) Do(a plan.Action) (string, error) {
+	s.calls++
+	if a.Op == s.failOp {
+		return "", errors.New("bd exploded")
+	}
+	if a.Op == plan.OpCreate {
+		return "mg-100", nil
+	}
+	return "", nil
+}
+
+func stubPlanRunner(t *testing.T, r plan.Runner) {
+	t.Helper()
+	orig := planRunner
+	planRunner = r
+	t.Cleanup(func() { planRunner = orig })
+}
+
+func writePlan(t *testing.T) (string, []byte, *plan.Plan) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(file, []byte(testPlan), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := plan.Parse(strings.NewReader(testPlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file, []byte(testPlan), p
+}
+
+func TestRunApplyUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no file", nil},
+		{"bad flag", []string{"plan.yaml", "--bogus"}},
+		{"resume and rollback", []string{"plan.yaml", "--resume", "--rollback"}},
+		{"missing file", []string{filepath.Join(t.TempDir(), "nope.yaml")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runApply(tt.args, &stdout, &stderr); code != 2 {
+				t.Errorf("runApply(%v) = %d, want 2", tt.args, code)
+			}
+			if stderr.Len() == 0 {
+				t.Error("expected a message on stderr")
+			}
+		})
+	}
+}
+
+func TestApplyPlanDryRun(t *testing.T) {
+	file, raw, p := writePlan(t)
+	r := &stubRunner{}
+	stubPlanRunner(t, r)
+	var stdout, stderr bytes.Buffer
+	code := applyPlan(file, raw, p, []data.Issue{{ID: "mg-1"}}, true, &stdout, &stderr)
+	if code != 0 || r.calls != 0 {
+		t.Fatalf("code = %d, calls = %d, stderr = %s", code, r.calls, stderr.String())
+	}
+	for _, want := range []string{`+ create api: "Build API" (task, P2)`, "+ mg-1 depends on api", "2 change(s)"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+	if _, err := os.Stat(plan.JournalPath(file)); !os.IsNotExist(err) {
+		t.Error("a dry run must not write a journal")
+	}
+}
+
+func TestApplyPlanInvalid(t *testing.T) {
+	file, raw, p := writePlan(t)
+	var stdout, stderr bytes.Buffer
+	if code := applyPlan(file, raw, p, nil, false, &stdout, &stderr); code != 2 {
+		t.Errorf("code = %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), "unknown issue mg-1") {
+		t.Errorf("stderr = %s", stderr.String())
+	}
+}
+
+func TestApplyPlanFailureThenResumeAndRollback(t *testing.T) {
+	file, raw, p := writePlan(t)
+	stubPlanRunner(t, &stubRunner{failOp: plan.OpAddDep})
+	var stdout, stderr bytes.Buffer
+	if code := applyPlan(file, raw, p, []data.Issue{{ID: "mg-1"}}, false, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "--resume") {
+		t.Errorf("stderr should suggest --resume: %s", stderr.String())
+	}
+
+	// Without --resume a leftover journal is refused.
+	stdout.Reset()
+	stderr.Reset()
+	if code := runApply([]string{file}, &stdout, &stderr); code != 2 {
+		t.Errorf("rerun without --resume = %d, want 2", code)
+	}
+
+	stubPlanRunner(t, &stubRunner{})
+	stdout.Reset()
+	if code := runApply([]string{file, "--resume"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("resume = %d, stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "done    + mg-1 depends on api") {
+		t.Errorf("resume output = %s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := runApply([]string{file, "--rollback"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("rollback = %d, stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "undone  + mg-1 depends on api") || !strings.Contains(stdout.String(), "Rolled back.") {
+		t.Errorf("rollback output = %s", stdout.String())
+	}
+}
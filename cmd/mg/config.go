@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/hooks"
+)
+
+// maxCmdTimeout caps cmd_timeout so a typo cannot hang mg for hours.
+const maxCmdTimeout = 300
+
+// loadConfig layers the config files, environment and any setting flags set
+// on fs. Problems are reported on stderr as warnings; mg keeps running with
+// the values that did load.
+func loadConfig(projectDir string, fs *flag.FlagSet, stderr io.Writer) config.Config {
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Warning: %v\n", err)
+	}
+	if fs != nil {
+		if err := cfg.ApplyFlags(fs); err != nil {
+			fmt.Fprintf(stderr, "Warning: %v\n", err)
+		}
+	}
+	for _, key := range cfg.Unknown {
+		fmt.Fprintf(stderr, "Warning: unknown config key %q ignored\n", key)
+	}
+	return cfg
+}
+
+// applyConfig pushes the effective timeouts, intervals and thresholds into
+// the packages that own them. Call it before any external command runs.
+func applyConfig(cfg config.Config) {
+	if cfg.Value(config.KeyCmdTimeout).Origin != config.OriginDefault {
+		timeout := min(cfg.Int(config.KeyCmdTimeout), maxCmdTimeout)
+		gastown.SetCmdTimeout(timeout)
+		data.SetCmdTimeout(timeout)
+		hooks.SetCmdTimeout(timeout)
+	}
+	data.SetTuning(data.Tuning{
+		WatchInterval:          cfg.Duration(config.KeyWatchInterval),
+		CLIPollInterval:        cfg.Duration(config.KeyCLIPollInterval),
+		CLIHealthCheckInterval: cfg.Duration(config.KeyCLIHealthCheckInterval),
+		JSONLMaxAge:            cfg.Duration(config.KeyJSONLMaxAge),
+		DegradeThreshold:       cfg.Int(config.KeyDegradeAfter),
+		RecoverThreshold:       cfg.Int(config.KeyRecoverAfter),
+		StaleAmber:             cfg.Duration(config.KeyStaleAmber),
+		StaleRed:               cfg.Duration(config.KeyStaleRed),
+	})
+	data.SetFocusUser(cfg.String(config.KeyFocusUser))
+}
+
+// runConfig implements `mg config show`.
+func runConfig(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "show" {
+		fmt.Fprintf(stderr, "usage: mg config show [--json] [--path FILE] [flags]\n")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	asJSON := fs.Bool("json", false, "Emit the effective config as JSON")
+	config.RegisterFlags(fs)
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 2
+	}
+	projectDir := resolveSource(cwd, *path).ProjectDir
+	if projectDir == "" {
+		projectDir = findBeadsDir(cwd)
+	}
+	cfg := loadConfig(projectDir, fs, stderr)
+
+	if *asJSON {
+		if err := writeConfigJSON(stdout, cfg); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+		return 0
+	}
+	writeConfigText(stdout, cfg)
+	return 0
+}
+
+// configEntry is one row of `mg config show`.
+type configEntry struct {
+	Key string `json:"key"`
+	config.Value
+}
+
+// configEntries lists every setting, then each hook and key binding
+// override, with its origin.
+func configEntries(cfg config.Config) []configEntry {
+	entries := make([]configEntry, 0, len(config.Settings)+len(cfg.Hooks)+len(cfg.Keys))
+	for _, s := range config.Settings {
+		entries = append(entries, configEntry{Key: s.Key, Value: cfg.Value(s.Key)})
+	}
+	events := make([]string, 0, len(cfg.Hooks))
+	for event := range cfg.Hooks {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		entries = append(entries, configEntry{Key: "hooks." + event, Value: cfg.HookValue(event)})
+	}
+	names := make([]string, 0, len(cfg.Keys))
+	for name := range cfg.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, configEntry{Key: "keys." + name, Value: cfg.KeyValue(name)})
+	}
+	return entries
+}
+
+func writeConfigText(w io.Writer, cfg config.Config) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+	for _, e := range configEntries(cfg) {
+		val := e.Raw
+		if val == "" {
+			val = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.Key, val, e.Label())
+	}
+	tw.Flush()
+}
+
+func writeConfigJSON(w io.Writer, cfg config.Config) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(configEntries(cfg))
+}
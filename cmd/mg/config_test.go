@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestBlockTypesDefaultMatchesData(t *testing.T) {
+	var want []string
+	for typ := range data.DefaultBlockingTypes {
+		want = append(want, typ)
+	}
+	sort.Strings(want)
+
+	var s config.Setting
+	for _, cand := range config.Settings {
+		if cand.Key == config.KeyBlockTypes {
+			s = cand
+		}
+	}
+	got := strings.Split(s.Default, ",")
+	sort.Strings(got)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("block_types default = %v, want %v", got, want)
+	}
+}
+
+func TestRunConfigShow(t *testing.T) {
+	for _, s := range config.Settings {
+		t.Setenv(s.Env, "")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("MG_CMD_TIMEOUT", "60")
+
+	dir := t.TempDir()
+	mustMkdir(t, filepath.Join(dir, ".beads"))
+	jsonl := filepath.Join(dir, ".beads", "issues.jsonl")
+	mustWrite(t, jsonl, nil)
+	mustWrite(t, filepath.Join(dir, config.ProjectFileName), []byte("layout: wide\nhooks:\n  issue_closed: echo done\n"))
+
+	var stdout, stderr bytes.Buffer
+	code := runConfig([]string{"show", "--path", jsonl, "--no-animations"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("runConfig() = %d, stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"layout", "wide", "project (" + filepath.Join(dir, config.ProjectFileName) + ")",
+		"env (MG_CMD_TIMEOUT)",
+		"flag (--no-animations)",
+		"hooks.issue_closed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	if code := runConfig([]string{"show", "--json", "--path", jsonl}, &stdout, &stderr); code != 0 {
+		t.Fatalf("runConfig(--json) = %d", code)
+	}
+	var entries []configEntry
+	if err := json.Unmarshal(stdout.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(entries) != len(config.Settings)+1 {
+		t.Errorf("got %d entries, want %d", len(entries), len(config.Settings)+1)
+	}
+	if entries[0].Key != config.KeyBlockTypes || entries[0].Origin != config.OriginDefault {
+		t.Errorf("first entry = %+v, want block_types default", entries[0])
+	}
+}
+
+func TestRunConfigUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runConfig(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("runConfig(nil) = %d, want 2", code)
+	}
+	if code := runConfig([]string{"edit"}, &stdout, &stderr); code != 2 {
+		t.Errorf("runConfig(edit) = %d, want 2", code)
+	}
+}
+
+func TestApplyConfigTuning(t *testing.T) {
+	orig := data.CurrentTuning()
+	t.Cleanup(func() { data.SetTuning(orig); data.SetFocusUser("") })
+
+	for _, s := range config.Settings {
+		t.Setenv(s.Env, "")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("MG_JSONL_MAX_AGE", "3h")
+	cfg := loadConfig("", nil, os.Stderr)
+	applyConfig(cfg)
+
+	if got := data.CurrentTuning().JSONLMaxAge.String(); got != "3h0m0s" {
+		t.Errorf("JSONLMaxAge = %s, want 3h", got)
+	}
+}
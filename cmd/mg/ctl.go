@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/control"
+)
+
+// controlSocketPath returns the control socket for projectDir, honouring the
+// control_socket setting. It reports false when the socket is disabled.
+func controlSocketPath(cfg config.Config, projectDir string) (string, bool) {
+	switch path := cfg.String(config.KeyControlSocket); path {
+	case "off":
+		return "", false
+	case "":
+		return control.SocketPath(projectDir), true
+	default:
+		return path, true
+	}
+}
+
+// startControl opens the control socket for a TUI session. Failures are
+// warnings: mg runs fine without remote control.
+func startControl(cfg config.Config, projectDir string, stderr io.Writer) *control.Server {
+	path, ok := controlSocketPath(cfg, projectDir)
+	if !ok {
+		return nil
+	}
+	srv, err := control.Listen(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Warning: control socket disabled: %v\n", err)
+		return nil
+	}
+	return srv
+}
+
+// runCtl implements `mg ctl`: it sends one command to a running mg and
+// prints the resulting selection as JSON. Exit status is 0 on success, 1 when
+// mg rejects the command, and 2 on usage or connection errors.
+func runCtl(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file (selects the project)")
+	socket := fs.String("socket", "", "Control socket path (default: the project's socket)")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: mg ctl [--socket PATH] <command> [arg]\n\ncommands:\n")
+		fmt.Fprintf(stderr, "  select <id>        move the parade to an issue\n")
+		fmt.Fprintf(stderr, "  filter [query]     set the filter; no query clears it\n")
+		fmt.Fprintf(stderr, "  focus [on|off]     toggle or set focus mode\n")
+		fmt.Fprintf(stderr, "  layout <name>      default, gastown, wide or next\n")
+		fmt.Fprintf(stderr, "  refresh            reload issues now\n")
+		fmt.Fprintf(stderr, "  selection          print the current selection\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	req := control.Request{Cmd: fs.Arg(0), Arg: strings.Join(fs.Args()[1:], " ")}
+
+	sockPath := *socket
+	if sockPath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+			return 2
+		}
+		projectDir := resolveSource(cwd, *path).ProjectDir
+		if projectDir == "" {
+			projectDir = findBeadsDir(cwd)
+		}
+		cfg := loadConfig(projectDir, nil, stderr)
+		var ok bool
+		if sockPath, ok = controlSocketPath(cfg, projectDir); !ok {
+			fmt.Fprintf(stderr, "Control socket is disabled (control_socket: off).\n")
+			return 2
+		}
+	}
+
+	resp, err := control.Call(sockPath, req)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: no running mg at %s: %v\n", sockPath, err)
+		return 2
+	}
+	if !resp.OK {
+		fmt.Fprintf(stderr, "Error: %s\n", resp.Error)
+		return 1
+	}
+	enc := json.NewEncoder(stdout)
+	if err := enc.Encode(resp.Selection); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
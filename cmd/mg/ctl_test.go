@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/control"
+)
+
+func ctlSocket(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "mgctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+func TestRunCtlSendsCommand(t *testing.T) {
+	path := ctlSocket(t)
+	srv, err := control.Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	go srv.Serve(func(msg tea.Msg) {
+		m := msg.(control.Msg)
+		if m.Cmd == control.CmdFilter {
+			m.Reply(control.Response{OK: true, Selection: &control.Selection{Filter: m.Arg}})
+			return
+		}
+		m.Fail("nope")
+	})
+
+	var stdout, stderr bytes.Buffer
+	if code := runCtl([]string{"--socket", path, "filter", "type:bug", "p1"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("runCtl() = %d, stderr: %s", code, stderr.String())
+	}
+	var sel control.Selection
+	if err := json.Unmarshal(stdout.Bytes(), &sel); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if sel.Filter != "type:bug p1" {
+		t.Errorf("filter = %q, want args joined", sel.Filter)
+	}
+
+	stderr.Reset()
+	if code := runCtl([]string{"--socket", path, "refresh"}, &stdout, &stderr); code != 1 {
+		t.Errorf("rejected command exit = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "nope") {
+		t.Errorf("stderr = %q, want error message", stderr.String())
+	}
+}
+
+func TestRunCtlErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runCtl(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("no command exit = %d, want 2", code)
+	}
+	if code := runCtl([]string{"--socket", ctlSocket(t), "refresh"}, &stdout, &stderr); code != 2 {
+		t.Errorf("no server exit = %d, want 2", code)
+	}
+}
+
+func TestControlSocketPathSetting(t *testing.T) {
+	for _, s := range config.Settings {
+		t.Setenv(s.Env, "")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := loadConfig("", nil, os.Stderr)
+	if path, ok := controlSocketPath(cfg, "/src/a"); !ok || path != control.SocketPath("/src/a") {
+		t.Errorf("default = %q, %v", path, ok)
+	}
+
+	t.Setenv("MG_CONTROL_SOCKET", "off")
+	cfg = loadConfig("", nil, os.Stderr)
+	if _, ok := controlSocketPath(cfg, "/src/a"); ok {
+		t.Error("control_socket: off should disable the socket")
+	}
+
+	t.Setenv("MG_CONTROL_SOCKET", "/tmp/custom.sock")
+	cfg = loadConfig("", nil, os.Stderr)
+	if path, _ := controlSocketPath(cfg, "/src/a"); path != "/tmp/custom.sock" {
+		t.Errorf("custom = %q", path)
+	}
+}
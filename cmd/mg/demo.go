@@ -0,0 +1,23 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/demo"
+)
+
+// startDemo installs the demo project in place of the bd, gt and git CLIs
+// and returns a source that reads from it. The project directory is an empty
+// temp dir, so no config or hooks from the current checkout apply; cleanup
+// removes it. main also drops the user's hooks and skips the control socket.
+func startDemo() (source data.Source, cleanup func(), err error) {
+	dir, err := os.MkdirTemp("", "mg-demo-")
+	if err != nil {
+		return data.Source{}, nil, err
+	}
+	demo.New(time.Now).Install()
+	source = data.Source{Mode: SourceCLI, ProjectDir: dir}
+	return source, func() { os.RemoveAll(dir) }, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/matt-wright86/mardi-gras/internal/doctor"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// runDoctor implements `mg doctor`: it runs every health check in parallel
+// and prints one categorised report. It exits 0 when healthy, 1 on warnings
+// only and 3 on any failed check (see doctor.ExitCode), and 2 when the
+// report could not be produced: bad flags, no working directory or a JSON
+// encoding failure.
+func runDoctor(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	asJSON := fs.Bool("json", false, "Emit the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 2
+	}
+	source := resolveSource(cwd, *path)
+	projectDir := source.ProjectDir
+	if projectDir == "" {
+		projectDir = findBeadsDir(cwd)
+	}
+
+	report := doctor.Run(doctor.Options{
+		ProjectDir: projectDir,
+		Mode:       source.Mode,
+		GasTown:    gastown.Detect().Available,
+	}, doctor.DefaultProbes())
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+	} else {
+		doctor.WriteText(stdout, report)
+	}
+	return doctor.ExitCode(report)
+}
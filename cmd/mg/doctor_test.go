@@ -0,0 +1,16 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRunDoctorBadFlag(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runDoctor([]string{"--bogus"}, &stdout, &stderr); code != 2 {
+		t.Errorf("runDoctor(--bogus) = %d, want 2", code)
+	}
+	if stderr.Len() == 0 {
+		t.Error("expected usage on stderr")
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/importer"
+)
+
+// importReport is the --json output of `mg import`.
+type importReport struct {
+	Applied bool               `json:"applied"`
+	Drafts  []importer.Draft   `json:"drafts"`
+	Created []importer.Created `json:"created,omitempty"`
+	Errors  []string           `json:"errors,omitempty"`
+}
+
+// runImport implements `mg import FILE`, turning a Markdown task list, CSV
+// sheet or GitHub issue export into Beads issues. Without --apply it only
+// prints what would be created. Exit status is 1 when any bd call fails and 2
+// on usage, parse or load failure.
+func runImport(args []string, stdout, stderr io.Writer) int {
+	var file string
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		file, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	format := fs.String("format", "", "Input format: md, csv or github (default: from the file extension)")
+	mapping := fs.String("map", "", "CSV column mapping, e.g. title=Summary,priority=Prio")
+	typeFlag := fs.String("type", string(importer.DefaultDefaults.Type), "Issue type when the source has none")
+	priority := fs.Int("priority", int(importer.DefaultDefaults.Priority), "Priority (0-4) when the source has none")
+	apply := fs.Bool("apply", false, "Create the issues (default is a dry run)")
+	allowDups := fs.Bool("allow-duplicates", false, "Create issues even when a similar title already exists")
+	asJSON := fs.Bool("json", false, "Emit a JSON report")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if file == "" && fs.NArg() == 1 {
+		file = fs.Arg(0)
+	}
+	if file == "" {
+		fmt.Fprintf(stderr, "usage: mg import FILE [--format md|csv|github] [--map field=Column,...] [--apply]\n")
+		return 2
+	}
+
+	opts := importer.Options{Defaults: importer.Defaults{
+		Type:     data.IssueType(strings.ToLower(*typeFlag)),
+		Priority: data.Priority(*priority),
+	}}
+	if *priority < int(data.PriorityCritical) || *priority > int(data.PriorityBacklog) {
+		fmt.Fprintf(stderr, "Error: --priority must be 0-4\n")
+		return 2
+	}
+	if *mapping != "" {
+		m, err := importer.ParseMapping(*mapping)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+		opts.Mapping = m
+	}
+	if *format == "" {
+		f, err := importer.DetectFormat(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+		*format = f
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	drafts, err := importer.Parse(f, *format, opts)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error reading %s: %v\n", file, err)
+		return 2
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 2
+	}
+	source := resolveSource(cwd, *path)
+	if source.Mode == SourceJSONL && source.Path == "" {
+		fmt.Fprintf(stderr, "No .beads/issues.jsonl found and bd not on PATH.\n")
+		return 2
+	}
+	applyConfig(loadConfig(source.ProjectDir, fs, stderr))
+	existing, _, err := loadIssues(source)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading issues: %v\n", err)
+		return 2
+	}
+	if !*allowDups {
+		importer.MarkDuplicates(drafts, existing)
+	}
+
+	report := importReport{Applied: *apply, Drafts: drafts}
+	if *apply {
+		res := importer.Apply(importer.BD{}, drafts)
+		report.Created = res.Created
+		for _, e := range res.Errors {
+			report.Errors = append(report.Errors, e.Error())
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	} else {
+		writeImport(stdout, report)
+	}
+	if len(report.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// writeImport prints the dry-run table, or what was created.
+func writeImport(w io.Writer, r importReport) {
+	if r.Applied {
+		for _, c := range r.Created {
+			fmt.Fprintf(w, "created %s  %s\n", c.ID, c.Title)
+		}
+		for _, e := range r.Errors {
+			fmt.Fprintf(w, "error   %s\n", e)
+		}
+		fmt.Fprintf(w, "%d created, %d skipped, %d errors\n", len(r.Created), countSkipped(r.Drafts), len(r.Errors))
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REF\tTITLE\tTYPE\tPRI\tPARENT\tLABELS\tDEPENDS\tNOTE")
+	for _, d := range r.Drafts {
+		var note []string
+		if d.Duplicate != "" {
+			note = append(note, "duplicate of "+d.Duplicate)
+		}
+		if d.Closed {
+			note = append(note, "closed")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\tP%d\t%s\t%s\t%s\t%s\n",
+			d.Ref, d.Title, d.Type, d.Priority, d.Parent,
+			strings.Join(d.Labels, ","), strings.Join(d.DependsOn, ","), strings.Join(note, ", "))
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\n%d to create, %d skipped. Re-run with --apply to create them.\n",
+		len(r.Drafts)-countSkipped(r.Drafts), countSkipped(r.Drafts))
+}
+
+func countSkipped(drafts []importer.Draft) int {
+	n := 0
+	for _, d := range drafts {
+		if d.Skip {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/importer"
+)
+
+func TestRunImportUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no file", nil},
+		{"bad flag", []string{"plan.md", "--bogus"}},
+		{"bad priority", []string{"plan.md", "--priority", "9"}},
+		{"bad mapping", []string{"sheet.csv", "--map", "colour=Red"}},
+		{"unknown extension", []string{"plan.docx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runImport(tt.args, &stdout, &stderr); code != 2 {
+				t.Errorf("runImport(%v) = %d, want 2", tt.args, code)
+			}
+			if stderr.Len() == 0 {
+				t.Error("expected a message on stderr")
+			}
+		})
+	}
+}
+
+func TestWriteImportDryRun(t *testing.T) {
+	var buf bytes.Buffer
+	writeImport(&buf, importReport{Drafts: []importer.Draft{
+		{Ref: "1", Title: "Ship it", Type: data.TypeEpic, Priority: data.PriorityHigh, Labels: []string{"q3"}},
+		{Ref: "2", Title: "Old news", Type: data.TypeTask, Parent: "1", Duplicate: "mg-9", Skip: true, Closed: true},
+	}})
+	out := buf.String()
+	for _, want := range []string{"REF", "Ship it", "epic", "P1", "q3", "duplicate of mg-9, closed", "1 to create, 1 skipped", "--apply"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteImportApplied(t *testing.T) {
+	var buf bytes.Buffer
+	writeImport(&buf, importReport{
+		Applied: true,
+		Drafts:  []importer.Draft{{Ref: "1", Title: "Ship it"}},
+		Created: []importer.Created{{Ref: "1", ID: "mg-12", Title: "Ship it"}},
+	})
+	out := buf.String()
+	if !strings.Contains(out, "created mg-12") || !strings.Contains(out, "1 created, 0 skipped, 0 errors") {
+		t.Errorf("output = %q", out)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// lintReport is the --json output of `mg lint`.
+type lintReport struct {
+	Mode       string                   `json:"mode"`
+	Checked    int                      `json:"checked"`
+	Errors     int                      `json:"errors"`
+	Warnings   int                      `json:"warnings"`
+	Violations []data.MetadataViolation `json:"violations"`
+}
+
+// runLint implements `mg lint`: it validates every issue's metadata against
+// the schema in .beads/config.yaml. Exit status is 1 when any violation has
+// error severity (or any violation at all with --strict), 2 on usage or load
+// failure, and 0 otherwise.
+func runLint(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	asJSON := fs.Bool("json", false, "Emit a JSON report")
+	strict := fs.Bool("strict", false, "Exit non-zero on warnings as well as errors")
+	fs.String("exclude-type", "", "Comma-separated issue types to skip")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 2
+	}
+	source := resolveSource(cwd, *path)
+	if source.Mode == SourceJSONL && source.Path == "" {
+		fmt.Fprintf(stderr, "No .beads/issues.jsonl found and bd not on PATH.\n")
+		return 2
+	}
+	cfg := loadConfig(source.ProjectDir, fs, stderr)
+	applyConfig(cfg)
+	issues, _, err := loadIssues(source)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading issues: %v\n", err)
+		return 2
+	}
+	issues = data.ExcludeByType(issues, parseTypeSet(cfg.String(config.KeyExcludeTypes)))
+
+	report := buildLintReport(data.LoadMetadataSchema(source.ProjectDir), issues)
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+	} else {
+		printLintReport(stdout, report)
+	}
+
+	if report.Errors > 0 || (*strict && report.Warnings > 0) {
+		return 1
+	}
+	return 0
+}
+
+// buildLintReport validates issues against schema. A nil schema yields an
+// empty report with mode "none".
+func buildLintReport(schema *data.MetadataSchema, issues []data.Issue) lintReport {
+	report := lintReport{Mode: "none", Checked: len(issues), Violations: []data.MetadataViolation{}}
+	if schema == nil {
+		return report
+	}
+	report.Mode = schema.Mode
+
+	byIssue := schema.ValidateIssues(issues)
+	ids := make([]string, 0, len(byIssue))
+	for id := range byIssue {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		for _, v := range byIssue[id] {
+			report.Violations = append(report.Violations, v)
+			if v.Severity == data.SeverityError {
+				report.Errors++
+			} else {
+				report.Warnings++
+			}
+		}
+	}
+	return report
+}
+
+// printLintReport writes the human-readable lint output.
+func printLintReport(w io.Writer, r lintReport) {
+	if r.Mode == "none" || r.Mode == "" {
+		fmt.Fprintf(w, "Metadata validation is off (mode: none); %d issues not checked.\n", r.Checked)
+		return
+	}
+	for _, v := range r.Violations {
+		fmt.Fprintf(w, "%-5s %s  %s: %s\n", v.Severity, v.IssueID, v.Field, v.Message)
+	}
+	if len(r.Violations) == 0 {
+		fmt.Fprintf(w, "%d issues checked, metadata valid (mode: %s)\n", r.Checked, r.Mode)
+		return
+	}
+	fmt.Fprintf(w, "\n%d issues checked: %d error(s), %d warning(s) (mode: %s)\n", r.Checked, r.Errors, r.Warnings, r.Mode)
+}
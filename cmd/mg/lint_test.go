@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeLintProject creates a .beads directory with the given config and issues.
+func writeLintProject(t *testing.T, config, issues string) string {
+	t.Helper()
+	dir := t.TempDir()
+	beads := filepath.Join(dir, ".beads")
+	mustMkdir(t, beads)
+	if config != "" {
+		mustWrite(t, filepath.Join(beads, "config.yaml"), []byte(config))
+	}
+	path := filepath.Join(beads, "issues.jsonl")
+	mustWrite(t, path, []byte(issues))
+	return path
+}
+
+const lintIssues = `{"id":"mg-1","title":"Good","status":"open","priority":2,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z","metadata":{"team":"core"}}
+{"id":"mg-2","title":"Bad","status":"open","priority":2,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+`
+
+func lintConfig(mode string) string {
+	return "validation:\n  metadata:\n    mode: " + mode + "\n    fields:\n      team:\n        type: string\n        required: true\n"
+}
+
+func TestRunLintExitCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		args   []string
+		want   int
+	}{
+		{"error mode fails", lintConfig("error"), nil, 1},
+		{"warn mode passes", lintConfig("warn"), nil, 0},
+		{"warn mode strict fails", lintConfig("warn"), []string{"--strict"}, 1},
+		{"none mode passes", lintConfig("none"), nil, 0},
+		{"no schema passes", "", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeLintProject(t, tt.config, lintIssues)
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"--path", path}, tt.args...)
+			if got := runLint(args, &stdout, &stderr); got != tt.want {
+				t.Errorf("runLint() = %d, want %d (stdout=%q stderr=%q)", got, tt.want, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunLintJSON(t *testing.T) {
+	path := writeLintProject(t, lintConfig("error"), lintIssues)
+	var stdout, stderr bytes.Buffer
+	runLint([]string{"--path", path, "--json"}, &stdout, &stderr)
+
+	var report lintReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if report.Mode != "error" || report.Checked != 2 || report.Errors != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Violations) != 1 || report.Violations[0].IssueID != "mg-2" || report.Violations[0].Field != "team" {
+		t.Errorf("violations = %+v", report.Violations)
+	}
+}
+
+func TestRunLintText(t *testing.T) {
+	path := writeLintProject(t, lintConfig("warn"), lintIssues)
+	var stdout, stderr bytes.Buffer
+	runLint([]string{"--path", path}, &stdout, &stderr)
+	out := stdout.String()
+	if !strings.Contains(out, "mg-2  team: required field missing") {
+		t.Errorf("output missing violation line:\n%s", out)
+	}
+	if !strings.Contains(out, "0 error(s), 1 warning(s)") {
+		t.Errorf("output missing summary:\n%s", out)
+	}
+}
+
+func TestRunLintMissingSource(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if got := runLint([]string{"--path", filepath.Join(t.TempDir(), "nope.jsonl")}, &stdout, &stderr); got != 2 {
+		t.Errorf("runLint() = %d, want 2", got)
+	}
+}
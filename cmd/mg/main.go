@@ -9,14 +9,17 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/matt-wright86/mardi-gras/internal/app"
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/control"
 	"github.com/matt-wright86/mardi-gras/internal/data"
-	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/hooks"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/tmux"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
 // Alias SourceMode constants for convenience.
@@ -29,95 +32,180 @@ const (
 var version = "dev"
 
 func main() {
+	if code, ok := runSubcommand(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+	os.Exit(run())
+}
+
+// run starts the TUI and returns the exit code. It returns rather than
+// exiting so deferred cleanup, such as removing the demo project, runs.
+func run() int {
 	path := flag.String("path", "", "Path to .beads/issues.jsonl file")
-	blockTypesFlag := flag.String("block-types", "", "Comma-separated dependency types that count as blockers (default: blocks)")
-	excludeTypesFlag := flag.String("exclude-type", "", "Comma-separated issue types to hide from the parade and status output")
 	statusMode := flag.Bool("status", false, "Output tmux status line and exit")
 	showVersion := flag.Bool("version", false, "Print version and exit")
-	noAnimations := flag.Bool("no-animations", false, "Disable confetti and header shimmer animations")
-	cmdTimeout := flag.Int("cmd-timeout", 0, "Command timeout in seconds (scales all external command timeouts; default 30)")
+	demoMode := flag.Bool("demo", false, "Explore a generated project with a simulated Gas Town (no bd or gt needed)")
+	fresh := flag.Bool("fresh", false, "Start without restoring the last session's selection, filter and panes")
+	config.RegisterFlags(flag.CommandLine)
 	flag.Parse()
 
-	// MG_NO_ANIMATIONS=1 env var as alternative to --no-animations flag
-	if !*noAnimations && os.Getenv("MG_NO_ANIMATIONS") == "1" {
-		*noAnimations = true
-	}
-
-	// MG_CMD_TIMEOUT env var as alternative to --cmd-timeout flag
-	if *cmdTimeout <= 0 {
-		if envTimeout := os.Getenv("MG_CMD_TIMEOUT"); envTimeout != "" {
-			if v, err := strconv.Atoi(envTimeout); err == nil && v > 0 {
-				*cmdTimeout = v
-			}
-		}
-	}
-	if *cmdTimeout > 300 {
-		*cmdTimeout = 300
-	}
-	if *cmdTimeout > 0 {
-		gastown.SetCmdTimeout(*cmdTimeout)
-		data.SetCmdTimeout(*cmdTimeout)
-	}
-
 	if *showVersion {
 		fmt.Println("mg", version)
-		return
+		return 0
 	}
 
-	// Parse blocking types from flag, env var, or default
-	blockingTypes := parseBlockingTypes(*blockTypesFlag)
-	excludeTypes := parseTypeSet(*excludeTypesFlag)
-
 	// Resolve data source: JSONL file or bd CLI fallback
 	cwd, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting working directory: %v\n", err)
-		os.Exit(1)
+		return 1
 	}
 	source := resolveSource(cwd, *path)
+	if *demoMode {
+		var cleanup func()
+		if source, cleanup, err = startDemo(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting demo: %v\n", err)
+			return 1
+		}
+		defer cleanup()
+	}
 	if source.Mode == SourceJSONL && source.Path == "" {
 		fmt.Fprintf(os.Stderr, "No .beads/issues.jsonl found and bd not on PATH.\n\n")
 		fmt.Fprintf(os.Stderr, "Run mg from inside a project with Beads, or specify a path:\n")
 		fmt.Fprintf(os.Stderr, "  mg --path /path/to/.beads/issues.jsonl\n")
-		os.Exit(1)
+		return 1
+	}
+
+	// Layer config files, env and flags, then apply timeouts and intervals
+	// before the first bd call.
+	cfg := loadConfig(source.ProjectDir, flag.CommandLine, os.Stderr)
+	if *demoMode {
+		// The demo's closes, stalls and agents are simulated: keep the
+		// user's hooks from firing on them.
+		cfg.Hooks = nil
 	}
+	applyConfig(cfg)
+	blockingTypes := parseBlockingTypes(cfg.String(config.KeyBlockTypes))
+	excludeTypes := parseTypeSet(cfg.String(config.KeyExcludeTypes))
 
 	// Load issues
-	var issues []data.Issue
-	switch source.Mode {
-	case SourceCLI:
-		issues, err = data.FetchIssuesCLI(source.ProjectDir)
-		if err != nil {
+	issues, skipped, err := loadIssues(source)
+	if err != nil {
+		if source.Mode == SourceCLI {
 			fmt.Fprintf(os.Stderr, "Error loading issues via bd list: %v\n\n", err)
 			fmt.Fprintf(os.Stderr, "Ensure the Dolt server is running (dolt sql-server) and bd is working.\n")
-			os.Exit(1)
-		}
-	default:
-		var skipped int
-		issues, skipped, err = data.LoadIssues(source.Path)
-		if err != nil {
+		} else {
 			fmt.Fprintf(os.Stderr, "Error loading issues from %s: %v\n", source.Path, err)
-			os.Exit(1)
-		}
-		if skipped > 0 {
-			fmt.Fprintf(os.Stderr, "Warning: skipped %d malformed line(s) in %s\n", skipped, source.Path)
 		}
+		return 1
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d malformed line(s) in %s\n", skipped, source.Path)
 	}
 
 	if *statusMode {
 		groups := data.GroupByParade(data.ExcludeByType(issues, excludeTypes), blockingTypes)
 		fmt.Print(tmux.StatusLine(groups))
-		return
+		return 0
+	}
+
+	for _, event := range hooks.Unknown(cfg.Hooks) {
+		fmt.Fprintf(os.Stderr, "Warning: unknown hook event %q ignored\n", event)
+	}
+	keys, err := keymap.Default().Override(cfg.Keys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	keymap.Use(keys)
+
+	themes, err := ui.LoadThemeDir(config.ThemeDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	themeName := cfg.String(config.KeyTheme)
+	accessible := cfg.Bool(config.KeyAccessible)
+	if ui.NoColor(os.Getenv) {
+		themeName, accessible = ui.ThemeMono, true
+	}
+	if _, ok := ui.FindTheme(append(ui.BuiltinThemes(), themes...), themeName); !ok && themeName != app.ThemeAuto {
+		fmt.Fprintf(os.Stderr, "Warning: unknown theme %q, following the terminal background\n", themeName)
+	}
+
+	if accessible {
+		ui.SetAccessible(true)
 	}
 
 	// Run TUI
 	guard := app.NewOSCGuard()
-	model := app.NewWithGuard(issues, source, blockingTypes, guard, *noAnimations, excludeTypes)
+	model := app.NewWithGuard(issues, source, blockingTypes, guard, cfg.Bool(config.KeyNoAnimations), excludeTypes).
+		WithHooks(hooks.NewRunner(cfg.Hooks, source.ProjectDir)).
+		WithSectionRows(cfg.Int(config.KeySectionRows)).
+		WithTheme(themeName, themes)
+	if !*demoMode {
+		model = model.WithSession(config.SessionPath(source.ProjectDir), !*fresh)
+	}
+	if layout, pinned := app.ParseLayoutPreset(cfg.String(config.KeyLayout)); pinned {
+		model = model.WithLayout(layout)
+	}
 	p := tea.NewProgram(model, tea.WithFilter(guard.Filter()))
-	if _, err := p.Run(); err != nil {
+	var ctl *control.Server
+	if !*demoMode {
+		ctl = startControl(cfg, source.ProjectDir, os.Stderr)
+	}
+	if ctl != nil {
+		go ctl.Serve(p.Send)
+	}
+	final, err := p.Run()
+	if ctl != nil {
+		ctl.Close()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return 1
+	}
+	if fm, ok := final.(app.Model); ok {
+		if err := fm.SaveSession(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: saving session: %v\n", err)
+		}
+	}
+	return 0
+}
+
+// runSubcommand dispatches `mg <name> ...` to its handler. It reports false
+// when args do not name a subcommand, leaving flag parsing to the TUI path.
+func runSubcommand(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	switch args[0] {
+	case "watch":
+		return runWatch(args[1:], os.Stdout, os.Stderr), true
+	case "lint":
+		return runLint(args[1:], os.Stdout, os.Stderr), true
+	case "doctor":
+		return runDoctor(args[1:], os.Stdout, os.Stderr), true
+	case "config":
+		return runConfig(args[1:], os.Stdout, os.Stderr), true
+	case "ctl":
+		return runCtl(args[1:], os.Stdout, os.Stderr), true
+	case "worktree":
+		return runWorktree(args[1:], os.Stdout, os.Stderr), true
+	case "import":
+		return runImport(args[1:], os.Stdout, os.Stderr), true
+	case "apply":
+		return runApply(args[1:], os.Stdout, os.Stderr), true
+	}
+	return 0, false
+}
+
+// loadIssues reads issues from the resolved source. The skipped count is the
+// number of malformed JSONL lines and is always zero in CLI mode.
+func loadIssues(source data.Source) ([]data.Issue, int, error) {
+	if source.Mode == SourceCLI {
+		issues, err := data.FetchIssuesCLI(source.ProjectDir)
+		return issues, 0, err
 	}
+	return data.LoadIssues(source.Path)
 }
 
 // parseBlockingTypes builds the blocking types set from flag, env var, or default.
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/config"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// Default polling intervals for `mg watch`, per source mode.
+const (
+	watchCLIInterval   = 5 * time.Second
+	watchJSONLInterval = 2 * time.Second
+)
+
+// runWatch implements `mg watch`: it polls the data source and prints one
+// JSON object per issue transition to stdout until interrupted.
+func runWatch(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	fs.String("block-types", "", "Comma-separated dependency types that count as blockers (default: blocks)")
+	fs.String("exclude-type", "", "Comma-separated issue types to ignore")
+	interval := fs.Duration("interval", 0, "Polling interval (default 5s for bd, 2s for JSONL)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 1
+	}
+	source := resolveSource(cwd, *path)
+	if source.Mode == SourceJSONL && source.Path == "" {
+		fmt.Fprintf(stderr, "No .beads/issues.jsonl found and bd not on PATH.\n")
+		return 1
+	}
+	if *interval <= 0 {
+		*interval = watchJSONLInterval
+		if source.Mode == SourceCLI {
+			*interval = watchCLIInterval
+		}
+	}
+
+	cfg := loadConfig(source.ProjectDir, fs, stderr)
+	applyConfig(cfg)
+	excludeTypes := parseTypeSet(cfg.String(config.KeyExcludeTypes))
+	load := func() ([]data.Issue, error) {
+		issues, _, err := loadIssues(source)
+		if err != nil {
+			return nil, err
+		}
+		return data.ExcludeByType(issues, excludeTypes), nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := watchLoop(ctx, load, *interval, parseBlockingTypes(cfg.String(config.KeyBlockTypes)), stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// watchLoop takes an initial snapshot, then reloads every interval and
+// writes each transition as a JSON line. Load errors after the first are
+// reported on stderr and retried on the next tick.
+func watchLoop(ctx context.Context, load func() ([]data.Issue, error), interval time.Duration, blockingTypes map[string]bool, stdout, stderr io.Writer) error {
+	issues, err := load()
+	if err != nil {
+		return err
+	}
+	prev := data.TakeSnapshot(issues, blockingTypes)
+	enc := json.NewEncoder(stdout)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			issues, err := load()
+			if err != nil {
+				fmt.Fprintf(stderr, "watch: %v\n", err)
+				continue
+			}
+			next := data.TakeSnapshot(issues, blockingTypes)
+			for _, ev := range data.DiffSnapshots(prev, next, now.UTC()) {
+				if err := enc.Encode(ev); err != nil {
+					return err
+				}
+			}
+			prev = next
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent reads and writes.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchLoopEmitsTransitions(t *testing.T) {
+	steps := [][]data.Issue{
+		{{ID: "a", Title: "A", Status: data.StatusOpen}},
+		{{ID: "a", Title: "A", Status: data.StatusInProgress}},
+		{{ID: "a", Title: "A", Status: data.StatusClosed}},
+	}
+	var mu sync.Mutex
+	call := 0
+	load := func() ([]data.Issue, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		i := call
+		if i >= len(steps) {
+			i = len(steps) - 1
+		}
+		call++
+		return steps[i], nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stdout, stderr syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- watchLoop(ctx, load, 5*time.Millisecond, nil, &stdout, &stderr) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && !strings.Contains(stdout.String(), `"closed"`) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watchLoop() error = %v", err)
+	}
+
+	var types []data.ChangeType
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var ev data.ChangeEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		if ev.IssueID != "a" {
+			t.Errorf("IssueID = %q, want a", ev.IssueID)
+		}
+		types = append(types, ev.Type)
+	}
+	want := []data.ChangeType{data.ChangeStatus, data.ChangeParade, data.ChangeClosed, data.ChangeParade}
+	if len(types) != len(want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("event[%d] = %q, want %q", i, types[i], want[i])
+		}
+	}
+}
+
+func TestWatchLoopInitialLoadError(t *testing.T) {
+	load := func() ([]data.Issue, error) { return nil, errors.New("boom") }
+	var stdout, stderr bytes.Buffer
+	if err := watchLoop(context.Background(), load, time.Millisecond, nil, &stdout, &stderr); err == nil {
+		t.Error("watchLoop() error = nil, want initial load error")
+	}
+}
+
+func TestRunSubcommandUnknown(t *testing.T) {
+	if _, ok := runSubcommand([]string{"--path", "x"}); ok {
+		t.Error("runSubcommand(--path) ok = true, want false")
+	}
+	if _, ok := runSubcommand(nil); ok {
+		t.Error("runSubcommand(nil) ok = true, want false")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// runWorktree implements `mg worktree [list|add ID|prune]`, managing a git
+// worktree per issue. Exit status is 1 when a git operation fails and 2 on
+// usage or load failure.
+func runWorktree(args []string, stdout, stderr io.Writer) int {
+	action := "list"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("worktree "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("path", "", "Path to .beads/issues.jsonl file")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting working directory: %v\n", err)
+		return 2
+	}
+	source := resolveSource(cwd, *path)
+	if source.Mode == SourceJSONL && source.Path == "" {
+		fmt.Fprintf(stderr, "No .beads/issues.jsonl found and bd not on PATH.\n")
+		return 2
+	}
+	applyConfig(loadConfig(source.ProjectDir, fs, stderr))
+	issues, _, err := loadIssues(source)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading issues: %v\n", err)
+		return 2
+	}
+
+	switch action {
+	case "list":
+		wts, err := data.ListWorktrees(source.ProjectDir, issues)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		writeWorktrees(stdout, wts)
+	case "add":
+		if fs.NArg() != 1 {
+			fmt.Fprintf(stderr, "usage: mg worktree add ISSUE-ID\n")
+			return 2
+		}
+		issue := findIssue(issues, fs.Arg(0))
+		if issue == nil {
+			fmt.Fprintf(stderr, "No issue %s\n", fs.Arg(0))
+			return 2
+		}
+		wtPath, err := data.CreateWorktree(source.ProjectDir, *issue)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, wtPath)
+	case "prune":
+		wts, err := data.ListWorktrees(source.ProjectDir, issues)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		removed, err := data.PruneMergedWorktrees(source.ProjectDir, wts)
+		for _, w := range removed {
+			fmt.Fprintf(stdout, "removed %s (%s)\n", w.Path, w.Branch)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(stderr, "usage: mg worktree [list|add ISSUE-ID|prune] [--path FILE]\n")
+		return 2
+	}
+	return 0
+}
+
+// findIssue returns the issue with id, or nil.
+func findIssue(issues []data.Issue, id string) *data.Issue {
+	for i := range issues {
+		if issues[i].ID == id {
+			return &issues[i]
+		}
+	}
+	return nil
+}
+
+func writeWorktrees(w io.Writer, wts []data.Worktree) {
+	if len(wts) == 0 {
+		fmt.Fprintln(w, "No issue worktrees.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ISSUE\tBRANCH\tSTATE\tAHEAD\tBEHIND\tPATH")
+	for _, wt := range wts {
+		state := "clean"
+		switch {
+		case wt.Dirty:
+			state = "dirty"
+		case wt.Merged():
+			state = "merged"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\n", wt.IssueID, wt.Branch, state, wt.Ahead, wt.Behind, wt.Path)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestRunWorktreeBadFlag(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runWorktree([]string{"list", "--bogus"}, &stdout, &stderr); code != 2 {
+		t.Errorf("runWorktree(list --bogus) = %d, want 2", code)
+	}
+	if stderr.Len() == 0 {
+		t.Error("expected usage on stderr")
+	}
+}
+
+func TestWriteWorktrees(t *testing.T) {
+	var buf bytes.Buffer
+	writeWorktrees(&buf, nil)
+	if !strings.Contains(buf.String(), "No issue worktrees") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+
+	buf.Reset()
+	writeWorktrees(&buf, []data.Worktree{
+		{IssueID: "mg-1", Branch: "task/mg-1-a", Path: "/p/mg-1", Behind: 2, Landed: true},
+		{IssueID: "mg-2", Branch: "fix/mg-2-b", Path: "/p/mg-2", Dirty: true, Ahead: 1},
+	})
+	out := buf.String()
+	for _, want := range []string{"ISSUE", "mg-1", "merged", "mg-2", "dirty", "/p/mg-2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
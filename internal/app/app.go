@@ -17,8 +17,12 @@ import (
 	"github.com/atotto/clipboard"
 	"github.com/matt-wright86/mardi-gras/internal/agent"
 	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/control"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/doctor"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/hooks"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 	"github.com/matt-wright86/mardi-gras/internal/views"
 )
@@ -38,9 +42,50 @@ const (
 	LayoutDefault LayoutPreset = iota // parade + detail (2:3 split)
 	LayoutGasTown                     // parade + gas town
 	LayoutWide                        // full-width parade only
+	LayoutStacked                     // parade above detail, for narrow terminals
+	LayoutColumns                     // parade | detail | gas town, for ultra-wide terminals
 	layoutPresetCount
 )
 
+// String returns the config name of the preset.
+func (p LayoutPreset) String() string {
+	switch p {
+	case LayoutGasTown:
+		return "gastown"
+	case LayoutWide:
+		return "wide"
+	case LayoutStacked:
+		return "stacked"
+	case LayoutColumns:
+		return "columns"
+	}
+	return "default"
+}
+
+// Label returns the preset name shown in toasts.
+func (p LayoutPreset) Label() string {
+	return [...]string{"Default", "Gas Town", "Wide", "Stacked", "Columns"}[p]
+}
+
+// ParseLayoutPreset maps a config name (default, gastown, wide, stacked,
+// columns) to a preset. "auto" is not a preset: it reports false so the
+// layout follows the terminal size.
+func ParseLayoutPreset(name string) (LayoutPreset, bool) {
+	switch strings.ToLower(name) {
+	case "", "default":
+		return LayoutDefault, true
+	case "gastown":
+		return LayoutGasTown, true
+	case "wide":
+		return LayoutWide, true
+	case "stacked":
+		return LayoutStacked, true
+	case "columns":
+		return LayoutColumns, true
+	}
+	return LayoutDefault, false
+}
+
 const (
 	toastDuration           = 4 * time.Second
 	changeIndicatorDuration = 30 * time.Second
@@ -75,6 +120,7 @@ type Model struct {
 	townStatus    *gastown.TownStatus // Latest gt status, nil when unavailable
 	gasTown       views.GasTown       // Gas Town control surface panel
 	showGasTown   bool                // Whether the Gas Town panel replaces detail
+	gasTownAuto   bool                // Gas Town was opened by the automatic switch to Columns
 
 	// Toast notification
 	toast components.Toast
@@ -85,7 +131,7 @@ type Model struct {
 	// Change indicators: track recently changed issue IDs
 	changedIDs   map[string]bool
 	changedAt    time.Time
-	prevIssueMap map[string]data.Status // issueID -> previous status for diffing
+	prevSnapshot data.Snapshot // previous issue state for diffing
 
 	// Focus mode
 	focusMode bool
@@ -144,9 +190,23 @@ type Model struct {
 	showDoctor bool
 	doctor     views.Doctor
 
+	// Timeline view of the parade rows
+	showTimeline bool
+	timeline     views.Timeline
+
+	// Flow charts (cumulative flow, burndown, lead/cycle times)
+	showCharts bool
+	charts     views.Charts
+
 	// Recovery confirmation dialog
 	recovering     bool
 	recoveryDialog components.RecoveryDialog
+	importing      bool
+	importDialog   components.ImportDialog
+	bulkEditing    bool
+	bulkDialog     components.BulkEditDialog
+	showCalendar   bool
+	calendar       components.Calendar
 
 	// Data source mode (JSONL file watcher vs bd CLI polling)
 	sourceMode data.SourceMode
@@ -167,21 +227,50 @@ type Model struct {
 	// Gas Town panel liveness tick
 	gasTownTicking bool
 
-	// Layout preset (cycle with command palette)
+	// Most issue rows per parade section before a "N more" row; 0 is no
+	// cap.
+	sectionRows int
+
+	// Layout preset (cycle with command palette). Unless pinned, layout
+	// picks it from the terminal size.
 	layoutPreset LayoutPreset
+	layoutPinned bool
+	panes        paneGeometry
+
+	// Per-project session file, written on exit; empty disables. The
+	// restored state waits in pendingSession for the first parade build,
+	// and sessionSelected keeps the startup current issue from moving a
+	// restored selection.
+	sessionPath     string
+	pendingSession  *sessionState
+	sessionSelected bool
+
+	// Parade share of the split set by dragging the divider or < and >; 0
+	// is the 2:3 default
+	splitRatio    float64
+	draggingSplit bool
+
+	// Color themes: built-ins then theme files, cycled from the palette.
+	// themeAuto follows the terminal background until a theme is picked.
+	themes    []ui.Theme
+	themeAuto bool
 
 	// Bead string shimmer animation
 	beadOffset int
 
-	// Metadata schema from .beads/config.yaml
+	// Metadata schema from .beads/config.yaml, and the current violations
+	// (issue ID → failed checks), recomputed on every rebuildParade.
 	metadataSchema *data.MetadataSchema
+	metaViolations map[string][]data.MetadataViolation
 
 	// Workspace identity from bd context --json (fetched at startup)
 	beadsContext *data.BeadsContext
 
-	// Doctor diagnostics from bd doctor --agent --json (fetched at startup)
+	// Doctor diagnostics from bd doctor --agent --json (fetched at startup),
+	// and the aggregated mg doctor report shown by the D overlay.
 	doctorResult   *data.DoctorResult
 	doctorProblems []gastown.Problem
+	doctorReport   *data.DoctorResult
 
 	// Patrol scan from gt patrol scan --json (background TTL poll)
 	patrolScan         *gastown.PatrolScanResult
@@ -198,6 +287,29 @@ type Model struct {
 
 	// When true, confetti and header shimmer animations are disabled.
 	noAnimations bool
+	// shimmerPaused is set when accessible mode stopped the shimmer loop, so
+	// turning it off restarts the loop.
+	shimmerPaused bool
+
+	// User hooks from the config hooks section, and the agent problems
+	// already reported to them (keyed by type and agent address).
+	hooks        hooks.Runner
+	hookProblems map[string]bool
+
+	// Source-code references per issue ID, dropped on every reload.
+	codeRefs map[string]*data.CodeRefs
+
+	// Git worktrees checked out on issue branches, keyed by issue ID.
+	worktrees map[string]data.Worktree
+
+	// bd answers commands: always in CLI mode (including the demo), and in
+	// JSONL mode only when bd is on PATH. Gates the current issue lookup.
+	bdAvail bool
+
+	// Pull requests from gh, and the one matched to each issue ID.
+	ghAvail      bool
+	pullRequests []data.PullRequest
+	prByIssue    map[string]*data.PullRequest
 
 	// Transient flag set by rebuildParade when the previously-selected issue ID
 	// was not found in the new issue set. Cleared by the FileChangedMsg handler
@@ -237,11 +349,6 @@ func NewWithGuard(issues []data.Issue, source data.Source, blockingTypes map[str
 	ti.SetWidth(50)
 
 	// Build initial status snapshot for change detection
-	prevMap := make(map[string]data.Status, len(issues))
-	for _, iss := range issues {
-		prevMap[iss.ID] = iss.Status
-	}
-
 	gtEnv := gastown.Detect()
 	metaSchema := data.LoadMetadataSchema(projectDir)
 
@@ -257,13 +364,16 @@ func NewWithGuard(issues []data.Issue, source data.Source, blockingTypes map[str
 		filterInput:    ti,
 		agentAvail:     agent.Available(),
 		agentRuntime:   agent.DetectRuntime(),
+		bdAvail:        source.Mode == data.SourceCLI || data.BDAvailable(),
+		ghAvail:        projectDir != "" && data.GHAvailable(),
 		projectDir:     projectDir,
 		inTmux:         agent.InTmux() && agent.TmuxAvailable(),
 		activeAgents:   make(map[string]string),
 		gtEnv:          gtEnv,
 		gtPollInFlight: gtEnv.Available, // Init() launches the first poll; gate subsequent ones
 		changedIDs:     make(map[string]bool),
-		prevIssueMap:   prevMap,
+		codeRefs:       make(map[string]*data.CodeRefs),
+		prevSnapshot:   data.TakeSnapshot(issues, blockingTypes),
 		sourceMode:     source.Mode,
 		metadataSchema: metaSchema,
 		startedAt:      time.Now(),
@@ -272,6 +382,63 @@ func NewWithGuard(issues []data.Issue, source data.Source, blockingTypes map[str
 	}
 }
 
+// WithHooks attaches the user hook runner built from config.
+func (m Model) WithHooks(r hooks.Runner) Model {
+	m.hooks = r
+	return m
+}
+
+// WithSectionRows caps each parade section at n issue rows, with a "N
+// more" row standing in for the rest; 0 shows every row.
+func (m Model) WithSectionRows(n int) Model {
+	m.sectionRows = n
+	return m
+}
+
+// setFocusMode turns focus mode on or off and toasts the new state.
+func (m Model) setFocusMode(on bool) (tea.Model, tea.Cmd) {
+	m.focusMode = on
+	m.rebuildParade()
+	label := "Focus mode ON"
+	if !on {
+		label = "Focus mode OFF"
+	}
+	toast, cmd := components.ShowToast(label, components.ToastInfo, toastDuration)
+	m.toast = toast
+	return m, cmd
+}
+
+// setLayout pins preset p and toasts the new layout. The Gas
+// Town and Columns presets open the Gas Town panel; Default closes it.
+func (m Model) setLayout(p LayoutPreset) (tea.Model, tea.Cmd) {
+	m.layoutPreset = p
+	m.layoutPinned = true
+	m.gasTownAuto = false
+	toast, cmd := components.ShowToast("Layout: "+p.Label(), components.ToastInfo, toastDuration)
+	m.toast = toast
+	switch p {
+	case LayoutGasTown, LayoutColumns:
+		m.layout()
+		return m, tea.Batch(cmd, m.activateGasTown())
+	case LayoutDefault:
+		m.showGasTown = false
+	}
+	m.layout()
+	return m, cmd
+}
+
+// WithLayout pins the initial layout preset. The Gas Town and Columns
+// presets open the Gas Town panel on startup when gt is available.
+func (m Model) WithLayout(p LayoutPreset) Model {
+	m.layoutPreset = p
+	m.layoutPinned = true
+	if (p == LayoutGasTown || p == LayoutColumns) && m.gtEnv.Available {
+		m.showGasTown = true
+		m.gasTownTicking = true // Init starts the tick
+	}
+	return m
+}
+
 // Init implements tea.Model.
 // NOTE: Init is a value receiver (tea.Model interface), so pointer-method mutations
 // are lost. We call poll functions directly and pre-set gtPollInFlight in New().
@@ -289,24 +456,78 @@ func (m Model) Init() tea.Cmd {
 	if !m.noAnimations {
 		cmds = append(cmds, headerShimmerCmd())
 	}
+	if m.themeAuto {
+		cmds = append(cmds, tea.RequestBackgroundColor)
+	}
+	if m.showGasTown {
+		cmds = append(cmds, fetchConvoyList, fetchMailInbox, fetchCosts, fetchActivity, fetchVitals, gasTownTickCmd())
+	}
+	if m.bdAvail {
+		cmds = append(cmds, fetchCurrentIssue)
+	}
 	if m.sourceMode == data.SourceCLI {
-		cmds = append(cmds, fetchCurrentIssue, fetchDoctorDiagnostics, fetchBeadsContext)
+		cmds = append(cmds, fetchDoctorDiagnostics, fetchBeadsContext)
+	}
+	if m.projectDir != "" {
+		cmds = append(cmds, fetchWorktrees(m.projectDir, m.issues), worktreeTickCmd())
+	}
+	if m.ghAvail {
+		cmds = append(cmds, fetchPullRequests(m.projectDir))
 	}
 	return tea.Batch(cmds...)
 }
 
+// fetchCurrentIssueID is the lookup behind the current issue messages.
+// Tests replace it.
+var fetchCurrentIssueID = data.FetchCurrentIssueID
+
 // fetchCurrentIssue asks bd for the active issue ID at startup.
 func fetchCurrentIssue() tea.Msg {
-	id, _ := data.FetchCurrentIssueID()
+	id, _ := fetchCurrentIssueID()
 	return currentIssueMsg{issueID: id}
 }
 
+// refreshCurrentIssue re-reads the active issue after a reload so the header
+// and the current_issue_changed hook track claims made outside mg. A failed
+// lookup (bd missing or briefly erroring) sends nothing rather than
+// reporting the issue as released.
+func refreshCurrentIssue() tea.Msg {
+	id, err := fetchCurrentIssueID()
+	if err != nil {
+		return nil
+	}
+	return currentIssueMsg{issueID: id, refresh: true}
+}
+
 // fetchDoctorDiagnostics runs bd doctor --agent --json in the background.
 func fetchDoctorDiagnostics() tea.Msg {
 	result, _ := data.FetchDoctorDiagnostics()
 	return doctorResultMsg{result: result}
 }
 
+// doctorReportMsg carries the aggregated health report shown in the D overlay.
+type doctorReportMsg struct {
+	report *data.DoctorResult
+}
+
+// fetchDoctorReport runs every doctor check in the background, using the
+// live source health rather than probing bd list again.
+func (m Model) fetchDoctorReport() tea.Cmd {
+	health := m.sourceHealth
+	opts := doctor.Options{
+		ProjectDir: m.projectDir,
+		Mode:       m.sourceMode,
+		Health:     &health,
+		GasTown:    m.gtEnv.Available,
+	}
+	if health.InFallback() {
+		opts.Mode = data.SourceCLI
+	}
+	return func() tea.Msg {
+		return doctorReportMsg{report: doctor.Run(opts, doctor.DefaultProbes())}
+	}
+}
+
 type beadsContextMsg struct {
 	ctx *data.BeadsContext
 }
@@ -372,6 +593,66 @@ func (m Model) allProblems() []gastown.Problem {
 	return problems
 }
 
+// fireIssueHooks maps reload transitions to issue_closed and issue_stalled hooks.
+func (m Model) fireIssueHooks(events []data.ChangeEvent) []tea.Cmd {
+	if !m.hooks.Enabled() {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, ev := range events {
+		hookEv := hooks.Event{IssueID: ev.IssueID, Title: ev.Title, From: ev.From, To: ev.To, At: ev.At}
+		switch {
+		case ev.Type == data.ChangeClosed:
+			hookEv.Type = hooks.IssueClosed
+		case ev.Type == data.ChangeParade && ev.To == data.ParadeStalled.String():
+			hookEv.Type = hooks.IssueStalled
+		default:
+			continue
+		}
+		if cmd := m.hooks.Fire(hookEv); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+// fireAgentHooks runs agent_stuck and agent_backoff hooks for agents that
+// newly entered those states. Agents that recover are forgotten so a later
+// relapse fires again.
+func (m *Model) fireAgentHooks() tea.Cmd {
+	if !m.hooks.Has(hooks.AgentStuck) && !m.hooks.Has(hooks.AgentBackoff) {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var cmds []tea.Cmd
+	for _, p := range gastown.DetectProblems(m.townStatus) {
+		var event string
+		switch p.Type {
+		case "stuck":
+			event = hooks.AgentStuck
+		case "backoff":
+			event = hooks.AgentBackoff
+		default:
+			continue
+		}
+		key := p.Type + "\x00" + p.Agent.Address + "\x00" + p.Agent.Name
+		seen[key] = true
+		if m.hookProblems[key] {
+			continue
+		}
+		cmds = append(cmds, m.hooks.Fire(hooks.Event{
+			Type:    event,
+			IssueID: p.Agent.HookBead,
+			Title:   p.Agent.WorkTitle,
+			Agent:   p.Agent.Name,
+			Rig:     p.Agent.Rig,
+			Detail:  p.Detail,
+		}))
+	}
+	m.hookProblems = seen
+	return tea.Batch(cmds...)
+}
+
 // startQuickAction initializes the quick-action input bar for comment/assign/label/link.
 func (m *Model) startQuickAction(mode, issueID, prompt, placeholder string) tea.Cmd {
 	m.qaMode = mode
@@ -559,9 +840,11 @@ type mutateResultMsg struct {
 // changeIndicatorExpiredMsg clears change indicators after timeout.
 type changeIndicatorExpiredMsg struct{}
 
-// currentIssueMsg carries the active issue ID from bd show --current at startup.
+// currentIssueMsg carries the active issue ID from bd show --current. Startup
+// fetches move the selection to it; refreshes only update the header.
 type currentIssueMsg struct {
 	issueID string
+	refresh bool
 }
 
 // doctorResultMsg carries bd doctor --agent results from a background fetch.
@@ -579,6 +862,12 @@ type headerShimmerMsg struct{}
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	logMsg(msg)
 
+	// Control socket commands are answered before any modal input grabs the
+	// message, so a client never waits on an open form.
+	if cm, ok := msg.(control.Msg); ok {
+		return m.handleControl(cm)
+	}
+
 	skipDeferredKeyBuffer := false
 	if deferred, ok := msg.(deferredKeyMsg); ok {
 		var key tea.KeyPressMsg
@@ -597,6 +886,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if result.Cancelled || result.Title == "" {
 			return m, nil
 		}
+		if result.Template != nil {
+			return m, createFromTemplate(result)
+		}
 		title := result.Title
 		issueType := data.IssueType(result.Type)
 		priority := components.ParsePriority(result.Priority)
@@ -715,6 +1007,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle import preview result
+	if result, ok := msg.(components.ImportDialogResult); ok {
+		return m.handleImportMsg(result)
+	}
+
+	// Forward keys to the import preview when active. Other messages fall
+	// through so polling keeps running while the preview is open.
+	if km, ok := msg.(tea.KeyPressMsg); ok && m.importing {
+		if km.String() == "ctrl+c" {
+			logRoute("importDialog ctrl+c -> quit")
+			return m, tea.Quit
+		}
+		logRoute("importDialog forward")
+		var cmd tea.Cmd
+		m.importDialog, cmd = m.importDialog.Update(msg)
+		return m, cmd
+	}
+
+	// Handle bulk edit confirmation and close
+	if result, ok := msg.(components.BulkEditResult); ok {
+		return m.handleBulkEditMsg(result)
+	}
+
+	// Forward keys to the bulk edit dialog when active
+	if km, ok := msg.(tea.KeyPressMsg); ok && m.bulkEditing {
+		if km.String() == "ctrl+c" {
+			logRoute("bulkDialog ctrl+c -> quit")
+			return m, tea.Quit
+		}
+		logRoute("bulkDialog forward")
+		var cmd tea.Cmd
+		m.bulkDialog, cmd = m.bulkDialog.Update(msg)
+		return m, cmd
+	}
+
+	// Handle calendar results and reschedules
+	switch msg.(type) {
+	case components.CalendarResult, calendarMovedMsg:
+		return m.handleCalendarMsg(msg)
+	}
+
+	// Forward keys to the calendar when active
+	if km, ok := msg.(tea.KeyPressMsg); ok && m.showCalendar {
+		if km.String() == "ctrl+c" {
+			logRoute("calendar ctrl+c -> quit")
+			return m, tea.Quit
+		}
+		logRoute("calendar forward")
+		var cmd tea.Cmd
+		m.calendar, cmd = m.calendar.Update(msg)
+		return m, cmd
+	}
+
+	// Route mouse events to the pane under the pointer
+	if mm, ok := msg.(tea.MouseMsg); ok {
+		return m.handleMouse(mm)
+	}
+
 	// Forward all messages to nudge input when active
 	if m.nudging {
 		if km, ok := msg.(tea.KeyPressMsg); ok {
@@ -756,6 +1106,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if value == "" {
 					return m, nil
 				}
+				if mode == "import" {
+					return m, m.previewImport(value)
+				}
 				return m, func() tea.Msg {
 					var err error
 					var action string
@@ -904,11 +1257,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyPressMsg:
 		return m.handleKeyPress(msg, !skipDeferredKeyBuffer)
 
+	case tea.BackgroundColorMsg:
+		return m.handleBackgroundColor(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.layout()
 		m.ready = true
+		// An automatic switch to Columns needs the Gas Town feeds running.
+		if m.layoutPreset == LayoutColumns && !m.showGasTown {
+			m.gasTownAuto = !m.layoutPinned
+			return m, m.activateGasTown()
+		}
 		return m, nil
 
 	case data.FileChangedMsg:
@@ -929,7 +1290,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Diff against previous state for change indicators
-		changes := m.diffIssues(msg.Issues)
+		changes, events := m.diffIssues(msg.Issues)
+		cmds = append(cmds, m.fireIssueHooks(events)...)
+		if len(events) > 0 && m.bdAvail {
+			cmds = append(cmds, refreshCurrentIssue)
+		}
 		if changes > 0 {
 			m.changedAt = time.Now()
 			toast, toastCmd := components.ShowToast(
@@ -944,16 +1309,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Update snapshot for next diff
-		m.prevIssueMap = make(map[string]data.Status, len(msg.Issues))
-		for _, iss := range msg.Issues {
-			m.prevIssueMap[iss.ID] = iss.Status
-		}
+		m.prevSnapshot = data.TakeSnapshot(msg.Issues, m.blockingTypes)
 
 		m.issues = msg.Issues
 		m.groups = data.GroupByParade(msg.Issues, m.blockingTypes)
 		if !msg.LastMod.IsZero() {
 			m.lastFileMod = msg.LastMod
 		}
+		if len(m.pullRequests) > 0 {
+			m.prByIssue = data.MatchPullRequests(m.pullRequests, m.issues)
+		}
 		m.rebuildParade()
 		if m.selectionLost {
 			lostID := m.lostIssueID
@@ -967,6 +1332,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, toastCmd)
 		}
 		m.recomputeVelocity()
+		// Code refs are cached per reload. Worktrees are rescanned when an
+		// issue changed, since branches map to issues, and on their own tick
+		// otherwise.
+		clear(m.codeRefs)
+		if changes > 0 {
+			cmds = append(cmds, fetchWorktrees(m.projectDir, m.issues))
+		}
 		cmds = append(cmds, m.detailFetchBatch()...)
 		return m, tea.Batch(cmds...)
 
@@ -992,7 +1364,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// On entering degraded: probe for a fresh JSONL fallback file.
-		if m.sourceHealth.State == data.HealthDegraded && m.sourceHealth.ConsecFailures == data.DegradeThreshold {
+		if m.sourceHealth.State == data.HealthDegraded && m.sourceHealth.ConsecFailures == data.CurrentTuning().DegradeThreshold {
 			if path, _, ok := data.ProbeJSONLFallback(m.projectDir); ok {
 				m.sourceHealth.State = data.HealthFallback
 				m.jsonlPath = path
@@ -1008,6 +1380,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				)
 				m.toast = toast
 				cmds = append(cmds, toastCmd)
+				cmds = append(cmds, m.hooks.Fire(hooks.Event{
+					Type:   hooks.SourceFallback,
+					From:   "bd",
+					To:     "jsonl",
+					Detail: fmt.Sprint(msg.Err),
+				}))
 			}
 			// If JSONL probe fails: stay degraded with last-good data.
 		}
@@ -1042,6 +1420,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.townStatus = msg.status
 			m.activeAgents = msg.status.ActiveAgentMap()
 			m.propagateAgentState()
+			hookCmd := m.fireAgentHooks()
 			if m.showGasTown {
 				m.gasTown.SetStatus(m.townStatus, m.gtEnv)
 				m.recomputeVelocity()
@@ -1050,9 +1429,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.problems.SetProblems(m.allProblems())
 			}
 			// Check if selected issue now has an agent → fetch molecule
-			if cmd := m.maybeFetchMolecule(); cmd != nil {
-				return m, cmd
-			}
+			return m, tea.Batch(hookCmd, m.maybeFetchMolecule())
 		}
 		return m, nil
 
@@ -1258,7 +1635,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fmt.Sprintf("Unwatched convoy %s", msg.convoyID))
 
 	case moleculeDAGMsg:
-		if msg.err == nil && msg.dag != nil {
+		// A nil DAG is kept too: it marks the issue as having no molecule
+		if msg.err == nil {
 			// Only apply if still viewing the same issue
 			if m.detail.Issue != nil && m.detail.Issue.ID == msg.issueID {
 				m.detail.SetMolecule(msg.issueID, msg.dag, msg.progress)
@@ -1299,6 +1677,68 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case codeRefsMsg:
+		refs := msg.refs
+		if msg.err != nil {
+			logAction("code refs %s: %v", msg.issueID, msg.err)
+		}
+		if refs == nil {
+			// Cache a failed scan as empty so cursor moves don't rerun
+			// git until the next reload.
+			refs = &data.CodeRefs{IssueID: msg.issueID}
+		}
+		if m.codeRefs == nil {
+			m.codeRefs = make(map[string]*data.CodeRefs)
+		}
+		m.codeRefs[msg.issueID] = refs
+		if m.detail.Issue != nil && m.detail.Issue.ID == msg.issueID {
+			m.detail.SetCodeRefs(refs)
+		}
+		return m, nil
+
+	case pullRequestsMsg:
+		if msg.err == nil {
+			m.pullRequests = msg.prs
+			m.matchPullRequests()
+		} else {
+			logAction("gh pr list: %v", msg.err)
+		}
+		return m, prTickCmd()
+
+	case prTickMsg:
+		return m, fetchPullRequests(m.projectDir)
+
+	case prOpenedMsg:
+		if msg.err != nil {
+			toast, toastCmd := components.ShowToast(
+				fmt.Sprintf("Open PR failed: %v", msg.err),
+				components.ToastError, toastDuration,
+			)
+			m.toast = toast
+			return m, toastCmd
+		}
+		return m, nil
+
+	case worktreeTickMsg, worktreesMsg, worktreeCreatedMsg, worktreesPrunedMsg:
+		return m.handleWorktreeMsg(msg)
+
+	case importPreviewMsg, importDoneMsg:
+		return m.handleImportMsg(msg)
+
+	case bulkEditDoneMsg:
+		return m.handleBulkEditMsg(msg)
+
+	case editorFinishedMsg:
+		if msg.err != nil {
+			toast, toastCmd := components.ShowToast(
+				fmt.Sprintf("Editor failed: %v", msg.err),
+				components.ToastError, toastDuration,
+			)
+			m.toast = toast
+			return m, toastCmd
+		}
+		return m, nil
+
 	case issueDetailMsg:
 		if msg.err == nil && msg.issue != nil {
 			if m.detail.Issue != nil && m.detail.Issue.ID == msg.issueID {
@@ -1368,7 +1808,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds := []tea.Cmd{toastCmd, m.startPollImmediate()}
 		// Trigger confetti on close
 		isClose := strings.HasPrefix(msg.action, "closed")
-		if !m.noAnimations && isClose && m.width > 0 && m.height > 0 {
+		if !m.noAnimations && !ui.Accessible() && isClose && m.width > 0 && m.height > 0 {
 			m.confetti = NewConfetti(m.width, m.height)
 			cmds = append(cmds, m.confetti.Tick())
 		}
@@ -1391,6 +1831,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case headerShimmerMsg:
+		if ui.Accessible() {
+			m.shimmerPaused = true
+			return m, nil
+		}
 		m.beadOffset++
 		return m, headerShimmerCmd()
 
@@ -1404,10 +1848,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case currentIssueMsg:
+		if msg.refresh {
+			if msg.issueID == m.currentIssueID {
+				return m, nil
+			}
+			ev := hooks.Event{Type: hooks.CurrentIssueChanged, IssueID: msg.issueID, From: m.currentIssueID, To: msg.issueID}
+			if iss := data.BuildIssueMap(m.issues)[msg.issueID]; iss != nil {
+				ev.Title = iss.Title
+			}
+			m.currentIssueID = msg.issueID
+			return m, m.hooks.Fire(ev)
+		}
 		if msg.issueID == "" {
 			return m, nil
 		}
 		m.currentIssueID = msg.issueID
+		if m.sessionSelected {
+			return m, nil
+		}
 		if len(m.parade.Items) > 0 {
 			m.restoreParadeSelection(msg.issueID)
 			m.syncSelection()
@@ -1416,6 +1874,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case hooks.ResultMsg:
+		if msg.Err == nil {
+			return m, nil
+		}
+		toast, cmd := components.ShowToast(
+			fmt.Sprintf("Hook %s failed: %s", msg.Event, msg.Err),
+			components.ToastError, toastDuration,
+		)
+		m.toast = toast
+		return m, cmd
+
 	case doctorResultMsg:
 		if msg.result == nil {
 			return m, nil
@@ -1436,8 +1905,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.showProblems {
 			m.problems.SetProblems(m.allProblems())
 		}
+		return m, nil
+
+	case doctorReportMsg:
+		m.doctorReport = msg.report
 		if m.showDoctor {
-			m.doctor.SetResult(msg.result)
+			m.doctor.SetResult(msg.report)
 		}
 		return m, nil
 
@@ -1467,8 +1940,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleHelpKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q", "?":
+	switch keymap.Lookup(keymap.Global, msg.String()) {
+	case keymap.Back, keymap.Quit, keymap.Help:
 		m.showHelp = false
 		return m, nil
 	default:
@@ -1483,12 +1956,14 @@ func (m Model) handleHelpKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleFilteringKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q":
+	switch keymap.Lookup(keymap.Global, msg.String()) {
+	case keymap.Quit:
 		return m, tea.Quit
-	case "?":
+	case keymap.Help:
 		m.showHelp = true
 		return m, nil
+	}
+	switch msg.String() {
 	case "esc":
 		m.filtering = false
 		m.filterInput.SetValue("")
@@ -1519,67 +1994,76 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 	// When Doctor panel is focused, route its keys before global handlers
 	if m.showDoctor && m.activPane == PaneDetail {
-		switch msg.String() {
-		case "j", "k", "up", "down", "g", "G":
-			logAction("doctor panel key: %s", msg.String())
+		switch keymap.Lookup(keymap.Doctor, str) {
+		case "":
+		case keymap.Refresh:
+			logAction("doctor panel refresh")
+			return m, m.fetchDoctorReport()
+		default:
+			logAction("doctor panel key: %s", str)
 			var cmd tea.Cmd
 			m.doctor, cmd = m.doctor.Update(msg)
 			return m, cmd
-		case "R":
-			// Refresh diagnostics
-			logAction("doctor panel refresh")
-			return m, fetchDoctorDiagnostics
 		}
 	}
 
-	// When Problems panel is focused, route its keys before global handlers
-	if m.showProblems && m.activPane == PaneDetail {
-		switch msg.String() {
-		case "j", "k", "up", "down", "g", "G", "n", "h", "K", "R":
-			logAction("problems panel key: %s", msg.String())
-			var cmd tea.Cmd
-			m.problems, cmd = m.problems.Update(msg)
-			return m, cmd
+	// When the timeline is focused, route its keys before global handlers
+	if m.showTimeline && m.activPane == PaneDetail {
+		if model, cmd, ok := m.handleTimelineKey(msg); ok {
+			return model, cmd
 		}
 	}
 
+	// When the charts are focused, route their keys before global handlers
+	if m.showCharts && m.activPane == PaneDetail && keymap.Lookup(keymap.Charts, str) != "" {
+		logAction("charts key: %s", str)
+		var cmd tea.Cmd
+		m.charts, cmd = m.charts.Update(msg)
+		return m, cmd
+	}
+
+	// When Problems panel is focused, route its keys before global handlers
+	if m.showProblems && m.activPane == PaneDetail && keymap.Lookup(keymap.Problems, str) != "" {
+		logAction("problems panel key: %s", str)
+		var cmd tea.Cmd
+		m.problems, cmd = m.problems.Update(msg)
+		return m, cmd
+	}
+
 	// When Gas Town panel is focused, route its keys before global handlers
-	if m.showGasTown && m.activPane == PaneDetail {
-		switch msg.String() {
-		case "j", "k", "up", "down", "g", "G", "n", "h", "K", "tab", "enter", "l", "x", "r", "d", "w", "W", "R":
-			logAction("gastown panel key: %s", msg.String())
-			var cmd tea.Cmd
-			m.gasTown, cmd = m.gasTown.Update(msg)
-			return m, cmd
-		}
+	if m.showGasTown && m.activPane == PaneDetail && keymap.Lookup(keymap.GasTown, str) != "" {
+		logAction("gastown panel key: %s", str)
+		var cmd tea.Cmd
+		m.gasTown, cmd = m.gasTown.Update(msg)
+		return m, cmd
 	}
 
-	switch str {
-	case "q":
+	switch keymap.Lookup(keymap.Global, str) {
+	case keymap.Quit:
 		logAction("quit")
 		return m, tea.Quit
 
-	case "?":
+	case keymap.Help:
 		logAction("help")
 		m.showHelp = true
 		return m, nil
 
-	case "/":
+	case keymap.Filter:
 		m.filtering = true
 		m.filterInput.Focus()
 		return m, textinput.Blink
 
-	case "tab":
+	case keymap.SwitchPane:
 		if m.activPane == PaneParade {
 			m.activPane = PaneDetail
 			m.detail.Focused = true
-		} else {
-			m.activPane = PaneParade
-			m.detail.Focused = false
+			return m, tea.Batch(m.detailFetchBatch()...)
 		}
+		m.activPane = PaneParade
+		m.detail.Focused = false
 		return m, nil
 
-	case "esc":
+	case keymap.Back:
 		if m.focusMode {
 			m.focusMode = false
 			m.rebuildParade()
@@ -1591,31 +2075,25 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "f":
-		m.focusMode = !m.focusMode
-		m.rebuildParade()
-		if m.focusMode {
-			toast, cmd := components.ShowToast("Focus mode ON", components.ToastInfo, toastDuration)
-			m.toast = toast
-			return m, cmd
-		}
-		toast, cmd := components.ShowToast("Focus mode OFF", components.ToastInfo, toastDuration)
-		m.toast = toast
-		return m, cmd
+	case keymap.FocusMode:
+		return m.setFocusMode(!m.focusMode)
 
-	case "ctrl+g":
+	case keymap.ToggleGasTown:
 		if !m.gtEnv.Available {
 			return m, nil
 		}
 		m.showGasTown = !m.showGasTown
+		m.gasTownAuto = false
 		if m.showGasTown {
 			m.showDoctor = false
+			m.showTimeline = false
+			m.showCharts = false
 			cmd := m.activateGasTown()
 			return m, cmd
 		}
 		return m, nil
 
-	case "p":
+	case keymap.ToggleProblems:
 		if !m.gtEnv.Available {
 			return m, nil
 		}
@@ -1623,53 +2101,76 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		if m.showProblems {
 			m.showGasTown = false
 			m.showDoctor = false
+			m.showTimeline = false
+			m.showCharts = false
 			m.problems.SetProblems(m.allProblems())
 		}
 		return m, nil
 
-	case "D":
+	case keymap.ToggleDoctor:
 		m.showDoctor = !m.showDoctor
 		if m.showDoctor {
 			m.showGasTown = false
 			m.showProblems = false
-			// Set existing result if available, then refresh
-			if m.doctorResult != nil {
-				m.doctor.SetResult(m.doctorResult)
+			m.showTimeline = false
+			m.showCharts = false
+			// Set existing report if available, then refresh
+			if m.doctorReport != nil {
+				m.doctor.SetResult(m.doctorReport)
 			}
-			return m, fetchDoctorDiagnostics
+			return m, m.fetchDoctorReport()
 		}
 		return m, nil
 
-	case "c":
+	case keymap.ToggleTimeline:
+		return m.toggleTimeline()
+
+	case keymap.OpenCalendar:
+		return m.openCalendar()
+
+	case keymap.ToggleCharts:
+		return m.toggleCharts()
+
+	case keymap.GrowParade:
+		return m.resizeSplit(1)
+
+	case keymap.ShrinkParade:
+		return m.resizeSplit(-1)
+
+	case keymap.ToggleClosed:
 		m.parade.ToggleClosed()
 		m.syncSelection()
 		return m, nil
 
 	// Quick actions: status changes (6.1)
-	case "1":
+	case keymap.SetInProgress:
 		return m.quickAction(data.StatusInProgress, "in_progress")
-	case "2":
+	case keymap.SetOpen:
 		return m.quickAction(data.StatusOpen, "open")
-	case "3":
+	case keymap.CloseIssue:
 		return m.closeSelectedIssue()
 
 	// Quick actions: priority changes (6.1)
-	case "!": // Shift+1
+	case keymap.PriorityHigh:
 		return m.setPriority(data.PriorityHigh)
-	case "@": // Shift+2
+	case keymap.PriorityMedium:
 		return m.setPriority(data.PriorityMedium)
-	case "#": // Shift+3
+	case keymap.PriorityLow:
 		return m.setPriority(data.PriorityLow)
-	case "$": // Shift+4
+	case keymap.PriorityBacklog:
 		return m.setPriority(data.PriorityBacklog)
 
 	// Git branch name copy (5.5)
-	case "b":
+	case keymap.CopyBranch:
 		return m.copyBranchName()
-	case "B":
+	case keymap.CreateBranch:
 		return m.createAndSwitchBranch()
+	case keymap.CreateWorktree:
+		return m.createWorktree()
+	case keymap.OpenPR:
+		return m.openPullRequest()
 
-	case "a":
+	case keymap.LaunchAgent:
 		// Multi-sling with Gas Town
 		if selected := m.parade.SelectedIssues(); len(selected) > 0 && m.gtEnv.Available {
 			ids := make([]string, len(selected))
@@ -1703,22 +2204,23 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		deps := issue.EvaluateDependencies(m.detail.IssueMap, m.blockingTypes)
 		prompt := agent.BuildPrompt(*issue, deps, m.detail.IssueMap)
 
+		dir := m.agentDir(issue.ID)
 		if m.inTmux {
 			issueID := issue.ID
 			return m, func() tea.Msg {
-				winName, err := agent.LaunchInTmux(prompt, m.projectDir, issueID)
+				winName, err := agent.LaunchInTmux(prompt, dir, issueID)
 				if err != nil {
 					return agentLaunchErrorMsg{issueID: issueID, err: err}
 				}
 				return agentLaunchedMsg{issueID: issueID, windowName: winName}
 			}
 		}
-		c := agent.Command(prompt, m.projectDir)
+		c := agent.Command(prompt, dir)
 		return m, tea.ExecProcess(c, func(err error) tea.Msg {
 			return agentFinishedMsg{err: err}
 		})
 
-	case "A":
+	case keymap.KillAgent:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
 			return m, nil
@@ -1741,7 +2243,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "s":
+	case keymap.SlingFormula:
 		if !m.gtEnv.Available {
 			return m, nil
 		}
@@ -1771,7 +2273,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return formulaListMsg{formulas: formulas, err: err}
 		}
 
-	case "n":
+	case keymap.Nudge:
 		issue := m.parade.SelectedIssue
 		if issue == nil || !m.gtEnv.Available {
 			return m, nil
@@ -1789,12 +2291,10 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.nudgeInput.Focus()
 		return m, textinput.Blink
 
-	case "N":
-		m.creating = true
-		m.createForm = m.newCreateForm()
-		return m, m.createForm.Init()
+	case keymap.NewIssue:
+		return m.openCreateForm(false)
 
-	case "e": // Edit selected issue
+	case keymap.EditIssue:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
 			return m, nil
@@ -1803,7 +2303,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.editForm = components.NewEditForm(m.width, m.height, issue)
 		return m, m.editForm.Init()
 
-	case "r": // Comment (remark)
+	case keymap.Comment:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
 			return m, nil
@@ -1811,7 +2311,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		cmd := m.startQuickAction("comment", issue.ID, "comment> ", "Add comment to "+issue.ID+"...")
 		return m, cmd
 
-	case "y": // Assign (yours)
+	case keymap.Assign:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
 			return m, nil
@@ -1819,7 +2319,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		cmd := m.startQuickAction("assign", issue.ID, "assign> ", "Assignee name...")
 		return m, cmd
 
-	case "t": // Tag/label
+	case keymap.AddLabel:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
 			return m, nil
@@ -1827,7 +2327,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		cmd := m.startQuickAction("label", issue.ID, "label> ", "Label name...")
 		return m, cmd
 
-	case "l": // Link/dependency
+	case keymap.Link:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
 			return m, nil
@@ -1835,7 +2335,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		cmd := m.startQuickAction("link", issue.ID, "link> ", "Issue ID that "+issue.ID+" depends on...")
 		return m, cmd
 
-	case "C":
+	case keymap.CreateConvoy:
 		if !m.gtEnv.Available {
 			return m, nil
 		}
@@ -1871,11 +2371,7 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.convoyInput.Focus()
 		return m, textinput.Blink
 
-	case "ctrl+k":
-		m.showPalette = true
-		m.palette = components.NewPalette(m.width, m.height, m.buildPaletteCommands())
-		return m, m.palette.Init()
-	case ":":
+	case keymap.Palette:
 		m.showPalette = true
 		m.palette = components.NewPalette(m.width, m.height, m.buildPaletteCommands())
 		return m, m.palette.Init()
@@ -1884,44 +2380,47 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	// Navigation keys depend on active pane
 	if m.activPane == PaneParade {
 		logAction("parade nav: %s", str)
-		switch str {
-		case "j", "down":
+		switch keymap.Lookup(keymap.Parade, str) {
+		case keymap.Down:
 			m.parade.MoveDown()
 			m.syncSelection()
-		case "k", "up":
+		case keymap.Up:
 			m.parade.MoveUp()
 			m.syncSelection()
-		case "J": // Shift+J: select + move down
+		case keymap.SelectDown:
 			m.parade.ToggleSelect()
 			m.parade.MoveDown()
 			m.syncSelection()
-		case "K": // Shift+K: select + move up
+		case keymap.SelectUp:
 			m.parade.ToggleSelect()
 			m.parade.MoveUp()
 			m.syncSelection()
-		case "space", "x": // Toggle multi-select
+		case keymap.ToggleSelect:
 			m.parade.ToggleSelect()
-		case "X": // Clear all selections
+		case keymap.ClearSelection:
 			m.parade.ClearSelection()
-		case "g":
-			m.parade.Cursor = 0
-			m.parade.ScrollOffset = 0
-			for i, item := range m.parade.Items {
-				if !item.IsHeader {
-					m.parade.Cursor = i
-					break
-				}
-			}
+		case keymap.BulkEdit:
+			return m.openBulkEdit()
+		case keymap.Top:
+			m.parade.MoveTop()
 			m.syncSelection()
-		case "G":
-			for i := len(m.parade.Items) - 1; i >= 0; i-- {
-				if !m.parade.Items[i].IsHeader {
-					m.parade.Cursor = i
-					break
-				}
+		case keymap.Bottom:
+			m.parade.MoveBottom()
+			m.syncSelection()
+		case keymap.ToggleSection:
+			if status, ok := m.parade.CursorSection(); ok {
+				m.parade.ToggleSection(status)
+				m.syncSelection()
 			}
+		case keymap.ExpandSections:
+			m.parade.ExpandAll()
 			m.syncSelection()
-		case "enter":
+		case keymap.ToggleRows:
+			if status, ok := m.parade.CursorSection(); ok {
+				m.parade.ToggleRows(status)
+				m.syncSelection()
+			}
+		case keymap.Open:
 			m.activPane = PaneDetail
 			m.detail.Focused = true
 			if cmds := m.detailFetchBatch(); len(cmds) > 0 {
@@ -1940,12 +2439,24 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 		var cmd tea.Cmd
-		switch str {
-		case "j", "down":
+		switch keymap.Lookup(keymap.Detail, str) {
+		case keymap.Down:
 			m.detail.Viewport.ScrollDown(1)
-		case "k", "up":
+		case keymap.Up:
 			m.detail.Viewport.ScrollUp(1)
-		case "m":
+		case keymap.PrevTab:
+			m.detail.CycleTab(-1)
+			return m, tea.Batch(m.detailFetchBatch()...)
+		case keymap.NextTab:
+			m.detail.CycleTab(1)
+			return m, tea.Batch(m.detailFetchBatch()...)
+		case keymap.PrevRef:
+			m.detail.MoveRefCursor(-1)
+		case keymap.NextRef:
+			m.detail.MoveRefCursor(1)
+		case keymap.OpenRef:
+			return m.openCodeHit()
+		case keymap.StepDone:
 			// Mark current molecule step as done
 			if m.detail.MoleculeDAG != nil {
 				stepID := m.detail.MoleculeDAG.ActiveStepID()
@@ -2164,39 +2675,58 @@ func createBranchCmd(ctx context.Context, projectDir, branch string) *exec.Cmd {
 // buildPaletteCommands returns the context-aware list of palette commands.
 func (m Model) buildPaletteCommands() []components.PaletteCommand {
 	cmds := []components.PaletteCommand{
-		{Name: "Set status: in_progress", Desc: "Mark issue as rolling", Key: "1", Action: components.ActionSetInProgress},
-		{Name: "Set status: open", Desc: "Mark issue as lined up", Key: "2", Action: components.ActionSetOpen},
-		{Name: "Close issue", Desc: "Mark issue as closed", Key: "3", Action: components.ActionCloseIssue},
-		{Name: "Set priority: P1 high", Desc: "Urgent work", Key: "!", Action: components.ActionSetPriorityHigh},
-		{Name: "Set priority: P2 medium", Desc: "Normal priority", Key: "@", Action: components.ActionSetPriorityMedium},
-		{Name: "Set priority: P3 low", Desc: "Can wait", Key: "#", Action: components.ActionSetPriorityLow},
-		{Name: "Set priority: P4 backlog", Desc: "Someday maybe", Key: "$", Action: components.ActionSetPriorityBacklog},
-		{Name: "Copy branch name", Desc: "Copy git branch to clipboard", Key: "b", Action: components.ActionCopyBranch},
-		{Name: "Create git branch", Desc: "Checkout new branch for issue", Key: "B", Action: components.ActionCreateBranch},
-		{Name: "New issue", Desc: "Create a new beads issue", Key: "N", Action: components.ActionNewIssue},
+		{Name: "Set status: in_progress", Desc: "Mark issue as rolling", Key: keymap.Label(keymap.Global, keymap.SetInProgress), Action: components.ActionSetInProgress},
+		{Name: "Set status: open", Desc: "Mark issue as lined up", Key: keymap.Label(keymap.Global, keymap.SetOpen), Action: components.ActionSetOpen},
+		{Name: "Close issue", Desc: "Mark issue as closed", Key: keymap.Label(keymap.Global, keymap.CloseIssue), Action: components.ActionCloseIssue},
+		{Name: "Set priority: P1 high", Desc: "Urgent work", Key: keymap.Label(keymap.Global, keymap.PriorityHigh), Action: components.ActionSetPriorityHigh},
+		{Name: "Set priority: P2 medium", Desc: "Normal priority", Key: keymap.Label(keymap.Global, keymap.PriorityMedium), Action: components.ActionSetPriorityMedium},
+		{Name: "Set priority: P3 low", Desc: "Can wait", Key: keymap.Label(keymap.Global, keymap.PriorityLow), Action: components.ActionSetPriorityLow},
+		{Name: "Set priority: P4 backlog", Desc: "Someday maybe", Key: keymap.Label(keymap.Global, keymap.PriorityBacklog), Action: components.ActionSetPriorityBacklog},
+		{Name: "Copy branch name", Desc: "Copy git branch to clipboard", Key: keymap.Label(keymap.Global, keymap.CopyBranch), Action: components.ActionCopyBranch},
+		{Name: "Create git branch", Desc: "Checkout new branch for issue", Key: keymap.Label(keymap.Global, keymap.CreateBranch), Action: components.ActionCreateBranch},
+		{Name: "Create worktree", Desc: "Check out issue branch in its own worktree", Key: keymap.Label(keymap.Global, keymap.CreateWorktree), Action: components.ActionCreateWorktree},
+		{Name: "Prune merged worktrees", Desc: "Remove clean worktrees whose branch is merged", Key: "", Action: components.ActionPruneWorktrees},
+		{Name: "New issue", Desc: "Create a new beads issue", Key: keymap.Label(keymap.Global, keymap.NewIssue), Action: components.ActionNewIssue},
+		{Name: "New from template", Desc: "Create an issue from .beads/templates", Key: "", Action: components.ActionNewFromTemplate},
 		{Name: "Add note", Desc: "Add a note to the selected issue", Key: "", Action: components.ActionAddNote},
-		{Name: "Toggle focus mode", Desc: "Show only my work + top priority", Key: "f", Action: components.ActionToggleFocus},
-		{Name: "Toggle closed issues", Desc: "Show/hide past the stand", Key: "c", Action: components.ActionToggleClosed},
-		{Name: "Filter", Desc: "Fuzzy filter the parade list", Key: "/", Action: components.ActionFilter},
-		{Name: "Help", Desc: "Show keybinding help", Key: "?", Action: components.ActionHelp},
-		{Name: "Quit", Desc: "Exit Mardi Gras", Key: "q", Action: components.ActionQuit},
+		{Name: "Bulk edit selected", Desc: "Priority, assignee, labels, dates, blocker, convoy or close", Key: keymap.Label(keymap.Parade, keymap.BulkEdit), Action: components.ActionBulkEdit},
+		{Name: "Import issues", Desc: "Create issues from a Markdown, CSV or GitHub export", Key: "", Action: components.ActionImport},
+		{Name: "Toggle focus mode", Desc: "Show only my work + top priority", Key: keymap.Label(keymap.Global, keymap.FocusMode), Action: components.ActionToggleFocus},
+		{Name: "Toggle closed issues", Desc: "Show/hide past the stand", Key: keymap.Label(keymap.Global, keymap.ToggleClosed), Action: components.ActionToggleClosed},
+		{Name: "Filter", Desc: "Fuzzy filter the parade list", Key: keymap.Label(keymap.Global, keymap.Filter), Action: components.ActionFilter},
+		{Name: "Help", Desc: "Show keybinding help", Key: keymap.Label(keymap.Global, keymap.Help), Action: components.ActionHelp},
+		{Name: "Quit", Desc: "Exit Mardi Gras", Key: keymap.Label(keymap.Global, keymap.Quit), Action: components.ActionQuit},
 		{Name: "Cycle layout", Desc: "Switch panel arrangement", Key: "", Action: components.ActionCycleLayout},
+		{Name: "Toggle timeline", Desc: "Plot issues on a day/week time axis", Key: keymap.Label(keymap.Global, keymap.ToggleTimeline), Action: components.ActionToggleTimeline},
+		{Name: "Calendar", Desc: "Due and defer dates by month or week", Key: keymap.Label(keymap.Global, keymap.OpenCalendar), Action: components.ActionCalendar},
+		{Name: "Toggle charts", Desc: "Cumulative flow, burndown and lead/cycle times", Key: keymap.Label(keymap.Global, keymap.ToggleCharts), Action: components.ActionToggleCharts},
+		{Name: "Auto layout", Desc: "Pick the layout from the terminal size", Key: "", Action: components.ActionAutoLayout},
+		{Name: "Grow parade", Desc: "Move the split to widen the parade", Key: keymap.Label(keymap.Global, keymap.GrowParade), Action: components.ActionGrowParade},
+		{Name: "Shrink parade", Desc: "Move the split to narrow the parade", Key: keymap.Label(keymap.Global, keymap.ShrinkParade), Action: components.ActionShrinkParade},
+		{Name: "Cycle theme", Desc: "Switch between dark, light, high-contrast, mono and custom themes", Key: "", Action: components.ActionCycleTheme},
+		{Name: "Toggle accessible mode", Desc: "ASCII symbols, text labels, no animation", Key: "", Action: components.ActionToggleAccessible},
+	}
+
+	if issue := m.parade.SelectedIssue; issue != nil && m.prByIssue[issue.ID] != nil {
+		cmds = append(cmds,
+			components.PaletteCommand{Name: "Open pull request", Desc: fmt.Sprintf("Open #%d in the browser", m.prByIssue[issue.ID].Number), Key: keymap.Label(keymap.Global, keymap.OpenPR), Action: components.ActionOpenPR},
+		)
 	}
 
 	if m.agentAvail {
 		cmds = append(cmds,
-			components.PaletteCommand{Name: "Launch agent", Desc: fmt.Sprintf("Start %s agent on issue", m.agentRuntime.RuntimeLabel()), Key: "a", Action: components.ActionLaunchAgent},
-			components.PaletteCommand{Name: "Kill agent", Desc: "Stop agent working on issue", Key: "A", Action: components.ActionKillAgent},
+			components.PaletteCommand{Name: "Launch agent", Desc: fmt.Sprintf("Start %s agent on issue", m.agentRuntime.RuntimeLabel()), Key: keymap.Label(keymap.Global, keymap.LaunchAgent), Action: components.ActionLaunchAgent},
+			components.PaletteCommand{Name: "Kill agent", Desc: "Stop agent working on issue", Key: keymap.Label(keymap.Global, keymap.KillAgent), Action: components.ActionKillAgent},
 		)
 	}
 
 	if m.gtEnv.Available {
 		cmds = append(cmds,
-			components.PaletteCommand{Name: "Toggle Gas Town", Desc: "Show/hide Gas Town panel", Key: "^g", Action: components.ActionToggleGasTown},
-			components.PaletteCommand{Name: "Sling with formula", Desc: "Pick formula and sling to polecat", Key: "s", Action: components.ActionSlingFormula},
-			components.PaletteCommand{Name: "Nudge agent", Desc: "Nudge agent with message", Key: "n", Action: components.ActionNudgeAgent},
+			components.PaletteCommand{Name: "Toggle Gas Town", Desc: "Show/hide Gas Town panel", Key: keymap.Label(keymap.Global, keymap.ToggleGasTown), Action: components.ActionToggleGasTown},
+			components.PaletteCommand{Name: "Sling with formula", Desc: "Pick formula and sling to polecat", Key: keymap.Label(keymap.Global, keymap.SlingFormula), Action: components.ActionSlingFormula},
+			components.PaletteCommand{Name: "Nudge agent", Desc: "Nudge agent with message", Key: keymap.Label(keymap.Global, keymap.Nudge), Action: components.ActionNudgeAgent},
 			components.PaletteCommand{Name: "Create & assign to crew", Desc: "Create issue and hook to crew member", Key: "", Action: components.ActionAssign},
-			components.PaletteCommand{Name: "Create convoy", Desc: "Create convoy from selected issues", Key: "C", Action: components.ActionCreateConvoy},
+			components.PaletteCommand{Name: "Create convoy", Desc: "Create convoy from selected issues", Key: keymap.Label(keymap.Global, keymap.CreateConvoy), Action: components.ActionCreateConvoy},
 			components.PaletteCommand{Name: "Cascade close", Desc: "Close issue and all children", Key: "", Action: components.ActionCascadeClose},
 		)
 		if deadRigs := gastown.FindDeadRigs(m.townStatus); len(deadRigs) > 0 {
@@ -2230,10 +2760,16 @@ func (m Model) executePaletteAction(action components.PaletteAction) (tea.Model,
 		return m.copyBranchName()
 	case components.ActionCreateBranch:
 		return m.createAndSwitchBranch()
+	case components.ActionCreateWorktree:
+		return m.createWorktree()
+	case components.ActionPruneWorktrees:
+		return m.pruneWorktrees()
+	case components.ActionOpenPR:
+		return m.openPullRequest()
 	case components.ActionNewIssue:
-		m.creating = true
-		m.createForm = m.newCreateForm()
-		return m, m.createForm.Init()
+		return m.openCreateForm(false)
+	case components.ActionNewFromTemplate:
+		return m.openCreateForm(true)
 	case components.ActionAddNote:
 		issue := m.parade.SelectedIssue
 		if issue == nil {
@@ -2241,16 +2777,13 @@ func (m Model) executePaletteAction(action components.PaletteAction) (tea.Model,
 		}
 		cmd := m.startQuickAction("note", issue.ID, "note> ", "Add note to "+issue.ID+"...")
 		return m, cmd
-	case components.ActionToggleFocus:
-		m.focusMode = !m.focusMode
-		m.rebuildParade()
-		label := "Focus mode ON"
-		if !m.focusMode {
-			label = "Focus mode OFF"
-		}
-		toast, cmd := components.ShowToast(label, components.ToastInfo, toastDuration)
-		m.toast = toast
+	case components.ActionImport:
+		cmd := m.startQuickAction("import", "", "import> ", "Path to a .md, .csv or GitHub .json file...")
 		return m, cmd
+	case components.ActionBulkEdit:
+		return m.openBulkEdit()
+	case components.ActionToggleFocus:
+		return m.setFocusMode(!m.focusMode)
 	case components.ActionToggleClosed:
 		m.parade.ToggleClosed()
 		m.syncSelection()
@@ -2276,7 +2809,10 @@ func (m Model) executePaletteAction(action components.PaletteAction) (tea.Model,
 			return m, nil
 		}
 		m.showGasTown = !m.showGasTown
+		m.gasTownAuto = false
 		if m.showGasTown {
+			m.showTimeline = false
+			m.showCharts = false
 			cmd := m.activateGasTown()
 			return m, cmd
 		}
@@ -2286,22 +2822,23 @@ func (m Model) executePaletteAction(action components.PaletteAction) (tea.Model,
 	case components.ActionCascadeClose:
 		return m.cascadeCloseIssue()
 	case components.ActionCycleLayout:
-		m.layoutPreset = (m.layoutPreset + 1) % layoutPresetCount
-		labels := [...]string{"Default", "Gas Town", "Wide"}
-		// Auto-toggle gastown panel for the GasTown preset
-		switch m.layoutPreset {
-		case LayoutGasTown:
-			toast, cmd := components.ShowToast("Layout: "+labels[m.layoutPreset], components.ToastInfo, toastDuration)
-			m.toast = toast
-			m.layout()
-			return m, tea.Batch(cmd, m.activateGasTown())
-		case LayoutDefault:
-			m.showGasTown = false
-		}
-		toast, cmd := components.ShowToast("Layout: "+labels[m.layoutPreset], components.ToastInfo, toastDuration)
-		m.toast = toast
-		m.layout()
-		return m, cmd
+		return m.setLayout((m.layoutPreset + 1) % layoutPresetCount)
+	case components.ActionAutoLayout:
+		return m.setAutoLayout()
+	case components.ActionGrowParade:
+		return m.resizeSplit(1)
+	case components.ActionShrinkParade:
+		return m.resizeSplit(-1)
+	case components.ActionCycleTheme:
+		return m.cycleTheme()
+	case components.ActionToggleAccessible:
+		return m.toggleAccessible()
+	case components.ActionToggleTimeline:
+		return m.toggleTimeline()
+	case components.ActionCalendar:
+		return m.openCalendar()
+	case components.ActionToggleCharts:
+		return m.toggleCharts()
 	case components.ActionRecoverRigs:
 		deadRigs := gastown.FindDeadRigs(m.townStatus)
 		if len(deadRigs) == 0 {
@@ -2537,35 +3074,28 @@ func (m Model) handleRecoveryResult(msg recoveryResultMsg) (tea.Model, tea.Cmd)
 	return m, tea.Batch(cmd, refreshCmd)
 }
 
-// diffIssues compares new issues against the previous snapshot and returns the count of changes.
-func (m *Model) diffIssues(newIssues []data.Issue) int {
-	if len(m.prevIssueMap) == 0 {
-		return 0
-	}
-
-	changed := 0
-	newMap := make(map[string]data.Status, len(newIssues))
-	for _, iss := range newIssues {
-		newMap[iss.ID] = iss.Status
+// diffIssues compares new issues against the previous snapshot and returns
+// the number of issues whose status changed or that appeared or disappeared,
+// along with every transition (priority, assignee and blocking too) for the
+// hooks. Only the counted issues get the change highlight.
+func (m *Model) diffIssues(newIssues []data.Issue) (int, []data.ChangeEvent) {
+	if len(m.prevSnapshot) == 0 {
+		return 0, nil
 	}
 
-	// Check for status changes or new issues
-	for id, newStatus := range newMap {
-		oldStatus, existed := m.prevIssueMap[id]
-		if !existed || oldStatus != newStatus {
-			m.changedIDs[id] = true
-			changed++
-		}
-	}
-
-	// Check for removed issues
-	for id := range m.prevIssueMap {
-		if _, exists := newMap[id]; !exists {
-			changed++
+	events := data.DiffSnapshots(m.prevSnapshot, data.TakeSnapshot(newIssues, m.blockingTypes), time.Now())
+	seen := make(map[string]bool, len(events))
+	for _, ev := range events {
+		switch ev.Type {
+		case data.ChangeCreated, data.ChangeStatus, data.ChangeClosed:
+			m.changedIDs[ev.IssueID] = true
+		case data.ChangeRemoved:
+		default:
+			continue
 		}
+		seen[ev.IssueID] = true
 	}
-
-	return changed
+	return len(seen), events
 }
 
 // syncSelection updates the detail panel with the currently selected issue.
@@ -2601,8 +3131,13 @@ func (m *Model) maybeFetchMolecule() tea.Cmd {
 	if _, active := m.activeAgents[issue.ID]; !active {
 		return nil
 	}
-	// Don't re-fetch if we already have data for this issue
-	if m.detail.MoleculeIssueID == issue.ID && m.detail.MoleculeDAG != nil {
+	// Fetched when the Molecule tab opens
+	if m.detail.Tab != views.TabMolecule {
+		return nil
+	}
+	// Don't re-fetch if we already have data for this issue, including a
+	// nil DAG for "no molecule"
+	if m.detail.MoleculeIssueID == issue.ID {
 		return nil
 	}
 	return fetchMoleculeDAG(issue.ID)
@@ -2614,6 +3149,10 @@ func (m *Model) maybeFetchComments() tea.Cmd {
 	if issue == nil {
 		return nil
 	}
+	// Fetched when the Comments tab opens
+	if m.detail.Tab != views.TabComments {
+		return nil
+	}
 	// Don't re-fetch if we already have comments cached for this issue
 	if m.detail.CommentsIssueID == issue.ID {
 		return nil
@@ -2633,8 +3172,8 @@ func (m *Model) maybeFetchIssueDetail() tea.Cmd {
 	return fetchIssueDetail(issue.ID)
 }
 
-// detailFetchBatch returns Cmds to refetch molecule, comments, and rich detail
-// for the currently selected issue when their caches are stale.
+// detailFetchBatch returns Cmds to refetch molecule, comments, rich detail and
+// code references for the currently selected issue when their caches are stale.
 func (m *Model) detailFetchBatch() []tea.Cmd {
 	var cmds []tea.Cmd
 	if cmd := m.maybeFetchMolecule(); cmd != nil {
@@ -2646,6 +3185,9 @@ func (m *Model) detailFetchBatch() []tea.Cmd {
 	if cmd := m.maybeFetchIssueDetail(); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
+	if cmd := m.maybeFetchCodeRefs(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 	return cmds
 }
 
@@ -2658,18 +3200,17 @@ func (m *Model) layout() {
 		bodyH = 1
 	}
 
-	var paradeW, detailW int
-	switch m.layoutPreset {
-	case LayoutWide:
-		paradeW = m.width
-		detailW = 0
-	default:
-		paradeW = m.width * 2 / 5
-		if paradeW < 30 {
-			paradeW = 30
+	if !m.layoutPinned {
+		m.layoutPreset = autoLayout(m.width, m.gtEnv.Available)
+		// Leaving Columns closes the Gas Town panel it opened.
+		if m.layoutPreset != LayoutColumns && m.gasTownAuto {
+			m.showGasTown = false
+			m.gasTownAuto = false
 		}
-		detailW = m.width - paradeW
 	}
+	m.panes = m.paneGeometry(bodyH)
+	paradeW, detailW := m.panes.paradeW, m.panes.detailW
+	paradeH, detailH := m.panes.paradeH, m.panes.detailH
 
 	m.header = components.Header{
 		Width:            m.width,
@@ -2682,11 +3223,18 @@ func (m *Model) layout() {
 		CurrentIssueID:   m.currentIssueID,
 	}
 
-	m.parade.SetSize(paradeW, bodyH)
-	m.detail.SetSize(detailW, bodyH)
-	m.gasTown.SetSize(detailW, bodyH)
-	m.problems.SetSize(detailW, bodyH)
-	m.doctor.SetSize(detailW, bodyH)
+	m.parade.SetSize(paradeW, paradeH)
+	m.detail.SetSize(detailW, detailH)
+	if m.panes.gasTownW > 0 {
+		m.gasTown.SetSize(m.panes.gasTownW, bodyH)
+	} else {
+		m.gasTown.SetSize(detailW, detailH)
+	}
+	m.problems.SetSize(detailW, detailH)
+	m.doctor.SetSize(detailW, detailH)
+	m.timeline.SetSize(detailW, detailH)
+	m.charts.SetSize(detailW, detailH)
+	m.calendar.SetSize(m.width, m.height)
 	m.detail.AllIssues = m.issues
 	detailIssueMap := data.BuildIssueMap(m.issues)
 	m.detail.IssueMap = detailIssueMap
@@ -2695,7 +3243,10 @@ func (m *Model) layout() {
 
 	if len(m.parade.Items) == 0 {
 		visibleIssues := data.ExcludeByType(m.issues, m.excludeTypes)
-		m.parade = views.NewParadeWithData(visibleIssues, m.groups, detailIssueMap, paradeW, bodyH, m.blockingTypes)
+		m.parade = views.NewParadeWithData(visibleIssues, m.groups, detailIssueMap, paradeW, paradeH, m.blockingTypes)
+		if m.sectionRows > 0 {
+			m.parade.SetFolding(nil, nil, m.sectionRows)
+		}
 		m.syncSelection()
 		if m.pendingCurrentID != "" {
 			m.restoreParadeSelection(m.pendingCurrentID)
@@ -2703,8 +3254,11 @@ func (m *Model) layout() {
 			m.pendingCurrentID = ""
 		}
 	}
+	if m.pendingSession != nil {
+		m.restoreSession()
+	}
 
-	m.detail.Viewport = viewport.New(viewport.WithWidth(detailW-2), viewport.WithHeight(bodyH))
+	m.detail.Viewport = viewport.New(viewport.WithWidth(detailW-2), viewport.WithHeight(m.detail.Viewport.Height()))
 	m.propagateAgentState()
 	if m.parade.SelectedIssue != nil {
 		m.detail.SetIssue(m.parade.SelectedIssue)
@@ -2727,6 +3281,8 @@ func (m *Model) rebuildParade() {
 		oldSelectedID = m.parade.SelectedIssue.ID
 	}
 	oldShowClosed := m.parade.ShowClosed
+	oldCollapsed := m.parade.Collapsed
+	oldRevealed := m.parade.Revealed
 
 	paradeW := m.parade.Width
 	bodyH := m.parade.Height
@@ -2737,7 +3293,8 @@ func (m *Model) rebuildParade() {
 		bodyH = m.height - 4
 	}
 
-	filteredIssues, highlights := data.FilterIssuesWithHighlights(m.issues, m.filterInput.Value())
+	m.metaViolations = m.metadataSchema.ValidateIssues(m.issues)
+	filteredIssues, highlights := data.FilterIssuesWithHighlights(m.issues, m.filterInput.Value(), m.filterPredicates())
 	filteredIssues = data.ExcludeByType(filteredIssues, m.excludeTypes)
 	if m.focusMode {
 		filteredIssues = data.FocusFilter(filteredIssues, m.blockingTypes)
@@ -2763,9 +3320,15 @@ func (m *Model) rebuildParade() {
 
 	m.parade = views.NewParadeWithData(filteredIssues, groups, paradeIssueMap, paradeW, bodyH, m.blockingTypes)
 	m.parade.MatchHighlights = highlights
+	m.parade.InvalidIDs = m.invalidSeverities()
+	m.parade.WorktreeIDs = m.worktreeIDs()
+	m.parade.PullRequests = m.prByIssue
 	if oldShowClosed {
 		m.parade.ToggleClosed()
 	}
+	if len(oldCollapsed) > 0 || m.sectionRows > 0 {
+		m.parade.SetFolding(oldCollapsed, oldRevealed, m.sectionRows)
+	}
 	found := m.restoreParadeSelection(oldSelectedID)
 	if !found && oldSelectedID != "" {
 		// The previously-selected issue is gone. Fall back to the nearest
@@ -2785,12 +3348,38 @@ func (m *Model) rebuildParade() {
 	m.detail.AllIssues = m.issues
 	m.detail.IssueMap = detailIssueMap
 	m.detail.BlockingTypes = m.blockingTypes
+	m.detail.PullRequests = m.prByIssue
+	if m.showCalendar {
+		m.calendar.SetIssues(m.issues)
+	}
 	m.propagateAgentState()
 	m.syncSelection()
 }
 
-// restoreParadeSelection restores selection by issue ID when possible.
-// Returns true if the ID was found and selection was restored, false if not found.
+// filterPredicates returns the filter tokens whose answer depends on model
+// state rather than the issue itself.
+func (m *Model) filterPredicates() data.TokenPredicates {
+	violations := m.metaViolations
+	preds := prFilterPredicates(m.prByIssue)
+	preds["is:invalid"] = func(iss data.Issue) bool { return len(violations[iss.ID]) > 0 }
+	return preds
+}
+
+// invalidSeverities maps each issue with metadata violations to its severity.
+func (m *Model) invalidSeverities() map[string]string {
+	if len(m.metaViolations) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m.metaViolations))
+	for id, vs := range m.metaViolations {
+		out[id] = vs[0].Severity
+	}
+	return out
+}
+
+// restoreParadeSelection restores selection by issue ID when possible,
+// expanding the section or rows that hide it. Returns true if the ID was
+// found and selection was restored, false if not found.
 func (m *Model) restoreParadeSelection(issueID string) bool {
 	if issueID == "" {
 		return false
@@ -2821,6 +3410,10 @@ func (m *Model) restoreParadeSelection(issueID string) bool {
 		}
 		return true
 	}
+	// A collapsed section or the row cap may be hiding it.
+	if m.parade.Reveal(issueID) {
+		return m.restoreParadeSelection(issueID)
+	}
 	return false
 }
 
@@ -2829,36 +3422,7 @@ func (m *Model) restoreParadeSelection(issueID string) bool {
 // previously-selected issue has been removed from the issue set. If the parade
 // contains no selectable items, selection is cleared.
 func (m *Model) selectNearestSelectable(hintCursor int) {
-	items := m.parade.Items
-	if len(items) == 0 {
-		m.parade.SelectedIssue = nil
-		return
-	}
-
-	// Clamp the hint to valid range.
-	if hintCursor >= len(items) {
-		hintCursor = len(items) - 1
-	}
-	if hintCursor < 0 {
-		hintCursor = 0
-	}
-
-	// Walk outward from hintCursor to find the nearest selectable item.
-	for delta := 0; delta < len(items); delta++ {
-		for _, candidate := range []int{hintCursor - delta, hintCursor + delta} {
-			if candidate < 0 || candidate >= len(items) {
-				continue
-			}
-			if !items[candidate].IsHeader && !items[candidate].IsFooter {
-				m.parade.Cursor = candidate
-				m.parade.SelectedIssue = items[candidate].Issue
-				return
-			}
-		}
-	}
-
-	// No selectable item found (empty parade after filtering).
-	m.parade.SelectedIssue = nil
+	m.parade.SelectNearest(hintCursor)
 }
 
 // recomputeVelocity recalculates velocity metrics and scorecards from current data
@@ -3079,10 +3643,12 @@ func fetchMoleculeDAG(issueID string) tea.Cmd {
 	}
 }
 
-// altView wraps a string as a tea.View with AltScreen enabled.
+// altView wraps a string as a tea.View with AltScreen and mouse reporting
+// enabled.
 func altView(s string) tea.View {
 	v := tea.NewView(s)
 	v.AltScreen = true
+	v.MouseMode = tea.MouseModeCellMotion
 	return v
 }
 
@@ -3095,25 +3661,15 @@ func (m Model) View() tea.View {
 	header := m.header.View()
 
 	var body string
-	if m.layoutPreset == LayoutWide {
+	switch m.layoutPreset {
+	case LayoutWide:
 		body = m.parade.View()
-	} else {
-		var rightPanel string
-		switch {
-		case m.showDoctor:
-			rightPanel = m.doctor.View()
-		case m.showProblems && m.gtEnv.Available:
-			rightPanel = m.problems.View()
-		case m.showGasTown && m.gtEnv.Available:
-			rightPanel = m.gasTown.View()
-		default:
-			rightPanel = m.detail.View()
-		}
-		body = lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.parade.View(),
-			rightPanel,
-		)
+	case LayoutStacked:
+		body = lipgloss.JoinVertical(lipgloss.Left, m.parade.View(), m.rightPanelView(true))
+	case LayoutColumns:
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.parade.View(), m.rightPanelView(false), m.gasTown.View())
+	default:
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.parade.View(), m.rightPanelView(true))
 	}
 
 	inputBarStyle := lipgloss.NewStyle().Padding(0, 1).Width(m.width)
@@ -3201,9 +3757,56 @@ func (m Model) View() tea.View {
 		return altView(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, rdBox))
 	}
 
+	if m.bulkEditing {
+		beTitle := ui.HelpTitle.Render("[ BULK EDIT ]")
+		beContent := lipgloss.JoinVertical(lipgloss.Left, beTitle, "", m.bulkDialog.View())
+		beBox := ui.HelpOverlayBg.Width(m.width - 8).Render(beContent)
+		return altView(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, beBox))
+	}
+
+	if m.showCalendar {
+		calTitle := ui.HelpTitle.Render("[ CALENDAR ]")
+		calContent := lipgloss.JoinVertical(lipgloss.Left, calTitle, "", m.calendar.View())
+		calBox := ui.HelpOverlayBg.Width(m.width - 8).Render(calContent)
+		return altView(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, calBox))
+	}
+
+	if m.importing {
+		imTitle := ui.HelpTitle.Render("[ IMPORT ISSUES ]")
+		imBody := m.importDialog.View()
+		imHint := ui.HelpHint.Render("space to toggle  enter to create  esc to cancel")
+		imContent := lipgloss.JoinVertical(lipgloss.Left, imTitle, "", imBody, "", imHint)
+		imBox := ui.HelpOverlayBg.Width(m.width - 8).Render(imContent)
+		return altView(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, imBox))
+	}
+
 	return altView(screen)
 }
 
+// rightPanelView renders the view shown beside the parade: a toggled panel,
+// else the issue detail. The Columns layout passes withGasTown false since
+// Gas Town has its own column there.
+func (m Model) rightPanelView(withGasTown bool) string {
+	switch {
+	case m.showDoctor:
+		return m.doctor.View()
+	case m.showTimeline:
+		timeline := m.timeline
+		timeline.SetParade(&m.parade)
+		return timeline.View()
+	case m.showCharts:
+		charts := m.charts
+		charts.SetIssues(m.issues, m.blockingTypes)
+		charts.SetScope(m.burndownScope())
+		return charts.View()
+	case m.showProblems && m.gtEnv.Available:
+		return m.problems.View()
+	case withGasTown && m.showGasTown && m.gtEnv.Available:
+		return m.gasTown.View()
+	}
+	return m.detail.View()
+}
+
 // overlayStrings composites non-space characters from overlay onto base.
 func overlayStrings(base, overlay string) string {
 	if overlay == "" {
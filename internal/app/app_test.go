@@ -1,12 +1,16 @@
 package app
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/matt-wright86/mardi-gras/internal/data"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/views"
 )
 
 func testIssue(id string, status data.Status) data.Issue {
@@ -134,6 +138,7 @@ func TestFileChangedMsgQueuesDetailRefetchesForPendingSelection(t *testing.T) {
 	got.detail.MoleculeDAG = &gastown.DAGInfo{}
 	got.detail.MoleculeProgress = &gastown.MoleculeProgress{}
 	got.detail.RichIssueID = "open-1"
+	got.detail.Tab = views.TabMolecule // molecule data is fetched for its tab only
 
 	model, cmd := got.Update(data.FileChangedMsg{Issues: issues})
 	got = model.(Model)
@@ -166,8 +171,25 @@ func TestFileChangedMsgQueuesDetailRefetchesForPendingSelection(t *testing.T) {
 			nonNil++
 		}
 	}
-	if nonNil != 3 {
-		t.Fatalf("expected 3 detail refetch commands, got %d", nonNil)
+	if nonNil != 2 {
+		t.Fatalf("expected molecule and rich detail refetch commands, got %d", nonNil)
+	}
+}
+
+func TestNoMoleculeIsNotRefetched(t *testing.T) {
+	m := setupModel(t)
+	id := m.parade.SelectedIssue.ID
+	m.gtEnv.Available = true
+	m.activeAgents[id] = "Toast"
+	m.detail.Tab = views.TabMolecule
+
+	if m.maybeFetchMolecule() == nil {
+		t.Fatal("expected a molecule fetch for the open tab")
+	}
+	model, _ := m.Update(moleculeDAGMsg{issueID: id})
+	m = model.(Model)
+	if m.maybeFetchMolecule() != nil {
+		t.Error("an issue known to have no molecule should not be refetched")
 	}
 }
 
@@ -301,3 +323,115 @@ func TestHelpCanOpenFromFilteringMode(t *testing.T) {
 		t.Fatal("expected filtering mode to resume after closing help")
 	}
 }
+
+func TestIsInvalidFilter(t *testing.T) {
+	good := testIssue("good", data.StatusOpen)
+	good.Metadata = map[string]interface{}{"team": "core"}
+	bad := testIssue("bad", data.StatusOpen)
+
+	m := New([]data.Issue{good, bad}, data.Source{}, data.DefaultBlockingTypes)
+	m.metadataSchema = &data.MetadataSchema{
+		Mode:   "warn",
+		Fields: map[string]data.MetadataFieldSchema{"team": {Type: data.MetaString, Required: true}},
+	}
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = model.(Model)
+	m.rebuildParade()
+
+	if sev := m.parade.InvalidIDs["bad"]; sev != data.SeverityWarn {
+		t.Errorf("InvalidIDs[bad] = %q, want warn", sev)
+	}
+	if _, ok := m.parade.InvalidIDs["good"]; ok {
+		t.Error("good issue should not be marked invalid")
+	}
+
+	m.filterInput.SetValue("is:invalid")
+	m.rebuildParade()
+	var ids []string
+	for _, item := range m.parade.Items {
+		if item.Issue != nil {
+			ids = append(ids, item.Issue.ID)
+		}
+	}
+	if len(ids) != 1 || ids[0] != "bad" {
+		t.Errorf("is:invalid parade = %v, want [bad]", ids)
+	}
+}
+
+func TestParseLayoutPreset(t *testing.T) {
+	tests := []struct {
+		name string
+		want LayoutPreset
+		ok   bool
+	}{
+		{"", LayoutDefault, true},
+		{"default", LayoutDefault, true},
+		{"GasTown", LayoutGasTown, true},
+		{"wide", LayoutWide, true},
+		{"stacked", LayoutStacked, true},
+		{"Columns", LayoutColumns, true},
+		{"auto", LayoutDefault, false},
+		{"sideways", LayoutDefault, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLayoutPreset(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseLayoutPreset(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWithLayoutGasTownOpensPanelOnlyWhenAvailable(t *testing.T) {
+	m := New(nil, data.Source{}, data.DefaultBlockingTypes)
+	m.gtEnv.Available = false
+	if got := m.WithLayout(LayoutGasTown); got.showGasTown {
+		t.Error("Gas Town panel should stay closed without gt")
+	}
+
+	m.gtEnv.Available = true
+	got := m.WithLayout(LayoutGasTown)
+	if got.layoutPreset != LayoutGasTown || !got.showGasTown || !got.gasTownTicking {
+		t.Errorf("WithLayout(GasTown) = preset %v, show %v, ticking %v", got.layoutPreset, got.showGasTown, got.gasTownTicking)
+	}
+
+	if got := m.WithLayout(LayoutWide); got.layoutPreset != LayoutWide || got.showGasTown {
+		t.Errorf("WithLayout(Wide) = preset %v, show %v", got.layoutPreset, got.showGasTown)
+	}
+}
+
+func TestRefreshCurrentIssueKeepsIDOnError(t *testing.T) {
+	orig := fetchCurrentIssueID
+	t.Cleanup(func() { fetchCurrentIssueID = orig })
+
+	fetchCurrentIssueID = func() (string, error) { return "", errors.New("bd busy") }
+	if msg := refreshCurrentIssue(); msg != nil {
+		t.Errorf("failed lookup sent %#v, want nothing", msg)
+	}
+
+	fetchCurrentIssueID = func() (string, error) { return "open-2", nil }
+	m := setupModel(t)
+	m.currentIssueID = "open-1"
+	model, _ := m.Update(refreshCurrentIssue())
+	if got := model.(Model).currentIssueID; got != "open-2" {
+		t.Errorf("currentIssueID = %q, want open-2", got)
+	}
+}
+
+func TestCurrentIssueLookupNeedsBD(t *testing.T) {
+	bin := t.TempDir()
+	t.Setenv("PATH", bin)
+	issues := []data.Issue{testIssue("open-1", data.StatusOpen)}
+
+	if New(issues, data.Source{Mode: data.SourceJSONL}, data.DefaultBlockingTypes).bdAvail {
+		t.Error("JSONL mode without bd on PATH should skip the current issue lookup")
+	}
+	if !New(issues, data.Source{Mode: data.SourceCLI}, data.DefaultBlockingTypes).bdAvail {
+		t.Error("CLI mode should look up the current issue")
+	}
+	if err := os.WriteFile(filepath.Join(bin, "bd"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !New(issues, data.Source{Mode: data.SourceJSONL}, data.DefaultBlockingTypes).bdAvail {
+		t.Error("JSONL mode with bd on PATH should look up the current issue")
+	}
+}
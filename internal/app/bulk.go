@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// bulkEditDoneMsg carries the per-issue results of a bulk edit.
+type bulkEditDoneMsg struct {
+	results []data.BulkResult
+}
+
+// convoyAdd adds issues to a convoy; tests replace it.
+var convoyAdd = gastown.ConvoyAdd
+
+// openBulkEdit shows the bulk edit dialog for the multi-selected issues.
+func (m Model) openBulkEdit() (tea.Model, tea.Cmd) {
+	issues := m.parade.SelectedIssues()
+	if len(issues) == 0 {
+		toast, toastCmd := components.ShowToast("Select issues with space first", components.ToastWarn, toastDuration)
+		m.toast = toast
+		return m, toastCmd
+	}
+	m.bulkEditing = true
+	m.bulkDialog = components.NewBulkEditDialog(issues, m.gtEnv.Available, m.width, m.height)
+	return m, nil
+}
+
+// bulkEdit returns a Cmd that applies op to every issue, a few bd calls at
+// a time. Adding to a convoy is a single gt call, so its outcome is shared
+// by all issues.
+func bulkEdit(op components.BulkOp, value string, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		var fn func(id string) error
+		switch op {
+		case components.BulkSetPriority:
+			priority := components.ParsePriority(value)
+			fn = func(id string) error { return data.SetPriority(id, priority) }
+		case components.BulkSetAssignee:
+			fn = func(id string) error { return data.SetAssignee(id, value) }
+		case components.BulkAddLabel:
+			fn = func(id string) error { return data.AddLabel(id, value) }
+		case components.BulkRemoveLabel:
+			fn = func(id string) error { return data.RemoveLabel(id, value) }
+		case components.BulkSetDue:
+			fn = func(id string) error { return data.SetDueDate(id, value) }
+		case components.BulkSetDefer:
+			fn = func(id string) error { return data.SetDeferUntil(id, value) }
+		case components.BulkAddBlocker:
+			fn = func(id string) error {
+				if id == value {
+					return fmt.Errorf("%s cannot block itself", id)
+				}
+				return data.AddDependency(id, value)
+			}
+		case components.BulkClose:
+			fn = func(id string) error { return data.CloseWithReason(id, value) }
+		case components.BulkAddToConvoy:
+			err := convoyAdd(value, ids)
+			results := make([]data.BulkResult, len(ids))
+			for i, id := range ids {
+				results[i] = data.BulkResult{IssueID: id, Err: err}
+			}
+			return bulkEditDoneMsg{results: results}
+		}
+		return bulkEditDoneMsg{results: data.RunBulk(ids, fn)}
+	}
+}
+
+// handleBulkEditMsg runs a confirmed bulk edit, or shows its report, clears
+// the selection and refreshes.
+func (m Model) handleBulkEditMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case components.BulkEditResult:
+		if msg.Cancelled {
+			m.bulkEditing = false
+			return m, nil
+		}
+		return m, bulkEdit(msg.Op, msg.Value, msg.IssueIDs)
+	case bulkEditDoneMsg:
+		m.bulkDialog = m.bulkDialog.ShowResults(msg.results)
+		m.parade.ClearSelection()
+		n, failed := len(msg.results), data.BulkFailures(msg.results)
+		text, level := fmt.Sprintf("Updated %d issue%s", n, plural(n)), components.ToastSuccess
+		if failed > 0 {
+			text, level = fmt.Sprintf("Updated %d of %d issues; %d failed", n-failed, n, failed), components.ToastError
+		}
+		toast, toastCmd := components.ShowToast(text, level, toastDuration)
+		m.toast = toast
+		m.lastFileMod = time.Time{}
+		return m, tea.Batch(toastCmd, m.startPollImmediate())
+	}
+	return m, nil
+}
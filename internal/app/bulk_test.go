@@ -0,0 +1,71 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestKeyEWithoutSelectionToasts(t *testing.T) {
+	m := setupModel(t)
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'E', Text: "E"})
+	m = model.(Model)
+	if m.bulkEditing || !m.toast.Active() {
+		t.Error("E without a selection should toast instead of opening the dialog")
+	}
+}
+
+func TestBulkEditFlow(t *testing.T) {
+	m := setupModel(t)
+	model, _ := m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	model, _ = model.(Model).Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	model, _ = model.(Model).Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	model, _ = model.(Model).Update(tea.KeyPressMsg{Code: 'E', Text: "E"})
+	m = model.(Model)
+	if !m.bulkEditing {
+		t.Fatal("expected E to open the bulk edit dialog")
+	}
+	if view := m.View().Content; !strings.Contains(view, "BULK EDIT: 2 issue(s)") {
+		t.Error("view should render the bulk edit dialog")
+	}
+
+	model, _ = m.Update(components.BulkEditResult{Op: components.BulkSetPriority, Value: "1", IssueIDs: []string{"open-1", "open-2"}})
+	m = model.(Model)
+	if !m.bulkEditing {
+		t.Fatal("dialog should stay open while the edit runs")
+	}
+
+	results := []data.BulkResult{{IssueID: "open-1"}, {IssueID: "open-2", Err: errors.New("boom")}}
+	model, cmd := m.Update(bulkEditDoneMsg{results: results})
+	m = model.(Model)
+	if cmd == nil || !m.toast.Active() || m.parade.SelectionCount() != 0 {
+		t.Errorf("after the edit: toast=%v selection=%d", m.toast.Active(), m.parade.SelectionCount())
+	}
+	if view := m.View().Content; !strings.Contains(view, "1 ok, 1 failed") {
+		t.Error("view should show the per-issue report")
+	}
+
+	model, _ = m.Update(components.BulkEditResult{Cancelled: true})
+	if model.(Model).bulkEditing {
+		t.Error("closing the report should close the dialog")
+	}
+}
+
+func TestBulkEditConvoySharesOneCall(t *testing.T) {
+	var calls int
+	orig := convoyAdd
+	convoyAdd = func(convoyID string, issueIDs []string) error {
+		calls++
+		return errors.New("no convoy " + convoyID)
+	}
+	defer func() { convoyAdd = orig }()
+
+	msg := bulkEdit(components.BulkAddToConvoy, "cv-1", []string{"mg-1", "mg-2"})().(bulkEditDoneMsg)
+	if calls != 1 || len(msg.results) != 2 || data.BulkFailures(msg.results) != 2 {
+		t.Errorf("calls = %d, results = %+v", calls, msg.results)
+	}
+}
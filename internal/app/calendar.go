@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// calendarMovedMsg reports the result of rescheduling an issue from the
+// calendar.
+type calendarMovedMsg struct {
+	issueID string
+	field   components.CalendarField
+	date    string
+	err     error
+}
+
+// openCalendar shows the calendar overlay with the cursor on today.
+func (m Model) openCalendar() (tea.Model, tea.Cmd) {
+	m.showCalendar = true
+	m.calendar = components.NewCalendar(m.issues, m.width, m.height)
+	return m, nil
+}
+
+// rescheduleIssue returns a Cmd that sets the due or defer date of an issue.
+// An empty date clears it.
+func rescheduleIssue(issueID string, field components.CalendarField, date string) tea.Cmd {
+	return func() tea.Msg {
+		set := data.SetDueDate
+		if field == components.CalendarDefer {
+			set = data.SetDeferUntil
+		}
+		return calendarMovedMsg{issueID: issueID, field: field, date: date, err: set(issueID, date)}
+	}
+}
+
+// handleCalendarMsg closes the calendar, jumps to an issue, or reschedules
+// one and refreshes once bd has the new date.
+func (m Model) handleCalendarMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case components.CalendarResult:
+		switch {
+		case msg.Closed:
+			m.showCalendar = false
+			return m, nil
+		case msg.JumpID != "":
+			m.showCalendar = false
+			if !m.restoreParadeSelection(msg.JumpID) {
+				toast, cmd := components.ShowToast(msg.JumpID+" is hidden by the current filter", components.ToastWarn, toastDuration)
+				m.toast = toast
+				return m, cmd
+			}
+			m.syncSelection()
+			return m, nil
+		}
+		return m, rescheduleIssue(msg.IssueID, msg.Field, msg.Date)
+	case calendarMovedMsg:
+		field, verb := "due", "due"
+		if msg.field == components.CalendarDefer {
+			field, verb = "defer", "deferred until"
+		}
+		text, level := fmt.Sprintf("%s %s %s", msg.issueID, verb, msg.date), components.ToastSuccess
+		if msg.date == "" {
+			text = fmt.Sprintf("Cleared %s date on %s", field, msg.issueID)
+		}
+		if msg.err != nil {
+			text, level = fmt.Sprintf("Reschedule %s failed: %v", msg.issueID, msg.err), components.ToastError
+		}
+		toast, toastCmd := components.ShowToast(text, level, toastDuration)
+		m.toast = toast
+		m.lastFileMod = time.Time{}
+		return m, tea.Batch(toastCmd, m.startPollImmediate())
+	}
+	return m, nil
+}
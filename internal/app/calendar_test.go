@@ -0,0 +1,52 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+)
+
+func TestKeyMOpensCalendar(t *testing.T) {
+	m := setupModel(t)
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'M', Text: "M"})
+	m = model.(Model)
+	if !m.showCalendar {
+		t.Fatal("M should open the calendar")
+	}
+	if view := m.View().Content; !strings.Contains(view, "CALENDAR") {
+		t.Error("view should render the calendar overlay")
+	}
+
+	model, _ = m.Update(components.CalendarResult{Closed: true})
+	if model.(Model).showCalendar {
+		t.Error("a Closed result should hide the calendar")
+	}
+}
+
+func TestCalendarJumpSelectsIssue(t *testing.T) {
+	m := setupModel(t)
+	m.showCalendar = true
+	model, _ := m.Update(components.CalendarResult{JumpID: "open-3"})
+	m = model.(Model)
+	if m.showCalendar || m.parade.SelectedIssue == nil || m.parade.SelectedIssue.ID != "open-3" {
+		t.Errorf("jump should close the calendar and select open-3, got %v", m.parade.SelectedIssue)
+	}
+}
+
+func TestCalendarRescheduleResult(t *testing.T) {
+	m := setupModel(t)
+	m.showCalendar = true
+	model, cmd := m.Update(calendarMovedMsg{issueID: "open-1", field: components.CalendarDue, date: "2026-11-02"})
+	m = model.(Model)
+	if cmd == nil || !m.toast.Active() || !m.showCalendar {
+		t.Error("a reschedule should toast, refresh, and keep the calendar open")
+	}
+
+	model, _ = m.Update(calendarMovedMsg{issueID: "open-1", err: errors.New("boom")})
+	if view := model.(Model).toast.View(100); !strings.Contains(view, "boom") {
+		t.Errorf("failed reschedule should surface the error, got %q", view)
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// toggleCharts shows or hides the flow charts in place of the detail pane.
+func (m Model) toggleCharts() (tea.Model, tea.Cmd) {
+	m.showCharts = !m.showCharts
+	if m.showCharts {
+		m.showGasTown = false
+		m.showProblems = false
+		m.showDoctor = false
+		m.showTimeline = false
+	}
+	return m, nil
+}
+
+// burndownScope picks what the burndown tracks from the parade selection:
+// the selected epic, else a convoy tracking the selected issue, else the
+// selected issue's parent epic, else every issue.
+func (m *Model) burndownScope() (string, map[string]bool) {
+	issue := m.parade.SelectedIssue
+	if issue == nil {
+		return "all issues", allIDs(m.issues)
+	}
+	if issue.IssueType == data.TypeEpic {
+		return "epic " + issue.ID + " " + issue.Title, childIDs(m.issues, issue.ID)
+	}
+	for _, c := range m.gasTown.GetConvoys() {
+		if slices.ContainsFunc(c.Tracked, func(t gastown.TrackedIssueInfo) bool { return t.ID == issue.ID }) {
+			ids := make(map[string]bool, len(c.Tracked))
+			for _, t := range c.Tracked {
+				ids[t.ID] = true
+			}
+			return "convoy " + c.ID + " " + c.Title, ids
+		}
+	}
+	if parent := m.detail.IssueMap[parentOf(issue)]; parent != nil && parent.IssueType == data.TypeEpic {
+		return "epic " + parent.ID + " " + parent.Title, childIDs(m.issues, parent.ID)
+	}
+	return "all issues", allIDs(m.issues)
+}
+
+// parentOf returns the issue's parent: a parent-child dependency, else the
+// dotted-ID parent.
+func parentOf(issue *data.Issue) string {
+	for _, dep := range issue.Dependencies {
+		if dep.Type == "parent-child" {
+			return dep.DependsOnID
+		}
+	}
+	return issue.ParentID()
+}
+
+// childIDs returns the IDs of the issues whose parent is parentID.
+func childIDs(issues []data.Issue, parentID string) map[string]bool {
+	ids := make(map[string]bool)
+	for i := range issues {
+		if parentOf(&issues[i]) == parentID {
+			ids[issues[i].ID] = true
+		}
+	}
+	return ids
+}
+
+func allIDs(issues []data.Issue) map[string]bool {
+	ids := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		ids[issue.ID] = true
+	}
+	return ids
+}
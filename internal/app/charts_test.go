@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/views"
+)
+
+func TestKeyVTogglesCharts(t *testing.T) {
+	m := setupModel(t)
+	m.showTimeline = true
+
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'V', Text: "V"})
+	m = model.(Model)
+	if !m.showCharts || m.showTimeline {
+		t.Fatalf("V should show the charts in place of the timeline (charts=%v timeline=%v)", m.showCharts, m.showTimeline)
+	}
+
+	m.activPane = PaneDetail
+	model, _ = m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	m = model.(Model)
+	if m.charts.Window() != views.ChartSixWeeks {
+		t.Errorf("w should widen the window, got %v", m.charts.Window())
+	}
+
+	model, _ = m.Update(tea.KeyPressMsg{Code: 'V', Text: "V"})
+	if model.(Model).showCharts {
+		t.Error("second V should hide the charts")
+	}
+}
+
+func TestBurndownScopeFollowsEpic(t *testing.T) {
+	epic := testIssue("epic-1", data.StatusOpen)
+	epic.IssueType = data.TypeEpic
+	child := testIssue("task-1", data.StatusOpen)
+	child.Dependencies = []data.Dependency{{IssueID: "task-1", DependsOnID: "epic-1", Type: "parent-child"}}
+	dotted := testIssue("epic-1.2", data.StatusOpen)
+	other := testIssue("task-2", data.StatusOpen)
+
+	m := setupModel(t)
+	m.issues = []data.Issue{epic, child, dotted, other}
+	m.detail.IssueMap = data.BuildIssueMap(m.issues)
+
+	m.parade.SelectedIssue = &m.issues[1]
+	title, scope := m.burndownScope()
+	if title != "epic epic-1 "+epic.Title {
+		t.Errorf("title = %q", title)
+	}
+	if len(scope) != 2 || !scope["task-1"] || !scope["epic-1.2"] {
+		t.Errorf("scope = %v, want task-1 and epic-1.2", scope)
+	}
+
+	m.parade.SelectedIssue = &m.issues[3]
+	if title, scope := m.burndownScope(); title != "all issues" || len(scope) != 4 {
+		t.Errorf("unparented issue should chart everything, got %q %v", title, scope)
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// codeRefsMsg carries the result of scanning the project for an issue ID.
+type codeRefsMsg struct {
+	issueID string
+	refs    *data.CodeRefs
+	err     error
+}
+
+// editorFinishedMsg is sent when the editor opened on a code hit exits.
+type editorFinishedMsg struct{ err error }
+
+// scanCodeRefs is the scanner used by fetchCodeRefs. Tests replace it.
+var scanCodeRefs = data.ScanCodeRefs
+
+// fetchCodeRefs returns a Cmd that scans projectDir for references to issue.
+func fetchCodeRefs(projectDir string, issue data.Issue) tea.Cmd {
+	return func() tea.Msg {
+		refs, err := scanCodeRefs(projectDir, issue)
+		return codeRefsMsg{issueID: issue.ID, refs: refs, err: err}
+	}
+}
+
+// maybeFetchCodeRefs applies cached references for the selected issue, or
+// returns a Cmd to scan for them. The cache is dropped on every reload.
+func (m *Model) maybeFetchCodeRefs() tea.Cmd {
+	issue := m.parade.SelectedIssue
+	if issue == nil || m.projectDir == "" {
+		return nil
+	}
+	if refs, ok := m.codeRefs[issue.ID]; ok {
+		if m.detail.CodeRefs != refs {
+			m.detail.SetCodeRefs(refs)
+		}
+		return nil
+	}
+	return fetchCodeRefs(m.projectDir, *issue)
+}
+
+// openCodeHit opens the selected reference in $VISUAL or $EDITOR at its line.
+func (m Model) openCodeHit() (tea.Model, tea.Cmd) {
+	hit := m.detail.SelectedHit()
+	if hit == nil {
+		return m, nil
+	}
+	c, err := editorCommand(filepath.Join(m.projectDir, hit.Path), hit.Line)
+	if err != nil {
+		toast, toastCmd := components.ShowToast(err.Error(), components.ToastError, toastDuration)
+		m.toast = toast
+		return m, toastCmd
+	}
+	return m, tea.ExecProcess(c, func(err error) tea.Msg {
+		return editorFinishedMsg{err: err}
+	})
+}
+
+// editorCommand builds `<editor> +line path` from $VISUAL, then $EDITOR,
+// falling back to vi. The editor variable may carry its own arguments.
+func editorCommand(path string, line int) (*exec.Cmd, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return nil, fmt.Errorf("editor %q not found", fields[0])
+	}
+	args := append(fields[1:], fmt.Sprintf("+%d", line), path)
+	return exec.Command(fields[0], args...), nil
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func testCodeRefs(issueID string) *data.CodeRefs {
+	return &data.CodeRefs{
+		IssueID: issueID,
+		Hits: []data.CodeHit{
+			{Path: "main.go", Line: 3, Text: "// TODO(" + issueID + ")"},
+			{Path: "docs/notes.md", Line: 9, Text: issueID},
+		},
+	}
+}
+
+func TestCodeRefsMsgCachesAndApplies(t *testing.T) {
+	m := setupModel(t)
+	id := m.parade.SelectedIssue.ID
+
+	model, _ := m.Update(codeRefsMsg{issueID: id, refs: testCodeRefs(id)})
+	got := model.(Model)
+
+	if got.codeRefs[id] == nil {
+		t.Fatal("refs not cached")
+	}
+	if hit := got.detail.SelectedHit(); hit == nil || hit.Path != "main.go" {
+		t.Errorf("detail SelectedHit() = %+v, want main.go", hit)
+	}
+}
+
+func TestMaybeFetchCodeRefsUsesCache(t *testing.T) {
+	m := setupModel(t)
+	m.projectDir = t.TempDir()
+
+	calls := 0
+	orig := scanCodeRefs
+	scanCodeRefs = func(dir string, issue data.Issue) (*data.CodeRefs, error) {
+		calls++
+		return testCodeRefs(issue.ID), nil
+	}
+	t.Cleanup(func() { scanCodeRefs = orig })
+
+	cmd := m.maybeFetchCodeRefs()
+	if cmd == nil {
+		t.Fatal("expected a scan on cache miss")
+	}
+	model, _ := m.Update(cmd())
+	m = model.(Model)
+
+	m.detail.SetCodeRefs(nil)
+	if cmd := m.maybeFetchCodeRefs(); cmd != nil {
+		t.Error("cache hit should not rescan")
+	}
+	if m.detail.CodeRefs == nil {
+		t.Error("cache hit should reapply refs to the detail pane")
+	}
+	if calls != 1 {
+		t.Errorf("scan calls = %d, want 1", calls)
+	}
+
+	model, _ = m.Update(data.FileChangedMsg{Issues: m.issues})
+	if len(model.(Model).codeRefs) != 0 {
+		t.Error("a reload should drop the code refs cache")
+	}
+}
+
+func TestFailedCodeRefsScanIsCached(t *testing.T) {
+	m := setupModel(t)
+	m.projectDir = t.TempDir()
+	id := m.parade.SelectedIssue.ID
+
+	model, _ := m.Update(codeRefsMsg{issueID: id, err: errors.New("not a git repository")})
+	m = model.(Model)
+	if cmd := m.maybeFetchCodeRefs(); cmd != nil {
+		t.Error("a failed scan should be cached until the next reload")
+	}
+	if !m.detail.CodeRefs.Empty() {
+		t.Errorf("detail refs = %+v, want empty", m.detail.CodeRefs)
+	}
+
+	model, _ = m.Update(data.FileChangedMsg{Issues: m.issues})
+	m = model.(Model)
+	if cmd := m.maybeFetchCodeRefs(); cmd == nil {
+		t.Error("a reload should retry the scan")
+	}
+}
+
+func TestKeyBracketsMoveRefCursor(t *testing.T) {
+	m := setupModel(t)
+	id := m.parade.SelectedIssue.ID
+	model, _ := m.Update(codeRefsMsg{issueID: id, refs: testCodeRefs(id)})
+	m = model.(Model)
+	m.activPane = PaneDetail
+
+	model, _ = m.handleKey(tea.KeyPressMsg{Code: ']', Text: "]"})
+	m = model.(Model)
+	if hit := m.detail.SelectedHit(); hit == nil || hit.Path != "docs/notes.md" {
+		t.Fatalf("after ] SelectedHit() = %+v, want docs/notes.md", hit)
+	}
+	// A rescan of the same issue keeps the cursor.
+	model, _ = m.Update(codeRefsMsg{issueID: id, refs: testCodeRefs(id)})
+	m = model.(Model)
+	if hit := m.detail.SelectedHit(); hit == nil || hit.Path != "docs/notes.md" {
+		t.Fatalf("after rescan SelectedHit() = %+v, want docs/notes.md", hit)
+	}
+	model, _ = m.handleKey(tea.KeyPressMsg{Code: '[', Text: "["})
+	m = model.(Model)
+	if hit := m.detail.SelectedHit(); hit == nil || hit.Path != "main.go" {
+		t.Errorf("after [ SelectedHit() = %+v, want main.go", hit)
+	}
+}
+
+func TestKeyOWithMissingEditorToasts(t *testing.T) {
+	t.Setenv("VISUAL", "mg-no-such-editor")
+	m := setupModel(t)
+	id := m.parade.SelectedIssue.ID
+	model, _ := m.Update(codeRefsMsg{issueID: id, refs: testCodeRefs(id)})
+	m = model.(Model)
+	m.activPane = PaneDetail
+
+	model, cmd := m.handleKey(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if cmd == nil || !model.(Model).toast.Active() {
+		t.Error("missing editor should show a toast")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "sh -e")
+	path := filepath.Join("proj", "main.go")
+
+	c, err := editorCommand(path, 12)
+	if err != nil {
+		t.Fatalf("editorCommand() error = %v", err)
+	}
+	if want := []string{"sh", "-e", "+12", path}; !reflect.DeepEqual(c.Args, want) {
+		t.Errorf("Args = %v, want %v", c.Args, want)
+	}
+
+	t.Setenv("VISUAL", "mg-no-such-editor")
+	if _, err := editorCommand(path, 1); err == nil {
+		t.Error("expected error for missing editor")
+	}
+}
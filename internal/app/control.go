@@ -0,0 +1,116 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/control"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// handleControl applies a command from the control socket and replies with
+// the resulting selection.
+func (m Model) handleControl(msg control.Msg) (tea.Model, tea.Cmd) {
+	var model tea.Model = m
+	var cmd tea.Cmd
+
+	switch msg.Cmd {
+	case control.CmdSelect:
+		var err string
+		model, cmd, err = m.controlSelect(msg.Arg)
+		if err != "" {
+			msg.Fail("%s", err)
+			return m, nil
+		}
+	case control.CmdFilter:
+		m.filterInput.SetValue(msg.Arg)
+		m.rebuildParade()
+		model = m
+	case control.CmdFocus:
+		on := !m.focusMode
+		switch msg.Arg {
+		case "on":
+			on = true
+		case "off":
+			on = false
+		case "", "toggle":
+		default:
+			msg.Fail("focus: want on, off or toggle")
+			return m, nil
+		}
+		model, cmd = m.setFocusMode(on)
+	case control.CmdLayout:
+		if msg.Arg == "auto" {
+			model, cmd = m.setAutoLayout()
+			break
+		}
+		p, ok := ParseLayoutPreset(msg.Arg)
+		if msg.Arg == "next" {
+			p, ok = (m.layoutPreset+1)%layoutPresetCount, true
+		}
+		if !ok || msg.Arg == "" {
+			msg.Fail("layout: want default, gastown, wide, stacked, columns, auto or next")
+			return m, nil
+		}
+		model, cmd = m.setLayout(p)
+	case control.CmdRefresh:
+		m.lastFileMod = time.Time{}
+		model, cmd = m, m.startPollImmediate()
+	case control.CmdSelection:
+	default:
+		msg.Fail("unknown command %q", msg.Cmd)
+		return m, nil
+	}
+
+	msg.Reply(control.Response{OK: true, Selection: model.(Model).controlSelection()})
+	return model, cmd
+}
+
+// controlSelect moves the parade cursor to issueID. Filters and focus mode
+// are cleared, and the closed section expanded, when they hide the issue.
+func (m Model) controlSelect(issueID string) (tea.Model, tea.Cmd, string) {
+	var target *data.Issue
+	for i := range m.issues {
+		if m.issues[i].ID == issueID {
+			target = &m.issues[i]
+			break
+		}
+	}
+	if target == nil {
+		return m, nil, "no issue " + issueID
+	}
+	if m.excludeTypes[string(target.IssueType)] {
+		return m, nil, issueID + " is hidden by exclude_types"
+	}
+
+	if !m.restoreParadeSelection(issueID) {
+		if m.filterInput.Value() != "" || m.focusMode {
+			m.filterInput.SetValue("")
+			m.focusMode = false
+			m.rebuildParade()
+		}
+		if target.Status == data.StatusClosed && !m.parade.ShowClosed {
+			m.parade.ToggleClosed()
+		}
+		if !m.restoreParadeSelection(issueID) {
+			return m, nil, "cannot show " + issueID
+		}
+	}
+	m.syncSelection()
+	return m, tea.Batch(m.detailFetchBatch()...), ""
+}
+
+// controlSelection reports the selected issue and view state.
+func (m Model) controlSelection() *control.Selection {
+	sel := &control.Selection{
+		Filter: m.filterInput.Value(),
+		Focus:  m.focusMode,
+		Layout: m.layoutPreset.String(),
+	}
+	if issue := m.parade.SelectedIssue; issue != nil {
+		sel.ID = issue.ID
+		sel.Title = issue.Title
+		sel.Status = string(issue.Status)
+	}
+	return sel
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/control"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func newControlModel(t *testing.T) Model {
+	t.Helper()
+	issues := []data.Issue{
+		testIssue("open-1", data.StatusOpen),
+		testIssue("open-2", data.StatusOpen),
+		testIssue("done-1", data.StatusClosed),
+		testIssue("epic-1", data.StatusOpen),
+	}
+	issues[1].Title = "Fix login"
+	issues[3].IssueType = data.TypeEpic
+	m := New(issues, data.Source{}, data.DefaultBlockingTypes, map[string]bool{"epic": true})
+	m.startedAt = time.Now().Add(-time.Second)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	return model.(Model)
+}
+
+// sendControl runs one control command through Update and returns the reply.
+func sendControl(t *testing.T, m Model, cmd, arg string) (Model, control.Response) {
+	t.Helper()
+	msg, reply := control.NewMsg(control.Request{Cmd: cmd, Arg: arg})
+	model, _ := m.Update(msg)
+	select {
+	case resp := <-reply:
+		return model.(Model), resp
+	default:
+		t.Fatalf("%s %q: no reply", cmd, arg)
+		return model.(Model), control.Response{}
+	}
+}
+
+func TestControlSelect(t *testing.T) {
+	m := newControlModel(t)
+	m, resp := sendControl(t, m, control.CmdSelect, "open-2")
+	if !resp.OK || resp.Selection.ID != "open-2" || resp.Selection.Title != "Fix login" {
+		t.Fatalf("select = %+v", resp)
+	}
+	if m.parade.SelectedIssue == nil || m.parade.SelectedIssue.ID != "open-2" {
+		t.Error("parade selection not moved")
+	}
+	if m.detail.Issue == nil || m.detail.Issue.ID != "open-2" {
+		t.Error("detail pane not synced")
+	}
+}
+
+func TestControlSelectClearsHidingFilterAndShowsClosed(t *testing.T) {
+	m := newControlModel(t)
+	m.filterInput.SetValue("login")
+	m.rebuildParade()
+
+	m, resp := sendControl(t, m, control.CmdSelect, "done-1")
+	if !resp.OK || resp.Selection.ID != "done-1" {
+		t.Fatalf("select closed = %+v", resp)
+	}
+	if m.filterInput.Value() != "" {
+		t.Error("filter hiding the issue should be cleared")
+	}
+	if !m.parade.ShowClosed {
+		t.Error("closed section should be expanded")
+	}
+}
+
+func TestControlSelectErrors(t *testing.T) {
+	m := newControlModel(t)
+	if _, resp := sendControl(t, m, control.CmdSelect, "nope-9"); resp.OK || !strings.Contains(resp.Error, "no issue") {
+		t.Errorf("missing issue = %+v", resp)
+	}
+	if _, resp := sendControl(t, m, control.CmdSelect, "epic-1"); resp.OK || !strings.Contains(resp.Error, "exclude_types") {
+		t.Errorf("excluded issue = %+v", resp)
+	}
+}
+
+func TestControlFilterFocusLayout(t *testing.T) {
+	m := newControlModel(t)
+
+	m, resp := sendControl(t, m, control.CmdFilter, "login")
+	if !resp.OK || resp.Selection.Filter != "login" || resp.Selection.ID != "open-2" {
+		t.Errorf("filter = %+v", resp)
+	}
+	m, _ = sendControl(t, m, control.CmdFilter, "")
+	if m.filterInput.Value() != "" {
+		t.Error("empty filter should clear")
+	}
+
+	m, resp = sendControl(t, m, control.CmdFocus, "on")
+	if !resp.OK || !m.focusMode || !resp.Selection.Focus {
+		t.Errorf("focus on = %+v", resp)
+	}
+	m, _ = sendControl(t, m, control.CmdFocus, "")
+	if m.focusMode {
+		t.Error("focus with no arg should toggle off")
+	}
+	if _, resp = sendControl(t, m, control.CmdFocus, "sideways"); resp.OK {
+		t.Error("bad focus arg should fail")
+	}
+
+	m, resp = sendControl(t, m, control.CmdLayout, "wide")
+	if !resp.OK || m.layoutPreset != LayoutWide || resp.Selection.Layout != "wide" {
+		t.Errorf("layout wide = %+v", resp)
+	}
+	m, _ = sendControl(t, m, control.CmdLayout, "next")
+	if m.layoutPreset != LayoutStacked {
+		t.Errorf("layout next from wide = %v, want stacked", m.layoutPreset)
+	}
+	m, _ = sendControl(t, m, control.CmdLayout, "next")
+	m, _ = sendControl(t, m, control.CmdLayout, "next")
+	if m.layoutPreset != LayoutDefault {
+		t.Errorf("layout next from columns = %v, want default", m.layoutPreset)
+	}
+	m, _ = sendControl(t, m, control.CmdLayout, "auto")
+	if m.layoutPinned {
+		t.Error("layout auto should unpin the layout")
+	}
+	if _, resp = sendControl(t, m, control.CmdLayout, ""); resp.OK {
+		t.Error("empty layout should fail")
+	}
+}
+
+func TestControlRefreshAndSelection(t *testing.T) {
+	m := newControlModel(t)
+	m.lastFileMod = time.Now()
+	m, resp := sendControl(t, m, control.CmdRefresh, "")
+	if !resp.OK || !m.lastFileMod.IsZero() {
+		t.Errorf("refresh = %+v, lastFileMod = %v", resp, m.lastFileMod)
+	}
+
+	_, resp = sendControl(t, m, control.CmdSelection, "")
+	if !resp.OK || resp.Selection.ID != m.parade.SelectedIssue.ID || resp.Selection.Layout != "default" {
+		t.Errorf("selection = %+v", resp)
+	}
+}
+
+func TestControlAnsweredDuringModalInput(t *testing.T) {
+	m := newControlModel(t)
+	m.filtering = true
+	_, resp := sendControl(t, m, control.CmdSelection, "")
+	if !resp.OK {
+		t.Errorf("selection while filtering = %+v", resp)
+	}
+}
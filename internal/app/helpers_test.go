@@ -117,24 +117,22 @@ func TestOverlayStrings(t *testing.T) {
 
 func TestDiffIssuesEmptyPrev(t *testing.T) {
 	m := Model{
-		prevIssueMap: map[string]data.Status{},
+		prevSnapshot: data.Snapshot{},
 		changedIDs:   make(map[string]bool),
 	}
 	issues := []data.Issue{testIssue("a", data.StatusOpen)}
-	if got := m.diffIssues(issues); got != 0 {
-		t.Errorf("empty prevIssueMap: got %d changes, want 0", got)
+	if got, _ := m.diffIssues(issues); got != 0 {
+		t.Errorf("empty prevSnapshot: got %d changes, want 0", got)
 	}
 }
 
 func TestDiffIssuesStatusChanged(t *testing.T) {
 	m := Model{
-		prevIssueMap: map[string]data.Status{
-			"a": data.StatusOpen,
-		},
-		changedIDs: make(map[string]bool),
+		prevSnapshot: data.TakeSnapshot([]data.Issue{testIssue("a", data.StatusOpen)}, nil),
+		changedIDs:   make(map[string]bool),
 	}
 	issues := []data.Issue{testIssue("a", data.StatusInProgress)}
-	got := m.diffIssues(issues)
+	got, _ := m.diffIssues(issues)
 	if got != 1 {
 		t.Errorf("status changed: got %d changes, want 1", got)
 	}
@@ -145,13 +143,11 @@ func TestDiffIssuesStatusChanged(t *testing.T) {
 
 func TestDiffIssuesNewAndRemoved(t *testing.T) {
 	m := Model{
-		prevIssueMap: map[string]data.Status{
-			"old": data.StatusOpen,
-		},
-		changedIDs: make(map[string]bool),
+		prevSnapshot: data.TakeSnapshot([]data.Issue{testIssue("old", data.StatusOpen)}, nil),
+		changedIDs:   make(map[string]bool),
 	}
 	issues := []data.Issue{testIssue("new", data.StatusOpen)}
-	got := m.diffIssues(issues)
+	got, _ := m.diffIssues(issues)
 	// 1 new issue + 1 removed issue = 2
 	if got != 2 {
 		t.Errorf("new+removed: got %d changes, want 2", got)
@@ -161,19 +157,39 @@ func TestDiffIssuesNewAndRemoved(t *testing.T) {
 	}
 }
 
+func TestDiffIssuesPriorityChanged(t *testing.T) {
+	m := Model{
+		prevSnapshot: data.TakeSnapshot([]data.Issue{testIssue("a", data.StatusOpen)}, nil),
+		changedIDs:   make(map[string]bool),
+	}
+	iss := testIssue("a", data.StatusOpen)
+	iss.Priority = data.PriorityCritical
+	iss.Assignee = "alice"
+	got, events := m.diffIssues([]data.Issue{iss})
+	if got != 0 {
+		t.Errorf("priority and assignee changed: got %d changes, want 0", got)
+	}
+	if m.changedIDs["a"] {
+		t.Error("priority and assignee edits should not highlight 'a'")
+	}
+	if len(events) != 2 {
+		t.Errorf("got %d events, want priority and assignee for the hooks", len(events))
+	}
+}
+
 func TestDiffIssuesNoChange(t *testing.T) {
 	m := Model{
-		prevIssueMap: map[string]data.Status{
-			"a": data.StatusOpen,
-			"b": data.StatusClosed,
-		},
+		prevSnapshot: data.TakeSnapshot([]data.Issue{
+			testIssue("a", data.StatusOpen),
+			testIssue("b", data.StatusClosed),
+		}, nil),
 		changedIDs: make(map[string]bool),
 	}
 	issues := []data.Issue{
 		testIssue("a", data.StatusOpen),
 		testIssue("b", data.StatusClosed),
 	}
-	if got := m.diffIssues(issues); got != 0 {
+	if got, _ := m.diffIssues(issues); got != 0 {
 		t.Errorf("no change: got %d changes, want 0", got)
 	}
 }
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/importer"
+)
+
+// importPreviewMsg carries drafts parsed from an import file.
+type importPreviewMsg struct {
+	source string
+	drafts []importer.Draft
+	err    error
+}
+
+// importDoneMsg reports the issues created by an import.
+type importDoneMsg struct {
+	result importer.Result
+}
+
+// applyImport creates the drafts; tests replace it.
+var applyImport = func(drafts []importer.Draft) importer.Result {
+	return importer.Apply(importer.BD{}, drafts)
+}
+
+// previewImport returns a Cmd that parses path (relative to the project
+// root) and flags drafts that duplicate an existing issue.
+func (m Model) previewImport(path string) tea.Cmd {
+	if !filepath.IsAbs(path) && m.projectDir != "" {
+		path = filepath.Join(m.projectDir, path)
+	}
+	existing := m.issues
+	return func() tea.Msg {
+		source := filepath.Base(path)
+		format, err := importer.DetectFormat(path)
+		if err != nil {
+			return importPreviewMsg{source: source, err: err}
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return importPreviewMsg{source: source, err: err}
+		}
+		defer f.Close()
+		drafts, err := importer.Parse(f, format, importer.Options{})
+		if err != nil {
+			return importPreviewMsg{source: source, err: err}
+		}
+		importer.MarkDuplicates(drafts, existing)
+		return importPreviewMsg{source: source, drafts: drafts}
+	}
+}
+
+// handleImportMsg opens the preview, runs a confirmed import, or reports
+// its result.
+func (m Model) handleImportMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var text string
+	level := components.ToastSuccess
+	switch msg := msg.(type) {
+	case importPreviewMsg:
+		if msg.err == nil {
+			m.importing = true
+			m.importDialog = components.NewImportDialog(msg.source, msg.drafts, m.width, m.height)
+			return m, nil
+		}
+		text, level = fmt.Sprintf("Import failed: %v", msg.err), components.ToastError
+	case components.ImportDialogResult:
+		m.importing = false
+		if msg.Cancelled {
+			return m, nil
+		}
+		drafts := msg.Drafts
+		toast, toastCmd := components.ShowToast("Importing issues...", components.ToastInfo, toastDuration)
+		m.toast = toast
+		return m, tea.Batch(toastCmd, func() tea.Msg {
+			return importDoneMsg{result: applyImport(drafts)}
+		})
+	case importDoneMsg:
+		n := len(msg.result.Created)
+		if err := msg.result.Err(); err != nil {
+			text, level = fmt.Sprintf("Imported %d issue%s with errors: %v", n, plural(n), err), components.ToastError
+		} else {
+			text = fmt.Sprintf("Imported %d issue%s", n, plural(n))
+		}
+		toast, toastCmd := components.ShowToast(text, level, toastDuration)
+		m.toast = toast
+		m.lastFileMod = time.Time{}
+		return m, tea.Batch(toastCmd, m.startPollImmediate())
+	}
+	toast, toastCmd := components.ShowToast(text, level, toastDuration)
+	m.toast = toast
+	return m, toastCmd
+}
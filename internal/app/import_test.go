@@ -0,0 +1,100 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/importer"
+)
+
+func TestPreviewImportMarksDuplicates(t *testing.T) {
+	m := setupModel(t)
+	m.projectDir = t.TempDir()
+	plan := "- [ ] open-1\n- [ ] Brand new work\n"
+	if err := os.WriteFile(filepath.Join(m.projectDir, "plan.md"), []byte(plan), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := m.previewImport("plan.md")().(importPreviewMsg)
+	if msg.err != nil {
+		t.Fatalf("preview error = %v", msg.err)
+	}
+	if len(msg.drafts) != 2 || msg.drafts[0].Duplicate != "open-1" || msg.drafts[1].Skip {
+		t.Fatalf("drafts = %+v", msg.drafts)
+	}
+
+	model, _ := m.Update(msg)
+	m = model.(Model)
+	if !m.importing {
+		t.Fatal("expected the import preview to open")
+	}
+	if view := m.View().Content; !strings.Contains(view, "IMPORT ISSUES") {
+		t.Error("view should render the import preview")
+	}
+}
+
+func TestPreviewImportError(t *testing.T) {
+	m := setupModel(t)
+	m.projectDir = t.TempDir()
+	msg := m.previewImport("missing.csv")()
+	model, cmd := m.Update(msg)
+	m = model.(Model)
+	if m.importing || cmd == nil || !m.toast.Active() {
+		t.Error("a failed preview should toast instead of opening the dialog")
+	}
+}
+
+func TestImportConfirmRunsApply(t *testing.T) {
+	var got []importer.Draft
+	orig := applyImport
+	applyImport = func(drafts []importer.Draft) importer.Result {
+		got = drafts
+		return importer.Result{Created: []importer.Created{{Ref: "1", ID: "mg-9", Title: "New"}}}
+	}
+	defer func() { applyImport = orig }()
+
+	m := setupModel(t)
+	model, _ := m.Update(importPreviewMsg{source: "plan.md", drafts: []importer.Draft{{Ref: "1", Title: "New"}}})
+	m = model.(Model)
+
+	model, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = model.(Model)
+	if cmd == nil {
+		t.Fatal("expected enter to confirm the import")
+	}
+	model, cmd = m.Update(cmd())
+	m = model.(Model)
+	if m.importing {
+		t.Error("preview should close after confirming")
+	}
+	if cmd == nil {
+		t.Fatal("expected an apply command")
+	}
+	var done importDoneMsg
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if d, ok := msg().(importDoneMsg); ok {
+			done = d
+		}
+	}
+	if len(got) != 1 || len(done.result.Created) != 1 {
+		t.Fatalf("applied %+v, result %+v", got, done.result)
+	}
+
+	model, _ = m.Update(done)
+	if !model.(Model).toast.Active() {
+		t.Error("expected a toast after the import")
+	}
+}
+
+func TestImportCancel(t *testing.T) {
+	m := setupModel(t)
+	model, _ := m.Update(importPreviewMsg{source: "plan.md", drafts: []importer.Draft{{Ref: "1", Title: "New"}}})
+	model, _ = model.(Model).Update(components.ImportDialogResult{Cancelled: true})
+	if model.(Model).importing {
+		t.Error("cancel should close the preview")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/matt-wright86/mardi-gras/internal/components"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/views"
 )
 
 // ---------------------------------------------------------------------------
@@ -270,17 +272,17 @@ func TestKeyGGJumpsToBottom(t *testing.T) {
 	model, _ = got.Update(tea.KeyPressMsg{Code: 'G', Text: "G"})
 	got = model.(Model)
 
-	// The cursor should be on the last non-header item
+	// The cursor should be on the last issue row, not the closing footer
 	if got.parade.Cursor >= len(got.parade.Items) {
 		t.Fatal("cursor out of range")
 	}
-	if got.parade.Items[got.parade.Cursor].IsHeader {
-		t.Fatal("expected cursor to be on a non-header item after pressing G")
+	if got.parade.Items[got.parade.Cursor].Issue == nil {
+		t.Fatal("expected cursor to be on an issue row after pressing G")
 	}
-	// Verify no non-header items exist after the cursor
+	// Verify no issue rows exist after the cursor
 	for i := got.parade.Cursor + 1; i < len(got.parade.Items); i++ {
-		if !got.parade.Items[i].IsHeader {
-			t.Fatalf("expected no non-header items after cursor at %d, but item %d is selectable", got.parade.Cursor, i)
+		if got.parade.Items[i].Issue != nil {
+			t.Fatalf("expected no issue rows after cursor at %d, but item %d is an issue", got.parade.Cursor, i)
 		}
 	}
 }
@@ -621,3 +623,121 @@ func TestKeyAMultiSlingWithSelection(t *testing.T) {
 		t.Fatal("expected selection to be cleared after multi-sling")
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Remapped keys reach the handlers
+// ---------------------------------------------------------------------------
+
+func TestRemappedKeys(t *testing.T) {
+	k, err := keymap.Default().Override(map[string]string{
+		"global.quit": "ctrl+q",
+		"parade.down": "ctrl+j",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keymap.Use(k)
+	t.Cleanup(func() { keymap.Use(keymap.Default()) })
+
+	got := setupModel(t)
+	if _, cmd := got.Update(tea.KeyPressMsg{Code: 'q', Text: "q"}); cmd != nil {
+		t.Fatal("q should no longer quit")
+	}
+	_, cmd := got.Update(tea.KeyPressMsg{Code: 'q', Mod: tea.ModCtrl})
+	if cmd == nil {
+		t.Fatal("expected ctrl+q to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("expected tea.QuitMsg from ctrl+q")
+	}
+
+	before := got.parade.Cursor
+	model, _ := got.Update(tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	got = model.(Model)
+	if got.parade.Cursor == before {
+		t.Error("ctrl+j should move the parade cursor down")
+	}
+	model, _ = got.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if model.(Model).parade.Cursor != got.parade.Cursor {
+		t.Error("j should no longer move the cursor")
+	}
+
+	// The timeline moves its rows with the parade keys.
+	model, _ = got.Update(tea.KeyPressMsg{Code: 'T', Text: "T"})
+	got = model.(Model)
+	got.activPane = PaneDetail
+	before = got.parade.Cursor
+	model, _ = got.Update(tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	if model.(Model).parade.Cursor == before {
+		t.Error("ctrl+j should move the timeline cursor down")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Collapsing sections and capped rows
+// ---------------------------------------------------------------------------
+
+func TestKeyZCollapsesSection(t *testing.T) {
+	got := setupModel(t)
+
+	model, _ := got.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	got = model.(Model)
+	if !got.parade.Collapsed[data.ParadeLinedUp] {
+		t.Fatal("z should collapse the cursor's section")
+	}
+	if got.parade.SelectedIssue != nil {
+		t.Errorf("nothing left to select, got %s", got.parade.SelectedIssue.ID)
+	}
+
+	model, _ = got.Update(tea.KeyPressMsg{Code: 'Z', Text: "Z"})
+	got = model.(Model)
+	if len(got.parade.Collapsed) != 0 || got.parade.SelectedIssue == nil {
+		t.Errorf("Z should expand every section, collapsed %v", got.parade.Collapsed)
+	}
+}
+
+func TestSelectRevealsCappedRow(t *testing.T) {
+	got := resize(t, setupModel(t).WithSectionRows(1), 100, 20)
+	got.rebuildParade()
+	if !got.restoreParadeSelection("open-3") {
+		t.Fatal("selecting a capped issue should reveal it")
+	}
+	if got.parade.SelectedIssue.ID != "open-3" || !got.parade.Revealed[data.ParadeLinedUp] {
+		t.Errorf("selected %s, revealed %v", got.parade.SelectedIssue.ID, got.parade.Revealed)
+	}
+
+	model, _ := got.Update(tea.KeyPressMsg{Code: '+', Text: "+"})
+	if model.(Model).parade.Revealed[data.ParadeLinedUp] {
+		t.Error("+ should cap the section again")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Detail tabs
+// ---------------------------------------------------------------------------
+
+func TestDetailTabsFetchComments(t *testing.T) {
+	got := setupModel(t)
+	model, _ := got.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	got = model.(Model)
+	if cmd := got.maybeFetchComments(); cmd != nil {
+		t.Fatal("comments should not be fetched from the Overview tab")
+	}
+
+	// Overview → Activity → Comments
+	for range 2 {
+		model, _ = got.Update(tea.KeyPressMsg{Code: 'L', Text: "L"})
+		got = model.(Model)
+	}
+	if got.detail.Tab != views.TabComments {
+		t.Fatalf("tab = %v, want Comments", got.detail.Tab)
+	}
+	if cmd := got.maybeFetchComments(); cmd == nil {
+		t.Error("opening the Comments tab should fetch comments")
+	}
+
+	model, _ = got.Update(tea.KeyPressMsg{Code: 'H', Text: "H"})
+	if tab := model.(Model).detail.Tab; tab != views.TabActivity {
+		t.Errorf("tab = %v after H, want Activity", tab)
+	}
+}
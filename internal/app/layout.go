@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+)
+
+const (
+	// stackedMaxWidth is the widest terminal that gets the stacked layout
+	// when the layout follows the terminal size.
+	stackedMaxWidth = 80
+	// columnsMinWidth is the narrowest terminal that gets the three-column
+	// layout when Gas Town is available.
+	columnsMinWidth = 200
+	// minPaneH is the shortest either pane can be in the stacked layout.
+	minPaneH = 5
+	// splitStep is how far < and > move the split.
+	splitStep = 0.05
+)
+
+// paneGeometry is where layout placed the panes. The right-hand views share
+// the detail size; gasTownW is set only for the Columns layout.
+type paneGeometry struct {
+	bodyH    int
+	paradeW  int
+	paradeH  int
+	detailW  int
+	detailH  int
+	gasTownW int
+}
+
+// autoLayout picks a preset for the terminal width: stacked when too narrow
+// for two columns, three columns when wide enough and Gas Town is
+// available, else the default split.
+func autoLayout(width int, gtAvailable bool) LayoutPreset {
+	switch {
+	case width < stackedMaxWidth:
+		return LayoutStacked
+	case width >= columnsMinWidth && gtAvailable:
+		return LayoutColumns
+	}
+	return LayoutDefault
+}
+
+// paneGeometry sizes the panes for the current preset and split ratio.
+func (m *Model) paneGeometry(bodyH int) paneGeometry {
+	g := paneGeometry{bodyH: bodyH, paradeH: bodyH, detailH: bodyH}
+	switch m.layoutPreset {
+	case LayoutWide:
+		g.paradeW = m.width
+	case LayoutStacked:
+		g.paradeW, g.detailW = m.width, m.width
+		g.paradeH = bodyH * 2 / 5
+		if m.splitRatio > 0 {
+			g.paradeH = int(float64(bodyH)*m.splitRatio + 0.5)
+		}
+		g.paradeH = max(min(g.paradeH, bodyH-minPaneH), min(minPaneH, bodyH))
+		g.detailH = max(bodyH-g.paradeH, 0)
+	case LayoutColumns:
+		g.gasTownW = max(m.width/4, minPaneW)
+		rest := m.width - g.gasTownW
+		g.paradeW = m.splitWidth(rest)
+		g.detailW = rest - g.paradeW
+	default:
+		g.paradeW = m.splitWidth(m.width)
+		g.detailW = m.width - g.paradeW
+	}
+	return g
+}
+
+// splitWidth is the parade's share of total columns: 2/5 unless the split
+// was moved, never narrower than minPaneW.
+func (m *Model) splitWidth(total int) int {
+	w := total * 2 / 5
+	if m.splitRatio > 0 {
+		w = min(int(float64(total)*m.splitRatio+0.5), total-minPaneW)
+	}
+	return max(w, minPaneW)
+}
+
+// paradeShare is the parade's current share of the split.
+func (m *Model) paradeShare() float64 {
+	g := m.panes
+	switch m.layoutPreset {
+	case LayoutStacked:
+		if g.bodyH > 0 {
+			return float64(g.paradeH) / float64(g.bodyH)
+		}
+	default:
+		if total := g.paradeW + g.detailW; total > 0 {
+			return float64(g.paradeW) / float64(total)
+		}
+	}
+	return 0.4
+}
+
+// setAutoLayout unpins the layout so it follows the terminal size again.
+func (m Model) setAutoLayout() (tea.Model, tea.Cmd) {
+	m.layoutPinned = false
+	m.layout()
+	toast, cmd := components.ShowToast("Layout: auto ("+m.layoutPreset.Label()+")", components.ToastInfo, toastDuration)
+	m.toast = toast
+	cmds := []tea.Cmd{cmd}
+	if m.layoutPreset == LayoutColumns && !m.showGasTown {
+		m.gasTownAuto = true
+		cmds = append(cmds, m.activateGasTown())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// resizeSplit grows (steps > 0) or shrinks the parade by splitStep per step.
+func (m Model) resizeSplit(steps int) (tea.Model, tea.Cmd) {
+	if m.layoutPreset == LayoutWide {
+		return m, nil
+	}
+	ratio := m.paradeShare() + float64(steps)*splitStep
+	m.splitRatio = max(min(ratio, 0.8), 0.2)
+	m.layout()
+	toast, cmd := components.ShowToast(
+		fmt.Sprintf("Split: parade %d%%", int(m.paradeShare()*100+0.5)),
+		components.ToastInfo, toastDuration,
+	)
+	m.toast = toast
+	return m, cmd
+}
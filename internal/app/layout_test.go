@@ -0,0 +1,108 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func resize(t *testing.T, m Model, width, height int) Model {
+	t.Helper()
+	model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return model.(Model)
+}
+
+func TestAutoLayout(t *testing.T) {
+	tests := []struct {
+		width int
+		gt    bool
+		want  LayoutPreset
+	}{
+		{60, true, LayoutStacked},
+		{100, false, LayoutDefault},
+		{240, false, LayoutDefault},
+		{240, true, LayoutColumns},
+	}
+	for _, tt := range tests {
+		if got := autoLayout(tt.width, tt.gt); got != tt.want {
+			t.Errorf("autoLayout(%d, %v) = %v, want %v", tt.width, tt.gt, got, tt.want)
+		}
+	}
+}
+
+func TestLayoutFollowsTerminalUnlessPinned(t *testing.T) {
+	m := resize(t, setupModel(t), 60, 30)
+	if m.layoutPreset != LayoutStacked {
+		t.Fatalf("narrow terminal layout = %v, want stacked", m.layoutPreset)
+	}
+	if m.parade.Width != 60 || m.parade.Height+m.panes.detailH != m.panes.bodyH {
+		t.Errorf("stacked panes = %+v", m.panes)
+	}
+
+	m = resize(t, m, 120, 30)
+	if m.layoutPreset != LayoutDefault {
+		t.Errorf("wide terminal layout = %v, want default", m.layoutPreset)
+	}
+
+	m = m.WithLayout(LayoutWide)
+	m = resize(t, m, 60, 30)
+	if m.layoutPreset != LayoutWide {
+		t.Errorf("pinned layout = %v, want wide", m.layoutPreset)
+	}
+}
+
+func TestColumnsLayoutGivesGasTownAColumn(t *testing.T) {
+	m := setupModel(t)
+	m.gtEnv.Available = true
+	model, cmd := m.Update(tea.WindowSizeMsg{Width: 240, Height: 30})
+	m = model.(Model)
+	if m.layoutPreset != LayoutColumns {
+		t.Fatalf("layout = %v, want columns", m.layoutPreset)
+	}
+	if cmd == nil || !m.showGasTown {
+		t.Error("switching to columns should start Gas Town")
+	}
+	g := m.panes
+	if g.gasTownW != 60 || g.paradeW+g.detailW+g.gasTownW != 240 {
+		t.Errorf("column widths = %+v", g)
+	}
+}
+
+func TestLeavingColumnsClosesAutoGasTown(t *testing.T) {
+	m := setupModel(t)
+	m.gtEnv.Available = true
+	m = resize(t, m, 220, 30)
+	if !m.showGasTown {
+		t.Fatal("220 columns should open Gas Town")
+	}
+	m = resize(t, m, 120, 30)
+	if m.layoutPreset != LayoutDefault || m.showGasTown {
+		t.Errorf("at 120 columns layout = %v, Gas Town shown %v; want default with the detail", m.layoutPreset, m.showGasTown)
+	}
+
+	// A panel the user opened stays open.
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl})
+	m = resize(t, model.(Model), 220, 30)
+	m = resize(t, m, 120, 30)
+	if !m.showGasTown {
+		t.Error("resizing closed a Gas Town panel opened by the user")
+	}
+}
+
+func TestResizeKeysMoveSplit(t *testing.T) {
+	m := setupModel(t)
+	before := m.parade.Width
+
+	model, _ := m.Update(tea.KeyPressMsg{Code: '>', Text: ">"})
+	m = model.(Model)
+	if m.parade.Width != before+5 {
+		t.Fatalf("> parade width = %d, want %d", m.parade.Width, before+5)
+	}
+	for range 20 {
+		model, _ = m.Update(tea.KeyPressMsg{Code: '<', Text: "<"})
+		m = model.(Model)
+	}
+	if m.parade.Width != minPaneW {
+		t.Errorf("parade should stop at %d columns, got %d", minPaneW, m.parade.Width)
+	}
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// mouseWheelStep is how many rows one wheel notch scrolls.
+	mouseWheelStep = 3
+	// minPaneW is the narrowest either pane can be dragged to.
+	minPaneW = 30
+	// bodyTop is the first screen row below the header.
+	bodyTop = 2
+)
+
+// handleMouse routes a mouse event to the pane under the pointer. Modal
+// dialogs and bottom-bar inputs keep the keyboard focus, so the mouse is
+// ignored while they are open; the palette and forms see mouse events
+// through their own forwarding in Update.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.showHelp {
+		if wheel, ok := msg.(tea.MouseWheelMsg); ok {
+			m.help.Scroll(wheelDelta(wheel))
+		}
+		return m, nil
+	}
+	if m.importing || m.bulkEditing || m.showCalendar || m.nudging || m.qaMode != "" ||
+		m.mailComposing || m.mailReplying || m.convoyCreating {
+		return m, nil
+	}
+
+	mouse := msg.Mouse()
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if mouse.Button != tea.MouseLeft {
+			return m, nil
+		}
+		if m.onDivider(mouse.X, mouse.Y) {
+			m.draggingSplit = true
+			return m, nil
+		}
+		return m.handleClick(mouse.X, mouse.Y)
+
+	case tea.MouseMotionMsg:
+		if m.draggingSplit {
+			m.dragSplit(mouse.X, mouse.Y)
+		}
+		return m, nil
+
+	case tea.MouseReleaseMsg:
+		if !m.draggingSplit {
+			return m, nil
+		}
+		m.draggingSplit = false
+		return m, nil
+
+	case tea.MouseWheelMsg:
+		row := mouse.Y - bodyTop
+		if row < 0 || row >= m.panes.bodyH {
+			return m, nil
+		}
+		delta := wheelDelta(msg) * mouseWheelStep
+		if m.inParade(mouse.X, mouse.Y) {
+			m.parade.ScrollBy(delta)
+			return m, nil
+		}
+		if !m.inGasTownColumn(mouse.X) && !m.rightPanelTakenOver() {
+			if delta > 0 {
+				m.detail.Viewport.ScrollDown(delta)
+			} else {
+				m.detail.Viewport.ScrollUp(-delta)
+			}
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+// handleClick focuses the pane under (x, y) and selects the row clicked.
+func (m Model) handleClick(x, y int) (tea.Model, tea.Cmd) {
+	row := y - bodyTop
+	if row < 0 || row >= m.panes.bodyH {
+		return m, nil
+	}
+
+	if m.inParade(x, y) {
+		m.activPane = PaneParade
+		m.detail.Focused = false
+		idx := m.parade.ItemAt(row)
+		if idx < 0 {
+			return m, nil
+		}
+		item := m.parade.Items[idx]
+		if item.IsHeader {
+			logAction("mouse: toggle section %s", item.Section.Title)
+			m.parade.ToggleSection(item.Section.Status)
+			m.syncSelection()
+			return m, nil
+		}
+		if item.IsMore {
+			logAction("mouse: show all of %s", item.Section.Title)
+			m.parade.ToggleRows(item.Section.Status)
+			m.syncSelection()
+			return m, nil
+		}
+		if m.parade.Select(idx) {
+			logAction("mouse: select %s", item.Issue.ID)
+			m.syncSelection()
+			return m, nil
+		}
+		return m, nil
+	}
+
+	m.activPane = PaneDetail
+	m.detail.Focused = true
+	if m.layoutPreset == LayoutStacked {
+		row -= m.panes.paradeH
+	}
+	gasTownShown := m.showGasTown && m.gtEnv.Available && !m.rightPanelTakenOverBesidesGasTown()
+	if m.inGasTownColumn(x) || (gasTownShown && m.layoutPreset != LayoutColumns) {
+		if i := m.gasTown.AgentAt(row); i >= 0 {
+			logAction("mouse: select agent %d", i)
+			m.gasTown.SelectAgent(i)
+		}
+	}
+	return m, nil
+}
+
+// inParade reports whether cell (x, y) falls in the parade pane.
+func (m *Model) inParade(x, y int) bool {
+	switch m.layoutPreset {
+	case LayoutWide:
+		return true
+	case LayoutStacked:
+		return y-bodyTop < m.panes.paradeH
+	}
+	return x < m.panes.paradeW
+}
+
+// inGasTownColumn reports whether column x falls in the Columns layout's
+// Gas Town column.
+func (m *Model) inGasTownColumn(x int) bool {
+	return m.panes.gasTownW > 0 && x >= m.panes.paradeW+m.panes.detailW
+}
+
+// onDivider reports whether cell (x, y) is on the border between the parade
+// and the pane beside or below it.
+func (m *Model) onDivider(x, y int) bool {
+	switch m.layoutPreset {
+	case LayoutWide:
+		return false
+	case LayoutStacked:
+		edge := bodyTop + m.panes.paradeH
+		return y == edge || y == edge-1
+	}
+	return x == m.panes.paradeW || x == m.panes.paradeW-1
+}
+
+// dragSplit moves the divider to cell (x, y), keeping both panes usable,
+// and records the position as a ratio so resizes keep the split.
+func (m *Model) dragSplit(x, y int) {
+	if m.layoutPreset == LayoutStacked {
+		g := m.panes
+		if g.bodyH < 2*minPaneH {
+			return
+		}
+		rows := max(min(y-bodyTop, g.bodyH-minPaneH), minPaneH)
+		m.splitRatio = float64(rows) / float64(g.bodyH)
+		m.layout()
+		return
+	}
+	total := m.panes.paradeW + m.panes.detailW
+	if total < 2*minPaneW {
+		return
+	}
+	x = max(min(x, total-minPaneW), minPaneW)
+	m.splitRatio = float64(x) / float64(total)
+	m.layout()
+}
+
+// rightPanelTakenOver reports whether a view other than the detail pane is
+// shown beside the parade.
+func (m *Model) rightPanelTakenOver() bool {
+	return m.rightPanelTakenOverBesidesGasTown() ||
+		(m.showGasTown && m.gtEnv.Available && m.layoutPreset != LayoutColumns)
+}
+
+// rightPanelTakenOverBesidesGasTown reports whether a toggled panel other
+// than Gas Town replaces the detail pane.
+func (m *Model) rightPanelTakenOverBesidesGasTown() bool {
+	return m.showDoctor || m.showTimeline || m.showCharts || (m.showProblems && m.gtEnv.Available)
+}
+
+// wheelDelta is -1 for wheel up and 1 for wheel down.
+func wheelDelta(msg tea.MouseWheelMsg) int {
+	if msg.Mouse().Button == tea.MouseWheelUp {
+		return -1
+	}
+	return 1
+}
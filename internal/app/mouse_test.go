@@ -0,0 +1,99 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func click(x, y int) tea.MouseClickMsg {
+	return tea.MouseClickMsg{X: x, Y: y, Button: tea.MouseLeft}
+}
+
+func TestMouseClickSelectsParadeRow(t *testing.T) {
+	m := setupModel(t)
+	m.activPane = PaneDetail
+
+	// Row 0 of the body is the Lined Up header, so open-2 is on row 2.
+	model, _ := m.Update(click(5, bodyTop+2))
+	m = model.(Model)
+	if m.activPane != PaneParade {
+		t.Error("clicking the parade should focus it")
+	}
+	if m.parade.SelectedIssue == nil || m.parade.SelectedIssue.ID != "open-2" {
+		t.Fatalf("selected %v, want open-2", m.parade.SelectedIssue)
+	}
+	if m.detail.Issue == nil || m.detail.Issue.ID != "open-2" {
+		t.Error("detail should follow the clicked row")
+	}
+
+	model, _ = m.Update(click(m.parade.Width+5, bodyTop+1))
+	m = model.(Model)
+	if m.activPane != PaneDetail || !m.detail.Focused {
+		t.Error("clicking the detail pane should focus it")
+	}
+}
+
+func TestMouseClickHeaderCollapsesSection(t *testing.T) {
+	m := setupModel(t)
+	before := len(m.parade.Items)
+
+	model, _ := m.Update(click(5, bodyTop))
+	m = model.(Model)
+	if len(m.parade.Items) != before-3 {
+		t.Fatalf("collapsing Lined Up should hide 3 rows, got %d -> %d", before, len(m.parade.Items))
+	}
+
+	m.rebuildParade()
+	if len(m.parade.Items) != before-3 {
+		t.Error("collapsed sections should survive a rebuild")
+	}
+}
+
+func TestMouseWheelScrollsParade(t *testing.T) {
+	m := setupModel(t)
+	m.parade.Height = 2
+
+	model, _ := m.Update(tea.MouseWheelMsg{X: 5, Y: bodyTop, Button: tea.MouseWheelDown})
+	m = model.(Model)
+	if m.parade.ScrollOffset == 0 {
+		t.Error("wheel down should scroll the parade")
+	}
+}
+
+func TestMouseDragResizesSplit(t *testing.T) {
+	m := setupModel(t)
+	divider := m.parade.Width
+
+	model, _ := m.Update(click(divider, bodyTop+1))
+	m = model.(Model)
+	if !m.draggingSplit {
+		t.Fatal("pressing on the divider should start a drag")
+	}
+	model, _ = m.Update(tea.MouseMotionMsg{X: 60, Y: bodyTop + 1, Button: tea.MouseLeft})
+	model, _ = model.(Model).Update(tea.MouseReleaseMsg{X: 60, Y: bodyTop + 1, Button: tea.MouseLeft})
+	m = model.(Model)
+	if m.draggingSplit {
+		t.Error("release should end the drag")
+	}
+	if m.parade.Width != 60 {
+		t.Fatalf("parade width = %d, want 60", m.parade.Width)
+	}
+
+	// The ratio survives a terminal resize.
+	model, _ = m.Update(tea.WindowSizeMsg{Width: 200, Height: 20})
+	if w := model.(Model).parade.Width; w != 120 {
+		t.Errorf("parade width after resize = %d, want 120", w)
+	}
+}
+
+func TestMouseIgnoredUnderModal(t *testing.T) {
+	m := setupModel(t)
+	m.bulkEditing = true
+	before := m.parade.SelectedIssue.ID
+
+	model, _ := m.Update(click(5, bodyTop+3))
+	if model.(Model).parade.SelectedIssue.ID != before {
+		t.Error("clicks should not reach the parade under a dialog")
+	}
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// prPollInterval is how often pull requests are refetched from gh.
+const prPollInterval = time.Minute
+
+// pullRequestsMsg carries the repository's pull requests from gh.
+type pullRequestsMsg struct {
+	prs []data.PullRequest
+	err error
+}
+
+// prTickMsg triggers the next pull request fetch.
+type prTickMsg struct{}
+
+// prOpenedMsg reports the result of opening a PR in the browser.
+type prOpenedMsg struct{ err error }
+
+// fetchPullRequests returns a Cmd that lists pull requests via gh.
+func fetchPullRequests(projectDir string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := data.FetchPullRequests(projectDir)
+		return pullRequestsMsg{prs: prs, err: err}
+	}
+}
+
+func prTickCmd() tea.Cmd {
+	return tea.Tick(prPollInterval, func(time.Time) tea.Msg {
+		return prTickMsg{}
+	})
+}
+
+// prFilterPredicates resolves has:pr and the pr:<state> filter tokens.
+func prFilterPredicates(prs map[string]*data.PullRequest) data.TokenPredicates {
+	with := func(match func(*data.PullRequest) bool) func(data.Issue) bool {
+		return func(iss data.Issue) bool {
+			pr := prs[iss.ID]
+			return pr != nil && match(pr)
+		}
+	}
+	label := func(l string) func(data.Issue) bool {
+		return with(func(pr *data.PullRequest) bool { return pr.Label() == l })
+	}
+	checks := func(c string) func(data.Issue) bool {
+		return with(func(pr *data.PullRequest) bool { return pr.Checks == c })
+	}
+	review := func(r string) func(data.Issue) bool {
+		return with(func(pr *data.PullRequest) bool { return pr.ReviewDecision == r })
+	}
+	return data.TokenPredicates{
+		"has:pr":      with(func(*data.PullRequest) bool { return true }),
+		"pr:open":     label("open"),
+		"pr:draft":    label("draft"),
+		"pr:merged":   label("merged"),
+		"pr:closed":   label("closed"),
+		"pr:failing":  checks(data.ChecksFailing),
+		"pr:pending":  checks(data.ChecksPending),
+		"pr:passing":  checks(data.ChecksPassing),
+		"pr:approved": review("APPROVED"),
+		"pr:changes":  review("CHANGES_REQUESTED"),
+		"pr:review":   review("REVIEW_REQUIRED"),
+	}
+}
+
+// matchPullRequests pairs the latest PR list with the current issues and
+// pushes the result to the parade and detail views. A filter on PR state is
+// re-applied so it tracks the new list.
+func (m *Model) matchPullRequests() {
+	m.prByIssue = data.MatchPullRequests(m.pullRequests, m.issues)
+	m.parade.PullRequests = m.prByIssue
+	m.detail.PullRequests = m.prByIssue
+	if filtersPullRequests(m.filterInput.Value()) {
+		m.rebuildParade()
+		return
+	}
+	if m.detail.Issue != nil {
+		m.detail.SetIssue(m.detail.Issue)
+	}
+}
+
+// filtersPullRequests reports whether query uses a has:pr or pr: token.
+func filtersPullRequests(query string) bool {
+	query = strings.ToLower(query)
+	return strings.Contains(query, "has:pr") || strings.Contains(query, "pr:")
+}
+
+// openPullRequest opens the selected issue's PR in the browser.
+func (m Model) openPullRequest() (tea.Model, tea.Cmd) {
+	issue := m.parade.SelectedIssue
+	if issue == nil {
+		return m, nil
+	}
+	pr := m.prByIssue[issue.ID]
+	if pr == nil {
+		toast, cmd := components.ShowToast(fmt.Sprintf("No pull request for %s", issue.ID), components.ToastInfo, toastDuration)
+		m.toast = toast
+		return m, cmd
+	}
+	projectDir, number := m.projectDir, pr.Number
+	return m, func() tea.Msg {
+		return prOpenedMsg{err: data.OpenPullRequest(projectDir, number)}
+	}
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func newPRModel(t *testing.T) Model {
+	t.Helper()
+	m := setupModel(t)
+	model, cmd := m.Update(pullRequestsMsg{prs: []data.PullRequest{
+		{Number: 7, HeadRefName: "task/open-1", State: data.PRStateOpen, Checks: data.ChecksFailing},
+		{Number: 8, Title: "Finish open-2", State: data.PRStateMerged, ReviewDecision: "APPROVED"},
+	}})
+	if cmd == nil {
+		t.Fatal("pullRequestsMsg should schedule the next fetch")
+	}
+	return model.(Model)
+}
+
+func paradeIDs(m Model) []string {
+	var ids []string
+	for _, item := range m.parade.Items {
+		if item.Issue != nil {
+			ids = append(ids, item.Issue.ID)
+		}
+	}
+	return ids
+}
+
+func TestPullRequestsMsgMatchesIssues(t *testing.T) {
+	m := newPRModel(t)
+	if pr := m.parade.PullRequests["open-1"]; pr == nil || pr.Number != 7 {
+		t.Errorf("parade PR for open-1 = %+v, want #7", pr)
+	}
+	if pr := m.detail.PullRequests["open-2"]; pr == nil || pr.Number != 8 {
+		t.Errorf("detail PR for open-2 = %+v, want #8", pr)
+	}
+
+	// A failed refresh keeps the last good list.
+	model, _ := m.Update(pullRequestsMsg{err: errors.New("offline")})
+	if model.(Model).prByIssue["open-1"] == nil {
+		t.Error("error should not clear matched PRs")
+	}
+}
+
+func TestPRFilterTokens(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"has:pr", []string{"open-1", "open-2"}},
+		{"pr:failing", []string{"open-1"}},
+		{"pr:merged", []string{"open-2"}},
+		{"pr:approved", []string{"open-2"}},
+		{"pr:draft", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			m := newPRModel(t)
+			m.filterInput.SetValue(tt.query)
+			m.rebuildParade()
+			got := paradeIDs(m)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parade = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parade = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPRFilterFollowsRefresh(t *testing.T) {
+	m := newPRModel(t)
+	m.filterInput.SetValue("pr:failing")
+	m.rebuildParade()
+
+	// open-1's checks go green and open-3 gets a failing PR.
+	model, _ := m.Update(pullRequestsMsg{prs: []data.PullRequest{
+		{Number: 7, HeadRefName: "task/open-1", State: data.PRStateOpen, Checks: data.ChecksPassing},
+		{Number: 9, HeadRefName: "task/open-3", State: data.PRStateOpen, Checks: data.ChecksFailing},
+	}})
+	if got := paradeIDs(model.(Model)); len(got) != 1 || got[0] != "open-3" {
+		t.Errorf("parade = %v after refresh, want [open-3]", got)
+	}
+}
+
+func TestKeyOOpensPullRequest(t *testing.T) {
+	m := setupModel(t)
+	model, cmd := m.handleKey(tea.KeyPressMsg{Code: 'O', Text: "O"})
+	if cmd == nil || !model.(Model).toast.Active() {
+		t.Error("O without a PR should toast")
+	}
+
+	m = newPRModel(t)
+	if !m.restoreParadeSelection("open-1") {
+		t.Fatal("open-1 not in parade")
+	}
+	m.syncSelection()
+	model, cmd = m.handleKey(tea.KeyPressMsg{Code: 'O', Text: "O"})
+	if cmd == nil || model.(Model).toast.Active() {
+		t.Error("O with a PR should return an open command")
+	}
+}
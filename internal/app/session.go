@@ -0,0 +1,162 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/matt-wright86/mardi-gras/internal/views"
+)
+
+// sessionState is the per-project UI state remembered between sessions.
+// Issue IDs that no longer exist are dropped on restore.
+type sessionState struct {
+	SelectedID     string   `json:"selected_id,omitempty"`
+	Filter         string   `json:"filter,omitempty"`
+	FocusMode      bool     `json:"focus_mode,omitempty"`
+	ShowClosed     bool     `json:"show_closed,omitempty"`
+	Layout         string   `json:"layout,omitempty"` // empty when the layout follows the terminal
+	SplitRatio     float64  `json:"split_ratio,omitempty"`
+	Pane           string   `json:"pane,omitempty"` // parade or detail
+	GasTown        bool     `json:"gastown,omitempty"`
+	GasTownSection string   `json:"gastown_section,omitempty"`
+	Selection      []string `json:"selection,omitempty"`
+}
+
+// loadSessionState reads the session file at path. A missing file yields a
+// zero state.
+func loadSessionState(path string) (sessionState, error) {
+	var st sessionState
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return st, err
+	}
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return sessionState{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return st, nil
+}
+
+// WithSession saves the UI state to path on exit (see SaveSession) and,
+// when restore is set, brings back the state saved there. The layout, split
+// and Gas Town panel apply now; the rest waits for the first parade build.
+// Call WithLayout afterwards for a preset pinned by config, which wins over
+// the saved one.
+func (m Model) WithSession(path string, restore bool) Model {
+	m.sessionPath = path
+	if !restore || path == "" {
+		return m
+	}
+	st, err := loadSessionState(path)
+	if err != nil {
+		dbg("session: %v", err)
+		return m
+	}
+	if st.SplitRatio > 0 && st.SplitRatio < 1 {
+		m.splitRatio = st.SplitRatio
+	}
+	if p, ok := ParseLayoutPreset(st.Layout); ok && st.Layout != "" {
+		m = m.WithLayout(p)
+	}
+	if st.GasTown && m.gtEnv.Available {
+		m.showGasTown = true
+		m.gasTownTicking = true // Init starts the tick
+	}
+	if s, ok := views.ParseGasTownSection(st.GasTownSection); ok {
+		m.gasTown.SetSection(s)
+	}
+	m.pendingSession = &st
+	return m
+}
+
+// restoreSession applies the saved filter, focus mode, closed visibility,
+// selection and pane once the parade exists.
+func (m *Model) restoreSession() {
+	st := m.pendingSession
+	m.pendingSession = nil
+
+	m.filterInput.SetValue(st.Filter)
+	m.focusMode = st.FocusMode
+	m.parade.ShowClosed = st.ShowClosed
+	m.rebuildParade()
+	m.selectionLost = false
+
+	if m.restoreParadeSelection(st.SelectedID) {
+		m.sessionSelected = true
+		m.syncSelection()
+	}
+	ids := make(map[string]bool, len(m.issues))
+	for _, iss := range m.issues {
+		ids[iss.ID] = true
+	}
+	for _, id := range st.Selection {
+		if ids[id] {
+			if m.parade.Selected == nil {
+				m.parade.Selected = make(map[string]bool)
+			}
+			m.parade.Selected[id] = true
+		}
+	}
+	if st.Pane == "detail" && m.parade.SelectedIssue != nil {
+		m.activPane = PaneDetail
+	}
+}
+
+// session captures the state SaveSession writes.
+func (m Model) session() sessionState {
+	st := sessionState{
+		Filter:     m.filterInput.Value(),
+		FocusMode:  m.focusMode,
+		ShowClosed: m.parade.ShowClosed,
+		Pane:       "parade",
+		GasTown:    m.showGasTown,
+		SplitRatio: m.splitRatio,
+	}
+	if m.parade.SelectedIssue != nil {
+		st.SelectedID = m.parade.SelectedIssue.ID
+	}
+	if m.layoutPinned {
+		st.Layout = m.layoutPreset.String()
+	}
+	if m.activPane == PaneDetail {
+		st.Pane = "detail"
+	}
+	if m.showGasTown {
+		st.GasTownSection = m.gasTown.Section().String()
+	}
+	for id := range m.parade.Selected {
+		st.Selection = append(st.Selection, id)
+	}
+	slices.Sort(st.Selection)
+	return st
+}
+
+// SaveSession writes the UI state to the session file, if there is one.
+// mg calls it with the final model when the program exits.
+func (m Model) SaveSession() error {
+	if m.sessionPath == "" {
+		return nil
+	}
+	return writeStateFile(m.sessionPath, m.session())
+}
+
+// writeStateFile writes v as JSON to path atomically, creating its directory.
+func writeStateFile(path string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
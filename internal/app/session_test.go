@@ -0,0 +1,121 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	m := setupModel(t).WithSession(path, true)
+
+	m.parade.MoveDown()
+	m.parade.ToggleSelect()
+	m.parade.MoveDown()
+	m.parade.ToggleSelect()
+	m.parade.ToggleClosed()
+	m.filterInput.SetValue("open")
+	m.layoutPreset, m.layoutPinned = LayoutStacked, true
+	m.splitRatio = 0.6
+	m.activPane = PaneDetail
+	if err := m.SaveSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := resize(t, setupModel(t).WithSession(path, true), 100, 20)
+	if got.parade.SelectedIssue == nil || got.parade.SelectedIssue.ID != "open-3" {
+		t.Errorf("selected = %v, want open-3", got.parade.SelectedIssue)
+	}
+	if !got.parade.Selected["open-2"] || !got.parade.Selected["open-3"] || len(got.parade.Selected) != 2 {
+		t.Errorf("multi-selection = %v, want open-2 and open-3", got.parade.Selected)
+	}
+	if !got.parade.ShowClosed {
+		t.Error("closed issues hidden, want shown")
+	}
+	if got.filterInput.Value() != "open" {
+		t.Errorf("filter = %q, want open", got.filterInput.Value())
+	}
+	if got.layoutPreset != LayoutStacked || !got.layoutPinned || got.splitRatio != 0.6 {
+		t.Errorf("layout = %v pinned %v ratio %v, want pinned stacked at 0.6", got.layoutPreset, got.layoutPinned, got.splitRatio)
+	}
+	if got.activPane != PaneDetail {
+		t.Errorf("pane = %v, want detail", got.activPane)
+	}
+}
+
+func TestSessionFreshStartsUnpinned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	st := sessionState{Layout: "stacked", SplitRatio: 0.6, Filter: "open"}
+	if err := writeStateFile(path, st); err != nil {
+		t.Fatal(err)
+	}
+
+	got := resize(t, setupModel(t).WithSession(path, false), 100, 20)
+	if got.layoutPinned || got.splitRatio != 0 {
+		t.Errorf("layout = %v pinned %v ratio %v, want unpinned default", got.layoutPreset, got.layoutPinned, got.splitRatio)
+	}
+	if got.filterInput.Value() != "" {
+		t.Errorf("filter = %q, want empty", got.filterInput.Value())
+	}
+}
+
+func TestSessionDropsMissingIssues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	st := sessionState{SelectedID: "gone-1", Selection: []string{"gone-2", "open-2"}, Pane: "detail"}
+	if err := writeStateFile(path, st); err != nil {
+		t.Fatal(err)
+	}
+
+	got := resize(t, setupModel(t).WithSession(path, true), 100, 20)
+	if got.parade.SelectedIssue == nil || got.parade.SelectedIssue.ID != "open-1" {
+		t.Errorf("selected = %v, want the first issue", got.parade.SelectedIssue)
+	}
+	if len(got.parade.Selected) != 1 || !got.parade.Selected["open-2"] {
+		t.Errorf("multi-selection = %v, want only open-2", got.parade.Selected)
+	}
+	if got.selectionLost {
+		t.Error("restore reported a lost selection")
+	}
+
+	// The startup current issue still applies when the saved one is gone.
+	model, _ := got.Update(currentIssueMsg{issueID: "open-3"})
+	if sel := model.(Model).parade.SelectedIssue; sel == nil || sel.ID != "open-3" {
+		t.Errorf("selected = %v after current issue, want open-3", sel)
+	}
+}
+
+func TestSessionFreshSkipsRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := writeStateFile(path, sessionState{SelectedID: "open-2", Filter: "open-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := resize(t, setupModel(t).WithSession(path, false), 100, 20)
+	if got.filterInput.Value() != "" || got.parade.SelectedIssue.ID != "open-1" {
+		t.Errorf("fresh start restored filter %q, selection %s", got.filterInput.Value(), got.parade.SelectedIssue.ID)
+	}
+
+	// A fresh session still saves on exit.
+	if err := got.SaveSession(); err != nil {
+		t.Fatal(err)
+	}
+	st, err := loadSessionState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.SelectedID != "open-1" || st.Filter != "" {
+		t.Errorf("saved state = %+v", st)
+	}
+}
+
+func TestSessionIgnoresCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := resize(t, setupModel(t).WithSession(path, true), 100, 20)
+	if got.pendingSession != nil || got.parade.SelectedIssue == nil {
+		t.Errorf("corrupt session: pending %v, selected %v", got.pendingSession, got.parade.SelectedIssue)
+	}
+}
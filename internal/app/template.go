@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// loadTemplates reads the project's issue templates; tests replace it.
+var loadTemplates = data.LoadTemplates
+
+// openCreateForm shows the create form. With project templates it opens on
+// the template picker; fromTemplate drops the "Blank issue" choice. A broken
+// template file is reported in a toast and left out of the picker.
+func (m Model) openCreateForm(fromTemplate bool) (tea.Model, tea.Cmd) {
+	templates, err := loadTemplates(m.projectDir)
+	if fromTemplate && len(templates) == 0 {
+		text := "No templates in " + data.TemplatesDir(m.projectDir)
+		if err != nil {
+			text = fmt.Sprintf("Templates: %v", err)
+		}
+		toast, toastCmd := components.ShowToast(text, components.ToastWarn, toastDuration)
+		m.toast = toast
+		return m, toastCmd
+	}
+	m.creating = true
+	m.createForm = m.newCreateForm().WithTemplates(templates, m.metadataSchema, !fromTemplate)
+	cmds := []tea.Cmd{m.createForm.Init()}
+	if err != nil {
+		toast, toastCmd := components.ShowToast(fmt.Sprintf("Templates: %v", err), components.ToastWarn, toastDuration)
+		m.toast = toast
+		cmds = append(cmds, toastCmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// createFromTemplate returns a Cmd that creates the issue and applies the
+// template's extras. With a crew member the issue is then assigned in bd;
+// gt assign cannot take an existing issue.
+func createFromTemplate(result components.CreateFormResult) tea.Cmd {
+	tmpl := *result.Template
+	title := result.Title
+	issueType := data.IssueType(result.Type)
+	priority := components.ParsePriority(result.Priority)
+	metadata := result.Metadata
+	crew := result.CrewMember
+	return func() tea.Msg {
+		id, err := data.CreateFromTemplate(tmpl, title, issueType, priority, metadata)
+		if id == "" {
+			return mutateResultMsg{issueID: title, action: "created", err: err}
+		}
+		if err == nil && crew != "" {
+			err = data.SetAssignee(id, crew)
+		}
+		return mutateResultMsg{issueID: id, action: "created from " + tmpl.Name, err: err}
+	}
+}
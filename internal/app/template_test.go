@@ -0,0 +1,57 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func stubTemplates(t *testing.T, templates []data.Template, err error) {
+	t.Helper()
+	orig := loadTemplates
+	loadTemplates = func(string) ([]data.Template, error) { return templates, err }
+	t.Cleanup(func() { loadTemplates = orig })
+}
+
+func TestKeyNOpensTemplatePicker(t *testing.T) {
+	stubTemplates(t, []data.Template{{Name: "Bug report", Type: data.TypeBug}}, nil)
+	m := setupModel(t)
+
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'N', Text: "N"})
+	m = model.(Model)
+	if !m.creating {
+		t.Fatal("expected the create form to open")
+	}
+	view := m.View().Content
+	for _, want := range []string{"Blank issue", "Bug report"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("picker missing %q", want)
+		}
+	}
+}
+
+func TestNewFromTemplateWithoutTemplatesToasts(t *testing.T) {
+	stubTemplates(t, nil, nil)
+	m := setupModel(t)
+	model, cmd := m.openCreateForm(true)
+	m = model.(Model)
+	if m.creating || cmd == nil || !m.toast.Active() {
+		t.Error("expected a toast instead of an empty picker")
+	}
+}
+
+func TestOpenCreateFormReportsBrokenTemplates(t *testing.T) {
+	stubTemplates(t, []data.Template{{Name: "Spike"}}, errors.New("bad.yaml: yaml: line 1"))
+	m := setupModel(t)
+	model, _ := m.openCreateForm(true)
+	m = model.(Model)
+	if !m.creating || !m.toast.Active() {
+		t.Errorf("creating=%v toast=%v, want form open with a warning", m.creating, m.toast.Active())
+	}
+	if strings.Contains(m.View().Content, "Blank issue") {
+		t.Error("New from template should not offer a blank issue")
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// ThemeAuto follows the terminal background: dark unless the terminal
+// reports a light one.
+const ThemeAuto = "auto"
+
+// WithTheme applies the theme called name from the built-ins and custom,
+// which are also offered by Cycle theme. ThemeAuto, an empty name or an
+// unknown one pick dark or light from the terminal background once it is
+// reported.
+func (m Model) WithTheme(name string, custom []ui.Theme) Model {
+	m.themes = append(ui.BuiltinThemes(), custom...)
+	if name == "" || name == ThemeAuto {
+		m.themeAuto = true
+		return m
+	}
+	t, ok := ui.FindTheme(m.themes, name)
+	if !ok {
+		dbg("theme: unknown theme %q, following the terminal background", name)
+		m.themeAuto = true
+		return m
+	}
+	m.themeAuto = false
+	ui.ApplyTheme(t)
+	return m
+}
+
+// handleBackgroundColor picks the default theme for the terminal background
+// while no theme has been chosen.
+func (m Model) handleBackgroundColor(msg tea.BackgroundColorMsg) (tea.Model, tea.Cmd) {
+	if !m.themeAuto {
+		return m, nil
+	}
+	t := ui.DefaultTheme(msg.IsDark())
+	dbg("theme: terminal background %s, using %s", msg.String(), t.Name)
+	if t.Name != ui.ActiveTheme().Name {
+		m.applyTheme(t)
+	}
+	return m, nil
+}
+
+// cycleTheme switches to the next theme and stops following the terminal
+// background.
+func (m Model) cycleTheme() (tea.Model, tea.Cmd) {
+	if len(m.themes) == 0 {
+		m.themes = ui.BuiltinThemes()
+	}
+	i := slices.IndexFunc(m.themes, func(t ui.Theme) bool { return t.Name == ui.ActiveTheme().Name })
+	t := m.themes[(i+1)%len(m.themes)]
+	m.themeAuto = false
+	m.applyTheme(t)
+	logAction("theme: %s", t.Name)
+	toast, cmd := components.ShowToast("Theme: "+t.Name, components.ToastInfo, toastDuration)
+	m.toast = toast
+	return m, cmd
+}
+
+// toggleAccessible switches accessible mode and redraws with the new
+// symbols, resuming the header shimmer it paused.
+func (m Model) toggleAccessible() (tea.Model, tea.Cmd) {
+	on := !ui.Accessible()
+	ui.SetAccessible(on)
+	m.detail.Restyle()
+	m.rebuildParade()
+	logAction("accessible: %v", on)
+
+	label := "Accessible mode off"
+	if on {
+		label = "Accessible mode on"
+	}
+	toast, cmd := components.ShowToast(label, components.ToastInfo, toastDuration)
+	m.toast = toast
+	cmds := []tea.Cmd{cmd}
+	if !on && m.shimmerPaused && !m.noAnimations {
+		m.shimmerPaused = false
+		cmds = append(cmds, headerShimmerCmd())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// applyTheme switches the palette and re-renders everything that cached
+// styled output from the old one.
+func (m *Model) applyTheme(t ui.Theme) {
+	ui.ApplyTheme(t)
+	m.detail.Restyle()
+	m.rebuildParade()
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"image/color"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+func TestBackgroundColorPicksThemeWhenAuto(t *testing.T) {
+	t.Cleanup(func() { ui.ApplyTheme(ui.DarkTheme()) })
+	m := setupModel(t).WithTheme(ThemeAuto, nil)
+
+	model, _ := m.Update(tea.BackgroundColorMsg{Color: color.White})
+	m = model.(Model)
+	if got := ui.ActiveTheme().Name; got != ui.ThemeLight {
+		t.Fatalf("theme = %q after a light background, want light", got)
+	}
+	// setupModel has no rolling issues, so Lined Up heads the parade.
+	if got := m.parade.Items[0].Section.Color; got != ui.StatusLinedUp {
+		t.Errorf("parade section color = %v, want the light theme's %v", got, ui.StatusLinedUp)
+	}
+
+	m.Update(tea.BackgroundColorMsg{Color: color.Black})
+	if got := ui.ActiveTheme().Name; got != ui.ThemeDark {
+		t.Errorf("theme = %q after a dark background, want dark", got)
+	}
+}
+
+func TestBackgroundColorIgnoredForChosenTheme(t *testing.T) {
+	t.Cleanup(func() { ui.ApplyTheme(ui.DarkTheme()) })
+	m := setupModel(t).WithTheme(ui.ThemeHighContrast, nil)
+	if got := ui.ActiveTheme().Name; got != ui.ThemeHighContrast {
+		t.Fatalf("theme = %q, want high-contrast", got)
+	}
+	m.Update(tea.BackgroundColorMsg{Color: color.White})
+	if got := ui.ActiveTheme().Name; got != ui.ThemeHighContrast {
+		t.Errorf("theme = %q after a background report, want high-contrast kept", got)
+	}
+}
+
+func TestWithThemeFindsCustomTheme(t *testing.T) {
+	t.Cleanup(func() { ui.ApplyTheme(ui.DarkTheme()) })
+	custom := ui.LightTheme()
+	custom.Name = "paper"
+	m := setupModel(t).WithTheme("paper", []ui.Theme{custom})
+	if m.themeAuto || ui.ActiveTheme().Name != "paper" {
+		t.Errorf("theme = %q (auto %v), want paper", ui.ActiveTheme().Name, m.themeAuto)
+	}
+
+	m = setupModel(t).WithTheme("missing", nil)
+	if !m.themeAuto {
+		t.Error("unknown theme should fall back to following the background")
+	}
+}
+
+func TestCycleThemeStopsFollowingBackground(t *testing.T) {
+	t.Cleanup(func() { ui.ApplyTheme(ui.DarkTheme()) })
+	custom := ui.DarkTheme()
+	custom.Name = "midnight"
+	m := setupModel(t).WithTheme(ThemeAuto, []ui.Theme{custom})
+
+	var names []string
+	for range len(m.themes) {
+		model, _ := m.cycleTheme()
+		m = model.(Model)
+		names = append(names, ui.ActiveTheme().Name)
+	}
+	want := []string{ui.ThemeLight, ui.ThemeHighContrast, ui.ThemeMono, "midnight", ui.ThemeDark}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("cycle = %v, want %v", names, want)
+		}
+	}
+	if m.themeAuto {
+		t.Error("cycling should stop following the terminal background")
+	}
+	m.Update(tea.BackgroundColorMsg{Color: color.White})
+	if got := ui.ActiveTheme().Name; got != ui.ThemeDark {
+		t.Errorf("theme = %q after a background report, want the cycled dark theme kept", got)
+	}
+}
+
+func TestToggleAccessibleSwapsSymbolsAndPausesShimmer(t *testing.T) {
+	t.Cleanup(func() { ui.SetAccessible(false) })
+	m := setupModel(t)
+
+	model, _ := m.toggleAccessible()
+	m = model.(Model)
+	if !ui.Accessible() || ui.SymRolling != "*" {
+		t.Fatalf("accessible = %v, SymRolling = %q", ui.Accessible(), ui.SymRolling)
+	}
+	model, cmd := m.Update(headerShimmerMsg{})
+	m = model.(Model)
+	if cmd != nil || !m.shimmerPaused {
+		t.Fatal("shimmer should pause in accessible mode")
+	}
+
+	model, _ = m.toggleAccessible()
+	m = model.(Model)
+	if ui.Accessible() || m.shimmerPaused {
+		t.Error("switching off should restore symbols and resume the shimmer")
+	}
+}
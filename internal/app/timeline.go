@@ -0,0 +1,53 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+)
+
+// toggleTimeline shows or hides the timeline in place of the detail pane.
+// The timeline reads the parade's rows at render time, so it always follows
+// the current filter, grouping and cursor.
+func (m Model) toggleTimeline() (tea.Model, tea.Cmd) {
+	m.showTimeline = !m.showTimeline
+	if m.showTimeline {
+		m.showGasTown = false
+		m.showProblems = false
+		m.showDoctor = false
+		m.showCharts = false
+	}
+	return m, nil
+}
+
+// handleTimelineKey handles zoom, pan and row movement while the timeline
+// is focused. Rows move with the parade's down/up keys and drive the parade
+// cursor, keeping the detail selection in sync. It reports false for keys
+// the timeline does not use.
+func (m Model) handleTimelineKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool) {
+	key := msg.String()
+	switch keymap.Lookup(keymap.Timeline, key) {
+	case keymap.ZoomIn:
+		m.timeline.ZoomIn()
+	case keymap.ZoomOut:
+		m.timeline.ZoomOut()
+	case keymap.Left:
+		m.timeline.Pan(-1)
+	case keymap.Right:
+		m.timeline.Pan(1)
+	case keymap.Today:
+		m.timeline.Today()
+	default:
+		switch keymap.Lookup(keymap.Parade, key) {
+		case keymap.Down:
+			m.parade.MoveDown()
+			m.syncSelection()
+		case keymap.Up:
+			m.parade.MoveUp()
+			m.syncSelection()
+		default:
+			return m, nil, false
+		}
+	}
+	logAction("timeline key: %s", msg.String())
+	return m, nil, true
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestKeyTTogglesTimeline(t *testing.T) {
+	m := setupModel(t)
+	m.showDoctor = true
+
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'T', Text: "T"})
+	m = model.(Model)
+	if !m.showTimeline || m.showDoctor {
+		t.Fatalf("T should show the timeline in place of doctor (timeline=%v doctor=%v)", m.showTimeline, m.showDoctor)
+	}
+
+	model, _ = m.Update(tea.KeyPressMsg{Code: 'T', Text: "T"})
+	if model.(Model).showTimeline {
+		t.Error("second T should hide the timeline")
+	}
+}
+
+func TestTimelineKeysMoveParadeCursor(t *testing.T) {
+	m := setupModel(t)
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'T', Text: "T"})
+	m = model.(Model)
+	m.activPane = PaneDetail
+	before := m.parade.SelectedIssue.ID
+
+	model, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m = model.(Model)
+	if m.parade.SelectedIssue.ID == before {
+		t.Fatal("j in the timeline should move the parade cursor")
+	}
+	if m.detail.Issue == nil || m.detail.Issue.ID != m.parade.SelectedIssue.ID {
+		t.Error("detail selection should follow the timeline cursor")
+	}
+
+	model, _ = m.Update(tea.KeyPressMsg{Code: '-', Text: "-"})
+	m = model.(Model)
+	if m.timeline.Scale().String() != "week" {
+		t.Errorf("- should zoom out to weeks, got %v", m.timeline.Scale())
+	}
+}
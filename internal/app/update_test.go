@@ -8,6 +8,8 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/matt-wright86/mardi-gras/internal/components"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/hooks"
 )
 
 // ---------------------------------------------------------------------------
@@ -469,3 +471,69 @@ func TestConvoyCreateFromNonEpic(t *testing.T) {
 		t.Fatalf("expected convoyIssueIDs = [open-1], got %v", got.convoyIssueIDs)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Hooks
+// ---------------------------------------------------------------------------
+
+func TestHookResultErrorShowsToast(t *testing.T) {
+	m := New([]data.Issue{testIssue("open-1", data.StatusOpen)}, data.Source{}, data.DefaultBlockingTypes)
+	model, _ := m.Update(hooks.ResultMsg{Event: hooks.IssueClosed, Err: fmt.Errorf("exit status 1")})
+	got := model.(Model)
+	if !strings.Contains(got.toast.Message, "Hook issue_closed failed") {
+		t.Errorf("toast = %q, want hook failure", got.toast.Message)
+	}
+	if got.toast.Level != components.ToastError {
+		t.Errorf("toast level = %v, want error", got.toast.Level)
+	}
+}
+
+func TestHookResultSuccessIsSilent(t *testing.T) {
+	m := New([]data.Issue{testIssue("open-1", data.StatusOpen)}, data.Source{}, data.DefaultBlockingTypes)
+	model, _ := m.Update(hooks.ResultMsg{Event: hooks.IssueClosed})
+	if msg := model.(Model).toast.Message; msg != "" {
+		t.Errorf("toast = %q, want none on success", msg)
+	}
+}
+
+func TestFireIssueHooks(t *testing.T) {
+	m := New(nil, data.Source{}, data.DefaultBlockingTypes).WithHooks(hooks.NewRunner(map[string]string{
+		hooks.IssueClosed:  "true",
+		hooks.IssueStalled: "true",
+	}, ""))
+	events := []data.ChangeEvent{
+		{Type: data.ChangeClosed, IssueID: "a"},
+		{Type: data.ChangeParade, IssueID: "b", To: data.ParadeStalled.String()},
+		{Type: data.ChangeParade, IssueID: "c", To: data.ParadeRolling.String()},
+		{Type: data.ChangePriority, IssueID: "d"},
+	}
+	if got := len(m.fireIssueHooks(events)); got != 2 {
+		t.Errorf("fireIssueHooks() returned %d commands, want 2", got)
+	}
+
+	var none Model
+	if got := none.fireIssueHooks(events); got != nil {
+		t.Errorf("fireIssueHooks() without hooks = %v, want nil", got)
+	}
+}
+
+func TestFireAgentHooksOnlyOnTransition(t *testing.T) {
+	m := New(nil, data.Source{}, data.DefaultBlockingTypes).WithHooks(hooks.NewRunner(map[string]string{
+		hooks.AgentStuck: "true",
+	}, ""))
+	m.townStatus = &gastown.TownStatus{Agents: []gastown.AgentRuntime{{Name: "nux", State: "stuck", Running: true}}}
+
+	if cmd := m.fireAgentHooks(); cmd == nil {
+		t.Fatal("expected hook command on first stuck report")
+	}
+	if cmd := m.fireAgentHooks(); cmd != nil {
+		t.Error("expected no hook command while agent stays stuck")
+	}
+
+	m.townStatus = &gastown.TownStatus{Agents: []gastown.AgentRuntime{{Name: "nux", State: "working", Running: true}}}
+	m.fireAgentHooks()
+	m.townStatus = &gastown.TownStatus{Agents: []gastown.AgentRuntime{{Name: "nux", State: "stuck", Running: true}}}
+	if cmd := m.fireAgentHooks(); cmd == nil {
+		t.Error("expected hook command after agent relapses")
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/components"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// worktreePollInterval is how often the worktrees' dirty, ahead and behind
+// state is refreshed. Edits and commits in a worktree don't touch the issues,
+// so reloads alone would leave the badges stale.
+const worktreePollInterval = 30 * time.Second
+
+// worktreeTickMsg triggers the next worktree scan.
+type worktreeTickMsg struct{}
+
+// worktreesMsg carries the issue worktrees found in the project.
+type worktreesMsg struct {
+	worktrees []data.Worktree
+	err       error
+}
+
+// worktreeCreatedMsg reports the result of creating an issue worktree.
+type worktreeCreatedMsg struct {
+	issueID string
+	path    string
+	err     error
+}
+
+// worktreesPrunedMsg reports the merged worktrees that were removed.
+type worktreesPrunedMsg struct {
+	removed []data.Worktree
+	err     error
+}
+
+// fetchWorktrees returns a Cmd that lists the project's issue worktrees.
+func fetchWorktrees(projectDir string, issues []data.Issue) tea.Cmd {
+	if projectDir == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		wts, err := data.ListWorktrees(projectDir, issues)
+		return worktreesMsg{worktrees: wts, err: err}
+	}
+}
+
+func worktreeTickCmd() tea.Cmd {
+	return tea.Tick(worktreePollInterval, func(time.Time) tea.Msg {
+		return worktreeTickMsg{}
+	})
+}
+
+// createWorktree adds (or reuses) a worktree for the selected issue.
+func (m Model) createWorktree() (tea.Model, tea.Cmd) {
+	issue := m.parade.SelectedIssue
+	if issue == nil || m.projectDir == "" {
+		return m, nil
+	}
+	projectDir := m.projectDir
+	issueCopy := *issue
+	return m, func() tea.Msg {
+		path, err := data.CreateWorktree(projectDir, issueCopy)
+		return worktreeCreatedMsg{issueID: issueCopy.ID, path: path, err: err}
+	}
+}
+
+// pruneWorktrees removes every clean, merged issue worktree.
+func (m Model) pruneWorktrees() (tea.Model, tea.Cmd) {
+	if m.projectDir == "" {
+		return m, nil
+	}
+	projectDir := m.projectDir
+	issues := m.issues
+	return m, func() tea.Msg {
+		wts, err := data.ListWorktrees(projectDir, issues)
+		if err != nil {
+			return worktreesPrunedMsg{err: err}
+		}
+		removed, err := data.PruneMergedWorktrees(projectDir, wts)
+		return worktreesPrunedMsg{removed: removed, err: err}
+	}
+}
+
+// setWorktrees records the worktree list and refreshes the parade badges.
+func (m *Model) setWorktrees(wts []data.Worktree) {
+	m.worktrees = make(map[string]data.Worktree, len(wts))
+	for _, w := range wts {
+		m.worktrees[w.IssueID] = w
+	}
+	m.parade.WorktreeIDs = m.worktreeIDs()
+}
+
+// worktreeIDs maps each issue with a worktree to its dirty flag.
+func (m Model) worktreeIDs() map[string]bool {
+	if len(m.worktrees) == 0 {
+		return nil
+	}
+	ids := make(map[string]bool, len(m.worktrees))
+	for id, w := range m.worktrees {
+		ids[id] = w.Dirty
+	}
+	return ids
+}
+
+// agentDir returns the directory an agent for issueID should run in: the
+// issue's worktree when it has one, else the project root.
+func (m Model) agentDir(issueID string) string {
+	if w, ok := m.worktrees[issueID]; ok {
+		return w.Path
+	}
+	return m.projectDir
+}
+
+// handleWorktreeMsg applies the result of a worktree operation.
+func (m Model) handleWorktreeMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var text string
+	level := components.ToastSuccess
+	switch msg := msg.(type) {
+	case worktreeTickMsg:
+		return m, tea.Batch(fetchWorktrees(m.projectDir, m.issues), worktreeTickCmd())
+	case worktreesMsg:
+		if msg.err == nil {
+			m.setWorktrees(msg.worktrees)
+		}
+		return m, nil
+	case worktreeCreatedMsg:
+		if msg.err != nil {
+			text, level = fmt.Sprintf("Worktree failed: %v", msg.err), components.ToastError
+		} else {
+			text = fmt.Sprintf("Worktree for %s: %s", msg.issueID, msg.path)
+		}
+	case worktreesPrunedMsg:
+		switch {
+		case msg.err != nil:
+			text, level = fmt.Sprintf("Prune failed: %v", msg.err), components.ToastError
+		case len(msg.removed) == 0:
+			text, level = "No merged worktrees to prune", components.ToastInfo
+		default:
+			text = fmt.Sprintf("Pruned %d merged worktree%s", len(msg.removed), plural(len(msg.removed)))
+		}
+	}
+	toast, toastCmd := components.ShowToast(text, level, toastDuration)
+	m.toast = toast
+	return m, tea.Batch(toastCmd, fetchWorktrees(m.projectDir, m.issues))
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestWorktreesMsgSetsBadgesAndAgentDir(t *testing.T) {
+	m := setupModel(t)
+	m.projectDir = "/src/app"
+
+	model, _ := m.Update(worktreesMsg{worktrees: []data.Worktree{
+		{IssueID: "open-1", Path: "/src/app.worktrees/open-1", Dirty: true},
+	}})
+	m = model.(Model)
+
+	if dirty, ok := m.parade.WorktreeIDs["open-1"]; !ok || !dirty {
+		t.Errorf("WorktreeIDs = %v, want open-1 dirty", m.parade.WorktreeIDs)
+	}
+	if got := m.agentDir("open-1"); got != "/src/app.worktrees/open-1" {
+		t.Errorf("agentDir(open-1) = %q, want worktree path", got)
+	}
+	if got := m.agentDir("open-2"); got != "/src/app" {
+		t.Errorf("agentDir(open-2) = %q, want project dir", got)
+	}
+
+	m.rebuildParade()
+	if _, ok := m.parade.WorktreeIDs["open-1"]; !ok {
+		t.Error("rebuildParade dropped worktree badges")
+	}
+}
+
+func TestKeyWCreatesWorktree(t *testing.T) {
+	m := setupModel(t)
+	if _, cmd := m.handleKey(tea.KeyPressMsg{Code: 'w', Text: "w"}); cmd != nil {
+		t.Error("w without a project dir should be a no-op")
+	}
+	m.projectDir = t.TempDir()
+	if _, cmd := m.handleKey(tea.KeyPressMsg{Code: 'w', Text: "w"}); cmd == nil {
+		t.Error("w should return a create command")
+	}
+}
+
+func TestWorktreeResultToasts(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.Msg
+	}{
+		{"created", worktreeCreatedMsg{issueID: "open-1", path: "/p"}},
+		{"create failed", worktreeCreatedMsg{issueID: "open-1", err: errors.New("boom")}},
+		{"nothing pruned", worktreesPrunedMsg{}},
+		{"pruned", worktreesPrunedMsg{removed: []data.Worktree{{IssueID: "open-1"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, cmd := setupModel(t).Update(tt.msg)
+			if cmd == nil || !model.(Model).toast.Active() {
+				t.Error("expected a toast")
+			}
+		})
+	}
+}
+
+func TestWorktreeTickRescans(t *testing.T) {
+	m := setupModel(t)
+	m.projectDir = t.TempDir()
+
+	_, cmd := m.Update(worktreeTickMsg{})
+	if cmd == nil {
+		t.Fatal("worktree tick returned no command")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("worktree tick = %T %v, want a scan and the next tick", cmd(), batch)
+	}
+}
@@ -0,0 +1,329 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// BulkOp is an edit applied to every selected issue.
+type BulkOp int
+
+const (
+	BulkSetPriority BulkOp = iota
+	BulkSetAssignee
+	BulkAddLabel
+	BulkRemoveLabel
+	BulkSetDue
+	BulkSetDefer
+	BulkAddBlocker
+	BulkAddToConvoy
+	BulkClose
+)
+
+// BulkEditResult is sent when the bulk edit dialog is confirmed or closed.
+type BulkEditResult struct {
+	Op        BulkOp
+	Value     string
+	IssueIDs  []string
+	Cancelled bool
+}
+
+// bulkOpDef describes one choice in the bulk edit menu.
+type bulkOpDef struct {
+	op          BulkOp
+	label       string
+	placeholder string
+	verb        string // confirmation summary, formatted with the value
+	clear       string // summary when an empty value clears the field
+	validate    func(string) error
+	gtOnly      bool
+}
+
+var bulkOps = []bulkOpDef{
+	{op: BulkSetPriority, label: "Set priority", placeholder: "0-4", verb: "Set priority to P%s", validate: validatePriority},
+	{op: BulkSetAssignee, label: "Set assignee", placeholder: "name (empty to unassign)", verb: "Assign to %q", clear: "Unassign"},
+	{op: BulkAddLabel, label: "Add label", placeholder: "label", verb: "Add label %q", validate: required("label")},
+	{op: BulkRemoveLabel, label: "Remove label", placeholder: "label", verb: "Remove label %q", validate: required("label")},
+	{op: BulkSetDue, label: "Set due date", placeholder: "YYYY-MM-DD (empty to clear)", verb: "Set due date to %s", clear: "Clear due date", validate: validateDate},
+	{op: BulkSetDefer, label: "Defer until", placeholder: "YYYY-MM-DD (empty to clear)", verb: "Defer until %s", clear: "Clear defer date", validate: validateDate},
+	{op: BulkAddBlocker, label: "Add blocker", placeholder: "blocking issue ID", verb: "Mark as blocked by %s", validate: data.ValidateIssueID},
+	{op: BulkAddToConvoy, label: "Add to convoy", placeholder: "convoy ID", verb: "Add to convoy %s", validate: required("convoy ID"), gtOnly: true},
+	{op: BulkClose, label: "Close with reason", placeholder: "reason", verb: "Close with reason %q", validate: required("reason")},
+}
+
+func validatePriority(s string) error {
+	if len(s) != 1 || s[0] < '0' || s[0] > '4' {
+		return errors.New("priority must be 0-4")
+	}
+	return nil
+}
+
+func validateDate(s string) error {
+	if s == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return fmt.Errorf("date must be YYYY-MM-DD, got %q", s)
+	}
+	return nil
+}
+
+func required(what string) func(string) error {
+	return func(s string) error {
+		if s == "" {
+			return errors.New(what + " is required")
+		}
+		return nil
+	}
+}
+
+type bulkStage int
+
+const (
+	bulkPick bulkStage = iota
+	bulkInput
+	bulkConfirm
+	bulkRunning
+	bulkReport
+)
+
+// BulkEditDialog applies one edit to the multi-selected issues: pick an
+// operation, enter its value, confirm the summary, then read the per-issue
+// report.
+type BulkEditDialog struct {
+	issues  []*data.Issue
+	ops     []bulkOpDef
+	stage   bulkStage
+	cursor  int
+	input   textinput.Model
+	err     string
+	results []data.BulkResult
+	width   int
+	height  int
+}
+
+// NewBulkEditDialog creates a bulk edit dialog for issues. Convoy edits are
+// only offered with Gas Town.
+func NewBulkEditDialog(issues []*data.Issue, hasGasTown bool, width, height int) BulkEditDialog {
+	var ops []bulkOpDef
+	for _, op := range bulkOps {
+		if !op.gtOnly || hasGasTown {
+			ops = append(ops, op)
+		}
+	}
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.SetWidth(max(width-16, 20))
+	return BulkEditDialog{
+		issues: issues,
+		ops:    ops,
+		input:  ti,
+		width:  width,
+		height: height,
+	}
+}
+
+// Running reports whether the edit has been confirmed and is in flight.
+func (d BulkEditDialog) Running() bool {
+	return d.stage == bulkRunning
+}
+
+// ShowResults switches the dialog to the per-issue report.
+func (d BulkEditDialog) ShowResults(results []data.BulkResult) BulkEditDialog {
+	d.results = results
+	d.stage = bulkReport
+	return d
+}
+
+func (d BulkEditDialog) issueIDs() []string {
+	ids := make([]string, len(d.issues))
+	for i, iss := range d.issues {
+		ids[i] = iss.ID
+	}
+	return ids
+}
+
+func (d BulkEditDialog) value() string {
+	return strings.TrimSpace(d.input.Value())
+}
+
+// Update handles key events for the bulk edit dialog.
+func (d BulkEditDialog) Update(msg tea.Msg) (BulkEditDialog, tea.Cmd) {
+	km, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return d, nil
+	}
+	closeCmd := func() tea.Msg { return BulkEditResult{Cancelled: true} }
+
+	switch d.stage {
+	case bulkPick:
+		switch keymap.Lookup(keymap.Forms, km.String()) {
+		case keymap.Cancel, keymap.Close:
+			return d, closeCmd
+		case keymap.Down:
+			if d.cursor < len(d.ops)-1 {
+				d.cursor++
+			}
+		case keymap.Up:
+			if d.cursor > 0 {
+				d.cursor--
+			}
+		case keymap.Submit:
+			d.stage = bulkInput
+			d.err = ""
+			d.input.Placeholder = d.ops[d.cursor].placeholder
+			d.input.SetValue("")
+			return d, d.input.Focus()
+		}
+
+	case bulkInput:
+		switch keymap.Lookup(keymap.Forms, km.String()) {
+		case keymap.Cancel:
+			d.stage = bulkPick
+			d.input.Blur()
+			return d, nil
+		case keymap.Submit:
+			if v := d.ops[d.cursor].validate; v != nil {
+				if err := v(d.value()); err != nil {
+					d.err = err.Error()
+					return d, nil
+				}
+			}
+			d.err = ""
+			d.stage = bulkConfirm
+			d.input.Blur()
+			return d, nil
+		}
+		var cmd tea.Cmd
+		d.input, cmd = d.input.Update(msg)
+		return d, cmd
+
+	case bulkConfirm:
+		switch keymap.Lookup(keymap.Forms, km.String()) {
+		case keymap.Cancel, keymap.No:
+			d.stage = bulkInput
+			return d, d.input.Focus()
+		case keymap.Submit, keymap.Yes:
+			d.stage = bulkRunning
+			result := BulkEditResult{Op: d.ops[d.cursor].op, Value: d.value(), IssueIDs: d.issueIDs()}
+			return d, func() tea.Msg { return result }
+		}
+
+	case bulkReport:
+		switch keymap.Lookup(keymap.Forms, km.String()) {
+		case keymap.Cancel, keymap.Close, keymap.Submit:
+			return d, closeCmd
+		}
+	}
+	return d, nil
+}
+
+// summary is the confirmation line for the chosen operation and value.
+func (d BulkEditDialog) summary() string {
+	op := d.ops[d.cursor]
+	v := d.value()
+	if v == "" && op.clear != "" {
+		return op.clear
+	}
+	return fmt.Sprintf(op.verb, v)
+}
+
+// View renders the bulk edit dialog.
+func (d BulkEditDialog) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.BrightGold)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+	normalStyle := lipgloss.NewStyle().Foreground(ui.Light)
+	selectedStyle := lipgloss.NewStyle().Foreground(ui.BrightGreen)
+	errStyle := lipgloss.NewStyle().Foreground(ui.StatusStalled)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("  BULK EDIT: %d issue(s)", len(d.issues))))
+	lines = append(lines, "")
+
+	switch d.stage {
+	case bulkPick:
+		for i, op := range d.ops {
+			if i == d.cursor {
+				lines = append(lines, selectedStyle.Render("> "+op.label))
+			} else {
+				lines = append(lines, normalStyle.Render("  "+op.label))
+			}
+		}
+		lines = append(lines, "", dimStyle.Render("  "+keymap.HintBar(formHint("choose", keymap.Submit), formHint("cancel", keymap.Cancel))))
+
+	case bulkInput:
+		lines = append(lines, normalStyle.Render("  "+d.ops[d.cursor].label+":"))
+		lines = append(lines, "  "+d.input.View())
+		if d.err != "" {
+			lines = append(lines, errStyle.Render("  "+d.err))
+		}
+		lines = append(lines, "", dimStyle.Render("  "+keymap.HintBar(formHint("review", keymap.Submit), formHint("back", keymap.Cancel))))
+
+	case bulkConfirm, bulkRunning:
+		lines = append(lines, normalStyle.Render("  "+d.summary()+" on:"))
+		lines = append(lines, d.issueLines(dimStyle, nil)...)
+		if d.stage == bulkRunning {
+			lines = append(lines, "", dimStyle.Render("  Applying…"))
+		} else {
+			lines = append(lines, "", dimStyle.Render("  "+keymap.HintBar(formHint("apply", keymap.Yes, keymap.Submit), formHint("back", keymap.No, keymap.Cancel))))
+		}
+
+	case bulkReport:
+		failed := data.BulkFailures(d.results)
+		lines = append(lines, normalStyle.Render(fmt.Sprintf("  %s: %d ok, %d failed", d.summary(), len(d.results)-failed, failed)))
+		errs := make(map[string]error, len(d.results))
+		for _, r := range d.results {
+			errs[r.IssueID] = r.Err
+		}
+		lines = append(lines, d.issueLines(dimStyle, errs)...)
+		lines = append(lines, "", dimStyle.Render("  "+keymap.HintBar(formHint("close", keymap.Submit, keymap.Cancel))))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// issueLines lists the selected issues, truncated to fit. With errs each row
+// is marked ✓ or ✗ and failures show their error.
+func (d BulkEditDialog) issueLines(dimStyle lipgloss.Style, errs map[string]error) []string {
+	okStyle := lipgloss.NewStyle().Foreground(ui.BrightGreen)
+	failStyle := lipgloss.NewStyle().Foreground(ui.StatusStalled)
+	rows := max(d.height-10, 3)
+	titleWidth := max(d.width-30, 20)
+
+	var lines []string
+	for i, iss := range d.issues {
+		if i == rows {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  … %d more", len(d.issues)-rows)))
+			break
+		}
+		mark := "  "
+		if errs != nil {
+			if err := errs[iss.ID]; err != nil {
+				mark = failStyle.Render(ui.SymInvalid + " ")
+			} else {
+				mark = okStyle.Render(ui.SymPassed + " ")
+			}
+		}
+		line := "  " + mark + dimStyle.Render(iss.ID) + " " + ansi.Truncate(iss.Title, titleWidth, "...")
+		if err := errs[iss.ID]; err != nil {
+			line += failStyle.Render("  " + ansi.Truncate(err.Error(), titleWidth, "..."))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formHint describes form actions for a dialog's hint line.
+func formHint(desc string, actions ...keymap.Action) keymap.Hint {
+	return keymap.NewHint(keymap.Forms, desc, actions...)
+}
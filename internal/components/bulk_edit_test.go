@@ -0,0 +1,113 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func bulkIssues() []*data.Issue {
+	return []*data.Issue{
+		{ID: "mg-1", Title: "Fix login"},
+		{ID: "mg-2", Title: "Add search"},
+	}
+}
+
+func typeText(d BulkEditDialog, s string) BulkEditDialog {
+	for _, r := range s {
+		d, _ = d.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return d
+}
+
+func TestBulkEditDialogFlow(t *testing.T) {
+	d := NewBulkEditDialog(bulkIssues(), false, 80, 24)
+	d, _ = d.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	d, _ = d.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	d = typeText(d, "ui")
+	d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	view := d.View()
+	for _, want := range []string{`Add label "ui"`, "mg-1", "mg-2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirmation missing %q", want)
+		}
+	}
+
+	d, cmd := d.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if cmd == nil || !d.Running() {
+		t.Fatal("expected y to confirm")
+	}
+	result := cmd().(BulkEditResult)
+	if result.Cancelled || result.Op != BulkAddLabel || result.Value != "ui" || len(result.IssueIDs) != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	d = d.ShowResults([]data.BulkResult{{IssueID: "mg-1"}, {IssueID: "mg-2", Err: errors.New("no such issue")}})
+	view = d.View()
+	for _, want := range []string{"1 ok, 1 failed", "✓", "✗", "no such issue"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil || !cmd().(BulkEditResult).Cancelled {
+		t.Error("enter on the report should close the dialog")
+	}
+}
+
+func TestBulkEditDialogValidates(t *testing.T) {
+	tests := []struct {
+		name  string
+		op    int
+		value string
+		ok    bool
+	}{
+		{"priority in range", 0, "1", true},
+		{"priority out of range", 0, "7", false},
+		{"due date", 4, "2026-11-01", true},
+		{"clear due date", 4, "", true},
+		{"bad date", 4, "next week", false},
+		{"blocker needs an ID", 6, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewBulkEditDialog(bulkIssues(), false, 80, 24)
+			for range tt.op {
+				d, _ = d.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+			}
+			d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+			d = typeText(d, tt.value)
+			d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+			if got := d.stage == bulkConfirm; got != tt.ok {
+				t.Errorf("accepted = %v, want %v (err %q)", got, tt.ok, d.err)
+			}
+		})
+	}
+}
+
+func TestBulkEditDialogConvoyNeedsGasTown(t *testing.T) {
+	for _, gt := range []bool{false, true} {
+		view := NewBulkEditDialog(bulkIssues(), gt, 80, 24).View()
+		if got := strings.Contains(view, "Add to convoy"); got != gt {
+			t.Errorf("hasGasTown=%v: convoy offered = %v", gt, got)
+		}
+	}
+}
+
+func TestBulkEditDialogCancel(t *testing.T) {
+	d := NewBulkEditDialog(bulkIssues(), false, 80, 24)
+	d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if d.stage != bulkPick {
+		t.Fatal("esc in the value step should go back to the menu")
+	}
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if cmd == nil || !cmd().(BulkEditResult).Cancelled {
+		t.Error("esc in the menu should cancel")
+	}
+}
@@ -0,0 +1,446 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// CalendarField is the date a calendar entry sits on.
+type CalendarField int
+
+const (
+	CalendarDue   CalendarField = iota // Issue.DueAt
+	CalendarDefer                      // Issue.DeferUntil
+)
+
+// CalendarResult is sent when the calendar closes, jumps to an issue, or
+// reschedules one.
+type CalendarResult struct {
+	Closed bool
+
+	// JumpID selects this issue in the parade and closes the calendar.
+	JumpID string
+
+	// IssueID, Field and Date reschedule one issue. An empty Date clears
+	// the field.
+	IssueID string
+	Field   CalendarField
+	Date    string
+}
+
+// calendarEntry is one issue placed on a day.
+type calendarEntry struct {
+	issue *data.Issue
+	field CalendarField
+	day   time.Time
+}
+
+// Calendar is the month/week overlay that places open issues on their due
+// date and deferred issues on the day they resurface. Enter opens the list
+// for the selected day, where issues can be moved to another day.
+type Calendar struct {
+	entries map[time.Time][]calendarEntry
+	day     time.Time // cursor, local midnight
+	week    bool      // week view instead of month
+	listing bool      // showing the list for day
+	list    int       // cursor in the day list
+	moving  *calendarEntry
+	width   int
+	height  int
+
+	// Now is the reference time for "today"; zero means time.Now.
+	Now time.Time
+}
+
+// NewCalendar creates a month calendar over issues with the cursor on today.
+func NewCalendar(issues []data.Issue, width, height int) Calendar {
+	c := Calendar{width: width, height: height}
+	c.day = dayOf(c.now())
+	c.SetIssues(issues)
+	return c
+}
+
+// SetIssues replaces the issues shown, keeping the cursor.
+func (c *Calendar) SetIssues(issues []data.Issue) {
+	c.entries = make(map[time.Time][]calendarEntry)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status == data.StatusClosed {
+			continue
+		}
+		if issue.DueAt != nil {
+			c.add(calendarEntry{issue: issue, field: CalendarDue, day: dayOf(*issue.DueAt)})
+		}
+		if issue.DeferUntil != nil {
+			c.add(calendarEntry{issue: issue, field: CalendarDefer, day: dayOf(*issue.DeferUntil)})
+		}
+	}
+	for _, entries := range c.entries {
+		slices.SortFunc(entries, func(a, b calendarEntry) int {
+			if a.issue.Priority != b.issue.Priority {
+				return int(a.issue.Priority - b.issue.Priority)
+			}
+			return strings.Compare(a.issue.ID, b.issue.ID)
+		})
+	}
+	c.list = min(c.list, max(len(c.entries[c.day])-1, 0))
+}
+
+func (c *Calendar) add(e calendarEntry) {
+	c.entries[e.day] = append(c.entries[e.day], e)
+}
+
+// SetSize updates dimensions.
+func (c *Calendar) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// Day returns the date under the cursor.
+func (c Calendar) Day() time.Time {
+	return c.day
+}
+
+func (c Calendar) now() time.Time {
+	if c.Now.IsZero() {
+		return time.Now()
+	}
+	return c.Now
+}
+
+// dayOf returns local midnight of t's date.
+func dayOf(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// weekStart returns the Monday on or before day.
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// Update handles key input.
+func (c Calendar) Update(msg tea.Msg) (Calendar, tea.Cmd) {
+	km, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return c, nil
+	}
+	if c.listing {
+		return c.updateList(km)
+	}
+
+	switch keymap.Lookup(keymap.Calendar, km.String()) {
+	case keymap.Close:
+		if c.moving != nil {
+			c.moving = nil
+			return c, nil
+		}
+		return c, func() tea.Msg { return CalendarResult{Closed: true} }
+	case keymap.Left:
+		c.day = c.day.AddDate(0, 0, -1)
+	case keymap.Right:
+		c.day = c.day.AddDate(0, 0, 1)
+	case keymap.Up:
+		c.day = c.day.AddDate(0, 0, -7)
+	case keymap.Down:
+		c.day = c.day.AddDate(0, 0, 7)
+	case keymap.PrevPeriod:
+		if c.week {
+			c.day = c.day.AddDate(0, 0, -7)
+		} else {
+			c.day = c.day.AddDate(0, -1, 0)
+		}
+	case keymap.NextPeriod:
+		if c.week {
+			c.day = c.day.AddDate(0, 0, 7)
+		} else {
+			c.day = c.day.AddDate(0, 1, 0)
+		}
+	case keymap.Today:
+		c.day = dayOf(c.now())
+	case keymap.ToggleWeek:
+		c.week = !c.week
+	case keymap.Open:
+		if c.moving != nil {
+			e := *c.moving
+			c.moving = nil
+			return c, reschedule(e, c.day)
+		}
+		if len(c.entries[c.day]) > 0 {
+			c.listing = true
+			c.list = 0
+		}
+	}
+	return c, nil
+}
+
+// updateList handles keys in the day drill-down list.
+func (c Calendar) updateList(km tea.KeyPressMsg) (Calendar, tea.Cmd) {
+	entries := c.entries[c.day]
+	if len(entries) == 0 {
+		c.listing = false
+		return c, nil
+	}
+	c.list = min(c.list, len(entries)-1)
+	e := entries[c.list]
+
+	switch keymap.Lookup(keymap.Calendar, km.String()) {
+	case keymap.Close:
+		c.listing = false
+	case keymap.Down:
+		if c.list < len(entries)-1 {
+			c.list++
+		}
+	case keymap.Up:
+		if c.list > 0 {
+			c.list--
+		}
+	case keymap.Open:
+		return c, func() tea.Msg { return CalendarResult{JumpID: e.issue.ID} }
+	case keymap.MoveDate:
+		c.moving = &e
+		c.listing = false
+	case keymap.DayLater:
+		c.day = e.day.AddDate(0, 0, 1)
+		return c, reschedule(e, c.day)
+	case keymap.DayEarlier:
+		c.day = e.day.AddDate(0, 0, -1)
+		return c, reschedule(e, c.day)
+	case keymap.WeekLater:
+		c.day = e.day.AddDate(0, 0, 7)
+		return c, reschedule(e, c.day)
+	case keymap.ClearDate:
+		return c, func() tea.Msg { return CalendarResult{IssueID: e.issue.ID, Field: e.field} }
+	}
+	return c, nil
+}
+
+// reschedule returns a Cmd asking the app to move e to day.
+func reschedule(e calendarEntry, day time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return CalendarResult{IssueID: e.issue.ID, Field: e.field, Date: day.Format(time.DateOnly)}
+	}
+}
+
+// View renders the calendar.
+func (c Calendar) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.BrightGold)
+	hintStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+
+	var title, hint string
+	var body []string
+	switch {
+	case c.listing:
+		title = fmt.Sprintf("%s · %d issue(s)", c.day.Format("Mon Jan 2, 2006"), len(c.entries[c.day]))
+		body = c.renderList()
+		hint = keymap.HintBar(
+			keymap.NewHint(keymap.Calendar, "move", keymap.Down, keymap.Up),
+			keymap.NewHint(keymap.Calendar, "jump", keymap.Open),
+			keymap.NewHint(keymap.Calendar, "move to day", keymap.MoveDate),
+			keymap.NewHint(keymap.Calendar, "one day", keymap.DayLater, keymap.DayEarlier),
+			keymap.NewHint(keymap.Calendar, "next week", keymap.WeekLater),
+			keymap.NewHint(keymap.Calendar, "clear", keymap.ClearDate),
+			keymap.NewHint(keymap.Calendar, "back", keymap.Close),
+		)
+	case c.moving != nil:
+		what := "due date"
+		if c.moving.field == CalendarDefer {
+			what = "defer date"
+		}
+		title = fmt.Sprintf("Move %s %s to %s", c.moving.issue.ID, what, c.day.Format("Mon Jan 2"))
+		body = c.renderGrid()
+		hint = keymap.HintBar(
+			keymap.NewHint(keymap.Calendar, "pick a day", keymap.Left, keymap.Down, keymap.Up, keymap.Right),
+			keymap.NewHint(keymap.Calendar, "confirm", keymap.Open),
+			keymap.NewHint(keymap.Calendar, "cancel", keymap.Close),
+		)
+	default:
+		title = c.day.Format("January 2006")
+		period, view := "month", "week view"
+		if c.week {
+			title = "Week of " + weekStart(c.day).Format("Jan 2, 2006")
+			period, view = "week", "month view"
+		}
+		hint = keymap.HintBar(
+			keymap.NewHint(keymap.Calendar, "day", keymap.Left, keymap.Down, keymap.Up, keymap.Right),
+			keymap.NewHint(keymap.Calendar, period, keymap.PrevPeriod, keymap.NextPeriod),
+			keymap.NewHint(keymap.Calendar, view, keymap.ToggleWeek),
+			keymap.NewHint(keymap.Calendar, "today", keymap.Today),
+			keymap.NewHint(keymap.Calendar, "open day", keymap.Open),
+			keymap.NewHint(keymap.Calendar, "close", keymap.Close),
+		)
+		body = c.renderGrid()
+	}
+
+	lines := append([]string{titleStyle.Render(title), ""}, body...)
+	lines = append(lines, "", c.renderLegend(), hintStyle.Render(hint))
+	return strings.Join(lines, "\n")
+}
+
+// cellWidth is the width of one day column.
+func (c Calendar) cellWidth() int {
+	return max((c.width-14)/7, 6)
+}
+
+// renderGrid draws the month (or week) as rows of day cells.
+func (c Calendar) renderGrid() []string {
+	cw := c.cellWidth()
+	first := weekStart(c.day)
+	weeks := 1
+	if !c.week {
+		monthStart := time.Date(c.day.Year(), c.day.Month(), 1, 0, 0, 0, 0, time.Local)
+		first = weekStart(monthStart)
+		last := monthStart.AddDate(0, 1, -1)
+		weeks = int(weekStart(last).Sub(first).Hours()/24)/7 + 1
+	}
+
+	dowStyle := lipgloss.NewStyle().Foreground(ui.Muted).Bold(true)
+	var header strings.Builder
+	for d := 0; d < 7; d++ {
+		header.WriteString(dowStyle.Render(padRight(first.AddDate(0, 0, d).Format("Mon"), cw)))
+	}
+	lines := []string{header.String()}
+
+	// Week view trades rows for a taller cell that lists issue IDs.
+	cellLines := 2
+	if c.week {
+		cellLines = max(c.height-14, 3)
+	}
+	for w := 0; w < weeks; w++ {
+		rows := make([]strings.Builder, cellLines)
+		for d := 0; d < 7; d++ {
+			day := first.AddDate(0, 0, 7*w+d)
+			for i, cell := range c.renderCell(day, cw, cellLines) {
+				rows[i].WriteString(cell)
+			}
+		}
+		for i := range rows {
+			lines = append(lines, rows[i].String())
+		}
+		if !c.week {
+			lines = append(lines, "")
+		}
+	}
+	return lines
+}
+
+// renderCell returns the lines of one day: its number, then per-priority
+// counts (month) or issue IDs (week), each padded to width.
+func (c Calendar) renderCell(day time.Time, width, height int) []string {
+	entries := c.entries[day]
+	today := dayOf(c.now())
+
+	numStyle := lipgloss.NewStyle().Foreground(ui.Light)
+	switch {
+	case !c.week && day.Month() != c.day.Month():
+		numStyle = numStyle.Foreground(ui.Dim)
+	case day.Equal(today):
+		numStyle = numStyle.Foreground(ui.BrightGold).Bold(true)
+	}
+	if day.Before(today) && slices.ContainsFunc(entries, func(e calendarEntry) bool { return e.field == CalendarDue }) {
+		numStyle = numStyle.Foreground(ui.StatusStalled).Bold(true)
+	}
+	num := fmt.Sprintf("%2d", day.Day())
+	if day.Equal(c.day) {
+		num = ui.ItemSelectedBg.Render(numStyle.Render("[" + num + "]"))
+	} else {
+		num = numStyle.Render(" " + num + " ")
+	}
+	lines := []string{num}
+
+	if c.week {
+		for i, e := range entries {
+			if len(lines) == height-1 && len(entries) > i+1 {
+				lines = append(lines, lipgloss.NewStyle().Foreground(ui.Muted).Render(fmt.Sprintf("+%d more", len(entries)-i)))
+				break
+			}
+			sym := ui.SymDiamond
+			if e.field == CalendarDefer {
+				sym = ui.SymDeferred
+			}
+			style := lipgloss.NewStyle().Foreground(ui.PriorityColor(int(e.issue.Priority)))
+			lines = append(lines, style.Render(ansi.Truncate(sym+" "+e.issue.ID, width-1, "…")))
+		}
+	} else {
+		lines = append(lines, ansi.Truncate(countsLabel(entries), width-1, "…"))
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i := range lines {
+		lines[i] = padRight(lines[i], width)
+	}
+	return lines[:height]
+}
+
+// countsLabel summarizes a day's entries: due issues counted per priority in
+// the priority's colour, then deferred issues.
+func countsLabel(entries []calendarEntry) string {
+	var due [5]int
+	deferred := 0
+	for _, e := range entries {
+		if e.field == CalendarDefer {
+			deferred++
+			continue
+		}
+		due[min(max(int(e.issue.Priority), 0), 4)]++
+	}
+	var parts []string
+	for p, n := range due {
+		if n > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(ui.PriorityColor(p)).Render(fmt.Sprintf("%s%d", ui.SymDiamond, n)))
+		}
+	}
+	if deferred > 0 {
+		parts = append(parts, ui.DeferredStyle.Render(fmt.Sprintf("%s%d", ui.SymDeferred, deferred)))
+	}
+	return strings.Join(parts, "")
+}
+
+// renderList draws the drill-down list for the cursor day.
+func (c Calendar) renderList() []string {
+	var lines []string
+	for i, e := range c.entries[c.day] {
+		prefix := "  "
+		if i == c.list {
+			prefix = ui.ItemCursor.Render(ui.Cursor) + " "
+		}
+		kind := lipgloss.NewStyle().Foreground(ui.BrightGold).Render(ui.SymDiamond + " due  ")
+		if e.field == CalendarDefer {
+			kind = ui.DeferredStyle.Render(ui.SymDeferred + " defer")
+		} else if e.issue.IsOverdue() {
+			kind = ui.OverdueBadge.Render(ui.SymDiamond + " due  ")
+		}
+		prio := lipgloss.NewStyle().Foreground(ui.PriorityColor(int(e.issue.Priority))).Render(data.PriorityLabel(e.issue.Priority))
+		line := fmt.Sprintf("%s%s %s %s %s", prefix, kind, prio, lipgloss.NewStyle().Foreground(ui.Light).Render(e.issue.ID), e.issue.Title)
+		lines = append(lines, ansi.Truncate(line, max(c.width-12, 20), "…"))
+	}
+	return lines
+}
+
+// renderLegend explains the cell symbols.
+func (c Calendar) renderLegend() string {
+	var parts []string
+	for p := 0; p <= 4; p++ {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ui.PriorityColor(p)).Render(fmt.Sprintf("%sP%d", ui.SymDiamond, p)))
+	}
+	return strings.Join(parts, " ") + ui.DeferredStyle.Render("  "+ui.SymDeferred+" resurfaces")
+}
+
+// padRight pads s with spaces to width visible cells.
+func padRight(s string, width int) string {
+	if w := ansi.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
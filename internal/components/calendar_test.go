@@ -0,0 +1,156 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+)
+
+func newTestCalendar() Calendar {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local) // a Wednesday
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, days)
+		return &t
+	}
+	issues := []data.Issue{
+		{ID: "cal-1", Title: "Urgent", Status: data.StatusOpen, Priority: data.PriorityCritical, DueAt: at(1)},
+		{ID: "cal-2", Title: "Also due", Status: data.StatusOpen, Priority: data.PriorityLow, DueAt: at(1)},
+		{ID: "cal-3", Title: "Parked", Status: data.StatusOpen, Priority: data.PriorityMedium, DeferUntil: at(1)},
+		{ID: "cal-4", Title: "Late", Status: data.StatusInProgress, Priority: data.PriorityHigh, DueAt: at(-3)},
+		{ID: "cal-5", Title: "Done", Status: data.StatusClosed, DueAt: at(1)},
+	}
+	c := NewCalendar(issues, 120, 40)
+	c.Now = now
+	c.day = dayOf(now)
+	return c
+}
+
+func calendarKey(c Calendar, key string) (Calendar, tea.Msg) {
+	code := []rune(key)[0]
+	if key == "enter" {
+		code = tea.KeyEnter
+	}
+	c, cmd := c.Update(tea.KeyPressMsg{Code: code, Text: key})
+	if cmd == nil {
+		return c, nil
+	}
+	return c, cmd()
+}
+
+func TestCalendarPlacesEntries(t *testing.T) {
+	c := newTestCalendar()
+	tomorrow := c.day.AddDate(0, 0, 1)
+	entries := c.entries[tomorrow]
+	if len(entries) != 3 {
+		t.Fatalf("tomorrow has %d entries, want 3 (closed issues are skipped)", len(entries))
+	}
+	if entries[0].issue.ID != "cal-1" || entries[1].issue.ID != "cal-3" || entries[1].field != CalendarDefer {
+		t.Errorf("entries should sort by priority: %v, %v", entries[0].issue.ID, entries[1].issue.ID)
+	}
+	if got := ansi.Strip(countsLabel(entries)); got != "◆1◆1⏸1" {
+		t.Errorf("counts = %q, want ◆1◆1⏸1", got)
+	}
+
+	view := ansi.Strip(c.View())
+	if !strings.Contains(view, "October 2026") || !strings.Contains(view, "[14]") {
+		t.Errorf("month view should show the month and cursor day:\n%s", view)
+	}
+}
+
+func TestCalendarNavigation(t *testing.T) {
+	c := newTestCalendar()
+	start := c.Day()
+
+	c, _ = calendarKey(c, "l")
+	c, _ = calendarKey(c, "j")
+	if want := start.AddDate(0, 0, 8); !c.Day().Equal(want) {
+		t.Errorf("l then j = %v, want %v", c.Day(), want)
+	}
+	c, _ = calendarKey(c, "]")
+	if c.Day().Month() != time.November {
+		t.Errorf("] should move to next month, got %v", c.Day())
+	}
+	c, _ = calendarKey(c, "t")
+	if !c.Day().Equal(start) {
+		t.Errorf("t should return to today, got %v", c.Day())
+	}
+	c, _ = calendarKey(c, "w")
+	if view := ansi.Strip(c.View()); !strings.Contains(view, "Week of Oct 12, 2026") {
+		t.Errorf("w should switch to the week view:\n%s", view)
+	}
+	if _, msg := calendarKey(c, "q"); msg != (CalendarResult{Closed: true}) {
+		t.Errorf("q = %+v, want Closed", msg)
+	}
+}
+
+func TestCalendarFollowsKeymap(t *testing.T) {
+	k, err := keymap.Default().Override(map[string]string{"calendar.right": "n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keymap.Use(k)
+	t.Cleanup(func() { keymap.Use(keymap.Default()) })
+
+	c := newTestCalendar()
+	start := c.Day()
+	c, _ = calendarKey(c, "l")
+	if !c.Day().Equal(start) {
+		t.Errorf("l moved to %v after remapping calendar.right", c.Day())
+	}
+	c, _ = calendarKey(c, "n")
+	if want := start.AddDate(0, 0, 1); !c.Day().Equal(want) {
+		t.Errorf("n = %v, want %v", c.Day(), want)
+	}
+	if view := ansi.Strip(c.View()); !strings.Contains(view, "h/j/k/n day") {
+		t.Errorf("hint should show the remapped key:\n%s", view)
+	}
+}
+
+func TestCalendarDayListActions(t *testing.T) {
+	c := newTestCalendar()
+	c, _ = calendarKey(c, "l")
+	c, _ = calendarKey(c, "enter")
+	if !c.listing {
+		t.Fatal("enter on a day with issues should open its list")
+	}
+	if view := ansi.Strip(c.View()); !strings.Contains(view, "cal-1") || !strings.Contains(view, "3 issue(s)") {
+		t.Errorf("list should show the day's issues:\n%s", view)
+	}
+
+	if _, msg := calendarKey(c, "enter"); msg != (CalendarResult{JumpID: "cal-1"}) {
+		t.Errorf("enter = %+v, want jump to cal-1", msg)
+	}
+	if _, msg := calendarKey(c, "+"); msg != (CalendarResult{IssueID: "cal-1", Field: CalendarDue, Date: "2026-10-16"}) {
+		t.Errorf("+ = %+v, want cal-1 due 2026-10-16", msg)
+	}
+	c, _ = calendarKey(c, "j")
+	if _, msg := calendarKey(c, "x"); msg != (CalendarResult{IssueID: "cal-3", Field: CalendarDefer}) {
+		t.Errorf("x = %+v, want cal-3 defer cleared", msg)
+	}
+}
+
+func TestCalendarMoveToDay(t *testing.T) {
+	c := newTestCalendar()
+	c, _ = calendarKey(c, "l")
+	c, _ = calendarKey(c, "enter")
+	c, _ = calendarKey(c, "m")
+	if c.moving == nil || c.listing {
+		t.Fatal("m should pick a target day on the grid")
+	}
+	c, _ = calendarKey(c, "j")
+	if view := ansi.Strip(c.View()); !strings.Contains(view, "Move cal-1 due date to Thu Oct 22") {
+		t.Errorf("grid should name the pending move:\n%s", view)
+	}
+	c, msg := calendarKey(c, "enter")
+	if msg != (CalendarResult{IssueID: "cal-1", Field: CalendarDue, Date: "2026-10-22"}) {
+		t.Errorf("enter = %+v, want cal-1 due 2026-10-22", msg)
+	}
+	if c.moving != nil {
+		t.Error("confirming should end the move")
+	}
+}
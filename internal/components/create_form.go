@@ -8,6 +8,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
@@ -17,6 +18,8 @@ type CreateFormResult struct {
 	Type       string
 	Priority   string
 	CrewMember string // non-empty when assigning to a Gas Town crew member
+	Template   *data.Template
+	Metadata   map[string]any // parsed from the metadata field when a template is used
 	Cancelled  bool
 }
 
@@ -45,16 +48,28 @@ var priorityOptions = []selectOption{
 	{Label: "P4 Backlog", Value: "4"},
 }
 
-// CreateForm is a mini-form for creating a new issue.
+// CreateForm is a mini-form for creating a new issue. With templates it
+// opens on a template picker; the chosen template seeds the fields and adds
+// a metadata field.
 type CreateForm struct {
 	titleInput  textinput.Model
 	crewInput   textinput.Model
+	metaInput   textinput.Model
 	typeIdx     int // selected index in typeOptions
 	prioIdx     int // selected index in priorityOptions
-	activeField int // 0=title, 1=type, 2=priority, 3=crew (when gtAvailable)
-	fieldCount  int // 3 without GT, 4 with GT
+	activeField int // 0=title, 1=type, 2=priority, 3=crew (when gtAvailable), then metadata
+	fieldCount  int // 3 without GT, 4 with GT, +1 for metadata
+	metaField   int // index of the metadata field, -1 when hidden
 	width       int
 	height      int
+
+	templates []data.Template
+	schema    *data.MetadataSchema
+	picking   bool // choosing a template before the fields
+	pickIdx   int  // cursor in pickOptions
+	blank     bool // picker offers "Blank issue" first
+	template  *data.Template
+	err       string
 }
 
 // NewCreateForm creates a new issue creation form (without Gas Town crew field).
@@ -79,6 +94,11 @@ func newCreateForm(width, height int, gtAvailable bool) CreateForm {
 	ci.Placeholder = "Crew member name (optional)..."
 	ci.SetWidth(width - 16)
 
+	mi := textinput.New()
+	mi.Prompt = ""
+	mi.Placeholder = "key=value, key=value"
+	mi.SetWidth(width - 16)
+
 	fieldCount := 3
 	if gtAvailable {
 		fieldCount = 4
@@ -87,15 +107,97 @@ func newCreateForm(width, height int, gtAvailable bool) CreateForm {
 	return CreateForm{
 		titleInput:  ti,
 		crewInput:   ci,
+		metaInput:   mi,
 		typeIdx:     0, // default: task
 		prioIdx:     2, // default: P2 Medium
 		activeField: 0,
 		fieldCount:  fieldCount,
+		metaField:   -1,
 		width:       width,
 		height:      height,
 	}
 }
 
+// WithTemplates makes the form open on a template picker. blank adds a
+// "Blank issue" choice ahead of the templates. Without templates the form is
+// unchanged.
+func (cf CreateForm) WithTemplates(templates []data.Template, schema *data.MetadataSchema, blank bool) CreateForm {
+	if len(templates) == 0 {
+		return cf
+	}
+	cf.templates = templates
+	cf.schema = schema
+	cf.blank = blank
+	cf.picking = true
+	cf.titleInput.Blur()
+	return cf
+}
+
+// pickOptions returns the picker entries; nil stands for a blank issue.
+func (cf CreateForm) pickOptions() []*data.Template {
+	var opts []*data.Template
+	if cf.blank {
+		opts = append(opts, nil)
+	}
+	for i := range cf.templates {
+		opts = append(opts, &cf.templates[i])
+	}
+	return opts
+}
+
+// applyTemplate leaves the picker and seeds the fields from t (nil = blank).
+func (cf *CreateForm) applyTemplate(t *data.Template) {
+	cf.picking = false
+	cf.activeField = 0
+	cf.focusActiveInput()
+	if t == nil {
+		return
+	}
+	cf.template = t
+	cf.titleInput.SetValue(t.Title)
+	cf.titleInput.CursorEnd()
+	for i, opt := range typeOptions {
+		if opt.Value == string(t.Type) {
+			cf.typeIdx = i
+		}
+	}
+	if t.Priority != nil {
+		for i, opt := range priorityOptions {
+			if opt.Value == fmt.Sprint(int(*t.Priority)) {
+				cf.prioIdx = i
+			}
+		}
+	}
+	if meta := t.PrefillMetadata(cf.schema); len(meta) > 0 {
+		cf.metaInput.SetValue(data.FormatMetadata(meta))
+		cf.metaField = cf.fieldCount
+		cf.fieldCount++
+	}
+}
+
+// updatePicker handles keys while choosing a template.
+func (cf CreateForm) updatePicker(km tea.KeyPressMsg) (CreateForm, tea.Cmd) {
+	opts := cf.pickOptions()
+	switch keymap.Lookup(keymap.Forms, km.String()) {
+	case keymap.Cancel:
+		return cf, func() tea.Msg {
+			return CreateFormResult{Cancelled: true}
+		}
+	case keymap.Down:
+		if cf.pickIdx < len(opts)-1 {
+			cf.pickIdx++
+		}
+	case keymap.Up:
+		if cf.pickIdx > 0 {
+			cf.pickIdx--
+		}
+	case keymap.Submit:
+		cf.applyTemplate(opts[cf.pickIdx])
+		return cf, textinput.Blink
+	}
+	return cf, nil
+}
+
 // Init returns the blink command for the text input cursor.
 func (cf CreateForm) Init() tea.Cmd {
 	return textinput.Blink
@@ -104,6 +206,9 @@ func (cf CreateForm) Init() tea.Cmd {
 // Update handles messages for the form.
 func (cf CreateForm) Update(msg tea.Msg) (CreateForm, tea.Cmd) {
 	km, ok := msg.(tea.KeyPressMsg)
+	if ok && cf.picking {
+		return cf.updatePicker(km)
+	}
 	if !ok {
 		// Forward non-key messages to text input
 		var cmd tea.Cmd
@@ -113,35 +218,45 @@ func (cf CreateForm) Update(msg tea.Msg) (CreateForm, tea.Cmd) {
 
 	lastField := cf.fieldCount - 1
 
-	switch km.String() {
-	case "esc":
+	switch keymap.Lookup(keymap.Forms, km.String()) {
+	case keymap.Cancel:
 		return cf, func() tea.Msg {
 			return CreateFormResult{Cancelled: true}
 		}
 
-	case "tab":
+	case keymap.NextField:
 		cf.activeField = (cf.activeField + 1) % cf.fieldCount
 		cf.focusActiveInput()
 		return cf, nil
 
-	case "shift+tab":
+	case keymap.PrevField:
 		cf.activeField = (cf.activeField + cf.fieldCount - 1) % cf.fieldCount
 		cf.focusActiveInput()
 		return cf, nil
 
-	case "enter":
+	case keymap.Submit:
 		if cf.activeField == lastField {
 			// Submit on last field
 			title := cf.titleInput.Value()
 			if title == "" {
 				return cf, nil
 			}
+			var metadata map[string]any
+			if cf.metaField >= 0 {
+				var err error
+				if metadata, err = data.ParseMetadata(cf.metaInput.Value(), cf.schema); err != nil {
+					cf.err = err.Error()
+					return cf, nil
+				}
+			}
 			return cf, func() tea.Msg {
 				return CreateFormResult{
 					Title:      title,
 					Type:       typeOptions[cf.typeIdx].Value,
 					Priority:   priorityOptions[cf.prioIdx].Value,
 					CrewMember: cf.crewInput.Value(),
+					Template:   cf.template,
+					Metadata:   metadata,
 				}
 			}
 		}
@@ -150,7 +265,7 @@ func (cf CreateForm) Update(msg tea.Msg) (CreateForm, tea.Cmd) {
 		cf.focusActiveInput()
 		return cf, nil
 
-	case "j", "down":
+	case keymap.Down:
 		if cf.activeField == 1 {
 			if cf.typeIdx < len(typeOptions)-1 {
 				cf.typeIdx++
@@ -164,7 +279,7 @@ func (cf CreateForm) Update(msg tea.Msg) (CreateForm, tea.Cmd) {
 			return cf, nil
 		}
 
-	case "k", "up":
+	case keymap.Up:
 		if cf.activeField == 1 {
 			if cf.typeIdx > 0 {
 				cf.typeIdx--
@@ -185,6 +300,12 @@ func (cf CreateForm) Update(msg tea.Msg) (CreateForm, tea.Cmd) {
 		cf.titleInput, cmd = cf.titleInput.Update(msg)
 		return cf, cmd
 	}
+	if cf.activeField == cf.metaField {
+		var cmd tea.Cmd
+		cf.metaInput, cmd = cf.metaInput.Update(msg)
+		cf.err = ""
+		return cf, cmd
+	}
 	if cf.activeField == 3 {
 		var cmd tea.Cmd
 		cf.crewInput, cmd = cf.crewInput.Update(msg)
@@ -194,13 +315,25 @@ func (cf CreateForm) Update(msg tea.Msg) (CreateForm, tea.Cmd) {
 	return cf, nil
 }
 
+// hasCrew reports whether the form has the Gas Town crew field.
+func (cf CreateForm) hasCrew() bool {
+	n := cf.fieldCount
+	if cf.metaField >= 0 {
+		n--
+	}
+	return n > 3
+}
+
 // focusActiveInput ensures the correct text input has focus.
 func (cf *CreateForm) focusActiveInput() {
 	cf.titleInput.Blur()
 	cf.crewInput.Blur()
+	cf.metaInput.Blur()
 	switch cf.activeField {
 	case 0:
 		cf.titleInput.Focus()
+	case cf.metaField:
+		cf.metaInput.Focus()
 	case 3:
 		cf.crewInput.Focus()
 	}
@@ -213,8 +346,20 @@ func (cf CreateForm) View() string {
 	normalStyle := lipgloss.NewStyle().Foreground(ui.Light)
 	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
 
+	if cf.picking {
+		return cf.viewPicker()
+	}
+
 	var lines []string
 
+	if cf.template != nil {
+		lines = append(lines, dimStyle.Render("  Template: ")+normalStyle.Render(cf.template.Name))
+		if extras := templateExtras(cf.template); extras != "" {
+			lines = append(lines, dimStyle.Render("  + "+extras))
+		}
+		lines = append(lines, "")
+	}
+
 	// Title field
 	var label string
 	if cf.activeField == 0 {
@@ -262,7 +407,7 @@ func (cf CreateForm) View() string {
 	}
 
 	// Crew member field (only when Gas Town is available)
-	if cf.fieldCount > 3 {
+	if cf.hasCrew() {
 		lines = append(lines, "")
 		if cf.activeField == 3 {
 			label = titleStyle.Render("> Crew")
@@ -273,9 +418,70 @@ func (cf CreateForm) View() string {
 		lines = append(lines, "  "+cf.crewInput.View())
 	}
 
+	// Metadata field (only when a template supplies or the schema requires fields)
+	if cf.metaField >= 0 {
+		lines = append(lines, "")
+		if cf.activeField == cf.metaField {
+			label = titleStyle.Render("> Metadata")
+		} else {
+			label = dimStyle.Render("  Metadata")
+		}
+		lines = append(lines, label)
+		lines = append(lines, "  "+cf.metaInput.View())
+		if cf.err != "" {
+			lines = append(lines, "  "+lipgloss.NewStyle().Foreground(ui.StatusStalled).Render(cf.err))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// viewPicker renders the template picker.
+func (cf CreateForm) viewPicker() string {
+	titleStyle := lipgloss.NewStyle().Foreground(ui.BrightGold).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(ui.BrightGreen)
+	normalStyle := lipgloss.NewStyle().Foreground(ui.Light)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+
+	lines := []string{titleStyle.Render("> Template"), ""}
+	for i, t := range cf.pickOptions() {
+		name, summary := "Blank issue", "Title, type and priority only"
+		if t != nil {
+			name, summary = t.Name, t.Summary
+		}
+		cursor := "  "
+		style := normalStyle
+		if i == cf.pickIdx {
+			cursor = selectedStyle.Render("> ")
+			style = selectedStyle
+		}
+		line := fmt.Sprintf("  %s%s", cursor, style.Render(name))
+		if summary != "" {
+			line += dimStyle.Render("  " + summary)
+		}
+		lines = append(lines, line)
+	}
 	return strings.Join(lines, "\n")
 }
 
+// templateExtras summarizes what a template adds beyond the form fields.
+func templateExtras(t *data.Template) string {
+	var parts []string
+	if t.Description != "" {
+		parts = append(parts, "description")
+	}
+	if t.Acceptance != "" {
+		parts = append(parts, "acceptance criteria")
+	}
+	if len(t.Labels) > 0 {
+		parts = append(parts, "labels: "+strings.Join(t.Labels, ", "))
+	}
+	if n := len(t.Children); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d child issue(s)", n))
+	}
+	return strings.Join(parts, " · ")
+}
+
 // ParsePriority converts the form's priority string to data.Priority.
 func ParsePriority(s string) data.Priority {
 	switch s {
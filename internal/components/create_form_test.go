@@ -531,3 +531,98 @@ func TestCreateFormJKOnTitleFieldNoEffect(t *testing.T) {
 		t.Fatalf("expected prioIdx unchanged at %d, got %d", origPrio, cf.prioIdx)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Templates
+// ---------------------------------------------------------------------------
+
+func testTemplates() []data.Template {
+	high := data.PriorityHigh
+	return []data.Template{
+		{Name: "Bug report", Summary: "Something is broken", Title: "Bug: ", Type: data.TypeBug, Priority: &high,
+			Labels: []string{"bug"}, Metadata: map[string]any{"severity": "major"},
+			Children: []data.TemplateChild{{Title: "Write failing test"}}},
+		{Name: "Spike", Type: data.TypeSpike},
+	}
+}
+
+func TestCreateFormWithoutTemplatesSkipsPicker(t *testing.T) {
+	cf := newTestForm().WithTemplates(nil, nil, true)
+	if cf.picking {
+		t.Fatal("expected no picker without templates")
+	}
+}
+
+func TestCreateFormTemplatePickerBlank(t *testing.T) {
+	cf := newTestForm().WithTemplates(testTemplates(), nil, true)
+	if !cf.picking {
+		t.Fatal("expected the form to open on the picker")
+	}
+	if !strings.Contains(cf.View(), "Blank issue") {
+		t.Error("picker should offer a blank issue")
+	}
+	cf, _ = cf.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cf.picking || cf.template != nil || cf.metaField != -1 {
+		t.Fatalf("blank choice: picking=%v template=%v metaField=%d", cf.picking, cf.template, cf.metaField)
+	}
+}
+
+func TestCreateFormTemplatePrefills(t *testing.T) {
+	schema := &data.MetadataSchema{Mode: "error", Fields: map[string]data.MetadataFieldSchema{
+		"points":   {Type: data.MetaInt, Required: true},
+		"severity": {Type: data.MetaEnum, Values: []string{"minor", "major"}},
+	}}
+	cf := newTestForm().WithTemplates(testTemplates(), schema, false)
+	cf, _ = cf.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if cf.titleInput.Value() != "Bug: " || typeOptions[cf.typeIdx].Value != "bug" || priorityOptions[cf.prioIdx].Value != "1" {
+		t.Fatalf("fields = %q %s %s", cf.titleInput.Value(), typeOptions[cf.typeIdx].Value, priorityOptions[cf.prioIdx].Value)
+	}
+	if cf.metaField != 3 || cf.metaInput.Value() != "points=0, severity=major" {
+		t.Fatalf("metaField=%d meta=%q", cf.metaField, cf.metaInput.Value())
+	}
+	view := cf.View()
+	for _, want := range []string{"Template:", "Bug report", "labels: bug", "1 child issue(s)", "Metadata"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "Crew") {
+		t.Error("crew field should stay hidden without Gas Town")
+	}
+
+	cf.titleInput.SetValue("Bug: crash")
+	for range 3 {
+		cf, _ = cf.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	}
+	if cf.activeField != 3 {
+		t.Fatalf("activeField = %d, want metadata (3)", cf.activeField)
+	}
+	_, cmd := cf.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected submit")
+	}
+	result := cmd().(CreateFormResult)
+	if result.Template == nil || result.Template.Name != "Bug report" {
+		t.Errorf("Template = %v", result.Template)
+	}
+	if result.Metadata["points"] != 0 || result.Metadata["severity"] != "major" {
+		t.Errorf("Metadata = %v", result.Metadata)
+	}
+}
+
+func TestCreateFormTemplateBadMetadata(t *testing.T) {
+	schema := &data.MetadataSchema{Fields: map[string]data.MetadataFieldSchema{"points": {Type: data.MetaInt}}}
+	cf := newTestForm().WithTemplates(testTemplates(), schema, false)
+	cf, _ = cf.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	cf.titleInput.SetValue("Bug: crash")
+	cf.metaInput.SetValue("points=lots")
+	cf.activeField = cf.metaField
+	cf, cmd := cf.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatal("bad metadata should block submit")
+	}
+	if !strings.Contains(cf.View(), "expected int") {
+		t.Error("expected the parse error in the view")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
@@ -62,13 +63,13 @@ func (ef EditForm) Update(msg tea.Msg) (EditForm, tea.Cmd) {
 		return ef, cmd
 	}
 
-	switch km.String() {
-	case "esc":
+	switch keymap.Lookup(keymap.Forms, km.String()) {
+	case keymap.Cancel:
 		return ef, func() tea.Msg {
 			return EditFormResult{Cancelled: true}
 		}
 
-	case "tab":
+	case keymap.NextField:
 		ef.activeField = (ef.activeField + 1) % 2
 		if ef.activeField == 0 {
 			ef.titleInput.Focus()
@@ -77,7 +78,7 @@ func (ef EditForm) Update(msg tea.Msg) (EditForm, tea.Cmd) {
 		}
 		return ef, nil
 
-	case "shift+tab":
+	case keymap.PrevField:
 		ef.activeField = (ef.activeField + 1) % 2 // +1 == -1 mod 2
 		if ef.activeField == 0 {
 			ef.titleInput.Focus()
@@ -86,7 +87,7 @@ func (ef EditForm) Update(msg tea.Msg) (EditForm, tea.Cmd) {
 		}
 		return ef, nil
 
-	case "enter":
+	case keymap.Submit:
 		if ef.activeField == 1 {
 			title := ef.titleInput.Value()
 			if title == "" {
@@ -105,7 +106,7 @@ func (ef EditForm) Update(msg tea.Msg) (EditForm, tea.Cmd) {
 		ef.titleInput.Blur()
 		return ef, nil
 
-	case "j", "down":
+	case keymap.Down:
 		if ef.activeField == 1 {
 			if ef.prioIdx < len(priorityOptions)-1 {
 				ef.prioIdx++
@@ -113,7 +114,7 @@ func (ef EditForm) Update(msg tea.Msg) (EditForm, tea.Cmd) {
 			return ef, nil
 		}
 
-	case "k", "up":
+	case keymap.Up:
 		if ef.activeField == 1 {
 			if ef.prioIdx > 0 {
 				ef.prioIdx--
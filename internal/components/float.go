@@ -8,7 +8,7 @@ import (
 // Float renders a decorative ASCII parade float. Stub for v1.
 func Float(title string, width int) string {
 	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(ui.Border(lipgloss.RoundedBorder())).
 		BorderForeground(ui.BrightGold).
 		Foreground(ui.BrightPurple).
 		Bold(true).
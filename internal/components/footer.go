@@ -8,6 +8,7 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
@@ -29,29 +30,57 @@ type Footer struct {
 	SourceHealth *data.SourceHealth
 }
 
-// ParadeBindings are the default keybindings for the parade view.
-var ParadeBindings = []FooterBinding{
-	{Key: "?", Desc: "help"},
-	{Key: ":", Desc: "palette"},
-	{Key: "/", Desc: "filter"},
-	{Key: "j/k", Desc: "navigate"},
-	{Key: "1/2/3", Desc: "status"},
-	{Key: "b", Desc: "branch"},
-	{Key: "N", Desc: "new"},
-	{Key: "a", Desc: "agent"},
-	{Key: "q", Desc: "quit"},
+// paradeHints are the footer hints for the parade view.
+var paradeHints = []keymap.Hint{
+	keymap.NewHint(keymap.Global, "help", keymap.Help),
+	keymap.NewHint(keymap.Global, "palette", keymap.Palette),
+	keymap.NewHint(keymap.Global, "filter", keymap.Filter),
+	keymap.NewHint(keymap.Parade, "navigate", keymap.Down, keymap.Up),
+	keymap.NewHint(keymap.Global, "status", keymap.SetInProgress, keymap.SetOpen, keymap.CloseIssue),
+	keymap.NewHint(keymap.Global, "branch", keymap.CopyBranch),
+	keymap.NewHint(keymap.Global, "new", keymap.NewIssue),
+	keymap.NewHint(keymap.Global, "agent", keymap.LaunchAgent),
+	keymap.NewHint(keymap.Global, "quit", keymap.Quit),
 }
 
-// DetailBindings are keybindings when the detail pane is focused.
-var DetailBindings = []FooterBinding{
-	{Key: "?", Desc: "help"},
-	{Key: "/", Desc: "filter"},
-	{Key: "j/k", Desc: "scroll"},
-	{Key: "tab", Desc: "switch pane"},
-	{Key: "esc", Desc: "back"},
-	{Key: "a", Desc: "agent"},
-	{Key: "A", Desc: "kill agent"},
-	{Key: "q", Desc: "quit"},
+// detailHints are the footer hints when the detail pane is focused.
+var detailHints = []keymap.Hint{
+	keymap.NewHint(keymap.Global, "help", keymap.Help),
+	keymap.NewHint(keymap.Global, "filter", keymap.Filter),
+	keymap.NewHint(keymap.Detail, "scroll", keymap.Down, keymap.Up),
+	keymap.NewHint(keymap.Detail, "tabs", keymap.PrevTab, keymap.NextTab),
+	keymap.NewHint(keymap.Global, "switch pane", keymap.SwitchPane),
+	keymap.NewHint(keymap.Global, "back", keymap.Back),
+	keymap.NewHint(keymap.Global, "agent", keymap.LaunchAgent),
+	keymap.NewHint(keymap.Global, "kill agent", keymap.KillAgent),
+	keymap.NewHint(keymap.Global, "quit", keymap.Quit),
+}
+
+// gasTownHints are added before quit when Gas Town is available.
+var gasTownHints = []keymap.Hint{
+	keymap.NewHint(keymap.Global, "gas town", keymap.ToggleGasTown),
+	keymap.NewHint(keymap.Global, "problems", keymap.ToggleProblems),
+	keymap.NewHint(keymap.Global, "sling", keymap.SlingFormula),
+	keymap.NewHint(keymap.Global, "nudge", keymap.Nudge),
+}
+
+// footerBindings resolves hints to the keys currently bound.
+func footerBindings(hints []keymap.Hint) []FooterBinding {
+	bindings := make([]FooterBinding, len(hints))
+	for i, h := range hints {
+		bindings[i] = FooterBinding{Key: h.Key(), Desc: h.Desc}
+	}
+	return bindings
+}
+
+// ParadeBindings are the keybindings shown for the parade view.
+func ParadeBindings() []FooterBinding {
+	return footerBindings(paradeHints)
+}
+
+// DetailBindings are the keybindings shown when the detail pane is focused.
+func DetailBindings() []FooterBinding {
+	return footerBindings(detailHints)
 }
 
 // View renders the footer.
@@ -158,18 +187,13 @@ func (f Footer) renderHealthState(age string) string {
 
 // NewFooter creates a footer with the given width and pane focus.
 func NewFooter(width int, detailFocused, hasGasTown bool) Footer {
-	bindings := ParadeBindings
+	bindings := ParadeBindings()
 	if detailFocused {
-		bindings = DetailBindings
+		bindings = DetailBindings()
 	}
 	if hasGasTown {
-		gtBindings := []FooterBinding{
-			{Key: "^g", Desc: "gas town"},
-			{Key: "p", Desc: "problems"},
-			{Key: "s", Desc: "sling"},
-			{Key: "n", Desc: "nudge"},
-		}
-		bindings = insertBefore(bindings, "q", gtBindings...)
+		quit := keymap.Label(keymap.Global, keymap.Quit)
+		bindings = insertBefore(bindings, quit, footerBindings(gasTownHints)...)
 	}
 	return Footer{Width: width, Bindings: bindings}
 }
@@ -192,18 +216,22 @@ func insertBefore(bindings []FooterBinding, key string, extra ...FooterBinding)
 // BulkFooter renders the footer bar shown during multi-select.
 func BulkFooter(width, count int, hasGasTown bool) string {
 	label := ui.FooterKey.Render(fmt.Sprintf(" %d selected: ", count))
-	bindings := []FooterBinding{
-		{Key: "1", Desc: "in_progress"},
-		{Key: "2", Desc: "open"},
-		{Key: "3", Desc: "close"},
+	hints := []keymap.Hint{
+		keymap.NewHint(keymap.Global, "in_progress", keymap.SetInProgress),
+		keymap.NewHint(keymap.Global, "open", keymap.SetOpen),
+		keymap.NewHint(keymap.Global, "close", keymap.CloseIssue),
 	}
 	if hasGasTown {
-		bindings = append(bindings,
-			FooterBinding{Key: "a", Desc: "sling"},
-			FooterBinding{Key: "s", Desc: "sling+formula"},
+		hints = append(hints,
+			keymap.NewHint(keymap.Global, "sling", keymap.LaunchAgent),
+			keymap.NewHint(keymap.Global, "sling+formula", keymap.SlingFormula),
 		)
 	}
-	bindings = append(bindings, FooterBinding{Key: "X", Desc: "clear"})
+	hints = append(hints,
+		keymap.NewHint(keymap.Parade, "edit", keymap.BulkEdit),
+		keymap.NewHint(keymap.Parade, "clear", keymap.ClearSelection),
+	)
+	bindings := footerBindings(hints)
 	var parts []string
 	for _, b := range bindings {
 		key := ui.FooterKey.Render(b.Key)
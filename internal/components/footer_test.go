@@ -6,28 +6,31 @@ import (
 	"time"
 
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 )
 
 func TestNewFooterParadeBindings(t *testing.T) {
 	f := NewFooter(80, false, false)
-	if len(f.Bindings) != len(ParadeBindings) {
-		t.Fatalf("expected %d bindings, got %d", len(ParadeBindings), len(f.Bindings))
+	want := ParadeBindings()
+	if len(f.Bindings) != len(want) {
+		t.Fatalf("expected %d bindings, got %d", len(want), len(f.Bindings))
 	}
 	for i, b := range f.Bindings {
-		if b.Key != ParadeBindings[i].Key || b.Desc != ParadeBindings[i].Desc {
-			t.Fatalf("binding %d: got {%s,%s}, want {%s,%s}", i, b.Key, b.Desc, ParadeBindings[i].Key, ParadeBindings[i].Desc)
+		if b.Key != want[i].Key || b.Desc != want[i].Desc {
+			t.Fatalf("binding %d: got {%s,%s}, want {%s,%s}", i, b.Key, b.Desc, want[i].Key, want[i].Desc)
 		}
 	}
 }
 
 func TestNewFooterDetailBindings(t *testing.T) {
 	f := NewFooter(80, true, false)
-	if len(f.Bindings) != len(DetailBindings) {
-		t.Fatalf("expected %d bindings, got %d", len(DetailBindings), len(f.Bindings))
+	want := DetailBindings()
+	if len(f.Bindings) != len(want) {
+		t.Fatalf("expected %d bindings, got %d", len(want), len(f.Bindings))
 	}
 	for i, b := range f.Bindings {
-		if b.Key != DetailBindings[i].Key || b.Desc != DetailBindings[i].Desc {
-			t.Fatalf("binding %d: got {%s,%s}, want {%s,%s}", i, b.Key, b.Desc, DetailBindings[i].Key, DetailBindings[i].Desc)
+		if b.Key != want[i].Key || b.Desc != want[i].Desc {
+			t.Fatalf("binding %d: got {%s,%s}, want {%s,%s}", i, b.Key, b.Desc, want[i].Key, want[i].Desc)
 		}
 	}
 }
@@ -36,7 +39,7 @@ func TestNewFooterGasTownAddsBindings(t *testing.T) {
 	f := NewFooter(80, false, true)
 
 	// Should have ParadeBindings + 4 Gas Town bindings (gas town, problems, sling, nudge)
-	expected := len(ParadeBindings) + 4
+	expected := len(ParadeBindings()) + 4
 	if len(f.Bindings) != expected {
 		t.Fatalf("expected %d bindings with Gas Town, got %d", expected, len(f.Bindings))
 	}
@@ -132,7 +135,7 @@ func TestBulkFooterNoGasTownNoSling(t *testing.T) {
 func TestFooterViewWithBeadsContext(t *testing.T) {
 	f := Footer{
 		Width:       120,
-		Bindings:    ParadeBindings,
+		Bindings:    ParadeBindings(),
 		SourceMode:  data.SourceCLI,
 		LastRefresh: time.Now(),
 		BeadsContext: &data.BeadsContext{
@@ -149,7 +152,7 @@ func TestFooterViewWithBeadsContext(t *testing.T) {
 func TestFooterViewWithBeadsContextVersion(t *testing.T) {
 	f := Footer{
 		Width:       120,
-		Bindings:    ParadeBindings,
+		Bindings:    ParadeBindings(),
 		SourceMode:  data.SourceCLI,
 		LastRefresh: time.Now(),
 		BeadsContext: &data.BeadsContext{
@@ -167,7 +170,7 @@ func TestFooterViewWithBeadsContextVersion(t *testing.T) {
 func TestFooterViewWithBeadsContextNoBackend(t *testing.T) {
 	f := Footer{
 		Width:       120,
-		Bindings:    ParadeBindings,
+		Bindings:    ParadeBindings(),
 		SourceMode:  data.SourceCLI,
 		LastRefresh: time.Now(),
 		BeadsContext: &data.BeadsContext{
@@ -186,7 +189,7 @@ func TestFooterViewWithBeadsContextNoBackend(t *testing.T) {
 func TestFooterViewWithoutBeadsContext(t *testing.T) {
 	f := Footer{
 		Width:       120,
-		Bindings:    ParadeBindings,
+		Bindings:    ParadeBindings(),
 		SourceMode:  data.SourceCLI,
 		LastRefresh: time.Now(),
 	}
@@ -195,3 +198,21 @@ func TestFooterViewWithoutBeadsContext(t *testing.T) {
 		t.Fatalf("footer should not contain context info when nil, got: %s", output)
 	}
 }
+
+func TestFooterFollowsKeymap(t *testing.T) {
+	k, err := keymap.Default().Override(map[string]string{"global.quit": "ctrl+q", "global.toggle_gastown": "ctrl+t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keymap.Use(k)
+	t.Cleanup(func() { keymap.Use(keymap.Default()) })
+
+	f := NewFooter(80, false, true)
+	last := f.Bindings[len(f.Bindings)-1]
+	if last.Key != "^q" || last.Desc != "quit" {
+		t.Errorf("last binding = %+v, want ^q quit", last)
+	}
+	if f.Bindings[len(f.Bindings)-5].Key != "^t" {
+		t.Errorf("gas town hints not inserted before the remapped quit: %+v", f.Bindings)
+	}
+}
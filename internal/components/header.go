@@ -35,8 +35,12 @@ func (h Header) View() string {
 	titleStr := fmt.Sprintf("%s MARDI GRAS %s", ui.FleurDeLis, ui.FleurDeLis)
 	title := ui.HeaderStyle.Render(ui.ApplyMardiGrasGradient(titleStr))
 
+	countsFmt := " %d " + ui.SymStalled + "  %d " + ui.SymLinedUp + "  %d " + ui.SymRolling + "  %d " + ui.SymPassed + " "
+	if ui.Accessible() {
+		countsFmt = " %d stalled  %d lined up  %d rolling  %d passed "
+	}
 	counts := ui.HeaderCounts.Render(fmt.Sprintf(
-		" %d ⊘  %d ♪  %d ●  %d ✓ ",
+		countsFmt,
 		stalled, linedUp, rolling, len(h.Groups[data.ParadePastTheStand]),
 	))
 
@@ -162,8 +166,11 @@ func (h Header) renderProgressBar(total, done, length int) string {
 	filledLen := int((float64(done) / float64(total)) * float64(length))
 	emptyLen := length - filledLen
 
-	filled := strings.Repeat("█", filledLen)
-	empty := strings.Repeat("█", emptyLen) // Or "━"
+	filled := strings.Repeat(ui.SymProgress, filledLen)
+	empty := strings.Repeat(ui.SymProgress, emptyLen) // Or "━"
+	if ui.Accessible() {
+		empty = strings.Repeat(ui.SymProgressEmpty, emptyLen)
+	}
 
 	percent := int((float64(done) / float64(total)) * 100)
 
@@ -7,6 +7,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
@@ -63,115 +64,40 @@ func (h Help) Update(msg tea.Msg) (Help, bool) {
 	return h, false
 }
 
+// Scroll turns delta pages, stopping at the first and last.
+func (h *Help) Scroll(delta int) {
+	h.page = max(min(h.page+delta, h.pageCount()-1), 0)
+}
+
+// allSections builds the help from the key registry, one section per
+// binding section in registry order, followed by the filter syntax.
 func allSections() []helpSection {
-	return []helpSection{
-		{
-			title: "GLOBAL",
-			bindings: []helpBinding{
-				{key: "q", desc: "Quit application"},
-				{key: "tab", desc: "Switch active pane"},
-				{key: "?", desc: "Toggle help"},
-				{key: ": / Ctrl+K", desc: "Open command palette"},
-				{key: "p", desc: "Toggle problems view (gt)"},
-			},
-		},
-		{
-			title: "PARADE",
-			bindings: []helpBinding{
-				{key: "j / k", desc: "Navigate up/down"},
-				{key: "g / G", desc: "Jump to top/bottom"},
-				{key: "enter", desc: "Focus detail pane"},
-				{key: "c", desc: "Toggle closed issues"},
-				{key: "/", desc: "Enter filter mode (fuzzy)"},
-				{key: "f", desc: "Toggle focus mode (my work + top priority)"},
-				{key: "a", desc: "Launch agent (tmux: new window)"},
-				{key: "A", desc: "Kill active agent on issue"},
-			},
-		},
-		{
-			title: "QUICK ACTIONS",
-			bindings: []helpBinding{
-				{key: "1", desc: "Set status: in_progress"},
-				{key: "2", desc: "Set status: open"},
-				{key: "3", desc: "Close issue"},
-				{key: "!", desc: "Set priority: P1 (high)"},
-				{key: "@", desc: "Set priority: P2 (medium)"},
-				{key: "#", desc: "Set priority: P3 (low)"},
-				{key: "$", desc: "Set priority: P4 (backlog)"},
-				{key: "b", desc: "Copy branch name to clipboard"},
-				{key: "B", desc: "Create + checkout git branch"},
-				{key: "N", desc: "Create new issue"},
-			},
-		},
-		{
-			title: "MULTI-SELECT",
-			bindings: []helpBinding{
-				{key: "space / x", desc: "Toggle select on cursor issue"},
-				{key: "Shift+J/K", desc: "Select and move down/up"},
-				{key: "X", desc: "Clear all selections"},
-				{key: "1/2/3", desc: "Bulk set status on selected"},
-				{key: "a", desc: "Sling all selected issues"},
-				{key: "s", desc: "Pick formula and sling all selected"},
-			},
-		},
-		{
-			title: "DETAIL",
-			bindings: []helpBinding{
-				{key: "j / k", desc: "Scroll up/down"},
-				{key: "esc", desc: "Back to parade pane"},
-				{key: "/", desc: "Enter filter mode"},
-				{key: "a", desc: "Launch agent (tmux: new window)"},
-				{key: "A", desc: "Kill active agent on issue"},
-				{key: "m", desc: "Mark active molecule step done"},
-			},
-		},
-		{
-			title: "FILTER",
-			bindings: []helpBinding{
-				{key: "esc", desc: "Clear query and exit"},
-				{key: "enter", desc: "Apply query and exit"},
-				{key: "type:bug", desc: "Match issue type"},
-				{key: "p0, p1...", desc: "Match priority level"},
-			},
-		},
-		{
-			title: "GAS TOWN (when gt detected)",
-			bindings: []helpBinding{
-				{key: "ctrl+g", desc: "Toggle Gas Town panel"},
-				{key: "a", desc: "Sling issue to polecat (or tmux fallback)"},
-				{key: "s", desc: "Pick formula and sling to polecat"},
-				{key: "n", desc: "Nudge agent with message"},
-				{key: "A", desc: "Unsling/kill agent"},
-			},
-		},
-		{
-			title: "GAS TOWN PANEL (ctrl+g)",
-			bindings: []helpBinding{
-				{key: "j / k", desc: "Navigate agents/convoys"},
-				{key: "g / G", desc: "Jump to first/last"},
-				{key: "tab", desc: "Switch section (agents/convoys)"},
-				{key: "n", desc: "Nudge selected agent"},
-				{key: "h", desc: "Handoff work from agent"},
-				{key: "K", desc: "Decommission polecat"},
-				{key: "enter", desc: "Expand/collapse convoy or message"},
-				{key: "l", desc: "Land convoy"},
-				{key: "x", desc: "Close convoy"},
-				{key: "r", desc: "Reply to selected message"},
-				{key: "w", desc: "Compose new message to agent"},
-				{key: "d", desc: "Archive selected message"},
-				{key: "C", desc: "Create convoy from selection"},
-			},
-		},
-		{
-			title: "PROBLEMS (p)",
-			bindings: []helpBinding{
-				{key: "j / k", desc: "Navigate problems"},
-				{key: "g / G", desc: "Jump to first/last"},
-				{key: "n", desc: "Nudge agent on selected problem"},
-				{key: "h", desc: "Handoff from agent"},
-				{key: "K", desc: "Decommission polecat"},
-				{key: "R", desc: "Recover dead rig (opens confirmation)"},
-			},
+	var sections []helpSection
+	index := make(map[string]int)
+	for _, b := range keymap.Bindings() {
+		i, ok := index[b.Section]
+		if !ok {
+			i = len(sections)
+			index[b.Section] = i
+			sections = append(sections, helpSection{title: b.Section})
+		}
+		sections[i].bindings = append(sections[i].bindings, helpBinding{
+			key:  strings.Join(b.Keys, " / "),
+			desc: b.Help,
+		})
+	}
+	return append(sections, filterSection())
+}
+
+// filterSection describes the filter prompt, whose keys are typed text.
+func filterSection() helpSection {
+	return helpSection{
+		title: "FILTER",
+		bindings: []helpBinding{
+			{key: "esc", desc: "Clear query and exit"},
+			{key: "enter", desc: "Apply query and exit"},
+			{key: "type:bug", desc: "Match issue type"},
+			{key: "p0, p1...", desc: "Match priority level"},
 		},
 	}
 }
@@ -216,6 +142,10 @@ func paginateSections(sections []helpSection, maxLines int) [][]helpSection {
 
 // bodyLines returns the available height for section content.
 func (h Help) bodyLines() int {
+	if ui.Accessible() {
+		// title + blank, then blank + page indicator + close hint
+		return max(h.Height-5, 5)
+	}
 	// header (title 3 lines + subtitle 1 + blank 1) + footer (blank 1 + hint 1 + page indicator 1)
 	overhead := 8
 	return max(h.Height-overhead-6, 10) // 6 for box padding/border
@@ -249,6 +179,10 @@ func (h Help) View() string {
 		page = 0
 	}
 
+	if ui.Accessible() {
+		return h.plainView(pages, page)
+	}
+
 	var titleBlock string
 	if contentWidth >= 44 {
 		titleBlock = renderTitle(contentWidth)
@@ -272,7 +206,7 @@ func (h Help) View() string {
 		navHint := lipgloss.NewStyle().Foreground(ui.Dim).Render("  h/l or ←/→ to navigate")
 		footerParts = append(footerParts, pageLabel+navHint)
 	}
-	footerParts = append(footerParts, ui.HelpHint.Width(contentWidth).Render("Press esc, q, or ? to close"))
+	footerParts = append(footerParts, ui.HelpHint.Width(contentWidth).Render(closeHint()))
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -288,6 +222,34 @@ func (h Help) View() string {
 	return lipgloss.Place(h.Width, h.Height, lipgloss.Center, lipgloss.Center, box)
 }
 
+// plainView renders the help as left-aligned lines with no title art, box
+// or columns, so screen readers and braille displays read each binding in
+// full on its own line.
+func (h Help) plainView(pages [][]helpSection, page int) string {
+	lines := []string{"Mardi Gras help", ""}
+	for i, section := range pages[page] {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.ToUpper(section.title))
+		for _, b := range section.bindings {
+			lines = append(lines, b.key+": "+b.desc)
+		}
+	}
+	lines = append(lines, "")
+	if len(pages) > 1 {
+		lines = append(lines, fmt.Sprintf("Page %d of %d. h or l to turn the page.", page+1, len(pages)))
+	}
+	lines = append(lines, closeHint()+".")
+	return strings.Join(lines, "\n")
+}
+
+// closeHint names the keys that close the help.
+func closeHint() string {
+	label := func(a keymap.Action) string { return keymap.Label(keymap.Global, a) }
+	return fmt.Sprintf("Press %s, %s, or %s to close", label(keymap.Back), label(keymap.Quit), label(keymap.Help))
+}
+
 func (h Help) renderSections(width int, sections []helpSection) string {
 	blocks := make([]string, 0, len(sections))
 	for i := range sections {
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
 func TestHelpViewRendersContent(t *testing.T) {
@@ -147,3 +149,44 @@ func TestPaginateSectionsEmptyMaxLines(t *testing.T) {
 		t.Fatalf("expected 1 page with 0 maxLines, got %d", len(pages))
 	}
 }
+
+func TestHelpAccessibleViewIsLineOriented(t *testing.T) {
+	ui.SetAccessible(true)
+	t.Cleanup(func() { ui.SetAccessible(false) })
+
+	h := NewHelp(80, 200)
+	view := h.View()
+	if strings.Contains(view, asciiTitle[0]) {
+		t.Error("accessible help should not draw the title art")
+	}
+	found := false
+	for _, line := range strings.Split(view, "\n") {
+		if line != strings.TrimLeft(line, " ") {
+			t.Fatalf("line %q is indented", line)
+		}
+		found = found || strings.HasSuffix(line, ": Quit application")
+	}
+	if !found {
+		t.Errorf("no \"key: description\" line for quit in:\n%s", view)
+	}
+}
+
+func TestHelpFollowsKeymap(t *testing.T) {
+	k, err := keymap.Default().Override(map[string]string{"global.quit": "ctrl+q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keymap.Use(k)
+	t.Cleanup(func() { keymap.Use(keymap.Default()) })
+
+	for _, s := range allSections() {
+		for _, b := range s.bindings {
+			if b.desc == "Quit application" && b.key != "ctrl+q" {
+				t.Errorf("quit shown as %q, want ctrl+q", b.key)
+			}
+		}
+	}
+	if got := closeHint(); !strings.Contains(got, "^q") {
+		t.Errorf("closeHint() = %q, want the remapped quit key", got)
+	}
+}
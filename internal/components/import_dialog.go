@@ -0,0 +1,157 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/importer"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// ImportDialogResult is sent when the import preview completes.
+type ImportDialogResult struct {
+	Drafts    []importer.Draft
+	Cancelled bool
+}
+
+// ImportDialog previews parsed drafts before they are created. Each row can
+// be toggled in or out; duplicates start out excluded.
+type ImportDialog struct {
+	source string
+	drafts []importer.Draft
+	cursor int
+	offset int
+	width  int
+	height int
+}
+
+// NewImportDialog creates an import preview for drafts read from source.
+func NewImportDialog(source string, drafts []importer.Draft, width, height int) ImportDialog {
+	return ImportDialog{
+		source: source,
+		drafts: drafts,
+		width:  width,
+		height: height,
+	}
+}
+
+// Update handles key events for the import preview.
+func (d ImportDialog) Update(msg tea.Msg) (ImportDialog, tea.Cmd) {
+	km, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return d, nil
+	}
+
+	switch keymap.Lookup(keymap.Forms, km.String()) {
+	case keymap.Cancel, keymap.Close:
+		return d, func() tea.Msg {
+			return ImportDialogResult{Cancelled: true}
+		}
+
+	case keymap.Down:
+		if d.cursor < len(d.drafts)-1 {
+			d.cursor++
+		}
+
+	case keymap.Up:
+		if d.cursor > 0 {
+			d.cursor--
+		}
+
+	case keymap.Toggle:
+		if len(d.drafts) > 0 {
+			d.drafts = append([]importer.Draft(nil), d.drafts...)
+			d.drafts[d.cursor].Skip = !d.drafts[d.cursor].Skip
+		}
+
+	case keymap.Submit:
+		drafts := d.drafts
+		return d, func() tea.Msg {
+			return ImportDialogResult{Drafts: drafts}
+		}
+	}
+
+	d.scroll()
+	return d, nil
+}
+
+// Selected returns how many drafts will be created.
+func (d ImportDialog) Selected() int {
+	n := 0
+	for _, dr := range d.drafts {
+		if !dr.Skip {
+			n++
+		}
+	}
+	return n
+}
+
+// visibleRows is how many draft rows fit between the header and footer.
+func (d ImportDialog) visibleRows() int {
+	return max(d.height-10, 3)
+}
+
+func (d *ImportDialog) scroll() {
+	rows := d.visibleRows()
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	}
+	if d.cursor >= d.offset+rows {
+		d.offset = d.cursor - rows + 1
+	}
+}
+
+// View renders the import preview.
+func (d ImportDialog) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.BrightGold)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+	normalStyle := lipgloss.NewStyle().Foreground(ui.Light)
+	selectedStyle := lipgloss.NewStyle().Foreground(ui.BrightGreen)
+	warnStyle := lipgloss.NewStyle().Foreground(ui.StatusStalled)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("  IMPORT: %s", d.source)))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d issue(s) selected", d.Selected(), len(d.drafts))))
+	lines = append(lines, "")
+
+	titleWidth := max(d.width-40, 20)
+	end := min(d.offset+d.visibleRows(), len(d.drafts))
+	for i := d.offset; i < end; i++ {
+		dr := d.drafts[i]
+		check := "[x]"
+		if dr.Skip {
+			check = "[ ]"
+		}
+		indent := ""
+		if dr.Parent != "" {
+			indent = "  "
+		}
+		title := ansi.Truncate(dr.Title, titleWidth-len(indent), "...")
+		row := fmt.Sprintf("%s %s%s", check, indent, title)
+		meta := fmt.Sprintf(" %s P%d", dr.Type, dr.Priority)
+
+		cursor := "  "
+		style := normalStyle
+		if dr.Skip {
+			style = dimStyle
+		}
+		if i == d.cursor {
+			cursor = selectedStyle.Render("> ")
+			style = selectedStyle
+		}
+		line := cursor + style.Render(row) + dimStyle.Render(meta)
+		if dr.Duplicate != "" {
+			line += warnStyle.Render("  duplicate of " + dr.Duplicate)
+		}
+		lines = append(lines, line)
+	}
+	if len(d.drafts) > end {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  … %d more", len(d.drafts)-end)))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,85 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/importer"
+)
+
+func testDrafts() []importer.Draft {
+	return []importer.Draft{
+		{Ref: "1", Title: "Ship importer", Type: data.TypeEpic, Priority: data.PriorityHigh},
+		{Ref: "2", Title: "Parse CSV", Type: data.TypeTask, Parent: "1"},
+		{Ref: "3", Title: "Fix login", Type: data.TypeBug, Duplicate: "mg-7", Skip: true},
+	}
+}
+
+func TestImportDialogCancel(t *testing.T) {
+	d := NewImportDialog("plan.md", testDrafts(), 80, 24)
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if cmd == nil {
+		t.Fatal("expected cmd from esc")
+	}
+	if result := cmd().(ImportDialogResult); !result.Cancelled {
+		t.Fatal("expected Cancelled=true")
+	}
+}
+
+func TestImportDialogToggleAndConfirm(t *testing.T) {
+	drafts := testDrafts()
+	d := NewImportDialog("plan.md", drafts, 80, 24)
+	if got := d.Selected(); got != 2 {
+		t.Fatalf("Selected() = %d, want 2", got)
+	}
+
+	d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	d, _ = d.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	d, _ = d.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	d, _ = d.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if got := d.Selected(); got != 2 {
+		t.Fatalf("Selected() after toggles = %d, want 2", got)
+	}
+	if drafts[0].Skip {
+		t.Error("toggling must not modify the caller's drafts")
+	}
+
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd from enter")
+	}
+	result := cmd().(ImportDialogResult)
+	if result.Cancelled {
+		t.Fatal("expected Cancelled=false")
+	}
+	if !result.Drafts[0].Skip || result.Drafts[1].Skip || result.Drafts[2].Skip {
+		t.Errorf("Skip flags = %v %v %v, want true false false",
+			result.Drafts[0].Skip, result.Drafts[1].Skip, result.Drafts[2].Skip)
+	}
+}
+
+func TestImportDialogCursorBounds(t *testing.T) {
+	d := NewImportDialog("plan.md", testDrafts(), 80, 24)
+	d, _ = d.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if d.cursor != 0 {
+		t.Errorf("cursor = %d after k at top", d.cursor)
+	}
+	for range 5 {
+		d, _ = d.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	}
+	if d.cursor != 2 {
+		t.Errorf("cursor = %d after j past bottom, want 2", d.cursor)
+	}
+}
+
+func TestImportDialogView(t *testing.T) {
+	d := NewImportDialog("plan.md", testDrafts(), 100, 24)
+	view := d.View()
+	for _, want := range []string{"IMPORT: plan.md", "2 of 3", "Ship importer", "[ ] Fix login", "duplicate of mg-7"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
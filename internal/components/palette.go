@@ -42,6 +42,20 @@ const (
 	ActionCascadeClose
 	ActionCycleLayout
 	ActionRecoverRigs
+	ActionCreateWorktree
+	ActionPruneWorktrees
+	ActionOpenPR
+	ActionImport
+	ActionNewFromTemplate
+	ActionBulkEdit
+	ActionToggleTimeline
+	ActionCalendar
+	ActionToggleCharts
+	ActionAutoLayout
+	ActionGrowParade
+	ActionShrinkParade
+	ActionCycleTheme
+	ActionToggleAccessible
 )
 
 // PaletteCommand is a single entry in the command palette.
@@ -138,6 +152,26 @@ func (p Palette) Update(msg tea.Msg) (Palette, tea.Cmd) {
 		}
 	}
 
+	switch msg := msg.(type) {
+	case tea.MouseWheelMsg:
+		if msg.Mouse().Button == tea.MouseWheelUp {
+			p.moveUp()
+		} else {
+			p.moveDown()
+		}
+		return p, nil
+	case tea.MouseClickMsg:
+		idx := p.commandAt(msg.Mouse().X, msg.Mouse().Y)
+		if idx < 0 {
+			return p, nil
+		}
+		p.cursor = idx
+		action := p.filtered[idx].Action
+		return p, func() tea.Msg {
+			return PaletteResult{Action: action}
+		}
+	}
+
 	// Forward to text input
 	oldVal := p.input.Value()
 	var cmd tea.Cmd
@@ -195,8 +229,34 @@ func (p Palette) SelectedName() string {
 	return ""
 }
 
+// paletteListTop is the row of the first command within the box: border,
+// padding, title, blank line, input and separator.
+const paletteListTop = 6
+
+// commandAt returns the filtered index of the command drawn at screen cell
+// (x, y), or -1 when the cell is outside the list.
+func (p Palette) commandAt(x, y int) int {
+	box := p.box()
+	w, h := lipgloss.Width(box), lipgloss.Height(box)
+	left, top := (p.width-w)/2, (p.height-h)/2
+	if x < left || x >= left+w {
+		return -1
+	}
+	row := y - top - paletteListTop
+	idx := p.scrollOffset + row
+	if row < 0 || row >= paletteMaxVisible || idx >= len(p.filtered) {
+		return -1
+	}
+	return idx
+}
+
 // View renders the command palette overlay.
 func (p Palette) View() string {
+	return lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, p.box())
+}
+
+// box renders the palette frame before it is centered on screen.
+func (p Palette) box() string {
 	contentWidth := p.width - 8
 	if contentWidth > 60 {
 		contentWidth = 60
@@ -212,7 +272,7 @@ func (p Palette) View() string {
 	inputLine := p.input.View()
 
 	// Separator
-	sep := lipgloss.NewStyle().Foreground(ui.DimPurple).Render(strings.Repeat("─", contentWidth))
+	sep := lipgloss.NewStyle().Foreground(ui.DimPurple).Render(strings.Repeat(ui.BoxHorizontal, contentWidth))
 
 	// Command list
 	visible := p.filtered
@@ -280,7 +340,6 @@ func (p Palette) View() string {
 		hint,
 	)
 
-	box := ui.HelpOverlayBg.Width(contentWidth + 4).Render(content)
-
-	return lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, box)
+	// Width counts the border and padding, so rows stay on one line.
+	return ui.HelpOverlayBg.Width(contentWidth + 6).Render(content)
 }
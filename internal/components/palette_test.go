@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func testCommands() []PaletteCommand {
@@ -264,3 +265,39 @@ func TestPaletteViewNotEmpty(t *testing.T) {
 		t.Fatal("expected view to contain 'COMMAND PALETTE'")
 	}
 }
+
+func TestPaletteClickRunsCommand(t *testing.T) {
+	cmds := testCommands()
+	p := NewPalette(80, 30, cmds)
+
+	for y, line := range strings.Split(p.View(), "\n") {
+		x := strings.Index(ansi.Strip(line), "Close issue")
+		if x < 0 {
+			continue
+		}
+		_, cmd := p.Update(tea.MouseClickMsg{X: ansi.StringWidth(ansi.Strip(line)[:x]), Y: y, Button: tea.MouseLeft})
+		if cmd == nil {
+			t.Fatal("clicking a command should run it")
+		}
+		if result := cmd().(PaletteResult); result.Action != ActionCloseIssue {
+			t.Errorf("action = %d, want ActionCloseIssue", result.Action)
+		}
+		if _, cmd := p.Update(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft}); cmd != nil {
+			t.Error("clicking outside the list should do nothing")
+		}
+		return
+	}
+	t.Fatal("Close issue not rendered")
+}
+
+func TestPaletteWheelMovesCursor(t *testing.T) {
+	p := NewPalette(80, 30, testCommands())
+	p, _ = p.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if p.cursor != 1 {
+		t.Errorf("wheel down cursor = %d, want 1", p.cursor)
+	}
+	p, _ = p.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if p.cursor != 0 {
+		t.Errorf("wheel up cursor = %d, want 0", p.cursor)
+	}
+}
@@ -0,0 +1,237 @@
+// Package config loads mg's layered configuration. Built-in defaults are
+// overridden by a user-level file under $XDG_CONFIG_HOME/mardi-gras/, then a
+// project file (.mardi-gras.yaml) beside the .beads directory, then MG_*
+// environment variables, then command-line flags.
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the per-project config file, placed next to .beads/.
+const ProjectFileName = ".mardi-gras.yaml"
+
+// Config is the merged mg configuration.
+type Config struct {
+	// Hooks maps hook event names (e.g. "issue_closed") to shell commands.
+	Hooks map[string]string
+
+	// Keys maps key binding names (e.g. "parade.down") to comma-separated
+	// key lists that replace the built-in keys.
+	Keys map[string]string
+
+	// Unknown lists top-level keys in a config file that mg does not recognise.
+	Unknown []string
+
+	values      map[string]Value // effective settings, by key
+	hookOrigins map[string]Value // where each hook command came from
+	keyOrigins  map[string]Value // where each key binding came from
+}
+
+// fileConfig is the on-disk shape of a config file.
+type fileConfig struct {
+	Hooks    map[string]string    `yaml:"hooks"`
+	Keys     map[string]yaml.Node `yaml:"keys"`
+	Settings map[string]yaml.Node `yaml:",inline"`
+}
+
+// UserPath returns the user-level config file path, honouring
+// $XDG_CONFIG_HOME and falling back to ~/.config.
+func UserPath() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "mardi-gras", "config.yaml")
+}
+
+// ThemeDir returns the directory holding user theme files, beside the
+// user-level config file.
+func ThemeDir() string {
+	path := UserPath()
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(path), "themes")
+}
+
+// StatePath returns the path of a file mg keeps between sessions, honouring
+// $XDG_STATE_HOME and falling back to ~/.local/state.
+func StatePath(name string) string {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(base, "mardi-gras", name)
+}
+
+// SessionPath returns the state file for projectDir's UI session, named
+// after a hash of the directory so each project keeps its own.
+func SessionPath(projectDir string) string {
+	if projectDir == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		projectDir = abs
+	}
+	sum := sha256.Sum256([]byte(projectDir))
+	return StatePath(filepath.Join("sessions", hex.EncodeToString(sum[:8])+".json"))
+}
+
+// ProjectPath returns the project config file path for projectDir.
+func ProjectPath(projectDir string) string {
+	if projectDir == "" {
+		return ""
+	}
+	return filepath.Join(projectDir, ProjectFileName)
+}
+
+// Load reads the user and project config files and environment overrides
+// and merges them over the built-in defaults. Missing or unreadable files are
+// skipped; a malformed file or invalid value is reported as an error
+// alongside whatever was loaded successfully. Flags are layered on
+// afterwards with ApplyFlags.
+func Load(projectDir string) (Config, error) {
+	var cfg Config
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	layers := []struct {
+		path   string
+		origin Origin
+	}{
+		{UserPath(), OriginUser},
+		{ProjectPath(projectDir), OriginProject},
+	}
+	for _, l := range layers {
+		layer, err := readFile(l.path)
+		if err != nil {
+			keep(err)
+			continue
+		}
+		keep(cfg.merge(layer, l.origin, l.path))
+	}
+	keep(cfg.applyEnv(os.Getenv))
+	return cfg, firstErr
+}
+
+// readFile parses a single config file. A missing file yields a zero config.
+func readFile(path string) (fileConfig, error) {
+	var fc fileConfig
+	if path == "" {
+		return fc, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fc, nil
+		}
+		return fc, err
+	}
+	if err := yaml.Unmarshal(raw, &fc); err != nil {
+		return fileConfig{}, &ParseError{Path: path, Err: err}
+	}
+	return fc, nil
+}
+
+// merge overlays a file layer onto c. Hooks merge per event and key
+// bindings per binding, so a project file can override a single user hook
+// or binding without repeating the rest.
+func (c *Config) merge(fc fileConfig, origin Origin, path string) error {
+	for event, cmd := range fc.Hooks {
+		if c.Hooks == nil {
+			c.Hooks = make(map[string]string)
+			c.hookOrigins = make(map[string]Value)
+		}
+		c.Hooks[event] = cmd
+		c.hookOrigins[event] = Value{Raw: cmd, Origin: origin, From: path}
+	}
+
+	var firstErr error
+	names := make([]string, 0, len(fc.Keys))
+	for name := range fc.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		raw, ok := scalarString(fc.Keys[name])
+		if !ok {
+			if firstErr == nil {
+				firstErr = &ValueError{Key: "keys." + name, Origin: origin, Err: fmt.Errorf("want a key or list of keys")}
+			}
+			continue
+		}
+		if c.Keys == nil {
+			c.Keys = make(map[string]string)
+			c.keyOrigins = make(map[string]Value)
+		}
+		c.Keys[name] = raw
+		c.keyOrigins[name] = Value{Raw: raw, Origin: origin, From: path}
+	}
+
+	keys := make([]string, 0, len(fc.Settings))
+	for key := range fc.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node := fc.Settings[key]
+		s, ok := lookupSetting(key)
+		if !ok {
+			c.Unknown = append(c.Unknown, key)
+			continue
+		}
+		raw, ok := scalarString(node)
+		if !ok {
+			if firstErr == nil {
+				firstErr = &ValueError{Key: key, Origin: origin, Err: fmt.Errorf("want a scalar or list")}
+			}
+			continue
+		}
+		if err := c.set(s, raw, origin, path); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// HookValue returns a hook's command and the file it came from.
+func (c Config) HookValue(event string) Value {
+	return c.hookOrigins[event]
+}
+
+// KeyValue returns a key binding override and the file it came from.
+func (c Config) KeyValue(name string) Value {
+	return c.keyOrigins[name]
+}
+
+// ParseError reports a config file that could not be decoded.
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return "config " + filepath.Base(e.Path) + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserPathHonoursXDG(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got, want := UserPath(), filepath.Join("/xdg", "mardi-gras", "config.yaml"); got != want {
+		t.Errorf("UserPath() = %q, want %q", got, want)
+	}
+}
+
+func TestThemeDirBesideUserConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got, want := ThemeDir(), filepath.Join("/xdg", "mardi-gras", "themes"); got != want {
+		t.Errorf("ThemeDir() = %q, want %q", got, want)
+	}
+}
+
+func TestStatePathHonoursXDG(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	if got, want := StatePath("layout.json"), filepath.Join("/state", "mardi-gras", "layout.json"); got != want {
+		t.Errorf("StatePath() = %q, want %q", got, want)
+	}
+}
+
+func TestSessionPathIsPerProject(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	a, b := SessionPath("/work/a"), SessionPath("/work/b")
+	if a == b {
+		t.Errorf("SessionPath() = %q for both projects", a)
+	}
+	if dir := filepath.Join("/state", "mardi-gras", "sessions"); filepath.Dir(a) != dir {
+		t.Errorf("SessionPath() = %q, want a file in %s", a, dir)
+	}
+	if got := SessionPath(""); got != "" {
+		t.Errorf("SessionPath(\"\") = %q, want empty", got)
+	}
+}
+
+func TestLoadMergesHooks(t *testing.T) {
+	xdg := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	writeFile(t, filepath.Join(xdg, "mardi-gras", "config.yaml"), "hooks:\n  issue_closed: user-closed\n  agent_stuck: user-stuck\n")
+	writeFile(t, filepath.Join(project, ProjectFileName), "hooks:\n  issue_closed: project-closed\n")
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Hooks["issue_closed"]; got != "project-closed" {
+		t.Errorf("issue_closed = %q, want project-closed", got)
+	}
+	if got := cfg.Hooks["agent_stuck"]; got != "user-stuck" {
+		t.Errorf("agent_stuck = %q, want user-stuck", got)
+	}
+}
+
+func TestLoadMergesKeys(t *testing.T) {
+	xdg := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	writeFile(t, filepath.Join(xdg, "mardi-gras", "config.yaml"), "keys:\n  global.quit: Q\n  parade.down: [n, down]\n")
+	writeFile(t, filepath.Join(project, ProjectFileName), "keys:\n  global.quit: ctrl+q\n")
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Keys["global.quit"]; got != "ctrl+q" {
+		t.Errorf("global.quit = %q, want ctrl+q", got)
+	}
+	if got := cfg.Keys["parade.down"]; got != "n,down" {
+		t.Errorf("parade.down = %q, want n,down", got)
+	}
+	if got := cfg.KeyValue("parade.down"); got.Origin != OriginUser {
+		t.Errorf("parade.down origin = %v, want user", got.Origin)
+	}
+	if len(cfg.Unknown) != 0 {
+		t.Errorf("Unknown = %v, want keys recognised", cfg.Unknown)
+	}
+}
+
+func TestLoadMissingFiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Hooks) != 0 {
+		t.Errorf("Hooks = %v, want empty", cfg.Hooks)
+	}
+}
+
+func TestLoadMalformedProjectKeepsUser(t *testing.T) {
+	xdg := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	writeFile(t, filepath.Join(xdg, "mardi-gras", "config.yaml"), "hooks:\n  issue_closed: user-closed\n")
+	writeFile(t, filepath.Join(project, ProjectFileName), "hooks: [unterminated\n")
+
+	cfg, err := Load(project)
+	if err == nil {
+		t.Fatal("Load() error = nil, want parse error")
+	}
+	if cfg.Hooks["issue_closed"] != "user-closed" {
+		t.Errorf("user hooks lost: %v", cfg.Hooks)
+	}
+}
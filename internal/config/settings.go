@@ -0,0 +1,305 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Origin names the layer an effective setting came from.
+type Origin string
+
+const (
+	OriginDefault Origin = "default"
+	OriginUser    Origin = "user"
+	OriginProject Origin = "project"
+	OriginEnv     Origin = "env"
+	OriginFlag    Origin = "flag"
+)
+
+// kind selects how a raw setting value is validated.
+type kind int
+
+const (
+	kindString kind = iota
+	kindList
+	kindInt
+	kindBool
+	kindDuration
+	kindLayout
+)
+
+// Setting describes one configurable knob and its name in each layer.
+type Setting struct {
+	Key     string // YAML key and `mg config show` name
+	Env     string // environment variable
+	Flag    string // command-line flag; empty when the knob has no flag
+	Default string // built-in default, as a raw string
+	Help    string
+	kind    kind
+}
+
+// Setting keys.
+const (
+	KeyBlockTypes             = "block_types"
+	KeyExcludeTypes           = "exclude_types"
+	KeyCmdTimeout             = "cmd_timeout"
+	KeyNoAnimations           = "no_animations"
+	KeyLayout                 = "layout"
+	KeyTheme                  = "theme"
+	KeyAccessible             = "accessible"
+	KeyFocusUser              = "focus_user"
+	KeySectionRows            = "section_rows"
+	KeyWatchInterval          = "watch_interval"
+	KeyCLIPollInterval        = "cli_poll_interval"
+	KeyCLIHealthCheckInterval = "cli_health_check_interval"
+	KeyJSONLMaxAge            = "jsonl_max_age"
+	KeyDegradeAfter           = "degrade_after"
+	KeyRecoverAfter           = "recover_after"
+	KeyStaleAmber             = "stale_amber"
+	KeyStaleRed               = "stale_red"
+	KeyControlSocket          = "control_socket"
+)
+
+// Layouts lists the accepted values for the layout setting.
+var Layouts = []string{"auto", "default", "gastown", "wide", "stacked", "columns"}
+
+// Settings lists every setting in the order `mg config show` prints them.
+// Defaults mirror the built-in values in data and app.
+var Settings = []Setting{
+	{Key: KeyBlockTypes, Env: "MG_BLOCK_TYPES", Flag: "block-types", Default: "blocks,conditional-blocks", kind: kindList,
+		Help: "Comma-separated dependency types that count as blockers"},
+	{Key: KeyExcludeTypes, Env: "MG_EXCLUDE_TYPES", Flag: "exclude-type", kind: kindList,
+		Help: "Comma-separated issue types to hide from the parade and status output"},
+	{Key: KeyCmdTimeout, Env: "MG_CMD_TIMEOUT", Flag: "cmd-timeout", Default: "30", kind: kindInt,
+		Help: "Command timeout in seconds (scales all external command timeouts)"},
+	{Key: KeyNoAnimations, Env: "MG_NO_ANIMATIONS", Flag: "no-animations", Default: "false", kind: kindBool,
+		Help: "Disable confetti and header shimmer animations"},
+	{Key: KeyLayout, Env: "MG_LAYOUT", Flag: "layout", Default: "auto", kind: kindLayout,
+		Help: "Layout preset: auto (follow terminal size), default, gastown, wide, stacked or columns"},
+	{Key: KeyTheme, Env: "MG_THEME", Flag: "theme", Default: "auto", kind: kindString,
+		Help: "Color theme: auto (follow terminal background), dark, light, high-contrast, mono, or a theme file in the themes directory"},
+	{Key: KeyAccessible, Env: "MG_ACCESSIBLE", Flag: "accessible", Default: "false", kind: kindBool,
+		Help: "ASCII symbols, text labels for color-coded state, line-oriented overlays and no animation"},
+	{Key: KeyFocusUser, Env: "MG_FOCUS_USER", Flag: "focus-user", kind: kindString,
+		Help: "Identity focus mode treats as yours (default: $USER, then git user.name)"},
+	{Key: KeySectionRows, Env: "MG_SECTION_ROWS", Default: "50", kind: kindInt,
+		Help: "Most issue rows a parade section shows before a \"N more\" row"},
+	{Key: KeyWatchInterval, Env: "MG_WATCH_INTERVAL", Default: "1.2s", kind: kindDuration},
+	{Key: KeyCLIPollInterval, Env: "MG_CLI_POLL_INTERVAL", Default: "5s", kind: kindDuration},
+	{Key: KeyCLIHealthCheckInterval, Env: "MG_CLI_HEALTH_CHECK_INTERVAL", Default: "15s", kind: kindDuration},
+	{Key: KeyJSONLMaxAge, Env: "MG_JSONL_MAX_AGE", Default: "1h0m0s", kind: kindDuration},
+	{Key: KeyDegradeAfter, Env: "MG_DEGRADE_AFTER", Default: "3", kind: kindInt},
+	{Key: KeyRecoverAfter, Env: "MG_RECOVER_AFTER", Default: "2", kind: kindInt},
+	{Key: KeyStaleAmber, Env: "MG_STALE_AMBER", Default: "30s", kind: kindDuration},
+	{Key: KeyStaleRed, Env: "MG_STALE_RED", Default: "2m0s", kind: kindDuration},
+	{Key: KeyControlSocket, Env: "MG_CONTROL_SOCKET", kind: kindString,
+		Help: `Control socket path; "off" disables it (default: per-project socket in $XDG_RUNTIME_DIR)`},
+}
+
+// lookupSetting returns the setting registered under key.
+func lookupSetting(key string) (Setting, bool) {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Value is the effective value of one setting and the layer it came from.
+type Value struct {
+	Raw    string `json:"value"`
+	Origin Origin `json:"source"`
+	From   string `json:"from,omitempty"` // config file, env var or flag name
+}
+
+// Label describes where the value came from, e.g. "env (MG_CMD_TIMEOUT)".
+func (v Value) Label() string {
+	if v.From != "" {
+		return string(v.Origin) + " (" + v.From + ")"
+	}
+	return string(v.Origin)
+}
+
+// ValueError reports a setting whose raw value failed validation. The
+// setting keeps the value from the layer below.
+type ValueError struct {
+	Key    string
+	Raw    string
+	Origin Origin
+	Err    error
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("config %s=%q (%s): %v", e.Key, e.Raw, e.Origin, e.Err)
+}
+
+func (e *ValueError) Unwrap() error { return e.Err }
+
+// validate checks raw against the setting's kind and returns it normalised.
+func (s Setting) validate(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	switch s.kind {
+	case kindList:
+		var parts []string
+		for _, p := range strings.Split(raw, ",") {
+			if p = strings.TrimSpace(strings.ToLower(p)); p != "" {
+				parts = append(parts, p)
+			}
+		}
+		return strings.Join(parts, ","), nil
+	case kindInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return "", errors.New("want a positive integer")
+		}
+		return strconv.Itoa(n), nil
+	case kindBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", errors.New("want true or false")
+		}
+		return strconv.FormatBool(b), nil
+	case kindDuration:
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return "", errors.New("want a positive duration such as 5s or 1h")
+		}
+		return d.String(), nil
+	case kindLayout:
+		lower := strings.ToLower(raw)
+		for _, l := range Layouts {
+			if lower == l {
+				return lower, nil
+			}
+		}
+		return "", fmt.Errorf("want one of %s", strings.Join(Layouts, ", "))
+	}
+	return raw, nil
+}
+
+// set validates raw and records it as the value of key from origin.
+func (c *Config) set(s Setting, raw string, origin Origin, from string) error {
+	norm, err := s.validate(raw)
+	if err != nil {
+		return &ValueError{Key: s.Key, Raw: raw, Origin: origin, Err: err}
+	}
+	if c.values == nil {
+		c.values = make(map[string]Value)
+	}
+	c.values[s.Key] = Value{Raw: norm, Origin: origin, From: from}
+	return nil
+}
+
+// applyEnv overlays settings from environment variables. Empty variables
+// are treated as unset.
+func (c *Config) applyEnv(getenv func(string) string) error {
+	var firstErr error
+	for _, s := range Settings {
+		raw := getenv(s.Env)
+		if raw == "" {
+			continue
+		}
+		if err := c.set(s, raw, OriginEnv, s.Env); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// ApplyFlags overlays every setting flag that was explicitly set on fs.
+// Flags left at their defaults do not override lower layers.
+func (c *Config) ApplyFlags(fs *flag.FlagSet) error {
+	var firstErr error
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range Settings {
+			if s.Flag == "" || s.Flag != f.Name {
+				continue
+			}
+			if err := c.set(s, f.Value.String(), OriginFlag, "--"+s.Flag); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	})
+	return firstErr
+}
+
+// RegisterFlags defines a flag on fs for every setting that has one. The
+// values are read back through ApplyFlags, so the returned pointers are not
+// needed.
+func RegisterFlags(fs *flag.FlagSet) {
+	for _, s := range Settings {
+		if s.Flag == "" {
+			continue
+		}
+		usage := s.Help
+		if s.Default != "" {
+			usage += " (default " + s.Default + ")"
+		}
+		switch s.kind {
+		case kindBool:
+			fs.Bool(s.Flag, false, usage)
+		case kindInt:
+			fs.Int(s.Flag, 0, usage)
+		default:
+			fs.String(s.Flag, "", usage)
+		}
+	}
+}
+
+// Value returns the effective value of key, falling back to its default.
+func (c Config) Value(key string) Value {
+	if v, ok := c.values[key]; ok {
+		return v
+	}
+	s, _ := lookupSetting(key)
+	return Value{Raw: s.Default, Origin: OriginDefault}
+}
+
+// String returns the effective raw value of key.
+func (c Config) String(key string) string {
+	return c.Value(key).Raw
+}
+
+// Int returns key as an integer, or 0 when unset.
+func (c Config) Int(key string) int {
+	n, _ := strconv.Atoi(c.String(key))
+	return n
+}
+
+// Bool returns key as a boolean.
+func (c Config) Bool(key string) bool {
+	b, _ := strconv.ParseBool(c.String(key))
+	return b
+}
+
+// Duration returns key as a duration, or 0 when unset.
+func (c Config) Duration(key string) time.Duration {
+	d, _ := time.ParseDuration(c.String(key))
+	return d
+}
+
+// scalarString flattens a YAML node into a raw setting value. Sequences are
+// joined with commas so lists can be written either way.
+func scalarString(n yaml.Node) (string, bool) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		return n.Value, true
+	case yaml.SequenceNode:
+		parts := make([]string, 0, len(n.Content))
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", false
+			}
+			parts = append(parts, item.Value)
+		}
+		return strings.Join(parts, ","), true
+	}
+	return "", false
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// clearEnv blanks every setting's env var so the host environment cannot leak in.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, s := range Settings {
+		t.Setenv(s.Env, "")
+	}
+}
+
+func TestSettingsDefaults(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, s := range Settings {
+		v := cfg.Value(s.Key)
+		if v.Origin != OriginDefault || v.Raw != s.Default {
+			t.Errorf("%s = %+v, want default %q", s.Key, v, s.Default)
+		}
+	}
+	if got := cfg.Duration(KeyWatchInterval); got != 1200*time.Millisecond {
+		t.Errorf("watch_interval = %v, want 1.2s", got)
+	}
+	if got := cfg.Int(KeyDegradeAfter); got != 3 {
+		t.Errorf("degrade_after = %d, want 3", got)
+	}
+}
+
+func TestSettingsLayering(t *testing.T) {
+	clearEnv(t)
+	xdg := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	userPath := filepath.Join(xdg, "mardi-gras", "config.yaml")
+	projectPath := filepath.Join(project, ProjectFileName)
+
+	writeFile(t, userPath, "layout: wide\ncmd_timeout: 45\nno_animations: true\nexclude_types: [Epic, chore]\n")
+	writeFile(t, projectPath, "layout: gastown\ncli_poll_interval: 10s\n")
+	t.Setenv("MG_CMD_TIMEOUT", "60")
+	t.Setenv("MG_CLI_POLL_INTERVAL", "8s")
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	if err := fs.Parse([]string{"--cmd-timeout", "90", "--no-animations=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.ApplyFlags(fs); err != nil {
+		t.Fatalf("ApplyFlags() error = %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		raw    string
+		origin Origin
+		from   string
+	}{
+		{KeyLayout, "gastown", OriginProject, projectPath},
+		{KeyExcludeTypes, "epic,chore", OriginUser, userPath},
+		{KeyCLIPollInterval, "8s", OriginEnv, "MG_CLI_POLL_INTERVAL"},
+		{KeyCmdTimeout, "90", OriginFlag, "--cmd-timeout"},
+		{KeyNoAnimations, "false", OriginFlag, "--no-animations"},
+		{KeyBlockTypes, "blocks,conditional-blocks", OriginDefault, ""},
+	}
+	for _, tt := range tests {
+		got := cfg.Value(tt.key)
+		if got.Raw != tt.raw || got.Origin != tt.origin || got.From != tt.from {
+			t.Errorf("%s = %+v, want %q from %s %q", tt.key, got, tt.raw, tt.origin, tt.from)
+		}
+	}
+}
+
+func TestSettingsUnsetFlagsDoNotOverride(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("MG_LAYOUT", "wide")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, _ := Load("")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	_ = fs.Parse(nil)
+	if err := cfg.ApplyFlags(fs); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Value(KeyLayout); got.Raw != "wide" || got.Origin != OriginEnv {
+		t.Errorf("layout = %+v, want wide from env", got)
+	}
+}
+
+func TestSettingsInvalidValueKeepsLowerLayer(t *testing.T) {
+	clearEnv(t)
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	writeFile(t, filepath.Join(xdg, "mardi-gras", "config.yaml"), "stale_red: 5m\n")
+	t.Setenv("MG_STALE_RED", "soon")
+
+	cfg, err := Load("")
+	var verr *ValueError
+	if !errors.As(err, &verr) || verr.Key != KeyStaleRed {
+		t.Fatalf("Load() error = %v, want ValueError for stale_red", err)
+	}
+	if got := cfg.Duration(KeyStaleRed); got != 5*time.Minute {
+		t.Errorf("stale_red = %v, want 5m from user file", got)
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		key     string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{KeyCmdTimeout, "60", "60", false},
+		{KeyCmdTimeout, "0", "", true},
+		{KeyCmdTimeout, "abc", "", true},
+		{KeyNoAnimations, "1", "true", false},
+		{KeyNoAnimations, "maybe", "", true},
+		{KeyJSONLMaxAge, "90m", "1h30m0s", false},
+		{KeyJSONLMaxAge, "-1s", "", true},
+		{KeyLayout, "Wide", "wide", false},
+		{KeyLayout, "sideways", "", true},
+		{KeyBlockTypes, " blocks, ,Related ", "blocks,related", false},
+		{KeyFocusUser, "alice", "alice", false},
+	}
+	for _, tt := range tests {
+		s, _ := lookupSetting(tt.key)
+		got, err := s.validate(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("validate(%s=%q) = %q, %v; want %q, err=%v", tt.key, tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadReportsUnknownKeys(t *testing.T) {
+	clearEnv(t)
+	project := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	writeFile(t, filepath.Join(project, ProjectFileName), "zeta: 1\nalpha: 2\nlayout: wide\n")
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Unknown) != 2 || cfg.Unknown[0] != "alpha" || cfg.Unknown[1] != "zeta" {
+		t.Errorf("Unknown = %v, want [alpha zeta]", cfg.Unknown)
+	}
+}
+
+func TestHookValueOrigin(t *testing.T) {
+	clearEnv(t)
+	xdg := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	writeFile(t, filepath.Join(xdg, "mardi-gras", "config.yaml"), "hooks:\n  agent_stuck: page\n")
+	writeFile(t, filepath.Join(project, ProjectFileName), "hooks:\n  issue_closed: notify\n")
+
+	cfg, _ := Load(project)
+	if got := cfg.HookValue("agent_stuck"); got.Origin != OriginUser {
+		t.Errorf("agent_stuck origin = %s, want user", got.Origin)
+	}
+	if got := cfg.HookValue("issue_closed"); got.Origin != OriginProject || got.Raw != "notify" {
+		t.Errorf("issue_closed = %+v, want notify from project", got)
+	}
+}
@@ -0,0 +1,222 @@
+// Package control exposes a running mg over a unix socket so editors and
+// scripts can steer it. The protocol is newline-delimited JSON: each line a
+// client writes is a Request, and mg answers each with one Response line.
+// Requests reach the BubbleTea program as Msg values via Program.Send.
+package control
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Commands understood by the control socket.
+const (
+	CmdSelect    = "select"    // Arg: issue ID
+	CmdFilter    = "filter"    // Arg: filter query; empty clears it
+	CmdFocus     = "focus"     // Arg: "on", "off" or "" to toggle
+	CmdLayout    = "layout"    // Arg: a preset name, "auto" or "next"
+	CmdRefresh   = "refresh"   // reload issues now
+	CmdSelection = "selection" // report the current selection
+)
+
+// Commands lists every command in help order.
+var Commands = []string{CmdSelect, CmdFilter, CmdFocus, CmdLayout, CmdRefresh, CmdSelection}
+
+// replyTimeout bounds how long the server waits for the program to answer.
+var replyTimeout = 2 * time.Second
+
+// Request is one command from a client.
+type Request struct {
+	Cmd string `json:"cmd"`
+	Arg string `json:"arg,omitempty"`
+}
+
+// Response answers one Request.
+type Response struct {
+	OK        bool       `json:"ok"`
+	Error     string     `json:"error,omitempty"`
+	Selection *Selection `json:"selection,omitempty"`
+}
+
+// Selection describes what mg is showing after a command ran.
+type Selection struct {
+	ID     string `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	Filter string `json:"filter"`
+	Focus  bool   `json:"focus"`
+	Layout string `json:"layout"`
+}
+
+// Msg carries a Request into the BubbleTea program. The program must call
+// Reply exactly once.
+type Msg struct {
+	Request
+	reply chan Response
+}
+
+// NewMsg wraps req with a buffered reply channel. Exposed for tests.
+func NewMsg(req Request) (Msg, <-chan Response) {
+	ch := make(chan Response, 1)
+	return Msg{Request: req, reply: ch}, ch
+}
+
+// Reply sends the response back to the waiting client. It never blocks.
+func (m Msg) Reply(r Response) {
+	if m.reply == nil {
+		return
+	}
+	select {
+	case m.reply <- r:
+	default:
+	}
+}
+
+// Fail replies with an error response.
+func (m Msg) Fail(format string, args ...any) {
+	m.Reply(Response{Error: fmt.Sprintf(format, args...)})
+}
+
+// ErrInUse reports that another mg instance already owns the socket.
+var ErrInUse = errors.New("control socket in use by another mg")
+
+// SocketPath returns the default socket path for projectDir. It lives in
+// $XDG_RUNTIME_DIR when set, else the temp dir, and is keyed by a hash of the
+// project path so each project gets its own socket.
+func SocketPath(projectDir string) string {
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		projectDir = abs
+	}
+	sum := sha256.Sum256([]byte(projectDir))
+	name := fmt.Sprintf("mg-%d-%s.sock", os.Getuid(), hex.EncodeToString(sum[:6]))
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, name)
+}
+
+// Server accepts control connections on a unix socket.
+type Server struct {
+	path string
+	ln   net.Listener
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Listen opens the socket at path. A leftover socket from a crashed mg is
+// removed; a live one yields ErrInUse.
+func Listen(path string) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, 200*time.Millisecond); err == nil {
+			conn.Close()
+			return nil, ErrInUse
+		}
+		_ = os.Remove(path)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return &Server{path: path, ln: ln}, nil
+}
+
+// Path returns the socket path.
+func (s *Server) Path() string { return s.path }
+
+// Serve accepts connections until Close, forwarding each request to send.
+func (s *Server) Serve(send func(tea.Msg)) {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn, send)
+	}
+}
+
+// Close stops the server and removes the socket file.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.ln.Close()
+	_ = os.Remove(s.path)
+	return err
+}
+
+func (s *Server) handle(conn net.Conn, send func(tea.Msg)) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req Request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			_ = enc.Encode(Response{Error: "invalid request: " + err.Error()})
+			continue
+		}
+		_ = enc.Encode(dispatch(req, send))
+	}
+}
+
+// dispatch validates req, hands it to the program and waits for the reply.
+func dispatch(req Request, send func(tea.Msg)) Response {
+	if !known(req.Cmd) {
+		return Response{Error: fmt.Sprintf("unknown command %q", req.Cmd)}
+	}
+	msg, reply := NewMsg(req)
+	send(msg)
+	select {
+	case resp := <-reply:
+		return resp
+	case <-time.After(replyTimeout):
+		return Response{Error: "mg did not respond"}
+	}
+}
+
+func known(cmd string) bool {
+	for _, c := range Commands {
+		if c == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// Call sends one request to the socket at path and returns the response.
+func Call(path string, req Request) (Response, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return Response{}, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(replyTimeout + time.Second))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return Response{}, err
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return Response{}, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,129 @@
+package control
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// shortSocket returns a socket path short enough for the unix path limit.
+func shortSocket(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "mgc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+// echoProgram answers every request with its command as the selection ID.
+func echoProgram(msg tea.Msg) {
+	m := msg.(Msg)
+	go m.Reply(Response{OK: true, Selection: &Selection{ID: m.Cmd + ":" + m.Arg}})
+}
+
+func startServer(t *testing.T, send func(tea.Msg)) string {
+	t.Helper()
+	path := shortSocket(t)
+	srv, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	go srv.Serve(send)
+	return path
+}
+
+func TestCallRoundTrip(t *testing.T) {
+	path := startServer(t, echoProgram)
+	resp, err := Call(path, Request{Cmd: CmdSelect, Arg: "mg-1"})
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if !resp.OK || resp.Selection == nil || resp.Selection.ID != "select:mg-1" {
+		t.Errorf("Call() = %+v, want echoed select", resp)
+	}
+}
+
+func TestUnknownCommandRejectedByServer(t *testing.T) {
+	path := startServer(t, func(tea.Msg) { t.Error("unknown command reached the program") })
+	resp, err := Call(path, Request{Cmd: "explode"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OK || !strings.Contains(resp.Error, "unknown command") {
+		t.Errorf("Call() = %+v, want unknown command error", resp)
+	}
+}
+
+func TestNoReplyTimesOut(t *testing.T) {
+	old := replyTimeout
+	replyTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { replyTimeout = old })
+
+	path := startServer(t, func(tea.Msg) {})
+	resp, err := Call(path, Request{Cmd: CmdRefresh})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OK || resp.Error != "mg did not respond" {
+		t.Errorf("Call() = %+v, want timeout error", resp)
+	}
+}
+
+func TestListenReplacesStaleSocket(t *testing.T) {
+	path := shortSocket(t)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a crash: the file remains but nothing accepts.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+
+	srv, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen() over stale socket error = %v", err)
+	}
+	srv.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Close() should remove the socket file")
+	}
+}
+
+func TestListenRefusesLiveSocket(t *testing.T) {
+	path := startServer(t, echoProgram)
+	if _, err := Listen(path); err != ErrInUse {
+		t.Errorf("Listen() error = %v, want ErrInUse", err)
+	}
+}
+
+func TestSocketPathPerProject(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	a, b := SocketPath("/src/a"), SocketPath("/src/b")
+	if a == b {
+		t.Error("different projects should get different sockets")
+	}
+	if a != SocketPath("/src/a") {
+		t.Error("SocketPath should be stable")
+	}
+	if filepath.Dir(a) != "/run/user/1000" {
+		t.Errorf("SocketPath() = %q, want under XDG_RUNTIME_DIR", a)
+	}
+}
+
+func TestReplyNeverBlocks(t *testing.T) {
+	msg, reply := NewMsg(Request{Cmd: CmdSelection})
+	msg.Reply(Response{OK: true})
+	msg.Reply(Response{OK: false}) // second reply is dropped
+	if r := <-reply; !r.OK {
+		t.Errorf("first reply lost: %+v", r)
+	}
+	Msg{}.Reply(Response{}) // zero Msg is a no-op
+}
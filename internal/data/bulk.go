@@ -0,0 +1,42 @@
+package data
+
+import "sync"
+
+// bulkWorkers bounds how many bd calls RunBulk has in flight.
+const bulkWorkers = 4
+
+// BulkResult is the outcome of a bulk operation for one issue.
+type BulkResult struct {
+	IssueID string
+	Err     error
+}
+
+// RunBulk calls fn for every ID, at most bulkWorkers at a time, and returns
+// the results in the order of ids.
+func RunBulk(ids []string, fn func(issueID string) error) []BulkResult {
+	results := make([]BulkResult, len(ids))
+	sem := make(chan struct{}, bulkWorkers)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = BulkResult{IssueID: id, Err: fn(id)}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// BulkFailures counts the results that failed.
+func BulkFailures(results []BulkResult) int {
+	n := 0
+	for _, r := range results {
+		if r.Err != nil {
+			n++
+		}
+	}
+	return n
+}
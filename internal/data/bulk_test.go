@@ -0,0 +1,72 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBulk(t *testing.T) {
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("mg-%d", i)
+	}
+	var inFlight, peak atomic.Int32
+	results := RunBulk(ids, func(id string) error {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		if id == "mg-3" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if len(results) != len(ids) {
+		t.Fatalf("got %d results, want %d", len(results), len(ids))
+	}
+	for i, r := range results {
+		if r.IssueID != ids[i] {
+			t.Errorf("results[%d].IssueID = %s, want %s", i, r.IssueID, ids[i])
+		}
+	}
+	if results[3].Err == nil || BulkFailures(results) != 1 {
+		t.Errorf("failures = %d, results[3].Err = %v", BulkFailures(results), results[3].Err)
+	}
+	if p := peak.Load(); p > bulkWorkers || p < 2 {
+		t.Errorf("peak concurrency = %d, want 2..%d", p, bulkWorkers)
+	}
+}
+
+func TestBulkMutationArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"remove label", func() error { return RemoveLabel("mg-1", "ui") }, "bd label remove mg-1 -- ui"},
+		{"due", func() error { return SetDueDate("mg-1", "2026-11-01") }, "bd update mg-1 --due=2026-11-01"},
+		{"clear defer", func() error { return SetDeferUntil("mg-1", "") }, "bd update mg-1 --defer="},
+		{"close reason", func() error { return CloseWithReason("mg-1", "won't fix") }, "bd close mg-1 --reason=won't fix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, restore := mockExecCapture(nil)
+			defer restore()
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprint((*calls)[0]); got != "["+tt.want+"]" {
+				t.Errorf("args = %s, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
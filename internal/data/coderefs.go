@@ -0,0 +1,198 @@
+package data
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits keep a scan of a large repo from flooding the detail pane.
+const (
+	maxCodeHits   = 50
+	maxCommitRefs = 20
+)
+
+// CodeHit is one line in the project tree that mentions an issue ID.
+type CodeHit struct {
+	Path string // relative to the project root
+	Line int
+	Text string // the matching line, trimmed
+}
+
+// CommitRef is a commit whose message mentions an issue ID.
+type CommitRef struct {
+	Hash    string // short hash
+	Subject string
+	Author  string
+	Date    time.Time
+}
+
+// BranchRef is a local branch whose name contains an issue ID.
+type BranchRef struct {
+	Name      string
+	Current   bool // checked out in the project worktree
+	Generated bool // matches BranchName for the issue
+}
+
+// CodeRefs collects every reference to one issue found in the project's
+// working tree and git history.
+type CodeRefs struct {
+	IssueID   string
+	Hits      []CodeHit
+	Commits   []CommitRef
+	Branches  []BranchRef
+	Truncated bool // more than maxCodeHits lines matched
+}
+
+// Empty reports whether no references were found.
+func (r *CodeRefs) Empty() bool {
+	return r == nil || len(r.Hits)+len(r.Commits)+len(r.Branches) == 0
+}
+
+// ScanCodeRefs searches projectDir for references to issue. File hits come
+// from git grep, which skips .gitignore'd files (including untracked ones) and
+// the .beads directory; commits come from git log and branches from the local
+// heads. A project that is not a git repository yields empty results, not an
+// error, and history lookups are best effort (a repo with no commits still
+// reports file hits).
+func ScanCodeRefs(projectDir string, issue Issue) (*CodeRefs, error) {
+	if err := ValidateIssueID(issue.ID); err != nil {
+		return nil, err
+	}
+	refs := &CodeRefs{IssueID: issue.ID}
+	if projectDir == "" {
+		return refs, nil
+	}
+
+	out, err := runWithTimeout(timeoutMedium, "git", "-C", projectDir,
+		"grep", "-n", "-I", "-w", "-F", "--untracked", "--exclude-standard",
+		"-e", issue.ID, "--", ".", ":(exclude).beads")
+	if err != nil && !noMatches(err) {
+		if notARepo(err) {
+			return refs, nil
+		}
+		return nil, err
+	}
+	refs.Hits, refs.Truncated = parseGrepHits(out)
+
+	out, err = runWithTimeout(timeoutMedium, "git", "-C", projectDir,
+		"log", "-E", "-i", "--grep="+idPattern(issue.ID),
+		"--max-count="+strconv.Itoa(maxCommitRefs),
+		"--format=%h%x1f%s%x1f%an%x1f%aI")
+	if err == nil {
+		refs.Commits = parseCommitRefs(out)
+	}
+
+	out, err = runWithTimeout(timeoutShort, "git", "-C", projectDir,
+		"for-each-ref", "--format=%(HEAD)%(refname:short)", "refs/heads/")
+	if err == nil {
+		refs.Branches = matchBranches(out, issue)
+	}
+	return refs, nil
+}
+
+// idPattern builds a git log regex matching id as a whole token.
+func idPattern(id string) string {
+	return `(^|[^[:alnum:]_])` + regexp.QuoteMeta(id) + `([^[:alnum:]_]|$)`
+}
+
+// noMatches reports git grep's "nothing found" exit status.
+func noMatches(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == 1
+}
+
+// notARepo reports git's "not a git repository" failure.
+func notARepo(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), "not a git repository")
+}
+
+// parseGrepHits parses `git grep -n` output (path:line:text).
+func parseGrepHits(out []byte) ([]CodeHit, bool) {
+	var hits []CodeHit
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		path, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		num, text, ok := strings.Cut(rest, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			continue
+		}
+		if len(hits) == maxCodeHits {
+			return hits, true
+		}
+		hits = append(hits, CodeHit{Path: path, Line: n, Text: strings.TrimSpace(text)})
+	}
+	return hits, false
+}
+
+// parseCommitRefs parses unit-separated git log records.
+func parseCommitRefs(out []byte) []CommitRef {
+	var commits []CommitRef
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[3])
+		commits = append(commits, CommitRef{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Author:  fields[2],
+			Date:    date,
+		})
+	}
+	return commits
+}
+
+// matchBranches picks the branches whose names contain the issue ID as a
+// whole token. Input lines are "<HEAD marker><name>" from for-each-ref.
+func matchBranches(out []byte, issue Issue) []BranchRef {
+	generated := BranchName(issue)
+	var branches []BranchRef
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		current := line[0] == '*'
+		name := strings.TrimSpace(line[1:])
+		if !containsToken(strings.ToLower(name), strings.ToLower(issue.ID)) {
+			continue
+		}
+		branches = append(branches, BranchRef{Name: name, Current: current, Generated: name == generated})
+	}
+	sort.SliceStable(branches, func(i, j int) bool {
+		return branches[i].Generated && !branches[j].Generated
+	})
+	return branches
+}
+
+// containsToken reports whether tok appears in s bounded by non-word runes.
+func containsToken(s, tok string) bool {
+	for start := 0; ; {
+		i := strings.Index(s[start:], tok)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(tok)
+		if (i == 0 || !isWordByte(s[i-1])) && (end == len(s) || !isWordByte(s[end])) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Ada", "GIT_AUTHOR_EMAIL=ada@example.com",
+		"GIT_COMMITTER_NAME=Ada", "GIT_COMMITTER_EMAIL=ada@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanCodeRefsInGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "-q", "-b", "main")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\n// TODO(mg-42): handle errors\n// mg-420 is unrelated\n")
+	writeTestFile(t, filepath.Join(dir, ".gitignore"), "build/\n")
+	writeTestFile(t, filepath.Join(dir, "build", "out.txt"), "mg-42 ignored\n")
+	writeTestFile(t, filepath.Join(dir, ".beads", "issues.jsonl"), `{"id":"mg-42"}`+"\n")
+	writeTestFile(t, filepath.Join(dir, "notes.md"), "untracked mention of mg-42\n")
+	gitRun(t, dir, "add", "main.go", ".gitignore")
+	gitRun(t, dir, "commit", "-q", "-m", "Start mg-42 error handling")
+	gitRun(t, dir, "commit", "-q", "--allow-empty", "-m", "Unrelated mg-420 work")
+
+	issue := Issue{ID: "mg-42", Title: "Handle errors", IssueType: TypeBug}
+	gitRun(t, dir, "branch", BranchName(issue))
+	gitRun(t, dir, "branch", "spike/mg-42")
+	gitRun(t, dir, "branch", "spike/mg-420")
+
+	refs, err := ScanCodeRefs(dir, issue)
+	if err != nil {
+		t.Fatalf("ScanCodeRefs() error = %v", err)
+	}
+
+	if len(refs.Hits) != 2 {
+		t.Fatalf("Hits = %+v, want main.go and untracked notes.md", refs.Hits)
+	}
+	if refs.Hits[0].Path != "main.go" || refs.Hits[0].Line != 3 || refs.Hits[0].Text != "// TODO(mg-42): handle errors" {
+		t.Errorf("Hits[0] = %+v", refs.Hits[0])
+	}
+	if refs.Hits[1].Path != "notes.md" {
+		t.Errorf("Hits[1] = %+v, want notes.md", refs.Hits[1])
+	}
+
+	if len(refs.Commits) != 1 || refs.Commits[0].Subject != "Start mg-42 error handling" || refs.Commits[0].Author != "Ada" {
+		t.Errorf("Commits = %+v", refs.Commits)
+	}
+	if refs.Commits[0].Hash == "" || refs.Commits[0].Date.IsZero() {
+		t.Errorf("commit missing hash/date: %+v", refs.Commits[0])
+	}
+
+	if len(refs.Branches) != 2 {
+		t.Fatalf("Branches = %+v, want generated + spike", refs.Branches)
+	}
+	if !refs.Branches[0].Generated || refs.Branches[0].Name != "fix/mg-42-handle-errors" {
+		t.Errorf("Branches[0] = %+v, want generated branch first", refs.Branches[0])
+	}
+}
+
+func TestScanCodeRefsNotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+	refs, err := ScanCodeRefs(t.TempDir(), Issue{ID: "mg-1"})
+	if err != nil {
+		t.Fatalf("ScanCodeRefs() error = %v", err)
+	}
+	if !refs.Empty() {
+		t.Errorf("refs = %+v, want empty", refs)
+	}
+}
+
+func TestScanCodeRefsRejectsBadID(t *testing.T) {
+	if _, err := ScanCodeRefs(t.TempDir(), Issue{ID: "--all"}); err == nil {
+		t.Error("expected invalid ID error")
+	}
+}
+
+func TestParseGrepHitsTruncates(t *testing.T) {
+	var out []byte
+	for i := 0; i < maxCodeHits+5; i++ {
+		out = append(out, "a.go:1:mg-1\n"...)
+	}
+	out = append(out, "garbage line\n"...)
+	hits, truncated := parseGrepHits(out)
+	if len(hits) != maxCodeHits || !truncated {
+		t.Errorf("len = %d truncated = %v, want %d true", len(hits), truncated, maxCodeHits)
+	}
+}
+
+func TestContainsToken(t *testing.T) {
+	tests := []struct {
+		s, tok string
+		want   bool
+	}{
+		{"fix/mg-42-title", "mg-42", true},
+		{"mg-42", "mg-42", true},
+		{"mg-420", "mg-42", false},
+		{"xmg-42", "mg-42", false},
+		{"mg-420/mg-42", "mg-42", true},
+	}
+	for _, tt := range tests {
+		if got := containsToken(tt.s, tt.tok); got != tt.want {
+			t.Errorf("containsToken(%q, %q) = %v, want %v", tt.s, tt.tok, got, tt.want)
+		}
+	}
+}
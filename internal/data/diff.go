@@ -0,0 +1,128 @@
+package data
+
+import (
+	"sort"
+	"time"
+)
+
+// ChangeType identifies the kind of transition reported by DiffSnapshots.
+type ChangeType string
+
+const (
+	ChangeCreated   ChangeType = "created"
+	ChangeStatus    ChangeType = "status_changed"
+	ChangeParade    ChangeType = "parade_changed"
+	ChangePriority  ChangeType = "priority_changed"
+	ChangeAssignee  ChangeType = "assignee_changed"
+	ChangeBlocked   ChangeType = "blocked"
+	ChangeUnblocked ChangeType = "unblocked"
+	ChangeClosed    ChangeType = "closed"
+	ChangeRemoved   ChangeType = "removed"
+)
+
+// ChangeEvent is a single issue transition between two snapshots.
+type ChangeEvent struct {
+	Type    ChangeType `json:"type"`
+	IssueID string     `json:"issue_id"`
+	Title   string     `json:"title,omitempty"`
+	From    string     `json:"from,omitempty"`
+	To      string     `json:"to,omitempty"`
+	At      time.Time  `json:"at"`
+}
+
+// IssueSnapshot holds the fields of an issue that the differ tracks.
+type IssueSnapshot struct {
+	Title    string
+	Status   Status
+	Priority Priority
+	Assignee string
+	Group    ParadeStatus
+	Blocked  bool
+}
+
+// Snapshot is a point-in-time view of an issue set keyed by issue ID.
+type Snapshot map[string]IssueSnapshot
+
+// TakeSnapshot captures the diffable state of every issue, including its
+// parade group and whether it is blocked under the given blocking types.
+func TakeSnapshot(issues []Issue, blockingTypes map[string]bool) Snapshot {
+	issueMap := BuildIssueMap(issues)
+	snap := make(Snapshot, len(issues))
+	for _, iss := range issueMap {
+		blocked := false
+		if iss.Status != StatusClosed {
+			blocked = iss.EvaluateDependencies(issueMap, blockingTypes).IsBlocked
+		}
+		snap[iss.ID] = IssueSnapshot{
+			Title:    iss.Title,
+			Status:   iss.Status,
+			Priority: iss.Priority,
+			Assignee: iss.Assignee,
+			Group:    iss.ParadeGroup(issueMap, blockingTypes),
+			Blocked:  blocked,
+		}
+	}
+	return snap
+}
+
+// DiffSnapshots returns the transitions from prev to next, ordered by issue ID.
+// An issue moving to closed reports ChangeClosed in place of ChangeStatus.
+func DiffSnapshots(prev, next Snapshot, at time.Time) []ChangeEvent {
+	ids := make([]string, 0, len(next)+len(prev))
+	for id := range next {
+		ids = append(ids, id)
+	}
+	for id := range prev {
+		if _, ok := next[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var events []ChangeEvent
+	for _, id := range ids {
+		old, existed := prev[id]
+		cur, exists := next[id]
+		ev := func(t ChangeType, from, to string) {
+			title := cur.Title
+			if !exists {
+				title = old.Title
+			}
+			events = append(events, ChangeEvent{Type: t, IssueID: id, Title: title, From: from, To: to, At: at})
+		}
+
+		switch {
+		case !existed:
+			ev(ChangeCreated, "", string(cur.Status))
+			continue
+		case !exists:
+			ev(ChangeRemoved, string(old.Status), "")
+			continue
+		}
+
+		if old.Status != cur.Status {
+			if cur.Status == StatusClosed {
+				ev(ChangeClosed, string(old.Status), string(cur.Status))
+			} else {
+				ev(ChangeStatus, string(old.Status), string(cur.Status))
+			}
+		}
+		if old.Group != cur.Group {
+			ev(ChangeParade, old.Group.String(), cur.Group.String())
+		}
+		if old.Priority != cur.Priority {
+			ev(ChangePriority, PriorityLabel(old.Priority), PriorityLabel(cur.Priority))
+		}
+		if old.Assignee != cur.Assignee {
+			ev(ChangeAssignee, old.Assignee, cur.Assignee)
+		}
+		if old.Blocked != cur.Blocked && cur.Status != StatusClosed {
+			if cur.Blocked {
+				ev(ChangeBlocked, "", "")
+			} else {
+				ev(ChangeUnblocked, "", "")
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func diffIssue(id string, status Status) Issue {
+	return Issue{ID: id, Title: id, Status: status, Priority: PriorityMedium, IssueType: TypeTask}
+}
+
+func eventTypes(events []ChangeEvent) []ChangeType {
+	out := make([]ChangeType, len(events))
+	for i, ev := range events {
+		out[i] = ev.Type
+	}
+	return out
+}
+
+func TestParadeStatusString(t *testing.T) {
+	tests := []struct {
+		p    ParadeStatus
+		want string
+	}{
+		{ParadeRolling, "rolling"},
+		{ParadeLinedUp, "lined_up"},
+		{ParadeStalled, "stalled"},
+		{ParadePastTheStand, "past_the_stand"},
+		{ParadeStatus(99), "unknown"},
+	}
+	for _, tc := range tests {
+		if got := tc.p.String(); got != tc.want {
+			t.Errorf("ParadeStatus(%d).String() = %q, want %q", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestTakeSnapshotBlocked(t *testing.T) {
+	blocker := diffIssue("a", StatusOpen)
+	blocked := diffIssue("b", StatusOpen)
+	blocked.Dependencies = []Dependency{{IssueID: "b", DependsOnID: "a", Type: "blocks"}}
+
+	snap := TakeSnapshot([]Issue{blocker, blocked}, DefaultBlockingTypes)
+	if !snap["b"].Blocked {
+		t.Error("expected b to be blocked")
+	}
+	if snap["b"].Group != ParadeStalled {
+		t.Errorf("b group = %v, want stalled", snap["b"].Group)
+	}
+	if snap["a"].Blocked {
+		t.Error("expected a to be unblocked")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	reprioritised := diffIssue("a", StatusOpen)
+	reprioritised.Priority = PriorityHigh
+	assigned := diffIssue("a", StatusOpen)
+	assigned.Assignee = "alice"
+
+	tests := []struct {
+		name string
+		prev []Issue
+		next []Issue
+		want []ChangeType
+	}{
+		{"no change", []Issue{diffIssue("a", StatusOpen)}, []Issue{diffIssue("a", StatusOpen)}, nil},
+		{"created", nil, []Issue{diffIssue("a", StatusOpen)}, []ChangeType{ChangeCreated}},
+		{"removed", []Issue{diffIssue("a", StatusOpen)}, nil, []ChangeType{ChangeRemoved}},
+		{
+			"started",
+			[]Issue{diffIssue("a", StatusOpen)},
+			[]Issue{diffIssue("a", StatusInProgress)},
+			[]ChangeType{ChangeStatus, ChangeParade},
+		},
+		{
+			"closed",
+			[]Issue{diffIssue("a", StatusInProgress)},
+			[]Issue{diffIssue("a", StatusClosed)},
+			[]ChangeType{ChangeClosed, ChangeParade},
+		},
+		{"priority", []Issue{diffIssue("a", StatusOpen)}, []Issue{reprioritised}, []ChangeType{ChangePriority}},
+		{"assignee", []Issue{diffIssue("a", StatusOpen)}, []Issue{assigned}, []ChangeType{ChangeAssignee}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events := DiffSnapshots(TakeSnapshot(tc.prev, nil), TakeSnapshot(tc.next, nil), at)
+			got := eventTypes(events)
+			if len(got) != len(tc.want) {
+				t.Fatalf("DiffSnapshots() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("event[%d] = %q, want %q", i, got[i], tc.want[i])
+				}
+				if !events[i].At.Equal(at) || events[i].IssueID != "a" {
+					t.Errorf("event[%d] = %+v, want issue a at %v", i, events[i], at)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffSnapshotsBlockedTransitions(t *testing.T) {
+	blocker := diffIssue("a", StatusOpen)
+	dependent := diffIssue("b", StatusOpen)
+	dependent.Dependencies = []Dependency{{IssueID: "b", DependsOnID: "a", Type: "blocks"}}
+
+	before := TakeSnapshot([]Issue{blocker, dependent}, DefaultBlockingTypes)
+	blocker.Status = StatusClosed
+	after := TakeSnapshot([]Issue{blocker, dependent}, DefaultBlockingTypes)
+
+	events := DiffSnapshots(before, after, time.Now())
+	var unblocked bool
+	for _, ev := range events {
+		if ev.IssueID == "b" && ev.Type == ChangeUnblocked {
+			unblocked = true
+		}
+		if ev.IssueID == "a" && (ev.Type == ChangeBlocked || ev.Type == ChangeUnblocked) {
+			t.Errorf("unexpected %s event for closed issue a", ev.Type)
+		}
+	}
+	if !unblocked {
+		t.Errorf("expected unblocked event for b, got %v", eventTypes(events))
+	}
+
+	back := DiffSnapshots(after, before, time.Now())
+	var blocked bool
+	for _, ev := range back {
+		if ev.IssueID == "b" && ev.Type == ChangeBlocked {
+			blocked = true
+		}
+	}
+	if !blocked {
+		t.Errorf("expected blocked event for b, got %v", eventTypes(back))
+	}
+}
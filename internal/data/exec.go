@@ -51,6 +51,21 @@ var execWithTimeout = func(timeout time.Duration, name string, args ...string) e
 	return exec.CommandContext(ctx, name, args...).Run()
 }
 
+// UseCommandRunner routes every external command this package runs through
+// run instead of starting a process. mg --demo uses it to serve an in-memory
+// project in place of bd.
+//
+// SAFETY: like SetCmdTimeout, call it before tea.NewProgram.Run.
+func UseCommandRunner(run func(name string, args ...string) ([]byte, error)) {
+	runWithTimeout = func(_ time.Duration, name string, args ...string) ([]byte, error) {
+		return run(name, args...)
+	}
+	execWithTimeout = func(_ time.Duration, name string, args ...string) error {
+		_, err := run(name, args...)
+		return err
+	}
+}
+
 // bdStderrError represents a structured JSON error from bd's stderr.
 type bdStderrError struct {
 	Error   string `json:"error"`
@@ -22,34 +22,31 @@ func ExcludeByType(issues []Issue, excludeTypes map[string]bool) []Issue {
 	return filtered
 }
 
+// TokenPredicates resolves filter tokens whose answer depends on state held
+// outside the issue, such as is:invalid (metadata schema) or pr:failing. Keys
+// are lowercase tokens. An is:/has: token without a predicate falls back to
+// free-text search; an unknown pr: token matches nothing.
+type TokenPredicates map[string]func(Issue) bool
+
 // FilterIssues returns a new slice of issues that match the search query.
-// It supports explicit tokens (type:bug, p1, priority:high) and fuzzy free-text
-// search on ID and Title. All tokens in the query must match (AND logic).
-func FilterIssues(issues []Issue, query string) []Issue {
+// It supports explicit tokens (type:bug, p1, priority:high, plus any supplied
+// predicates) and fuzzy free-text search on ID and Title. All tokens in the
+// query must match (AND logic).
+func FilterIssues(issues []Issue, query string, preds ...TokenPredicates) []Issue {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return issues
 	}
 
-	rawTokens := strings.Fields(query)
-	var structuredTokens []string
-	var freeTokens []string
-
-	for _, t := range rawTokens {
-		lower := strings.ToLower(t)
-		if isStructuredToken(lower) {
-			structuredTokens = append(structuredTokens, lower)
-		} else {
-			freeTokens = append(freeTokens, lower)
-		}
-	}
+	pred := mergePredicates(preds)
+	structuredTokens, freeTokens := splitTokens(query, pred)
 
 	// First pass: structured token filtering (exact match)
 	candidates := issues
 	if len(structuredTokens) > 0 {
 		var filtered []Issue
 		for _, issue := range candidates {
-			if matchesStructuredTokens(issue, structuredTokens) {
+			if matchesStructuredTokens(issue, structuredTokens, pred) {
 				filtered = append(filtered, issue)
 			}
 		}
@@ -65,11 +62,52 @@ func FilterIssues(issues []Issue, query string) []Issue {
 	return candidates
 }
 
+// mergePredicates flattens the optional predicate maps passed to the filters.
+func mergePredicates(preds []TokenPredicates) TokenPredicates {
+	switch len(preds) {
+	case 0:
+		return nil
+	case 1:
+		return preds[0]
+	}
+	merged := make(TokenPredicates)
+	for _, p := range preds {
+		for k, fn := range p {
+			merged[k] = fn
+		}
+	}
+	return merged
+}
+
+// splitTokens lowercases the query's tokens and splits them into structured
+// tokens and free text. An is:/has: token with no predicate behind it is
+// treated as free text rather than matching nothing.
+func splitTokens(query string, pred TokenPredicates) (structured, free []string) {
+	for _, t := range strings.Fields(query) {
+		lower := strings.ToLower(t)
+		unknown := pred[lower] == nil && (strings.HasPrefix(lower, "is:") || strings.HasPrefix(lower, "has:"))
+		if isStructuredToken(lower) && !unknown {
+			structured = append(structured, lower)
+		} else {
+			free = append(free, lower)
+		}
+	}
+	return structured, free
+}
+
+// isPredicateToken reports whether token is answered by a TokenPredicates entry.
+func isPredicateToken(token string) bool {
+	return strings.HasPrefix(token, "is:") || strings.HasPrefix(token, "has:") || strings.HasPrefix(token, "pr:")
+}
+
 // isStructuredToken returns true for tokens with explicit prefixes or priority shorthands.
 func isStructuredToken(token string) bool {
 	if strings.HasPrefix(token, "type:") || strings.HasPrefix(token, "priority:") {
 		return true
 	}
+	if isPredicateToken(token) {
+		return true
+	}
 	// Priority shorthands: p0, p1, p2, p3, p4
 	if len(token) == 2 && token[0] == 'p' && token[1] >= '0' && token[1] <= '4' {
 		return true
@@ -77,7 +115,7 @@ func isStructuredToken(token string) bool {
 	return false
 }
 
-func matchesStructuredTokens(issue Issue, tokens []string) bool {
+func matchesStructuredTokens(issue Issue, tokens []string, preds TokenPredicates) bool {
 	issueType := strings.ToLower(string(issue.IssueType))
 	issuePriorityLevel := issue.Priority
 	issuePriorityName := strings.ToLower(PriorityName(issue.Priority))
@@ -110,6 +148,11 @@ func matchesStructuredTokens(issue Issue, tokens []string) bool {
 
 		case token == issuePriorityLabel:
 			matched = true
+
+		case isPredicateToken(token):
+			if fn, ok := preds[token]; ok {
+				matched = fn(issue)
+			}
 		}
 
 		if !matched {
@@ -176,30 +219,20 @@ func fuzzyFilter(issues []Issue, query string) []Issue {
 
 // FilterIssuesWithHighlights returns filtered issues plus a map of issue ID → matched
 // character indices in the "ID + Title" search string. Used for rendering highlights.
-func FilterIssuesWithHighlights(issues []Issue, query string) (result []Issue, matchMap map[string][]int) {
+func FilterIssuesWithHighlights(issues []Issue, query string, preds ...TokenPredicates) (result []Issue, matchMap map[string][]int) {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return issues, nil
 	}
 
-	rawTokens := strings.Fields(query)
-	var structuredTokens []string
-	var freeTokens []string
-
-	for _, t := range rawTokens {
-		lower := strings.ToLower(t)
-		if isStructuredToken(lower) {
-			structuredTokens = append(structuredTokens, lower)
-		} else {
-			freeTokens = append(freeTokens, lower)
-		}
-	}
+	pred := mergePredicates(preds)
+	structuredTokens, freeTokens := splitTokens(query, pred)
 
 	candidates := issues
 	if len(structuredTokens) > 0 {
 		var filtered []Issue
 		for _, issue := range candidates {
-			if matchesStructuredTokens(issue, structuredTokens) {
+			if matchesStructuredTokens(issue, structuredTokens, pred) {
 				filtered = append(filtered, issue)
 			}
 		}
@@ -247,6 +247,9 @@ func TestIsStructuredToken(t *testing.T) {
 		{"p5", false},
 		{"login", false},
 		{"type", false},
+		{"is:invalid", true},
+		{"has:pr", true},
+		{"pr:failing", true},
 	}
 
 	for _, tt := range tests {
@@ -257,3 +260,47 @@ func TestIsStructuredToken(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterIssuesPredicates(t *testing.T) {
+	issues := []Issue{
+		{ID: "a-1", Title: "Alpha", IssueType: TypeBug},
+		{ID: "a-2", Title: "Beta", IssueType: TypeBug},
+		{ID: "a-3", Title: "Gamma", IssueType: TypeTask, Notes: "is:blocked upstream, see pr:gamma"},
+	}
+	preds := TokenPredicates{
+		"is:invalid": func(i Issue) bool { return i.ID != "a-2" },
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		preds []TokenPredicates
+		want  []string
+	}{
+		{"predicate", "is:invalid", []TokenPredicates{preds}, []string{"a-1", "a-3"}},
+		{"predicate and type", "is:invalid type:bug", []TokenPredicates{preds}, []string{"a-1"}},
+		{"case insensitive", "IS:INVALID", []TokenPredicates{preds}, []string{"a-1", "a-3"}},
+		{"unknown predicate is text", "is:blocked", []TokenPredicates{preds}, []string{"a-3"}},
+		{"unknown predicate and type", "has:pr type:bug", []TokenPredicates{preds}, nil},
+		{"no predicates is text", "is:invalid", nil, nil},
+		{"unknown pr token matches nothing", "pr:gamma", []TokenPredicates{preds}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterIssues(issues, tt.query, tt.preds...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterIssues(%q) returned %d issues, want %d", tt.query, len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].ID != tt.want[i] {
+					t.Errorf("FilterIssues(%q)[%d] = %q, want %q", tt.query, i, got[i].ID, tt.want[i])
+				}
+			}
+
+			hl, _ := FilterIssuesWithHighlights(issues, tt.query, tt.preds...)
+			if len(hl) != len(tt.want) {
+				t.Errorf("FilterIssuesWithHighlights(%q) returned %d issues, want %d", tt.query, len(hl), len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package data
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// FlowPoint is the parade breakdown at the end of one day.
+type FlowPoint struct {
+	Day    time.Time
+	Counts [4]int // indexed by ParadeStatus
+}
+
+// Total returns the number of issues that existed at the end of the day.
+func (p FlowPoint) Total() int {
+	return p.Counts[ParadeRolling] + p.Counts[ParadeLinedUp] + p.Counts[ParadeStalled] + p.Counts[ParadePastTheStand]
+}
+
+// dayEnds returns the end of each of the last days days, oldest first. The
+// last entry is now rather than midnight.
+func dayEnds(days int, now time.Time) []time.Time {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	ends := make([]time.Time, days)
+	for i := range ends {
+		ends[i] = today.AddDate(0, 0, i-days+2)
+	}
+	if days > 0 {
+		ends[days-1] = now
+	}
+	return ends
+}
+
+// startedAt is when issue began rolling: StartedAt, or UpdatedAt for an
+// in-progress issue bd did not stamp. Nil means it never started.
+func startedAt(issue *Issue) *time.Time {
+	if issue.StartedAt != nil {
+		return issue.StartedAt
+	}
+	if issue.Status == StatusInProgress {
+		return &issue.UpdatedAt
+	}
+	return nil
+}
+
+// statusAt replays issue's parade section at t from its timestamps. A
+// blocker counts while it existed and was not yet closed. It reports false
+// when the issue did not exist yet.
+func statusAt(issue *Issue, issueMap map[string]*Issue, blockingTypes map[string]bool, t time.Time) (ParadeStatus, bool) {
+	if issue.CreatedAt.After(t) {
+		return 0, false
+	}
+	if issue.ClosedAt != nil && !issue.ClosedAt.After(t) {
+		return ParadePastTheStand, true
+	}
+	for _, dep := range issue.Dependencies {
+		if !blockingTypes[dep.Type] {
+			continue
+		}
+		blocker := issueMap[dep.DependsOnID]
+		if blocker != nil && !blocker.CreatedAt.After(t) && (blocker.ClosedAt == nil || blocker.ClosedAt.After(t)) {
+			return ParadeStalled, true
+		}
+	}
+	if started := startedAt(issue); started != nil && !started.After(t) {
+		return ParadeRolling, true
+	}
+	return ParadeLinedUp, true
+}
+
+// CumulativeFlow returns the parade breakdown at the end of each of the last
+// days days, oldest first, replayed from creation, start and close times.
+func CumulativeFlow(issues []Issue, blockingTypes map[string]bool, days int, now time.Time) []FlowPoint {
+	issueMap := BuildIssueMap(issues)
+	ends := dayEnds(days, now)
+	points := make([]FlowPoint, len(ends))
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	for i, end := range ends {
+		points[i].Day = today.AddDate(0, 0, i-days+1)
+		for j := range issues {
+			if status, ok := statusAt(&issues[j], issueMap, blockingTypes, end); ok {
+				points[i].Counts[status]++
+			}
+		}
+	}
+	return points
+}
+
+// Burndown returns how many of the issues in scope were still open at the
+// end of each of the last days days, oldest first. Issues created later
+// join the count on the day they appear.
+func Burndown(issues []Issue, scope map[string]bool, days int, now time.Time) []int {
+	ends := dayEnds(days, now)
+	remaining := make([]int, len(ends))
+	for _, issue := range issues {
+		if !scope[issue.ID] {
+			continue
+		}
+		for i, end := range ends {
+			if !issue.CreatedAt.After(end) && (issue.ClosedAt == nil || issue.ClosedAt.After(end)) {
+				remaining[i]++
+			}
+		}
+	}
+	return remaining
+}
+
+// FlowTimes returns the lead time (created to closed) and cycle time
+// (started to closed) of every issue closed in the last days days. Issues
+// that never recorded a start have no cycle time.
+func FlowTimes(issues []Issue, days int, now time.Time) (lead, cycle []time.Duration) {
+	since := dayEnds(days, now)[0].AddDate(0, 0, -1)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.ClosedAt == nil || issue.ClosedAt.Before(since) || issue.ClosedAt.After(now) {
+			continue
+		}
+		lead = append(lead, issue.ClosedAt.Sub(issue.CreatedAt))
+		if issue.StartedAt != nil {
+			cycle = append(cycle, issue.ClosedAt.Sub(*issue.StartedAt))
+		}
+	}
+	return lead, cycle
+}
+
+// DurationBucket is one bar of a lead or cycle time histogram.
+type DurationBucket struct {
+	Label string
+	Max   time.Duration // exclusive upper bound
+}
+
+// DurationBuckets are the histogram bars, shortest first.
+var DurationBuckets = []DurationBucket{
+	{Label: "<1d", Max: 24 * time.Hour},
+	{Label: "1-2d", Max: 2 * 24 * time.Hour},
+	{Label: "2-4d", Max: 4 * 24 * time.Hour},
+	{Label: "4-7d", Max: 7 * 24 * time.Hour},
+	{Label: "1-2w", Max: 14 * 24 * time.Hour},
+	{Label: "2-4w", Max: 28 * 24 * time.Hour},
+	{Label: ">4w", Max: math.MaxInt64},
+}
+
+// Histogram counts durations per DurationBuckets entry.
+func Histogram(durations []time.Duration) []int {
+	counts := make([]int, len(DurationBuckets))
+	for _, d := range durations {
+		for i, b := range DurationBuckets {
+			if d < b.Max {
+				counts[i]++
+				break
+			}
+		}
+	}
+	return counts
+}
+
+// MedianDuration returns the middle value of durations, or zero when empty.
+func MedianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	return sorted[len(sorted)/2]
+}
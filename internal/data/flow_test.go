@@ -0,0 +1,87 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func flowFixture(now time.Time) []Issue {
+	ago := func(days int) *time.Time {
+		t := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &t
+	}
+	return []Issue{
+		{ID: "f-1", Status: StatusClosed, CreatedAt: *ago(10), StartedAt: ago(6), ClosedAt: ago(3)},
+		{ID: "f-2", Status: StatusInProgress, CreatedAt: *ago(8), StartedAt: ago(2)},
+		{ID: "f-3", Status: StatusOpen, CreatedAt: *ago(5),
+			Dependencies: []Dependency{{IssueID: "f-3", DependsOnID: "f-2", Type: "blocks"}}},
+		{ID: "f-4", Status: StatusOpen, CreatedAt: *ago(1)},
+	}
+}
+
+func TestCumulativeFlowReplaysStatus(t *testing.T) {
+	now := time.Date(2026, 3, 20, 15, 0, 0, 0, time.Local)
+	points := CumulativeFlow(flowFixture(now), DefaultBlockingTypes, 14, now)
+	if len(points) != 14 {
+		t.Fatalf("got %d points, want 14", len(points))
+	}
+	if !points[13].Day.Equal(time.Date(2026, 3, 20, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("last point day = %v, want today", points[13].Day)
+	}
+
+	today := points[13].Counts
+	want := [4]int{}
+	want[ParadePastTheStand] = 1
+	want[ParadeRolling] = 1
+	want[ParadeStalled] = 1
+	want[ParadeLinedUp] = 1
+	if today != want {
+		t.Errorf("today = %v, want %v", today, want)
+	}
+
+	// Four days ago f-1 was rolling, f-2 lined up and f-3 stalled on f-2.
+	past := points[9].Counts
+	if past[ParadeRolling] != 1 || past[ParadeLinedUp] != 1 || past[ParadeStalled] != 1 || past[ParadePastTheStand] != 0 {
+		t.Errorf("four days ago = %v", past)
+	}
+	if points[0].Total() != 0 {
+		t.Errorf("nothing existed 13 days ago, got %d", points[0].Total())
+	}
+}
+
+func TestBurndownCountsOpenScope(t *testing.T) {
+	now := time.Date(2026, 3, 20, 15, 0, 0, 0, time.Local)
+	scope := map[string]bool{"f-1": true, "f-2": true}
+	remaining := Burndown(flowFixture(now), scope, 14, now)
+	if remaining[13] != 1 {
+		t.Errorf("today = %d open, want 1", remaining[13])
+	}
+	if remaining[9] != 2 {
+		t.Errorf("four days ago = %d open, want 2", remaining[9])
+	}
+	if remaining[0] != 0 {
+		t.Errorf("before creation = %d open, want 0", remaining[0])
+	}
+}
+
+func TestFlowTimesAndHistogram(t *testing.T) {
+	now := time.Date(2026, 3, 20, 15, 0, 0, 0, time.Local)
+	lead, cycle := FlowTimes(flowFixture(now), 14, now)
+	if len(lead) != 1 || lead[0] != 7*24*time.Hour {
+		t.Fatalf("lead = %v, want [168h]", lead)
+	}
+	if len(cycle) != 1 || cycle[0] != 3*24*time.Hour {
+		t.Fatalf("cycle = %v, want [72h]", cycle)
+	}
+
+	counts := Histogram([]time.Duration{time.Hour, 30 * time.Hour, 3 * 24 * time.Hour, 60 * 24 * time.Hour})
+	want := []int{1, 1, 1, 0, 0, 0, 1}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("bucket %s = %d, want %d", DurationBuckets[i].Label, counts[i], want[i])
+		}
+	}
+	if got := MedianDuration([]time.Duration{3, 1, 2}); got != 2 {
+		t.Errorf("median = %v, want 2", got)
+	}
+}
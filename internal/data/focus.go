@@ -59,8 +59,12 @@ func FocusFilter(issues []Issue, blockingTypes map[string]bool) []Issue {
 	return result
 }
 
-// currentUser tries to determine the current user from environment or git config.
+// currentUser tries to determine the current user from config, the
+// environment or git config.
 func currentUser() string {
+	if focusUser != "" {
+		return focusUser
+	}
 	if user := os.Getenv("USER"); user != "" {
 		return user
 	}
@@ -52,6 +52,22 @@ const (
 	ParadePastTheStand                     // closed
 )
 
+// String returns the snake_case name of the parade group.
+func (p ParadeStatus) String() string {
+	switch p {
+	case ParadeRolling:
+		return "rolling"
+	case ParadeLinedUp:
+		return "lined_up"
+	case ParadeStalled:
+		return "stalled"
+	case ParadePastTheStand:
+		return "past_the_stand"
+	default:
+		return "unknown"
+	}
+}
+
 // Dependency represents a relationship between two issues.
 type Dependency struct {
 	IssueID     string `json:"issue_id"`
@@ -0,0 +1,156 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Metadata validation severities, derived from MetadataSchema.Mode.
+const (
+	SeverityWarn  = "warn"
+	SeverityError = "error"
+)
+
+// MetadataViolation is a single schema check that an issue's metadata failed.
+type MetadataViolation struct {
+	IssueID  string `json:"issue_id"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// Severity returns the violation severity implied by the schema mode:
+// "warn", "error", or "" when enforcement is off ("none" or unknown).
+func (s *MetadataSchema) Severity() string {
+	if s == nil {
+		return ""
+	}
+	switch s.Mode {
+	case SeverityWarn:
+		return SeverityWarn
+	case SeverityError:
+		return SeverityError
+	default:
+		return ""
+	}
+}
+
+// ValidateIssue checks issue metadata against the schema. It returns nil when
+// the schema is nil, enforcement is off, or every field is valid. Violations
+// are ordered by field name.
+func (s *MetadataSchema) ValidateIssue(issue Issue) []MetadataViolation {
+	severity := s.Severity()
+	if severity == "" {
+		return nil
+	}
+
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []MetadataViolation
+	for _, name := range names {
+		field := s.Fields[name]
+		val, present := issue.Metadata[name]
+		if !present || val == nil || val == "" {
+			if field.Required {
+				out = append(out, MetadataViolation{IssueID: issue.ID, Field: name, Message: "required field missing", Severity: severity})
+			}
+			continue
+		}
+		if msg := field.check(val); msg != "" {
+			out = append(out, MetadataViolation{IssueID: issue.ID, Field: name, Message: msg, Severity: severity})
+		}
+	}
+	return out
+}
+
+// ValidateIssues runs ValidateIssue over every issue and returns violations
+// keyed by issue ID. Issues without violations are omitted.
+func (s *MetadataSchema) ValidateIssues(issues []Issue) map[string][]MetadataViolation {
+	if s.Severity() == "" {
+		return nil
+	}
+	out := make(map[string][]MetadataViolation)
+	for _, issue := range issues {
+		if v := s.ValidateIssue(issue); len(v) > 0 {
+			out[issue.ID] = v
+		}
+	}
+	return out
+}
+
+// check validates a single non-empty value and returns a message, or "" if valid.
+func (f MetadataFieldSchema) check(val interface{}) string {
+	switch f.Type {
+	case MetaString:
+		if _, ok := val.(string); !ok {
+			return fmt.Sprintf("expected string, got %s", jsonKind(val))
+		}
+	case MetaBool:
+		if _, ok := val.(bool); !ok {
+			return fmt.Sprintf("expected bool, got %s", jsonKind(val))
+		}
+	case MetaEnum:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Sprintf("expected enum, got %s", jsonKind(val))
+		}
+		if len(f.Values) > 0 && !slices.Contains(f.Values, s) {
+			return fmt.Sprintf("%q not in %s", s, f.FieldTypeLabel())
+		}
+	case MetaInt, MetaFloat:
+		n, ok := toFloat(val)
+		if !ok {
+			return fmt.Sprintf("expected %s, got %s", f.Type, jsonKind(val))
+		}
+		if f.Type == MetaInt && n != math.Trunc(n) {
+			return fmt.Sprintf("expected int, got %s", compactFloat(n))
+		}
+		if f.Min != nil && n < *f.Min {
+			return fmt.Sprintf("%s below min %s", compactFloat(n), compactFloat(*f.Min))
+		}
+		if f.Max != nil && n > *f.Max {
+			return fmt.Sprintf("%s above max %s", compactFloat(n), compactFloat(*f.Max))
+		}
+	}
+	return ""
+}
+
+// toFloat converts JSON-decoded numbers to float64.
+func toFloat(val interface{}) (float64, bool) {
+	switch n := val.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// jsonKind names the JSON type of a decoded metadata value for messages.
+func jsonKind(val interface{}) string {
+	switch val.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case float64, float32, int, int64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", val)
+	}
+}
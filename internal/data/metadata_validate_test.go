@@ -0,0 +1,105 @@
+package data
+
+import "testing"
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestMetadataSchemaSeverity(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"none", ""},
+		{"", ""},
+		{"warn", SeverityWarn},
+		{"error", SeverityError},
+		{"bogus", ""},
+	}
+	for _, tt := range tests {
+		s := &MetadataSchema{Mode: tt.mode}
+		if got := s.Severity(); got != tt.want {
+			t.Errorf("Severity(mode=%q) = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+	var nilSchema *MetadataSchema
+	if got := nilSchema.Severity(); got != "" {
+		t.Errorf("nil Severity() = %q, want empty", got)
+	}
+}
+
+func TestValidateIssue(t *testing.T) {
+	schema := &MetadataSchema{
+		Mode: "error",
+		Fields: map[string]MetadataFieldSchema{
+			"team":     {Type: MetaString, Required: true},
+			"points":   {Type: MetaInt, Min: floatPtr(1), Max: floatPtr(13)},
+			"ratio":    {Type: MetaFloat, Max: floatPtr(1)},
+			"reviewed": {Type: MetaBool},
+			"tier":     {Type: MetaEnum, Values: []string{"gold", "silver"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		meta   map[string]interface{}
+		fields []string
+	}{
+		{"valid", map[string]interface{}{"team": "core", "points": 3.0, "ratio": 0.5, "reviewed": true, "tier": "gold"}, nil},
+		{"missing required", nil, []string{"team"}},
+		{"empty required", map[string]interface{}{"team": ""}, []string{"team"}},
+		{"wrong string type", map[string]interface{}{"team": 5.0}, []string{"team"}},
+		{"non-integer int", map[string]interface{}{"team": "x", "points": 2.5}, []string{"points"}},
+		{"below min", map[string]interface{}{"team": "x", "points": 0.0}, []string{"points"}},
+		{"above max", map[string]interface{}{"team": "x", "points": 21.0, "ratio": 1.5}, []string{"points", "ratio"}},
+		{"number as string", map[string]interface{}{"team": "x", "points": "3"}, []string{"points"}},
+		{"bad bool", map[string]interface{}{"team": "x", "reviewed": "yes"}, []string{"reviewed"}},
+		{"enum not allowed", map[string]interface{}{"team": "x", "tier": "bronze"}, []string{"tier"}},
+		{"optional absent", map[string]interface{}{"team": "x"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schema.ValidateIssue(Issue{ID: "mg-1", Metadata: tt.meta})
+			if len(got) != len(tt.fields) {
+				t.Fatalf("ValidateIssue() = %+v, want fields %v", got, tt.fields)
+			}
+			for i, v := range got {
+				if v.Field != tt.fields[i] {
+					t.Errorf("violation[%d].Field = %q, want %q", i, v.Field, tt.fields[i])
+				}
+				if v.IssueID != "mg-1" || v.Severity != SeverityError || v.Message == "" {
+					t.Errorf("violation[%d] = %+v", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateIssueModeNone(t *testing.T) {
+	schema := &MetadataSchema{
+		Mode:   "none",
+		Fields: map[string]MetadataFieldSchema{"team": {Type: MetaString, Required: true}},
+	}
+	if got := schema.ValidateIssue(Issue{ID: "mg-1"}); got != nil {
+		t.Errorf("ValidateIssue() with mode none = %+v, want nil", got)
+	}
+	if got := schema.ValidateIssues([]Issue{{ID: "mg-1"}}); got != nil {
+		t.Errorf("ValidateIssues() with mode none = %+v, want nil", got)
+	}
+}
+
+func TestValidateIssuesWarn(t *testing.T) {
+	schema := &MetadataSchema{
+		Mode:   "warn",
+		Fields: map[string]MetadataFieldSchema{"team": {Type: MetaString, Required: true}},
+	}
+	got := schema.ValidateIssues([]Issue{
+		{ID: "ok", Metadata: map[string]interface{}{"team": "core"}},
+		{ID: "bad"},
+	})
+	if len(got) != 1 || len(got["bad"]) != 1 {
+		t.Fatalf("ValidateIssues() = %+v, want one violation for bad", got)
+	}
+	if sev := got["bad"][0].Severity; sev != SeverityWarn {
+		t.Errorf("Severity = %q, want warn", sev)
+	}
+}
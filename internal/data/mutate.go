@@ -31,6 +31,15 @@ func CloseIssue(issueID string) error {
 	return execWithTimeout(timeoutShort, "bd", "close", issueID)
 }
 
+// CloseWithReason runs `bd close <id> --reason=<reason>`.
+func CloseWithReason(issueID, reason string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	reason = sanitizeText(reason, maxTextLen)
+	return execWithTimeout(timeoutShort, "bd", "close", issueID, "--reason="+reason)
+}
+
 // CloseAndClaimNext runs `bd close --claim-next --json <id>` and returns the
 // next claimed issue ID, if any.
 func CloseAndClaimNext(issueID string) (string, error) {
@@ -88,6 +97,37 @@ func UpdateTitle(issueID, title string) error {
 	return execWithTimeout(timeoutShort, "bd", "update", issueID, "--title="+title)
 }
 
+// UpdateDescription runs `bd update <id> --description=<body>`.
+func UpdateDescription(issueID, body string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	body = sanitizeText(body, maxTextLen)
+	return execWithTimeout(timeoutShort, "bd", "update", issueID, "--description="+body)
+}
+
+// UpdateAcceptance runs `bd update <id> --acceptance=<criteria>`.
+func UpdateAcceptance(issueID, criteria string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	criteria = sanitizeText(criteria, maxTextLen)
+	return execWithTimeout(timeoutShort, "bd", "update", issueID, "--acceptance="+criteria)
+}
+
+// SetMetadata runs `bd update <id> --metadata=<json>` to merge fields into
+// an issue's metadata.
+func SetMetadata(issueID string, fields map[string]any) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return execWithTimeout(timeoutShort, "bd", "update", issueID, "--metadata="+string(raw))
+}
+
 // AddComment runs `bd comments add <id> -- <body>` to add a comment to an issue.
 func AddComment(issueID, body string) error {
 	if err := ValidateIssueID(issueID); err != nil {
@@ -126,6 +166,31 @@ func AddLabel(issueID, label string) error {
 	return execWithTimeout(timeoutShort, "bd", "label", "add", issueID, "--", label)
 }
 
+// RemoveLabel runs `bd label remove <id> -- <label>` to remove a label from an issue.
+func RemoveLabel(issueID, label string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	label = sanitizeText(label, maxTextLen)
+	return execWithTimeout(timeoutShort, "bd", "label", "remove", issueID, "--", label)
+}
+
+// SetDueDate runs `bd update <id> --due=<date>`. An empty date clears it.
+func SetDueDate(issueID, date string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	return execWithTimeout(timeoutShort, "bd", "update", issueID, "--due="+sanitizeText(date, maxTextLen))
+}
+
+// SetDeferUntil runs `bd update <id> --defer=<date>`. An empty date clears it.
+func SetDeferUntil(issueID, date string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	return execWithTimeout(timeoutShort, "bd", "update", issueID, "--defer="+sanitizeText(date, maxTextLen))
+}
+
 // AddDependency runs `bd dep add <id> -- <depends-on-id>` to add a blocking dependency.
 func AddDependency(issueID, dependsOnID string) error {
 	if err := ValidateIssueID(issueID); err != nil {
@@ -137,6 +202,37 @@ func AddDependency(issueID, dependsOnID string) error {
 	return execWithTimeout(timeoutShort, "bd", "dep", "add", issueID, "--", dependsOnID)
 }
 
+// AddParent links childID under parentID with a parent-child dependency.
+func AddParent(childID, parentID string) error {
+	if err := ValidateIssueID(childID); err != nil {
+		return err
+	}
+	if err := ValidateIssueID(parentID); err != nil {
+		return err
+	}
+	return execWithTimeout(timeoutShort, "bd", "dep", "add", "--type=parent-child", childID, "--", parentID)
+}
+
+// RemoveDependency runs `bd dep remove <id> -- <depends-on-id>`, dropping any
+// dependency between the two, parent-child links included.
+func RemoveDependency(issueID, dependsOnID string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	if err := ValidateIssueID(dependsOnID); err != nil {
+		return err
+	}
+	return execWithTimeout(timeoutShort, "bd", "dep", "remove", issueID, "--", dependsOnID)
+}
+
+// DeleteIssue runs `bd delete <id> --force` to remove an issue permanently.
+func DeleteIssue(issueID string) error {
+	if err := ValidateIssueID(issueID); err != nil {
+		return err
+	}
+	return execWithTimeout(timeoutShort, "bd", "delete", issueID, "--force")
+}
+
 // BranchName generates a git branch name from an issue.
 func BranchName(issue Issue) string {
 	prefix := "feat"
@@ -308,6 +308,44 @@ func TestAddDependencyArgs(t *testing.T) {
 	}
 }
 
+func TestAddParentArgs(t *testing.T) {
+	calls, restore := mockExecCapture(nil)
+	defer restore()
+	if err := AddParent("mg-42", "mg-10"); err != nil {
+		t.Fatalf("AddParent() error = %v", err)
+	}
+	args := (*calls)[0]
+	// Should be: bd dep add --type=parent-child mg-42 -- mg-10
+	if len(args) != 7 || args[3] != "--type=parent-child" || args[4] != "mg-42" || args[5] != "--" || args[6] != "mg-10" {
+		t.Errorf("args = %v", args)
+	}
+	if err := AddParent("mg-42", "--bad"); err == nil {
+		t.Error("expected invalid parent ID error")
+	}
+}
+
+func TestRemoveDependencyAndDeleteArgs(t *testing.T) {
+	calls, restore := mockExecCapture(nil)
+	defer restore()
+	if err := RemoveDependency("mg-42", "mg-10"); err != nil {
+		t.Fatalf("RemoveDependency() error = %v", err)
+	}
+	if err := DeleteIssue("mg-42"); err != nil {
+		t.Fatalf("DeleteIssue() error = %v", err)
+	}
+	// Should be: bd dep remove mg-42 -- mg-10, then bd delete mg-42 --force
+	dep, del := (*calls)[0], (*calls)[1]
+	if len(dep) != 6 || dep[2] != "remove" || dep[3] != "mg-42" || dep[5] != "mg-10" {
+		t.Errorf("dep remove args = %v", dep)
+	}
+	if len(del) != 4 || del[1] != "delete" || del[2] != "mg-42" || del[3] != "--force" {
+		t.Errorf("delete args = %v", del)
+	}
+	if err := DeleteIssue("--all"); err == nil {
+		t.Error("expected invalid issue ID error")
+	}
+}
+
 func TestAddDependencyError(t *testing.T) {
 	_, restore := mockExecCapture(errors.New("not found"))
 	defer restore()
@@ -0,0 +1,185 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PR states as reported by gh.
+const (
+	PRStateOpen   = "OPEN"
+	PRStateMerged = "MERGED"
+	PRStateClosed = "CLOSED"
+)
+
+// CI rollup values for PullRequest.Checks.
+const (
+	ChecksPassing = "passing"
+	ChecksFailing = "failing"
+	ChecksPending = "pending"
+)
+
+// prListLimit bounds how many recent PRs gh returns.
+const prListLimit = 100
+
+// PullRequest is a GitHub pull request as reported by `gh pr list --json`.
+type PullRequest struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	State          string `json:"state"`
+	IsDraft        bool   `json:"isDraft"`
+	HeadRefName    string `json:"headRefName"`
+	URL            string `json:"url"`
+	ReviewDecision string `json:"reviewDecision"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED or ""
+	Checks         string `json:"-"`              // ChecksPassing, ChecksFailing, ChecksPending or ""
+
+	StatusCheckRollup []prCheck `json:"statusCheckRollup"`
+}
+
+// prCheck is one entry of statusCheckRollup: a CheckRun (status and
+// conclusion) or a StatusContext (state).
+type prCheck struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+// runGH executes gh in dir with a timeout and returns its stdout. gh finds
+// the repository from the working directory, so it cannot use runWithTimeout.
+var runGH = func(timeout time.Duration, dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd.Dir = dir
+	return cmd.Output()
+}
+
+// GHAvailable reports whether the gh CLI is on PATH.
+func GHAvailable() bool {
+	_, err := exec.LookPath("gh")
+	return err == nil
+}
+
+// FetchPullRequests lists the repository's most recent pull requests in any
+// state, with review decision and CI rollup.
+func FetchPullRequests(projectDir string) ([]PullRequest, error) {
+	out, err := runGH(timeoutMedium, projectDir, "pr", "list",
+		"--state", "all", "--limit", strconv.Itoa(prListLimit),
+		"--json", "number,title,body,state,isDraft,headRefName,url,reviewDecision,statusCheckRollup")
+	if err != nil {
+		return nil, wrapExitError("gh pr list", err)
+	}
+	return parsePullRequests(out)
+}
+
+// OpenPullRequest opens PR number in the browser via gh.
+func OpenPullRequest(projectDir string, number int) error {
+	_, err := runGH(timeoutShort, projectDir, "pr", "view", strconv.Itoa(number), "--web")
+	return wrapExitError("gh pr view", err)
+}
+
+func parsePullRequests(out []byte) ([]PullRequest, error) {
+	var prs []PullRequest
+	if err := json.Unmarshal(out, &prs); err != nil {
+		return nil, err
+	}
+	for i := range prs {
+		prs[i].Checks = checksRollup(prs[i].StatusCheckRollup)
+		prs[i].StatusCheckRollup = nil
+	}
+	return prs, nil
+}
+
+// checksRollup folds individual checks into one state: any failure wins,
+// then anything still running, else passing.
+func checksRollup(checks []prCheck) string {
+	if len(checks) == 0 {
+		return ""
+	}
+	pending := false
+	for _, c := range checks {
+		result := c.Conclusion
+		if result == "" {
+			result = c.State
+		}
+		switch result {
+		case "FAILURE", "ERROR", "CANCELLED", "TIMED_OUT", "ACTION_REQUIRED", "STARTUP_FAILURE":
+			return ChecksFailing
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+		default:
+			pending = true
+		}
+	}
+	if pending {
+		return ChecksPending
+	}
+	return ChecksPassing
+}
+
+// MatchPullRequests pairs issues with pull requests. A PR belongs to an issue
+// when its head branch is the issue's BranchName or contains the issue ID,
+// or else when its title or body mentions the ID. When several PRs match one
+// issue, open ones win over merged or closed, then the newest.
+func MatchPullRequests(prs []PullRequest, issues []Issue) map[string]*PullRequest {
+	if len(prs) == 0 {
+		return nil
+	}
+	sorted := make([]*PullRequest, len(prs))
+	for i := range prs {
+		sorted[i] = &prs[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		oi, oj := sorted[i].State == PRStateOpen, sorted[j].State == PRStateOpen
+		if oi != oj {
+			return oi
+		}
+		return sorted[i].Number > sorted[j].Number
+	})
+
+	matched := make(map[string]*PullRequest)
+	for _, issue := range issues {
+		id := strings.ToLower(issue.ID)
+		branch := BranchName(issue)
+		var byText *PullRequest
+		for _, pr := range sorted {
+			if pr.HeadRefName == branch || containsToken(strings.ToLower(pr.HeadRefName), id) {
+				matched[issue.ID] = pr
+				break
+			}
+			if byText == nil && (containsToken(strings.ToLower(pr.Title), id) || containsToken(strings.ToLower(pr.Body), id)) {
+				byText = pr
+			}
+		}
+		if _, ok := matched[issue.ID]; !ok && byText != nil {
+			matched[issue.ID] = byText
+		}
+	}
+	return matched
+}
+
+// Label describes the PR's state for display: open, draft, merged or closed.
+func (pr *PullRequest) Label() string {
+	if pr.State == PRStateOpen && pr.IsDraft {
+		return "draft"
+	}
+	return strings.ToLower(pr.State)
+}
+
+// ReviewLabel describes the review decision for display.
+func (pr *PullRequest) ReviewLabel() string {
+	switch pr.ReviewDecision {
+	case "APPROVED":
+		return "approved"
+	case "CHANGES_REQUESTED":
+		return "changes requested"
+	case "REVIEW_REQUIRED":
+		return "review required"
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mockGH(output []byte, err error) (calls *[][]string, restore func()) {
+	var c [][]string
+	orig := runGH
+	runGH = func(_ time.Duration, dir string, args ...string) ([]byte, error) {
+		c = append(c, append([]string{dir}, args...))
+		return output, err
+	}
+	return &c, func() { runGH = orig }
+}
+
+const prListJSON = `[
+  {"number": 12, "title": "Fix crash", "body": "", "state": "OPEN", "isDraft": false,
+   "headRefName": "fix/mg-1-crash", "url": "https://example.com/pr/12", "reviewDecision": "APPROVED",
+   "statusCheckRollup": [{"status": "COMPLETED", "conclusion": "SUCCESS"}, {"state": "FAILURE"}]},
+  {"number": 9, "title": "Docs", "body": "Closes mg-2", "state": "MERGED", "isDraft": false,
+   "headRefName": "docs", "url": "https://example.com/pr/9", "reviewDecision": "",
+   "statusCheckRollup": []}
+]`
+
+func TestFetchPullRequests(t *testing.T) {
+	calls, restore := mockGH([]byte(prListJSON), nil)
+	defer restore()
+
+	prs, err := FetchPullRequests("/proj")
+	if err != nil {
+		t.Fatalf("FetchPullRequests() error = %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("len = %d, want 2", len(prs))
+	}
+	if prs[0].Number != 12 || prs[0].Checks != ChecksFailing || prs[0].ReviewDecision != "APPROVED" {
+		t.Errorf("prs[0] = %+v", prs[0])
+	}
+	if prs[1].Checks != "" || prs[1].StatusCheckRollup != nil {
+		t.Errorf("prs[1] = %+v, want no checks", prs[1])
+	}
+	got := strings.Join((*calls)[0], " ")
+	if !strings.HasPrefix(got, "/proj pr list --state all") || !strings.Contains(got, "statusCheckRollup") {
+		t.Errorf("gh call = %q", got)
+	}
+}
+
+func TestFetchPullRequestsError(t *testing.T) {
+	_, restore := mockGH(nil, errors.New("not logged in"))
+	defer restore()
+	if _, err := FetchPullRequests("/proj"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestChecksRollup(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []prCheck
+		want   string
+	}{
+		{"none", nil, ""},
+		{"passing", []prCheck{{Conclusion: "SUCCESS"}, {Conclusion: "SKIPPED"}, {State: "SUCCESS"}}, ChecksPassing},
+		{"running", []prCheck{{Conclusion: "SUCCESS"}, {Status: "IN_PROGRESS"}}, ChecksPending},
+		{"status pending", []prCheck{{State: "PENDING"}}, ChecksPending},
+		{"failure wins", []prCheck{{Status: "IN_PROGRESS"}, {Conclusion: "TIMED_OUT"}}, ChecksFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checksRollup(tt.checks); got != tt.want {
+				t.Errorf("checksRollup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPullRequests(t *testing.T) {
+	issues := []Issue{
+		{ID: "mg-1", Title: "Crash", IssueType: TypeBug},
+		{ID: "mg-2", Title: "Docs"},
+		{ID: "mg-3", Title: "Branch beats text"},
+		{ID: "mg-4", Title: "Open beats merged"},
+		{ID: "mg-5", Title: "No PR"},
+	}
+	prs := []PullRequest{
+		{Number: 1, HeadRefName: "fix/mg-1-crash", State: PRStateOpen},
+		{Number: 2, HeadRefName: "docs", Body: "Closes mg-2", State: PRStateMerged},
+		{Number: 3, HeadRefName: "misc", Title: "mention mg-3", State: PRStateOpen},
+		{Number: 4, HeadRefName: "spike/mg-3", State: PRStateClosed},
+		{Number: 5, HeadRefName: "feat/mg-4-old", State: PRStateMerged},
+		{Number: 6, HeadRefName: "feat/mg-4-new", State: PRStateOpen},
+		{Number: 7, HeadRefName: "feat/mg-50", Title: "mg-50 only", State: PRStateOpen},
+	}
+	got := MatchPullRequests(prs, issues)
+	want := map[string]int{"mg-1": 1, "mg-2": 2, "mg-3": 4, "mg-4": 6}
+	for id, num := range want {
+		if pr := got[id]; pr == nil || pr.Number != num {
+			t.Errorf("%s matched %+v, want #%d", id, pr, num)
+		}
+	}
+	if pr := got["mg-5"]; pr != nil {
+		t.Errorf("mg-5 matched #%d, want none", pr.Number)
+	}
+}
+
+func TestPullRequestLabels(t *testing.T) {
+	pr := PullRequest{State: PRStateOpen, IsDraft: true, ReviewDecision: "CHANGES_REQUESTED"}
+	if pr.Label() != "draft" || pr.ReviewLabel() != "changes requested" {
+		t.Errorf("labels = %q, %q", pr.Label(), pr.ReviewLabel())
+	}
+	pr = PullRequest{State: PRStateMerged}
+	if pr.Label() != "merged" || pr.ReviewLabel() != "" {
+		t.Errorf("labels = %q, %q", pr.Label(), pr.ReviewLabel())
+	}
+}
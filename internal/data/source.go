@@ -3,6 +3,7 @@ package data
 import (
 	"encoding/json"
 	"fmt"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -37,6 +38,12 @@ func (s Source) Label() string {
 	return "issues.jsonl"
 }
 
+// BDAvailable reports whether the bd CLI is on PATH.
+func BDAvailable() bool {
+	_, err := exec.LookPath("bd")
+	return err == nil
+}
+
 // CheckBdVersion runs `bd --version` and returns a warning if the installed
 // version is known to be broken. Returns "" for any safe or unparseable version.
 func CheckBdVersion() string {
@@ -16,17 +16,14 @@ const (
 	HealthRecovering                    // CLI responding again; counting successes before declaring healthy.
 )
 
-// Thresholds for state machine transitions.
-const (
+// Thresholds for state machine transitions. Package vars so SetTuning can
+// override them from config.
+var (
 	degradeThreshold  = 3         // Consecutive failures to enter degraded.
 	recoverThreshold  = 2         // Consecutive CLI successes to recover from fallback.
 	jsonlMaxAge       = time.Hour // Refuse JSONL fallback if file is older than this.
 	stalenessAmberAge = 30 * time.Second
 	stalenessRedAge   = 2 * time.Minute
-
-	// DegradeThreshold is the number of consecutive failures before the source
-	// transitions to degraded state. Exported for use by the app package.
-	DegradeThreshold = degradeThreshold
 )
 
 // SourceHealth tracks the resilience state of the issue data source.
@@ -156,7 +153,7 @@ func FindJSONLPath(dir string) string {
 
 // ProbeJSONLFallback locates a JSONL file and validates it is fresh enough to
 // use as a fallback source. Returns ok=true only when the file exists and its
-// modification time is within jsonlMaxAge (1 hour).
+// modification time is within jsonlMaxAge (1 hour by default).
 func ProbeJSONLFallback(dir string) (path string, modTime time.Time, ok bool) {
 	path = FindJSONLPath(dir)
 	if path == "" {
@@ -0,0 +1,290 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template prefills a new issue. Title, Type and Priority seed the create
+// form; the rest is applied after `bd create` by CreateFromTemplate.
+type Template struct {
+	Name        string          `yaml:"name"`
+	Summary     string          `yaml:"summary"` // one line shown in the picker
+	Title       string          `yaml:"title"`   // initial title text, e.g. "Bug: "
+	Type        IssueType       `yaml:"type"`
+	Priority    *Priority       `yaml:"priority"`
+	Labels      []string        `yaml:"labels"`
+	Description string          `yaml:"description"`
+	Acceptance  string          `yaml:"acceptance"`
+	Metadata    map[string]any  `yaml:"metadata"`
+	Children    []TemplateChild `yaml:"children"`
+	File        string          `yaml:"-"` // source file, for error messages
+}
+
+// TemplateChild is a child issue created under the templated issue.
+type TemplateChild struct {
+	Title       string    `yaml:"title"`
+	Type        IssueType `yaml:"type"`     // defaults to task
+	Priority    *Priority `yaml:"priority"` // defaults to the parent's
+	Labels      []string  `yaml:"labels"`
+	Description string    `yaml:"description"`
+	DependsOn   []string  `yaml:"depends_on"` // titles of sibling children
+}
+
+// TemplatesDir returns where project templates live: .beads/templates,
+// following a .beads redirect.
+func TemplatesDir(projectDir string) string {
+	return filepath.Join(ResolveBeadsDir(filepath.Join(projectDir, ".beads")), "templates")
+}
+
+// LoadTemplates reads every *.yaml and *.yml file in TemplatesDir, sorted by
+// name. A missing directory yields no templates. Files that fail to parse
+// are skipped and reported in the returned error alongside the good ones.
+func LoadTemplates(projectDir string) ([]Template, error) {
+	if projectDir == "" {
+		return nil, nil
+	}
+	dir := TemplatesDir(projectDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var templates []Template
+	var errs []error
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		t, err := loadTemplate(filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		templates = append(templates, t)
+	}
+	sort.SliceStable(templates, func(i, j int) bool {
+		return strings.ToLower(templates[i].Name) < strings.ToLower(templates[j].Name)
+	})
+	return templates, errors.Join(errs...)
+}
+
+func loadTemplate(path string) (Template, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Template{}, err
+	}
+	var t Template
+	if err := yaml.Unmarshal(raw, &t); err != nil {
+		return Template{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	t.File = filepath.Base(path)
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(t.File, filepath.Ext(t.File))
+	}
+	if err := t.validate(); err != nil {
+		return Template{}, fmt.Errorf("%s: %w", t.File, err)
+	}
+	return t, nil
+}
+
+func (t Template) validate() error {
+	if t.Priority != nil && (*t.Priority < PriorityCritical || *t.Priority > PriorityBacklog) {
+		return fmt.Errorf("priority %d out of range 0-4", *t.Priority)
+	}
+	titles := make(map[string]bool, len(t.Children))
+	for i, c := range t.Children {
+		if strings.TrimSpace(c.Title) == "" {
+			return fmt.Errorf("child %d has no title", i+1)
+		}
+		titles[c.Title] = true
+	}
+	for _, c := range t.Children {
+		for _, dep := range c.DependsOn {
+			if !titles[dep] {
+				return fmt.Errorf("child %q depends on unknown child %q", c.Title, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// PrefillMetadata returns the template's metadata plus a starting value for
+// every field schema requires that the template leaves out: the first enum
+// value, false, the minimum (or 0) for numbers, and "" for strings.
+func (t Template) PrefillMetadata(schema *MetadataSchema) map[string]any {
+	out := make(map[string]any, len(t.Metadata))
+	for k, v := range t.Metadata {
+		out[k] = v
+	}
+	if schema == nil {
+		return out
+	}
+	for name, field := range schema.Fields {
+		if _, ok := out[name]; ok || !field.Required {
+			continue
+		}
+		switch field.Type {
+		case MetaEnum:
+			if len(field.Values) > 0 {
+				out[name] = field.Values[0]
+			} else {
+				out[name] = ""
+			}
+		case MetaBool:
+			out[name] = false
+		case MetaInt, MetaFloat:
+			if field.Min != nil {
+				out[name] = *field.Min
+			} else {
+				out[name] = 0
+			}
+		default:
+			out[name] = ""
+		}
+	}
+	return out
+}
+
+// FormatMetadata renders fields as "key=value, key=value", sorted by key.
+func FormatMetadata(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		v := fields[k]
+		if f, ok := toFloat(v); ok {
+			v = compactFloat(f)
+		}
+		parts[i] = fmt.Sprintf("%s=%v", k, v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseMetadata reads "key=value, key=value" back into fields, typing each
+// value by its schema field (int, float, bool; else string). Pairs with an
+// empty value are dropped.
+func ParseMetadata(s string, schema *MetadataSchema) (map[string]any, error) {
+	out := make(map[string]any)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("bad metadata %q (want key=value)", pair)
+		}
+		if val == "" {
+			continue
+		}
+		var field MetadataFieldSchema
+		if schema != nil {
+			field = schema.Fields[key]
+		}
+		switch field.Type {
+		case MetaInt:
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected int, got %q", key, val)
+			}
+			out[key] = n
+		case MetaFloat:
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected float, got %q", key, val)
+			}
+			out[key] = f
+		case MetaBool:
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected bool, got %q", key, val)
+			}
+			out[key] = b
+		default:
+			out[key] = val
+		}
+	}
+	return out, nil
+}
+
+// CreateFromTemplate creates an issue with title, type and priority, then
+// applies t: description, acceptance criteria, labels, metadata, and child
+// issues linked as parent-child with their sibling dependencies. It returns
+// the new issue ID once the issue exists; later failures are joined into the
+// error but do not stop the remaining steps.
+func CreateFromTemplate(t Template, title string, issueType IssueType, priority Priority, metadata map[string]any) (string, error) {
+	id, err := CreateIssue(title, issueType, priority)
+	if err != nil {
+		return "", err
+	}
+	var errs []error
+	try := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if t.Description != "" {
+		try(UpdateDescription(id, t.Description))
+	}
+	if t.Acceptance != "" {
+		try(UpdateAcceptance(id, t.Acceptance))
+	}
+	for _, label := range t.Labels {
+		try(AddLabel(id, label))
+	}
+	if len(metadata) > 0 {
+		try(SetMetadata(id, metadata))
+	}
+
+	childIDs := make(map[string]string, len(t.Children))
+	for _, c := range t.Children {
+		childType, childPriority := c.Type, priority
+		if childType == "" {
+			childType = TypeTask
+		}
+		if c.Priority != nil {
+			childPriority = *c.Priority
+		}
+		childID, err := CreateIssue(c.Title, childType, childPriority)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		childIDs[c.Title] = childID
+		try(AddParent(childID, id))
+		if c.Description != "" {
+			try(UpdateDescription(childID, c.Description))
+		}
+		for _, label := range c.Labels {
+			try(AddLabel(childID, label))
+		}
+	}
+	for _, c := range t.Children {
+		childID, ok := childIDs[c.Title]
+		if !ok {
+			continue
+		}
+		for _, dep := range c.DependsOn {
+			if depID, ok := childIDs[dep]; ok {
+				try(AddDependency(childID, depID))
+			}
+		}
+	}
+	return id, errors.Join(errs...)
+}
@@ -0,0 +1,158 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTemplate(t *testing.T, projectDir, name, body string) {
+	t.Helper()
+	dir := filepath.Join(projectDir, ".beads", "templates")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "spike.yml", "type: spike\npriority: 3\n")
+	writeTemplate(t, dir, "bug.yaml", `
+name: Bug report
+summary: Something is broken
+title: "Bug: "
+type: bug
+priority: 1
+labels: [bug, triage]
+description: |
+  ## Steps to reproduce
+metadata:
+  severity: major
+children:
+  - title: Write failing test
+  - title: Fix
+    depends_on: [Write failing test]
+`)
+	writeTemplate(t, dir, "broken.yaml", "children:\n  - title: A\n    depends_on: [B]\n")
+	writeTemplate(t, dir, "notes.txt", "ignored")
+
+	templates, err := LoadTemplates(dir)
+	if err == nil || !strings.Contains(err.Error(), `broken.yaml: child "A" depends on unknown child "B"`) {
+		t.Errorf("LoadTemplates() error = %v, want broken.yaml reported", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("got %d templates, want 2", len(templates))
+	}
+	bug, spike := templates[0], templates[1]
+	if bug.Name != "Bug report" || bug.Type != TypeBug || *bug.Priority != PriorityHigh || bug.File != "bug.yaml" {
+		t.Errorf("bug template = %+v", bug)
+	}
+	if len(bug.Children) != 2 || bug.Children[1].DependsOn[0] != "Write failing test" {
+		t.Errorf("bug children = %+v", bug.Children)
+	}
+	if spike.Name != "spike" || spike.Type != TypeSpike {
+		t.Errorf("spike template = %+v", spike)
+	}
+}
+
+func TestLoadTemplatesMissingDir(t *testing.T) {
+	templates, err := LoadTemplates(t.TempDir())
+	if err != nil || templates != nil {
+		t.Errorf("LoadTemplates() = %v, %v; want nil, nil", templates, err)
+	}
+}
+
+func TestPrefillMetadata(t *testing.T) {
+	one := 1.0
+	schema := &MetadataSchema{Mode: "error", Fields: map[string]MetadataFieldSchema{
+		"severity": {Type: MetaEnum, Required: true, Values: []string{"minor", "major"}},
+		"points":   {Type: MetaInt, Required: true, Min: &one},
+		"customer": {Type: MetaString, Required: true},
+		"flaky":    {Type: MetaBool, Required: true},
+		"optional": {Type: MetaString},
+	}}
+	tmpl := Template{Metadata: map[string]any{"severity": "major"}}
+	got := tmpl.PrefillMetadata(schema)
+	want := map[string]any{"severity": "major", "points": 1.0, "customer": "", "flaky": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PrefillMetadata() = %v, want %v", got, want)
+	}
+	if s := FormatMetadata(got); s != "customer=, flaky=false, points=1, severity=major" {
+		t.Errorf("FormatMetadata() = %q", s)
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	schema := &MetadataSchema{Fields: map[string]MetadataFieldSchema{
+		"points": {Type: MetaInt},
+		"ratio":  {Type: MetaFloat},
+		"flaky":  {Type: MetaBool},
+	}}
+	got, err := ParseMetadata("points=3, ratio=0.5, flaky=true, team=core, customer=", schema)
+	if err != nil {
+		t.Fatalf("ParseMetadata() error = %v", err)
+	}
+	want := map[string]any{"points": 3, "ratio": 0.5, "flaky": true, "team": "core"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMetadata() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"points=many", "flaky=maybe", "novalue"} {
+		if _, err := ParseMetadata(bad, schema); err == nil {
+			t.Errorf("ParseMetadata(%q) expected error", bad)
+		}
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	var created [][]string
+	origRun := runWithTimeout
+	runWithTimeout = func(_ time.Duration, name string, args ...string) ([]byte, error) {
+		created = append(created, args)
+		return []byte(fmt.Sprintf("mg-%d\n", len(created))), nil
+	}
+	defer func() { runWithTimeout = origRun }()
+	calls, restore := mockExecCapture(nil)
+	defer restore()
+
+	low := PriorityLow
+	tmpl := Template{
+		Description: "## Steps",
+		Labels:      []string{"bug"},
+		Children: []TemplateChild{
+			{Title: "Write failing test"},
+			{Title: "Fix", Type: TypeBug, Priority: &low, DependsOn: []string{"Write failing test"}},
+		},
+	}
+	id, err := CreateFromTemplate(tmpl, "Bug: crash", TypeBug, PriorityHigh, map[string]any{"severity": "major"})
+	if err != nil || id != "mg-1" {
+		t.Fatalf("CreateFromTemplate() = %q, %v", id, err)
+	}
+
+	if len(created) != 3 || created[1][2] != "--type=task" || created[1][3] != "--priority=1" ||
+		created[2][2] != "--type=bug" || created[2][3] != "--priority=3" {
+		t.Errorf("bd create calls = %v", created)
+	}
+	var got []string
+	for _, c := range *calls {
+		got = append(got, strings.Join(c[1:], " "))
+	}
+	want := []string{
+		"update mg-1 --description=## Steps",
+		"label add mg-1 -- bug",
+		`update mg-1 --metadata={"severity":"major"}`,
+		"dep add --type=parent-child mg-2 -- mg-1",
+		"dep add --type=parent-child mg-3 -- mg-1",
+		"dep add mg-3 -- mg-2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bd calls =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,62 @@
+package data
+
+import "time"
+
+// Tuning holds the poll intervals and health thresholds that can be overridden
+// from config. A zero field means "keep the current value".
+type Tuning struct {
+	WatchInterval          time.Duration // JSONL modtime poll
+	CLIPollInterval        time.Duration // bd list poll
+	CLIHealthCheckInterval time.Duration // recovery probe while in JSONL fallback
+	JSONLMaxAge            time.Duration // oldest issues.jsonl accepted as fallback
+	DegradeThreshold       int           // consecutive failures before degraded
+	RecoverThreshold       int           // consecutive successes to leave fallback
+	StaleAmber             time.Duration // refresh age shown in amber
+	StaleRed               time.Duration // refresh age shown in red
+}
+
+// CurrentTuning returns the intervals and thresholds in effect.
+func CurrentTuning() Tuning {
+	return Tuning{
+		WatchInterval:          watchInterval,
+		CLIPollInterval:        cliPollInterval,
+		CLIHealthCheckInterval: cliHealthCheckInterval,
+		JSONLMaxAge:            jsonlMaxAge,
+		DegradeThreshold:       degradeThreshold,
+		RecoverThreshold:       recoverThreshold,
+		StaleAmber:             stalenessAmberAge,
+		StaleRed:               stalenessRedAge,
+	}
+}
+
+// SetTuning overrides intervals and thresholds. Call it once at startup,
+// before any polling begins.
+func SetTuning(t Tuning) {
+	setDuration(&watchInterval, t.WatchInterval)
+	setDuration(&cliPollInterval, t.CLIPollInterval)
+	setDuration(&cliHealthCheckInterval, t.CLIHealthCheckInterval)
+	setDuration(&jsonlMaxAge, t.JSONLMaxAge)
+	setDuration(&stalenessAmberAge, t.StaleAmber)
+	setDuration(&stalenessRedAge, t.StaleRed)
+	if t.DegradeThreshold > 0 {
+		degradeThreshold = t.DegradeThreshold
+	}
+	if t.RecoverThreshold > 0 {
+		recoverThreshold = t.RecoverThreshold
+	}
+}
+
+func setDuration(dst *time.Duration, v time.Duration) {
+	if v > 0 {
+		*dst = v
+	}
+}
+
+// focusUser overrides the identity focus mode treats as "me".
+var focusUser string
+
+// SetFocusUser sets the identity focus mode uses for "my work". An empty name
+// restores the default ($USER, then git user.name).
+func SetFocusUser(name string) {
+	focusUser = name
+}
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetTuningOverridesAndKeepsZeroFields(t *testing.T) {
+	orig := CurrentTuning()
+	t.Cleanup(func() { SetTuning(orig) })
+
+	SetTuning(Tuning{CLIPollInterval: 10 * time.Second, DegradeThreshold: 5})
+	got := CurrentTuning()
+	if got.CLIPollInterval != 10*time.Second {
+		t.Errorf("CLIPollInterval = %v, want 10s", got.CLIPollInterval)
+	}
+	if got.DegradeThreshold != 5 {
+		t.Errorf("DegradeThreshold = %d, want 5", got.DegradeThreshold)
+	}
+	if got.WatchInterval != orig.WatchInterval || got.JSONLMaxAge != orig.JSONLMaxAge {
+		t.Errorf("zero fields changed: %+v", got)
+	}
+
+	h := SourceHealth{}
+	for range 4 {
+		h = h.RecordFailure(nil)
+	}
+	if h.State != HealthHealthy {
+		t.Errorf("state after 4 failures = %v, want healthy with threshold 5", h.State)
+	}
+	h = h.RecordFailure(nil)
+	if h.State != HealthDegraded {
+		t.Errorf("state after 5 failures = %v, want degraded", h.State)
+	}
+}
+
+func TestSetFocusUser(t *testing.T) {
+	t.Cleanup(func() { SetFocusUser("") })
+	t.Setenv("USER", "env-user")
+
+	SetFocusUser("alice")
+	if got := currentUser(); got != "alice" {
+		t.Errorf("currentUser() = %q, want alice", got)
+	}
+	SetFocusUser("")
+	if got := currentUser(); got != "env-user" {
+		t.Errorf("currentUser() = %q, want env-user", got)
+	}
+}
@@ -26,8 +26,11 @@ type FileWatchErrorMsg struct {
 	Err error
 }
 
-const watchInterval = 1200 * time.Millisecond
-const cliPollInterval = 5 * time.Second
+// Poll intervals. Package vars so SetTuning can override them from config.
+var (
+	watchInterval   = 1200 * time.Millisecond
+	cliPollInterval = 5 * time.Second
+)
 
 // WatchFile polls a JSONL file and emits a single message (changed, unchanged, or error).
 // Callers should schedule it again after handling the returned message.
@@ -83,7 +86,7 @@ type CLIHealthCheckMsg struct {
 	Err    error
 }
 
-const cliHealthCheckInterval = 15 * time.Second
+var cliHealthCheckInterval = 15 * time.Second
 
 // CLIHealthCheck polls bd list on a longer interval to detect CLI recovery
 // while the app is operating in JSONL fallback mode.
@@ -0,0 +1,204 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Worktree is a git worktree checked out on an issue's branch.
+type Worktree struct {
+	IssueID string
+	Path    string
+	Branch  string
+	Dirty   bool // uncommitted changes in the worktree
+	Ahead   int  // commits on Branch not on the base branch
+	Behind  int  // commits on the base branch not on Branch
+	Landed  bool // Branch gained commits since it was created, all reachable from the base branch
+}
+
+// Merged reports whether the worktree's branch has been folded into the base
+// branch. A fresh branch with no commits of its own is never merged, however
+// far the base has moved on.
+func (w Worktree) Merged() bool {
+	return w.Landed
+}
+
+// WorktreePath returns where the worktree for issueID lives: a sibling
+// directory of the project named <project>.worktrees, so checkouts never
+// show up as untracked files in the main working copy.
+func WorktreePath(projectDir, issueID string) string {
+	projectDir = filepath.Clean(projectDir)
+	return filepath.Join(filepath.Dir(projectDir), filepath.Base(projectDir)+".worktrees", issueID)
+}
+
+// CreateWorktree adds a worktree for issue on its BranchName branch, creating
+// the branch from HEAD when it does not exist yet. An existing worktree for
+// the issue is reused. It returns the worktree path.
+func CreateWorktree(projectDir string, issue Issue) (string, error) {
+	if err := ValidateIssueID(issue.ID); err != nil {
+		return "", err
+	}
+	path := WorktreePath(projectDir, issue.ID)
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		return path, nil
+	}
+
+	branch := BranchName(issue)
+	args := []string{"-C", projectDir, "worktree", "add"}
+	if _, err := runWithTimeout(timeoutShort, "git", "-C", projectDir,
+		"rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		args = append(args, "--", path, branch)
+	} else {
+		args = append(args, "-b", branch, "--", path)
+	}
+	if _, err := runWithTimeout(timeoutMedium, "git", args...); err != nil {
+		return "", wrapExitError("git worktree add", err)
+	}
+	return path, nil
+}
+
+// ListWorktrees returns the worktrees of projectDir whose branch belongs to
+// one of issues, with dirty and ahead/behind status against the branch
+// checked out in the main working copy. Status lookups are best effort.
+func ListWorktrees(projectDir string, issues []Issue) ([]Worktree, error) {
+	out, err := runWithTimeout(timeoutShort, "git", "-C", projectDir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, wrapExitError("git worktree list", err)
+	}
+	entries := parseWorktreeList(out)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	base := entries[0].Branch
+
+	var worktrees []Worktree
+	for _, wt := range entries[1:] {
+		if wt.Branch == "" {
+			continue // detached HEAD
+		}
+		if wt.IssueID = issueForBranch(wt.Branch, issues); wt.IssueID == "" {
+			continue
+		}
+		loadWorktreeStatus(projectDir, base, &wt)
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees, nil
+}
+
+// RemoveWorktree deletes the worktree at w.Path and then its branch. git
+// refuses both when the worktree is dirty or the branch is unmerged.
+func RemoveWorktree(projectDir string, w Worktree) error {
+	if _, err := runWithTimeout(timeoutMedium, "git", "-C", projectDir, "worktree", "remove", "--", w.Path); err != nil {
+		return wrapExitError("git worktree remove", err)
+	}
+	if _, err := runWithTimeout(timeoutShort, "git", "-C", projectDir, "branch", "-d", "--", w.Branch); err != nil {
+		return wrapExitError("git branch -d", err)
+	}
+	return nil
+}
+
+// PruneMergedWorktrees removes every clean, merged worktree in worktrees and
+// returns the ones it removed. It stops at the first failure.
+func PruneMergedWorktrees(projectDir string, worktrees []Worktree) ([]Worktree, error) {
+	var removed []Worktree
+	for _, w := range worktrees {
+		if w.Dirty || !w.Merged() {
+			continue
+		}
+		if err := RemoveWorktree(projectDir, w); err != nil {
+			return removed, err
+		}
+		removed = append(removed, w)
+	}
+	return removed, nil
+}
+
+// parseWorktreeList parses `git worktree list --porcelain`. The first entry
+// is always the main working copy.
+func parseWorktreeList(out []byte) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(string(out)), "\n\n") {
+		var wt Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, val, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.Path = val
+			case "branch":
+				wt.Branch = strings.TrimPrefix(val, "refs/heads/")
+			}
+		}
+		if wt.Path != "" {
+			worktrees = append(worktrees, wt)
+		}
+	}
+	return worktrees
+}
+
+// issueForBranch returns the ID of the issue branch was made for: an exact
+// BranchName match first, then any branch containing the ID as a token.
+func issueForBranch(branch string, issues []Issue) string {
+	for _, issue := range issues {
+		if BranchName(issue) == branch {
+			return issue.ID
+		}
+	}
+	lower := strings.ToLower(branch)
+	for _, issue := range issues {
+		if containsToken(lower, strings.ToLower(issue.ID)) {
+			return issue.ID
+		}
+	}
+	return ""
+}
+
+// loadWorktreeStatus fills in the dirty flag, ahead/behind counts and
+// whether the branch has landed on base.
+func loadWorktreeStatus(projectDir, base string, wt *Worktree) {
+	if out, err := runWithTimeout(timeoutShort, "git", "-C", wt.Path, "status", "--porcelain"); err == nil {
+		wt.Dirty = len(strings.TrimSpace(string(out))) > 0
+	}
+	if base == "" {
+		return
+	}
+	out, err := runWithTimeout(timeoutShort, "git", "-C", projectDir,
+		"rev-list", "--left-right", "--count", base+"..."+wt.Branch)
+	if err != nil {
+		return
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return
+	}
+	wt.Behind, _ = strconv.Atoi(fields[0])
+	wt.Ahead, _ = strconv.Atoi(fields[1])
+	wt.Landed = branchLanded(projectDir, base, wt.Branch)
+}
+
+// branchLanded reports whether branch's tip is an ancestor of base and has
+// moved since the branch was created. The creation point comes from the
+// branch reflog; without one the branch is assumed unmerged, so prune never
+// removes a worktree it cannot vouch for.
+func branchLanded(projectDir, base, branch string) bool {
+	if _, err := runWithTimeout(timeoutShort, "git", "-C", projectDir,
+		"merge-base", "--is-ancestor", "refs/heads/"+branch, "refs/heads/"+base); err != nil {
+		return false
+	}
+	tip, err := runWithTimeout(timeoutShort, "git", "-C", projectDir, "rev-parse", "--verify", "refs/heads/"+branch)
+	if err != nil {
+		return false
+	}
+	log, err := runWithTimeout(timeoutShort, "git", "-C", projectDir,
+		"reflog", "show", "--format=%H", "refs/heads/"+branch, "--")
+	if err != nil {
+		return false
+	}
+	entries := strings.Fields(string(log))
+	if len(entries) == 0 {
+		return false
+	}
+	created := entries[len(entries)-1]
+	return created != strings.TrimSpace(string(tip))
+}
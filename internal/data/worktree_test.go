@@ -0,0 +1,150 @@
+package data
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktreePath(t *testing.T) {
+	got := WorktreePath("/src/app/", "mg-7")
+	if want := filepath.Join("/src", "app.worktrees", "mg-7"); got != want {
+		t.Errorf("WorktreePath() = %q, want %q", got, want)
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	out := []byte("worktree /src/app\nHEAD abc\nbranch refs/heads/main\n\n" +
+		"worktree /src/app.worktrees/mg-7\nHEAD def\nbranch refs/heads/fix/mg-7-crash\n\n" +
+		"worktree /tmp/detached\nHEAD 123\ndetached\n")
+	got := parseWorktreeList(out)
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	if got[0].Branch != "main" || got[1].Path != "/src/app.worktrees/mg-7" || got[1].Branch != "fix/mg-7-crash" {
+		t.Errorf("entries = %+v", got)
+	}
+	if got[2].Branch != "" {
+		t.Errorf("detached entry branch = %q, want empty", got[2].Branch)
+	}
+}
+
+func TestIssueForBranch(t *testing.T) {
+	issues := []Issue{
+		{ID: "mg-7", Title: "Crash on start", IssueType: TypeBug},
+		{ID: "mg-70", Title: "Other"},
+	}
+	tests := []struct {
+		branch, want string
+	}{
+		{"fix/mg-7-crash-on-start", "mg-7"},
+		{"spike/mg-70", "mg-70"},
+		{"spike/mg-700", ""},
+		{"main", ""},
+	}
+	for _, tt := range tests {
+		if got := issueForBranch(tt.branch, issues); got != tt.want {
+			t.Errorf("issueForBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestWorktreeLifecycle(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Ada"}, {"GIT_AUTHOR_EMAIL", "ada@example.com"},
+		{"GIT_COMMITTER_NAME", "Ada"}, {"GIT_COMMITTER_EMAIL", "ada@example.com"},
+		{"GIT_CONFIG_GLOBAL", "/dev/null"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	dir := filepath.Join(t.TempDir(), "proj")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "init", "-q", "-b", "main")
+	gitRun(t, dir, "commit", "-q", "--allow-empty", "-m", "root")
+
+	issues := []Issue{
+		{ID: "mg-1", Title: "First", IssueType: TypeTask},
+		{ID: "mg-2", Title: "Second", IssueType: TypeBug},
+		{ID: "mg-3", Title: "Third", IssueType: TypeTask},
+	}
+	path1, err := CreateWorktree(dir, issues[0])
+	if err != nil {
+		t.Fatalf("CreateWorktree(mg-1) error = %v", err)
+	}
+	if path1 != WorktreePath(dir, "mg-1") {
+		t.Errorf("path = %q", path1)
+	}
+	if again, err := CreateWorktree(dir, issues[0]); err != nil || again != path1 {
+		t.Errorf("CreateWorktree reuse = %q, %v", again, err)
+	}
+
+	// mg-2's branch already exists; the worktree should check it out.
+	gitRun(t, dir, "branch", BranchName(issues[1]))
+	path2, err := CreateWorktree(dir, issues[1])
+	if err != nil {
+		t.Fatalf("CreateWorktree(mg-2) error = %v", err)
+	}
+
+	// mg-3: created and left untouched while main moves on.
+	path3, err := CreateWorktree(dir, issues[2])
+	if err != nil {
+		t.Fatalf("CreateWorktree(mg-3) error = %v", err)
+	}
+
+	// mg-1: one commit, merged into main; main then moves on.
+	gitRun(t, path1, "commit", "-q", "--allow-empty", "-m", "mg-1 work")
+	gitRun(t, dir, "merge", "-q", "--no-ff", "-m", "merge mg-1", BranchName(issues[0]))
+	// mg-2: unmerged commit plus uncommitted changes.
+	gitRun(t, path2, "commit", "-q", "--allow-empty", "-m", "mg-2 work")
+	writeTestFile(t, filepath.Join(path2, "wip.txt"), "wip\n")
+
+	wts, err := ListWorktrees(dir, issues)
+	if err != nil {
+		t.Fatalf("ListWorktrees() error = %v", err)
+	}
+	if len(wts) != 3 {
+		t.Fatalf("worktrees = %+v, want 3", wts)
+	}
+	byID := map[string]Worktree{}
+	for _, w := range wts {
+		byID[w.IssueID] = w
+	}
+	if w := byID["mg-1"]; !w.Merged() || w.Dirty || w.Ahead != 0 || w.Behind != 1 {
+		t.Errorf("mg-1 = %+v, want clean and merged", w)
+	}
+	if w := byID["mg-2"]; w.Merged() || !w.Dirty || w.Ahead != 1 || w.Behind != 2 {
+		t.Errorf("mg-2 = %+v, want dirty, 1 ahead, 2 behind", w)
+	}
+	if w := byID["mg-3"]; w.Merged() || w.Ahead != 0 || w.Behind != 2 {
+		t.Errorf("mg-3 = %+v, want fresh and unmerged, 2 behind", w)
+	}
+
+	removed, err := PruneMergedWorktrees(dir, wts)
+	if err != nil {
+		t.Fatalf("PruneMergedWorktrees() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0].IssueID != "mg-1" {
+		t.Errorf("removed = %+v, want mg-1 only", removed)
+	}
+	if _, err := os.Stat(path1); !os.IsNotExist(err) {
+		t.Errorf("mg-1 worktree still on disk: %v", err)
+	}
+	if _, err := os.Stat(path3); err != nil {
+		t.Errorf("fresh mg-3 worktree pruned: %v", err)
+	}
+	if wts, _ := ListWorktrees(dir, issues); len(wts) != 2 {
+		t.Errorf("after prune worktrees = %+v, want mg-2 and mg-3", wts)
+	}
+}
+
+func TestCreateWorktreeRejectsBadID(t *testing.T) {
+	if _, err := CreateWorktree(t.TempDir(), Issue{ID: "--force"}); err == nil {
+		t.Error("expected invalid ID error")
+	}
+}
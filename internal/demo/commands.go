@@ -0,0 +1,397 @@
+package demo
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// command is one parsed invocation: --key=value and bare --key flags, and
+// positional arguments (including everything after "--").
+type command struct {
+	flags map[string]string
+	args  []string
+}
+
+func parseCommand(argv []string) command {
+	c := command{flags: make(map[string]string)}
+	for i, a := range argv {
+		if a == "--" {
+			c.args = append(c.args, argv[i+1:]...)
+			break
+		}
+		if name, ok := strings.CutPrefix(a, "--"); ok {
+			key, value, _ := strings.Cut(name, "=")
+			c.flags[key] = value
+			continue
+		}
+		c.args = append(c.args, a)
+	}
+	return c
+}
+
+func (c command) has(flag string) bool {
+	_, ok := c.flags[flag]
+	return ok
+}
+
+// arg returns the n-th positional argument, or "".
+func (c command) arg(n int) string {
+	if n < len(c.args) {
+		return c.args[n]
+	}
+	return ""
+}
+
+// notSimulated is the error for commands the demo does not answer.
+func notSimulated(name string, args []string) error {
+	return fmt.Errorf("%s %s is not simulated in demo mode", name, strings.Join(args, " "))
+}
+
+// bd answers the bd subcommands mg runs.
+func (p *Project) bd(argv []string, now time.Time) ([]byte, error) {
+	if len(argv) == 0 {
+		return nil, notSimulated("bd", argv)
+	}
+	c := parseCommand(argv[1:])
+	switch argv[0] {
+	case "--version":
+		return []byte("bd version 0.60.0 (demo)\n"), nil
+	case "list":
+		return json.Marshal(p.issues)
+	case "context":
+		return json.Marshal(data.BeadsContext{Backend: "demo", Database: Prefix, BdVersion: "0.60.0"})
+	case "doctor":
+		return json.Marshal(data.DoctorResult{OK: true, Summary: "demo project is healthy"})
+	case "show":
+		if c.has("current") {
+			return nil, fmt.Errorf("no current issue")
+		}
+		issue, err := p.lookup(c.arg(0))
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal([]data.Issue{*issue})
+	case "update":
+		return nil, p.update(c, now)
+	case "close":
+		return p.closeCmd(c, now)
+	case "create":
+		return p.create(c, now)
+	case "delete":
+		if _, err := p.lookup(c.arg(0)); err != nil {
+			return nil, err
+		}
+		p.issues = slices.DeleteFunc(p.issues, func(i data.Issue) bool { return i.ID == c.arg(0) })
+		return nil, nil
+	case "label":
+		return nil, p.label(c, now)
+	case "dep":
+		return nil, p.dep(c, now)
+	case "comments":
+		if c.arg(0) == "add" {
+			if _, err := p.lookup(c.arg(1)); err != nil {
+				return nil, err
+			}
+			p.comment(c.arg(1), "you", c.arg(2), now)
+			return nil, nil
+		}
+		comments := p.comments[c.arg(0)]
+		if comments == nil {
+			comments = []gastown.Comment{}
+		}
+		return json.Marshal(comments)
+	case "note":
+		issue, err := p.lookup(c.arg(0))
+		if err != nil {
+			return nil, err
+		}
+		issue.Notes = strings.TrimSpace(issue.Notes + "\n" + c.arg(1))
+		issue.UpdatedAt = now
+		return nil, nil
+	}
+	return nil, notSimulated("bd", argv)
+}
+
+// lookup returns the issue with id or a not-found error.
+func (p *Project) lookup(id string) (*data.Issue, error) {
+	if issue := p.find(id); issue != nil {
+		return issue, nil
+	}
+	return nil, fmt.Errorf("issue %s not found", id)
+}
+
+// update applies `bd update ID --field=value ...`.
+func (p *Project) update(c command, now time.Time) error {
+	issue, err := p.lookup(c.arg(0))
+	if err != nil {
+		return err
+	}
+	for key, value := range c.flags {
+		switch key {
+		case "status":
+			issue.Status = data.Status(value)
+			switch issue.Status {
+			case data.StatusInProgress:
+				issue.StartedAt = &now
+			case data.StatusClosed:
+				issue.ClosedAt = &now
+			default:
+				issue.ClosedAt = nil
+			}
+		case "claim":
+			issue.Status, issue.Assignee, issue.StartedAt = data.StatusInProgress, "you", &now
+		case "priority":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid priority %q", value)
+			}
+			issue.Priority = data.Priority(n)
+		case "assignee":
+			issue.Assignee = value
+		case "title":
+			issue.Title = value
+		case "description":
+			issue.Description = value
+		case "acceptance":
+			issue.AcceptanceCriteria = value
+		case "due":
+			if issue.DueAt, err = parseDate(value); err != nil {
+				return err
+			}
+		case "defer":
+			if issue.DeferUntil, err = parseDate(value); err != nil {
+				return err
+			}
+		case "metadata":
+			if err := json.Unmarshal([]byte(value), &issue.Metadata); err != nil {
+				return fmt.Errorf("invalid metadata: %w", err)
+			}
+		default:
+			return fmt.Errorf("bd update --%s is not simulated in demo mode", key)
+		}
+	}
+	issue.UpdatedAt = now
+	return nil
+}
+
+// parseDate reads a YYYY-MM-DD flag value; empty clears the date.
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", value)
+	}
+	return &t, nil
+}
+
+// closeCmd applies `bd close`, optionally claiming the next ready issue.
+func (p *Project) closeCmd(c command, now time.Time) ([]byte, error) {
+	if _, err := p.lookup(c.arg(0)); err != nil {
+		return nil, err
+	}
+	reason := c.flags["reason"]
+	if reason == "" {
+		reason = "done"
+	}
+	p.close(c.arg(0), reason, now)
+	if !c.has("claim-next") {
+		return nil, nil
+	}
+	var result struct {
+		Claimed *data.Issue `json:"claimed"`
+	}
+	if next := p.nextReady(); next != nil {
+		next.Status, next.Assignee, next.StartedAt, next.UpdatedAt = data.StatusInProgress, "you", &now, now
+		result.Claimed = next
+	}
+	return json.Marshal(result)
+}
+
+// create applies `bd create` and prints the new ID.
+func (p *Project) create(c command, now time.Time) ([]byte, error) {
+	prio, err := strconv.Atoi(c.flags["priority"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority %q", c.flags["priority"])
+	}
+	id := issueID(p.nextID)
+	p.nextID++
+	p.issues = append(p.issues, data.Issue{
+		ID:        id,
+		Title:     c.flags["title"],
+		Status:    data.StatusOpen,
+		Priority:  data.Priority(prio),
+		IssueType: data.IssueType(c.flags["type"]),
+		CreatedAt: now,
+		CreatedBy: "you",
+		UpdatedAt: now,
+	})
+	return []byte(id + "\n"), nil
+}
+
+// label applies `bd label add|remove ID -- LABEL`.
+func (p *Project) label(c command, now time.Time) error {
+	issue, err := p.lookup(c.arg(1))
+	if err != nil {
+		return err
+	}
+	label := c.arg(2)
+	switch c.arg(0) {
+	case "add":
+		if !slices.Contains(issue.Labels, label) {
+			issue.Labels = append(issue.Labels, label)
+		}
+	case "remove":
+		issue.Labels = slices.DeleteFunc(issue.Labels, func(l string) bool { return l == label })
+	default:
+		return notSimulated("bd label", c.args)
+	}
+	issue.UpdatedAt = now
+	return nil
+}
+
+// dep applies `bd dep add|remove [--type=T] ID -- DEPENDS_ON`.
+func (p *Project) dep(c command, now time.Time) error {
+	issue, err := p.lookup(c.arg(1))
+	if err != nil {
+		return err
+	}
+	target := c.arg(2)
+	if _, err := p.lookup(target); err != nil {
+		return err
+	}
+	switch c.arg(0) {
+	case "add":
+		typ := c.flags["type"]
+		if typ == "" {
+			typ = "blocks"
+		}
+		issue.Dependencies = append(issue.Dependencies, dependency(issue.ID, target, typ, now))
+	case "remove":
+		issue.Dependencies = slices.DeleteFunc(issue.Dependencies, func(d data.Dependency) bool {
+			return d.DependsOnID == target
+		})
+	default:
+		return notSimulated("bd dep", c.args)
+	}
+	issue.UpdatedAt = now
+	return nil
+}
+
+// gt answers the gt subcommands mg runs.
+func (p *Project) gt(argv []string, now time.Time) ([]byte, error) {
+	if len(argv) == 0 {
+		return nil, notSimulated("gt", argv)
+	}
+	c := parseCommand(argv[1:])
+	switch argv[0] {
+	case "status":
+		return json.Marshal(p.town.status(p))
+	case "convoy":
+		return p.convoy(c)
+	case "sling":
+		id := c.arg(0)
+		if on := c.flags["on"]; on != "" {
+			id = on
+		}
+		return nil, p.town.sling(id, now, p)
+	case "unsling":
+		return nil, p.town.unsling(c.arg(0), now, p)
+	case "formula":
+		return []byte("shiny\nquick-fix\nspike\n"), nil
+	case "nudge":
+		return []byte("nudged " + c.arg(0) + "\n"), nil
+	case "mail":
+		if c.arg(0) == "inbox" {
+			return json.Marshal(p.town.inbox(now))
+		}
+		return []byte("ok\n"), nil
+	case "costs":
+		return json.Marshal(p.town.costs())
+	case "patrol":
+		if c.arg(0) == "scan" {
+			return json.Marshal(p.town.patrol(now))
+		}
+	case "vitals":
+		return []byte(vitals), nil
+	}
+	return nil, notSimulated("gt", argv)
+}
+
+// git answers the git lookups mg runs as an empty repository: no code
+// references, branches or worktrees. Commands that would change the
+// repository fail, since the demo project has no checkout.
+func git(argv []string) ([]byte, error) {
+	sub := argv
+	if len(sub) >= 2 && sub[0] == "-C" {
+		sub = sub[2:]
+	}
+	if len(sub) == 0 {
+		return nil, notSimulated("git", argv)
+	}
+	switch sub[0] {
+	case "worktree":
+		if len(sub) > 1 && sub[1] == "list" {
+			return nil, nil
+		}
+		return nil, notSimulated("git", sub)
+	case "branch", "checkout", "commit":
+		return nil, notSimulated("git", sub)
+	}
+	return nil, nil
+}
+
+// convoy answers `gt convoy list|status|create|add|close`.
+func (p *Project) convoy(c command) ([]byte, error) {
+	t := p.town
+	details := t.convoyDetails(p)
+	switch c.arg(0) {
+	case "list":
+		return json.Marshal(details)
+	case "status":
+		for _, d := range details {
+			if d.ID == c.arg(1) {
+				return json.Marshal(d)
+			}
+		}
+		return nil, fmt.Errorf("convoy %s not found", c.arg(1))
+	case "create":
+		id := fmt.Sprintf("%s-cv-%d", Prefix, len(t.convoys)+1)
+		t.convoys = append(t.convoys, gastown.ConvoyDetail{ID: id, Title: c.arg(1), Status: "open"})
+		t.track(len(t.convoys)-1, c.args[min(2, len(c.args)):]...)
+		return []byte("Created convoy " + id + "\n"), nil
+	case "add", "close":
+		n := slices.IndexFunc(t.convoys, func(d gastown.ConvoyDetail) bool { return d.ID == c.arg(1) })
+		if n < 0 {
+			return nil, fmt.Errorf("convoy %s not found", c.arg(1))
+		}
+		if c.arg(0) == "close" {
+			t.convoys[n].Status = "closed"
+		} else {
+			t.track(n, c.args[2:]...)
+		}
+		return []byte("ok\n"), nil
+	}
+	return nil, notSimulated("gt convoy", c.args)
+}
+
+// vitals is canned `gt vitals` output.
+const vitals = `Dolt Servers
+  ● :13409  demo  PID 4242  6.0 MB  1/1000 conn  0ms
+
+Databases (1 registered)
+  Rig          Total  Open  Closed     %
+  beads_demo      25    21       4   16%
+
+Backups
+  Local:  not configured
+  JSONL:  not available
+`
@@ -0,0 +1,124 @@
+// Package demo serves a synthetic project for mg --demo: generated issues
+// and a simulated Gas Town, answered in-process in place of the bd and gt
+// CLIs so the rest of mg runs unchanged without either installed.
+package demo
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// Project is the in-memory demo project. Its Run method stands in for the
+// bd and gt binaries; every call first advances the simulated town to the
+// current time.
+type Project struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	issues   []data.Issue
+	comments map[string][]gastown.Comment
+	town     *town
+	nextID   int
+}
+
+// New generates a demo project as of clock().
+func New(clock func() time.Time) *Project {
+	now := clock()
+	issues := Generate(now)
+	p := &Project{
+		now:      clock,
+		issues:   issues,
+		comments: make(map[string][]gastown.Comment),
+		nextID:   len(issues),
+	}
+	p.town = newTown(now, issues)
+	return p
+}
+
+// Install routes the data and gastown packages' commands to p. Call it
+// before the TUI starts.
+func (p *Project) Install() {
+	data.UseCommandRunner(p.Run)
+	gastown.UseCommandRunner(p.Run)
+}
+
+// Issues returns a copy of the current issues.
+func (p *Project) Issues() []data.Issue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.town.advance(p.now(), p)
+	return slices.Clone(p.issues)
+}
+
+// Run answers one bd, gt or git invocation. Commands the demo does not
+// simulate fail with an error, as a missing subcommand would.
+func (p *Project) Run(name string, args ...string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	p.town.advance(now, p)
+	switch name {
+	case "bd":
+		return p.bd(args, now)
+	case "gt":
+		return p.gt(args, now)
+	case "git":
+		return git(args)
+	}
+	return nil, fmt.Errorf("%s is not available in demo mode", name)
+}
+
+// find returns the issue with id, or nil.
+func (p *Project) find(id string) *data.Issue {
+	for i := range p.issues {
+		if p.issues[i].ID == id {
+			return &p.issues[i]
+		}
+	}
+	return nil
+}
+
+// nextReady returns the most urgent open, unblocked, unassigned issue that
+// is not an epic or deferred, or nil.
+func (p *Project) nextReady() *data.Issue {
+	issueMap := data.BuildIssueMap(p.issues)
+	var best *data.Issue
+	for i := range p.issues {
+		issue := &p.issues[i]
+		if issue.Status != data.StatusOpen || issue.Assignee != "" || issue.IssueType == data.TypeEpic ||
+			issue.IsDeferred() || issue.IsBlocked(issueMap) {
+			continue
+		}
+		if best == nil || cmp.Or(cmp.Compare(issue.Priority, best.Priority), issue.CreatedAt.Compare(best.CreatedAt)) < 0 {
+			best = issue
+		}
+	}
+	return best
+}
+
+// close marks id closed with reason.
+func (p *Project) close(id, reason string, now time.Time) {
+	issue := p.find(id)
+	if issue == nil {
+		return
+	}
+	issue.Status = data.StatusClosed
+	issue.ClosedAt = &now
+	issue.CloseReason = reason
+	issue.UpdatedAt = now
+}
+
+// comment appends a comment to id.
+func (p *Project) comment(id, author, body string, now time.Time) {
+	p.comments[id] = append(p.comments[id], gastown.Comment{
+		ID:     fmt.Sprintf("c%d", len(p.comments[id])+1),
+		Author: author,
+		Body:   body,
+		Time:   now.Format(time.RFC3339),
+	})
+}
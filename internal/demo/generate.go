@@ -0,0 +1,165 @@
+package demo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// Prefix is the issue ID prefix of the demo project.
+const Prefix = "demo"
+
+// seed describes one generated issue. Times are whole days relative to now:
+// created and closed count back, due and deferred count ahead (negative is
+// overdue), and zero means unset.
+type seed struct {
+	title    string
+	typ      data.IssueType
+	prio     data.Priority
+	status   data.Status
+	assignee string
+	labels   []string
+	parent   int   // index of the parent epic, -1 for none
+	blocks   []int // indexes of issues this one depends on
+	created  int
+	closed   int
+	due      int
+	deferred int
+	desc     string
+	meta     map[string]any
+}
+
+const none = -1
+
+// seeds is the demo project: three epics with children, blocking chains,
+// overdue and deferred work, and a few standalone bugs and chores.
+var seeds = []seed{
+	// 0: Checkout epic
+	{title: "Checkout v2", typ: data.TypeEpic, prio: data.PriorityHigh, status: data.StatusInProgress, parent: none, created: 21,
+		desc: "Rebuild checkout on the new payments API with idempotent retries and a single-page flow."},
+	{title: "Design payment API contract", typ: data.TypeTask, prio: data.PriorityHigh, status: data.StatusClosed, parent: 0, created: 20, closed: 6,
+		labels: []string{"backend", "api"}, meta: map[string]any{"points": 3, "team": "payments"}},
+	{title: "Implement Stripe adapter", typ: data.TypeFeature, prio: data.PriorityHigh, status: data.StatusInProgress, assignee: "obsidian", parent: 0, blocks: []int{1}, created: 18,
+		labels: []string{"backend"}, meta: map[string]any{"points": 5, "team": "payments"},
+		desc: "Wrap the Stripe client behind the payments interface. Webhooks land in a queue, not the request path."},
+	{title: "Add idempotency keys to payment calls", typ: data.TypeTask, prio: data.PriorityHigh, status: data.StatusOpen, parent: 0, blocks: []int{2}, created: 17,
+		labels: []string{"backend"}, meta: map[string]any{"points": 2, "team": "payments"}},
+	{title: "Redesign checkout page", typ: data.TypeStory, prio: data.PriorityMedium, status: data.StatusOpen, parent: 0, created: 16, due: -2,
+		labels: []string{"frontend", "design"}, meta: map[string]any{"points": 5, "team": "web"}},
+	{title: "Load-test the checkout flow", typ: data.TypeTask, prio: data.PriorityMedium, status: data.StatusOpen, parent: 0, blocks: []int{3, 4}, created: 15, due: 9,
+		labels: []string{"perf"}, meta: map[string]any{"points": 3, "team": "platform"}},
+
+	// 6: Observability epic
+	{title: "Observability overhaul", typ: data.TypeEpic, prio: data.PriorityMedium, status: data.StatusOpen, parent: none, created: 30,
+		desc: "Move every service to OpenTelemetry and alert on error budgets instead of raw error counts."},
+	{title: "Ship the OpenTelemetry collector", typ: data.TypeFeature, prio: data.PriorityHigh, status: data.StatusInProgress, assignee: "matt", parent: 6, created: 12,
+		labels: []string{"infra", "observability"}, meta: map[string]any{"points": 5, "team": "platform"}},
+	{title: "Grafana dashboards per service", typ: data.TypeTask, prio: data.PriorityMedium, status: data.StatusOpen, parent: 6, blocks: []int{7}, created: 11,
+		labels: []string{"observability"}, meta: map[string]any{"points": 3, "team": "platform"}},
+	{title: "Alert on error budget burn", typ: data.TypeTask, prio: data.PriorityMedium, status: data.StatusOpen, parent: 6, blocks: []int{8}, created: 11, due: 5,
+		labels: []string{"observability", "sre"}, meta: map[string]any{"points": 2, "team": "sre"}},
+	{title: "Remove the legacy StatsD client", typ: data.TypeChore, prio: data.PriorityLow, status: data.StatusOpen, parent: 6, blocks: []int{7}, created: 10, deferred: 14,
+		labels: []string{"cleanup"}, meta: map[string]any{"points": 1, "team": "platform"}},
+
+	// 11: Onboarding epic
+	{title: "Mobile onboarding", typ: data.TypeEpic, prio: data.PriorityMedium, status: data.StatusOpen, parent: none, created: 9,
+		desc: "Cut time-to-first-order on mobile by half."},
+	{title: "Review onboarding screen copy", typ: data.TypeTask, prio: data.PriorityLow, status: data.StatusOpen, parent: 11, created: 8,
+		labels: []string{"mobile", "content"}, meta: map[string]any{"points": 1, "team": "mobile"}},
+	{title: "Push notification opt-in", typ: data.TypeFeature, prio: data.PriorityMedium, status: data.StatusOpen, parent: 11, created: 8,
+		labels: []string{"mobile"}, meta: map[string]any{"points": 3, "team": "mobile"}},
+	{title: "Deep links open the wrong tab", typ: data.TypeBug, prio: data.PriorityHigh, status: data.StatusOpen, parent: 11, created: 4, due: -1,
+		labels: []string{"mobile"}, meta: map[string]any{"severity": "major", "team": "mobile"}},
+
+	// Standalone work
+	{title: "Session expires during checkout", typ: data.TypeBug, prio: data.PriorityCritical, status: data.StatusInProgress, assignee: "quartz", parent: none, created: 2, due: 1,
+		labels: []string{"auth", "incident"}, meta: map[string]any{"severity": "critical", "team": "payments"},
+		desc: "Users on long checkouts are logged out at the payment step. Refresh tokens are not rotated on the checkout subdomain."},
+	{title: "Flaky TestSearchIndex on CI", typ: data.TypeBug, prio: data.PriorityMedium, status: data.StatusOpen, parent: none, created: 6,
+		labels: []string{"ci", "flaky"}, meta: map[string]any{"severity": "minor", "team": "platform"}},
+	{title: "Dark mode contrast on settings page", typ: data.TypeBug, prio: data.PriorityLow, status: data.StatusOpen, parent: none, created: 13,
+		labels: []string{"frontend", "a11y"}, meta: map[string]any{"severity": "minor", "team": "web"}},
+	{title: "Rotate staging credentials", typ: data.TypeChore, prio: data.PriorityHigh, status: data.StatusOpen, parent: none, created: 7, due: -3,
+		labels: []string{"security"}, meta: map[string]any{"team": "sre"}},
+	{title: "Evaluate vector search for product catalog", typ: data.TypeSpike, prio: data.PriorityLow, status: data.StatusOpen, parent: none, created: 5,
+		labels: []string{"research"}, meta: map[string]any{"points": 2, "team": "search"}},
+	{title: "Write the payments on-call runbook", typ: data.TypeTask, prio: data.PriorityMedium, status: data.StatusOpen, parent: none, blocks: []int{2}, created: 5,
+		labels: []string{"docs", "sre"}, meta: map[string]any{"points": 2, "team": "payments"}},
+	{title: "Bump Go to 1.25", typ: data.TypeChore, prio: data.PriorityMedium, status: data.StatusClosed, parent: none, created: 9, closed: 2,
+		labels: []string{"deps"}, meta: map[string]any{"points": 1, "team": "platform"}},
+	{title: "Migrate CI to ARM runners", typ: data.TypeTask, prio: data.PriorityMedium, status: data.StatusClosed, parent: none, created: 14, closed: 1,
+		labels: []string{"ci", "infra"}, meta: map[string]any{"points": 3, "team": "platform"}},
+	{title: "Fix N+1 query on the orders page", typ: data.TypeBug, prio: data.PriorityHigh, status: data.StatusClosed, parent: none, created: 10, closed: 3,
+		labels: []string{"backend", "perf"}, meta: map[string]any{"severity": "major", "team": "web"}},
+}
+
+// issueID is the ID of the n-th generated issue (0-based).
+func issueID(n int) string {
+	return fmt.Sprintf("%s-%03d", Prefix, n+1)
+}
+
+// Generate returns the demo project as of now. The same now always yields
+// the same issues.
+func Generate(now time.Time) []data.Issue {
+	day := 24 * time.Hour
+	at := func(days int) *time.Time {
+		t := now.Add(time.Duration(days) * day).Truncate(day)
+		return &t
+	}
+
+	issues := make([]data.Issue, len(seeds))
+	for i, s := range seeds {
+		id := issueID(i)
+		created := now.Add(-time.Duration(s.created) * day)
+		issue := data.Issue{
+			ID:          id,
+			Title:       s.title,
+			Description: s.desc,
+			Status:      s.status,
+			Priority:    s.prio,
+			IssueType:   s.typ,
+			Assignee:    s.assignee,
+			Owner:       "dev@demo.example",
+			CreatedAt:   created,
+			CreatedBy:   "demo",
+			UpdatedAt:   created,
+			Labels:      s.labels,
+			Metadata:    s.meta,
+		}
+		if s.status == data.StatusInProgress {
+			started := now.Add(-time.Duration(s.created) * day / 2)
+			issue.StartedAt = &started
+			issue.UpdatedAt = started
+		}
+		if s.status == data.StatusClosed {
+			issue.ClosedAt = at(-s.closed)
+			issue.UpdatedAt = *issue.ClosedAt
+			issue.CloseReason = "done"
+		}
+		if s.due != 0 {
+			issue.DueAt = at(s.due)
+		}
+		if s.deferred != 0 {
+			issue.DeferUntil = at(s.deferred)
+		}
+		if s.parent != none {
+			issue.Dependencies = append(issue.Dependencies, dependency(id, issueID(s.parent), "parent-child", created))
+		}
+		for _, b := range s.blocks {
+			issue.Dependencies = append(issue.Dependencies, dependency(id, issueID(b), "blocks", created))
+		}
+		issues[i] = issue
+	}
+	return issues
+}
+
+func dependency(id, dependsOn, typ string, at time.Time) data.Dependency {
+	return data.Dependency{
+		IssueID:     id,
+		DependsOnID: dependsOn,
+		Type:        typ,
+		CreatedAt:   at.Format(time.RFC3339),
+		CreatedBy:   "demo",
+	}
+}
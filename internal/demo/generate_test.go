@@ -0,0 +1,59 @@
+package demo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestGenerate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	issues := Generate(now)
+	if len(issues) != len(seeds) {
+		t.Fatalf("got %d issues, want %d", len(issues), len(seeds))
+	}
+
+	issueMap := data.BuildIssueMap(issues)
+	var epics, children, blocked, overdue, deferred, closed int
+	for i := range issues {
+		issue := &issues[i]
+		if err := data.ValidateIssueID(issue.ID); err != nil {
+			t.Errorf("%s: %v", issue.ID, err)
+		}
+		for _, dep := range issue.Dependencies {
+			if issueMap[dep.DependsOnID] == nil {
+				t.Errorf("%s depends on missing %s", issue.ID, dep.DependsOnID)
+			}
+			if dep.Type == "parent-child" {
+				children++
+			}
+		}
+		if issue.IssueType == data.TypeEpic {
+			epics++
+		}
+		if issue.ParentID() != "" {
+			children++
+		}
+		if issue.Status != data.StatusClosed && issue.IsBlocked(issueMap) {
+			blocked++
+		}
+		if issue.IsOverdue() {
+			overdue++
+		}
+		if issue.IsDeferred() {
+			deferred++
+		}
+		if issue.Status == data.StatusClosed {
+			closed++
+		}
+	}
+	for name, n := range map[string]int{
+		"epics": epics, "children": children, "blocked": blocked,
+		"overdue": overdue, "deferred": deferred, "closed": closed,
+	} {
+		if n == 0 {
+			t.Errorf("no %s in the demo project", name)
+		}
+	}
+}
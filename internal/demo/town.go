@@ -0,0 +1,303 @@
+package demo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// Rig is the name of the simulated Gas Town rig.
+const Rig = "demo"
+
+// TickEvery is how often the simulated town takes a step.
+const TickEvery = 15 * time.Second
+
+// maxCatchUp bounds how many missed steps advance replays at once.
+const maxCatchUp = 20
+
+// agent is a simulated Gas Town worker.
+type agent struct {
+	name  string
+	role  string // polecat or crew
+	state string // idle, working or stuck
+	issue string // hooked issue ID
+	ticks int    // steps spent in the current state
+}
+
+// town is the simulated Gas Town. Polecats pick up ready work, sometimes
+// get stuck, and close issues; crew members keep whatever they hold.
+type town struct {
+	agents  []*agent
+	convoys []gastown.ConvoyDetail
+	rng     *rand.Rand
+	last    time.Time
+	steps   int // steps taken, which drive the simulated costs
+}
+
+func newTown(now time.Time, issues []data.Issue) *town {
+	t := &town{
+		agents: []*agent{
+			{name: "obsidian", role: "polecat"},
+			{name: "quartz", role: "polecat"},
+			{name: "jasper", role: "polecat"},
+			{name: "matt", role: "crew"},
+		},
+		rng:  rand.New(rand.NewPCG(1, 86)),
+		last: now,
+	}
+	for _, a := range t.agents {
+		for _, issue := range issues {
+			if issue.Assignee == a.name && issue.Status == data.StatusInProgress {
+				a.state, a.issue = "working", issue.ID
+				break
+			}
+		}
+		if a.state == "" {
+			a.state = "idle"
+		}
+	}
+	t.convoys = []gastown.ConvoyDetail{
+		{ID: "demo-cv-1", Title: "Checkout v2 launch", Status: "open"},
+		{ID: "demo-cv-2", Title: "Observability rollout", Status: "open"},
+	}
+	t.track(0, issueID(1), issueID(2), issueID(3), issueID(4), issueID(5))
+	t.track(1, issueID(7), issueID(8), issueID(9))
+	return t
+}
+
+// track adds issue IDs to the n-th convoy.
+func (t *town) track(n int, ids ...string) {
+	for _, id := range ids {
+		t.convoys[n].Tracked = append(t.convoys[n].Tracked, gastown.TrackedIssueInfo{ID: id})
+	}
+}
+
+// advance replays the steps due between the last one and now.
+func (t *town) advance(now time.Time, p *Project) {
+	for n := 0; !now.Before(t.last.Add(TickEvery)); n++ {
+		t.last = t.last.Add(TickEvery)
+		if n < maxCatchUp {
+			t.step(t.last, p)
+		}
+	}
+}
+
+// step moves every polecat along: idle ones sling the next ready issue,
+// working ones may finish or get stuck, and stuck ones may recover.
+func (t *town) step(now time.Time, p *Project) {
+	t.steps++
+	for _, a := range t.agents {
+		if a.role != "polecat" {
+			continue
+		}
+		a.ticks++
+		switch a.state {
+		case "idle":
+			if issue := p.nextReady(); issue != nil {
+				t.hook(a, issue, now, p)
+			}
+		case "working":
+			if a.ticks < 3 {
+				continue
+			}
+			switch roll := t.rng.IntN(100); {
+			case roll < 45:
+				p.close(a.issue, "Completed by "+a.name, now)
+				a.state, a.issue, a.ticks = "idle", "", 0
+			case roll < 60:
+				a.state, a.ticks = "stuck", 0
+				p.comment(a.issue, a.name, "Stuck: waiting on a flaky integration test.", now)
+			}
+		case "stuck":
+			if a.ticks >= 2 && t.rng.IntN(2) == 0 {
+				a.state, a.ticks = "working", 0
+				p.comment(a.issue, a.name, "Unstuck, back on it.", now)
+			}
+		}
+	}
+}
+
+// hook assigns issue to a and starts it.
+func (t *town) hook(a *agent, issue *data.Issue, now time.Time, p *Project) {
+	issue.Status = data.StatusInProgress
+	issue.Assignee = a.name
+	issue.StartedAt = &now
+	issue.UpdatedAt = now
+	a.state, a.issue, a.ticks = "working", issue.ID, 0
+	p.comment(issue.ID, a.name, "Picked up by "+a.name+".", now)
+}
+
+// sling hooks issueID to the first idle polecat, or a new one.
+func (t *town) sling(issueID string, now time.Time, p *Project) error {
+	issue := p.find(issueID)
+	if issue == nil {
+		return fmt.Errorf("no issue %s", issueID)
+	}
+	i := slices.IndexFunc(t.agents, func(a *agent) bool { return a.role == "polecat" && a.state == "idle" })
+	if i < 0 {
+		t.agents = append(t.agents, &agent{name: fmt.Sprintf("polecat-%d", len(t.agents)+1), role: "polecat"})
+		i = len(t.agents) - 1
+	}
+	t.hook(t.agents[i], issue, now, p)
+	return nil
+}
+
+// unsling releases issueID from its agent and reopens it.
+func (t *town) unsling(issueID string, now time.Time, p *Project) error {
+	issue := p.find(issueID)
+	if issue == nil {
+		return fmt.Errorf("no issue %s", issueID)
+	}
+	for _, a := range t.agents {
+		if a.issue == issueID {
+			a.state, a.issue, a.ticks = "idle", "", 0
+		}
+	}
+	issue.Status = data.StatusOpen
+	issue.Assignee = ""
+	issue.UpdatedAt = now
+	return nil
+}
+
+// inbox is the overseer's mail: one message per stuck agent, plus a standing
+// note from the mayor.
+func (t *town) inbox(now time.Time) []gastown.MailMessage {
+	msgs := []gastown.MailMessage{{
+		ID: "demo-mail-0", From: "mayor", To: "crew/matt", Subject: "Welcome to the demo town",
+		Body: "Agents pick up ready work every few seconds. Sling an issue to watch it move.",
+		Time: t.last.Add(-time.Hour).Format(time.RFC3339), Read: true,
+	}}
+	for _, a := range t.agents {
+		if a.state == "stuck" {
+			msgs = append(msgs, gastown.MailMessage{
+				ID: "demo-mail-" + a.name, From: a.name, To: "crew/matt",
+				Subject: "Stuck on " + a.issue, Body: "Waiting on a flaky integration test.",
+				Time: now.Format(time.RFC3339),
+			})
+		}
+	}
+	return msgs
+}
+
+// costs grows the token spend with every simulated step.
+func (t *town) costs() gastown.CostsOutput {
+	spend := 1.25 + 0.08*float64(t.steps)
+	return gastown.CostsOutput{
+		Period:   "last 24h",
+		Total:    gastown.CostTotal{InputTokens: 60000 + 4000*t.steps, OutputTokens: 21000 + 1500*t.steps, Cost: spend},
+		Sessions: len(t.agents) + t.steps/4,
+		ByRole: []gastown.RoleCost{
+			{Role: "polecat", Sessions: 3 + t.steps/4, Cost: spend * 0.7},
+			{Role: "crew", Sessions: 1, Cost: spend * 0.3},
+		},
+		ByRig: []gastown.RigCost{{Rig: Rig, Sessions: len(t.agents) + t.steps/4, Cost: spend}},
+	}
+}
+
+// patrol reports stuck polecats as stalls.
+func (t *town) patrol(now time.Time) gastown.PatrolScanResult {
+	r := gastown.PatrolScanResult{Rig: Rig, Timestamp: now.Format(time.RFC3339)}
+	for _, a := range t.agents {
+		if a.role != "polecat" {
+			continue
+		}
+		r.Zombies.Checked++
+		r.Stalls.Checked++
+		r.Completions.Checked++
+		if a.state == "stuck" {
+			r.Stalls.Found++
+			r.Details = append(r.Details, gastown.PatrolDetail{
+				Type: "stall", Agent: a.name, Rig: Rig, Role: a.role, HookBead: a.issue,
+				Detail: "no progress for several minutes",
+			})
+		}
+	}
+	return r
+}
+
+// status renders the town in the shape of `gt status --json`.
+func (t *town) status(p *Project) map[string]any {
+	var agents []gastown.AgentRuntime
+	polecats, crew := 0, 0
+	for _, a := range t.agents {
+		if a.role == "polecat" {
+			polecats++
+		} else {
+			crew++
+		}
+		rt := gastown.AgentRuntime{
+			Name:       a.name,
+			Role:       a.role,
+			Address:    Rig + "/" + a.name,
+			Session:    Rig + "-" + a.name,
+			State:      a.state,
+			Running:    a.state != "idle",
+			HasWork:    a.issue != "",
+			HookBead:   a.issue,
+			AgentAlias: a.name,
+			AgentInfo:  "claude/sonnet",
+		}
+		if issue := p.find(a.issue); issue != nil {
+			rt.WorkTitle = issue.Title
+		}
+		agents = append(agents, rt)
+	}
+	agents = append(agents,
+		gastown.AgentRuntime{Name: "witness", Role: "witness", Address: Rig + "/witness", State: "idle"},
+		gastown.AgentRuntime{Name: "refinery", Role: "refinery", Address: Rig + "/refinery", State: "idle"},
+	)
+
+	var convoys []gastown.ConvoyInfo
+	for _, c := range t.convoyDetails(p) {
+		convoys = append(convoys, gastown.ConvoyInfo{ID: c.ID, Title: c.Title, Status: c.Status, Done: c.Completed, Total: c.Total})
+	}
+	return map[string]any{
+		"name": "demo-hq",
+		"agents": []gastown.AgentRuntime{
+			{Name: "mayor", Role: "coordinator", Address: "mayor/", State: "idle", AgentAlias: "Mayor"},
+		},
+		"rigs": []map[string]any{{
+			"name":          Rig,
+			"polecat_count": polecats,
+			"crew_count":    crew,
+			"has_witness":   true,
+			"has_refinery":  true,
+			"agents":        agents,
+		}},
+		"convoys": convoys,
+	}
+}
+
+// convoyDetails fills in each convoy's progress from the current issues.
+func (t *town) convoyDetails(p *Project) []gastown.ConvoyDetail {
+	out := make([]gastown.ConvoyDetail, len(t.convoys))
+	for i, c := range t.convoys {
+		c.Tracked = slices.Clone(c.Tracked)
+		c.Completed, c.Total = 0, len(c.Tracked)
+		for j, tr := range c.Tracked {
+			issue := p.find(tr.ID)
+			if issue == nil {
+				continue
+			}
+			c.Tracked[j] = gastown.TrackedIssueInfo{
+				ID: issue.ID, Title: issue.Title, Status: string(issue.Status),
+				IssueType: string(issue.IssueType), Worker: issue.Assignee,
+			}
+			if issue.Status == data.StatusClosed {
+				c.Completed++
+			}
+		}
+		if c.Total > 0 {
+			c.ProgressPct = float64(c.Completed) * 100 / float64(c.Total)
+		}
+		if c.Total > 0 && c.Completed == c.Total {
+			c.Status = "landed"
+		}
+		out[i] = c
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package demo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// clock is a settable time source for driving the simulation.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestProject() (*Project, *clock) {
+	c := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	return New(c.Now), c
+}
+
+func TestTownAdvances(t *testing.T) {
+	p, c := newTestProject()
+	closedBefore := countStatus(p.Issues(), data.StatusClosed)
+
+	c.now = c.now.Add(maxCatchUp * TickEvery)
+	issues := p.Issues()
+	if p.town.steps != maxCatchUp {
+		t.Fatalf("steps = %d, want %d", p.town.steps, maxCatchUp)
+	}
+	if got := countStatus(issues, data.StatusClosed); got <= closedBefore {
+		t.Errorf("closed = %d after %d steps, want more than %d", got, maxCatchUp, closedBefore)
+	}
+	for _, a := range p.town.agents {
+		if a.issue == "" {
+			continue
+		}
+		issue := p.find(a.issue)
+		if issue == nil || issue.Assignee != a.name || issue.Status != data.StatusInProgress {
+			t.Errorf("%s hooks %s, which is %+v", a.name, a.issue, issue)
+		}
+	}
+}
+
+func TestTownCatchUpIsBounded(t *testing.T) {
+	p, c := newTestProject()
+	c.now = c.now.Add(24 * time.Hour)
+	p.Issues()
+	if p.town.steps != maxCatchUp {
+		t.Errorf("steps = %d, want %d", p.town.steps, maxCatchUp)
+	}
+	if !p.town.last.Equal(c.now) {
+		t.Errorf("last = %v, want %v", p.town.last, c.now)
+	}
+}
+
+func TestTownSlingAndUnsling(t *testing.T) {
+	p, c := newTestProject()
+	id := issueID(12)
+	if err := p.town.sling(id, c.now, p); err != nil {
+		t.Fatal(err)
+	}
+	if issue := p.find(id); issue.Status != data.StatusInProgress || issue.Assignee == "" {
+		t.Fatalf("after sling: status %s, assignee %q", issue.Status, issue.Assignee)
+	}
+	if err := p.town.unsling(id, c.now, p); err != nil {
+		t.Fatal(err)
+	}
+	if issue := p.find(id); issue.Status != data.StatusOpen || issue.Assignee != "" {
+		t.Errorf("after unsling: status %s, assignee %q", issue.Status, issue.Assignee)
+	}
+	if err := p.town.sling("demo-999", c.now, p); err == nil {
+		t.Error("sling of unknown issue succeeded")
+	}
+}
+
+func TestConvoyDetails(t *testing.T) {
+	p, c := newTestProject()
+	for _, tr := range p.town.convoys[1].Tracked {
+		p.close(tr.ID, "done", c.now)
+	}
+	details := p.town.convoyDetails(p)
+	if d := details[1]; d.Status != "landed" || d.Completed != d.Total {
+		t.Errorf("convoy 2 = %s %d/%d, want landed", d.Status, d.Completed, d.Total)
+	}
+	if d := details[0]; d.Completed != 1 || d.Total != 5 {
+		t.Errorf("convoy 1 progress = %d/%d, want 1/5", d.Completed, d.Total)
+	}
+}
+
+func countStatus(issues []data.Issue, status data.Status) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func TestGitAnswersAsEmptyRepo(t *testing.T) {
+	p, _ := newTestProject()
+	for _, args := range [][]string{
+		{"-C", "/tmp/demo", "grep", "-n", "-e", "mg-1"},
+		{"-C", "/tmp/demo", "log", "--format=%h"},
+		{"-C", "/tmp/demo", "for-each-ref", "refs/heads/"},
+		{"-C", "/tmp/demo", "worktree", "list", "--porcelain"},
+	} {
+		if out, err := p.Run("git", args...); err != nil || len(out) != 0 {
+			t.Errorf("git %v = %q, %v; want empty output", args, out, err)
+		}
+	}
+	if _, err := p.Run("git", "-C", "/tmp/demo", "worktree", "add", "-b", "mg-1"); err == nil {
+		t.Error("git worktree add should fail in demo mode")
+	}
+}
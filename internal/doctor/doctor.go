@@ -0,0 +1,469 @@
+// Package doctor aggregates mg's health checks — bd doctor, the bd version
+// check, bd context, source health, JSONL freshness, gt vitals and gt patrol
+// scan — into a single report shared by `mg doctor` and the TUI overlay.
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// Categories for checks that don't come from bd doctor.
+const (
+	CategorySource  = "Data Source"
+	CategoryBd      = "bd CLI"
+	CategoryBeads   = "Beads"
+	CategoryGasTown = "Gas Town"
+)
+
+// Diagnostic statuses, matching bd doctor's vocabulary.
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusError   = "error"
+)
+
+// Options describes the environment the checks run against.
+type Options struct {
+	ProjectDir string
+	Mode       data.SourceMode
+	// Health is the live source state from the TUI. When nil the source is
+	// probed once instead.
+	Health *data.SourceHealth
+	// GasTown enables the gt vitals and gt patrol scan checks.
+	GasTown bool
+}
+
+// Probes are the external calls behind each check, replaceable in tests.
+type Probes struct {
+	BdOnPath  func() bool
+	BdDoctor  func() (*data.DoctorResult, error)
+	BdVersion func() string
+	BdContext func() (*data.BeadsContext, error)
+	Source    func(projectDir string) error
+	Vitals    func() (*gastown.Vitals, error)
+	Patrol    func() (*gastown.PatrolScanResult, error)
+	Now       func() time.Time
+}
+
+// DefaultProbes returns probes that shell out to bd and gt.
+func DefaultProbes() Probes {
+	return Probes{
+		BdOnPath: func() bool {
+			_, err := exec.LookPath("bd")
+			return err == nil
+		},
+		BdDoctor:  data.FetchDoctorDiagnostics,
+		BdVersion: data.CheckBdVersion,
+		BdContext: data.FetchContext,
+		Source: func(projectDir string) error {
+			_, err := data.FetchIssuesCLI(projectDir)
+			return err
+		},
+		Vitals: gastown.FetchVitals,
+		Patrol: gastown.FetchPatrolScan,
+		Now:    time.Now,
+	}
+}
+
+// Run executes every applicable check in parallel and returns one merged
+// result. Diagnostics keep a fixed order: data source, bd CLI, bd doctor
+// findings, then Gas Town.
+func Run(opts Options, p Probes) *data.DoctorResult {
+	bd := p.BdOnPath()
+	checks := []func() []data.DoctorDiagnostic{
+		func() []data.DoctorDiagnostic { return sourceChecks(opts, p, bd) },
+		func() []data.DoctorDiagnostic { return jsonlCheck(opts, p) },
+	}
+	if bd {
+		checks = append(checks,
+			func() []data.DoctorDiagnostic { return versionCheck(p) },
+			func() []data.DoctorDiagnostic { return contextCheck(p) },
+			func() []data.DoctorDiagnostic { return bdDoctorChecks(p) },
+		)
+	}
+	if opts.GasTown {
+		checks = append(checks,
+			func() []data.DoctorDiagnostic { return vitalsChecks(p) },
+			func() []data.DoctorDiagnostic { return patrolChecks(p) },
+		)
+	}
+
+	results := make([][]data.DoctorDiagnostic, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check()
+		}()
+	}
+	wg.Wait()
+
+	report := &data.DoctorResult{}
+	for _, r := range results {
+		report.Diagnostics = append(report.Diagnostics, r...)
+	}
+	if !opts.GasTown {
+		report.Diagnostics = append(report.Diagnostics, data.DoctorDiagnostic{
+			Name:        "gas town",
+			Status:      StatusOK,
+			Category:    CategoryGasTown,
+			Explanation: "gt not detected — Gas Town checks skipped",
+		})
+	}
+	summarize(report)
+	return report
+}
+
+// Counts returns the number of error and warning diagnostics in r.
+func Counts(r *data.DoctorResult) (errors, warnings int) {
+	if r == nil {
+		return 0, 0
+	}
+	for _, d := range r.Diagnostics {
+		switch d.Status {
+		case StatusError:
+			errors++
+		case StatusWarning:
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// summarize fills OK and Summary from the diagnostics.
+func summarize(r *data.DoctorResult) {
+	errs, warns := Counts(r)
+	r.OK = errs == 0 && warns == 0
+	switch {
+	case r.OK:
+		r.Summary = fmt.Sprintf("All %d checks passed", len(r.Diagnostics))
+	default:
+		r.Summary = fmt.Sprintf("%d error(s), %d warning(s)", errs, warns)
+	}
+}
+
+func sourceChecks(opts Options, p Probes, bd bool) []data.DoctorDiagnostic {
+	d := data.DoctorDiagnostic{Name: "source", Category: CategorySource}
+	if opts.ProjectDir == "" {
+		d.Status = StatusError
+		d.Explanation = "No .beads directory found here or in any parent"
+		d.Commands = []string{"bd init"}
+		return []data.DoctorDiagnostic{d}
+	}
+	if opts.Mode != data.SourceCLI {
+		d.Status = StatusOK
+		d.Explanation = "Reading issues.jsonl directly (JSONL mode)"
+		if !bd {
+			d.Status = StatusWarning
+			d.Explanation = "bd not on PATH — running in legacy JSONL mode"
+			d.Expected = "bd v0.60+ on PATH"
+		}
+		return []data.DoctorDiagnostic{d}
+	}
+
+	if opts.Health != nil {
+		h := *opts.Health
+		d.Observed = h.State.String()
+		switch {
+		case h.InFallback():
+			d.Status = StatusError
+			d.Explanation = "bd list is failing; showing issues.jsonl fallback"
+		case h.IsDegraded():
+			d.Status = StatusWarning
+			d.Explanation = fmt.Sprintf("bd list failed %d times in a row", h.ConsecFailures)
+		default:
+			d.Status = StatusOK
+			d.Explanation = "bd list --json responding"
+		}
+		if h.LastError != nil && d.Status != StatusOK {
+			d.Observed += ": " + h.LastError.Error()
+		}
+	} else if err := p.Source(opts.ProjectDir); err != nil {
+		d.Status = StatusError
+		d.Explanation = "bd list --json failed"
+		d.Observed = err.Error()
+	} else {
+		d.Status = StatusOK
+		d.Explanation = "bd list --json responding"
+	}
+	if d.Status != StatusOK {
+		d.Commands = []string{"dolt sql-server", "bd list --json --limit 1"}
+	}
+	return []data.DoctorDiagnostic{d}
+}
+
+func jsonlCheck(opts Options, p Probes) []data.DoctorDiagnostic {
+	d := data.DoctorDiagnostic{Name: "jsonl fallback", Category: CategorySource}
+	path := data.FindJSONLPath(opts.ProjectDir)
+	if opts.ProjectDir == "" || path == "" {
+		d.Status = StatusOK
+		if opts.Mode == data.SourceCLI {
+			d.Status = StatusWarning
+			d.Explanation = "No .beads/issues.jsonl — no fallback if bd becomes unavailable"
+			d.Commands = []string{"bd export -o .beads/issues.jsonl"}
+		} else {
+			d.Explanation = "No issues.jsonl found"
+		}
+		return []data.DoctorDiagnostic{d}
+	}
+
+	_, mod, fresh := data.ProbeJSONLFallback(opts.ProjectDir)
+	if !fresh {
+		if info, err := statModTime(path); err == nil {
+			mod = info
+		}
+	}
+	age := p.Now().Sub(mod).Round(time.Minute)
+	d.Observed = fmt.Sprintf("last written %s ago", age)
+	d.Expected = fmt.Sprintf("newer than %s", data.CurrentTuning().JSONLMaxAge)
+	switch {
+	case fresh:
+		d.Status = StatusOK
+		d.Explanation = "issues.jsonl is fresh enough to use as a fallback"
+	case opts.Mode == data.SourceCLI:
+		d.Status = StatusWarning
+		d.Explanation = "issues.jsonl is too old to use as a fallback"
+		d.Commands = []string{"bd export -o .beads/issues.jsonl"}
+	default:
+		d.Status = StatusWarning
+		d.Explanation = "issues.jsonl has not been updated recently"
+	}
+	return []data.DoctorDiagnostic{d}
+}
+
+func versionCheck(p Probes) []data.DoctorDiagnostic {
+	d := data.DoctorDiagnostic{Name: "bd version", Category: CategoryBd, Status: StatusOK, Explanation: "No known-broken bd version detected"}
+	if warn := p.BdVersion(); warn != "" {
+		d.Status = StatusWarning
+		d.Explanation = warn
+		d.Expected = "bd v0.60.0+"
+		d.Commands = []string{"bd --version"}
+	}
+	return []data.DoctorDiagnostic{d}
+}
+
+func contextCheck(p Probes) []data.DoctorDiagnostic {
+	d := data.DoctorDiagnostic{Name: "bd context", Category: CategoryBd}
+	ctx, err := p.BdContext()
+	switch {
+	case err != nil:
+		d.Status = StatusWarning
+		d.Explanation = "Could not read workspace identity"
+		d.Observed = err.Error()
+		d.Commands = []string{"bd context"}
+	case ctx == nil:
+		d.Status = StatusWarning
+		d.Explanation = "bd context returned nothing"
+	default:
+		d.Status = StatusOK
+		d.Explanation = fmt.Sprintf("database %s (%s backend)", orDash(ctx.Database), orDash(ctx.Backend))
+		if ctx.IsRedirected {
+			d.Explanation += ", redirected"
+		}
+	}
+	return []data.DoctorDiagnostic{d}
+}
+
+func bdDoctorChecks(p Probes) []data.DoctorDiagnostic {
+	result, err := p.BdDoctor()
+	if err != nil {
+		return []data.DoctorDiagnostic{{
+			Name:        "bd doctor",
+			Status:      StatusError,
+			Category:    CategoryBeads,
+			Explanation: "bd doctor --agent failed",
+			Observed:    err.Error(),
+			Commands:    []string{"bd doctor"},
+		}}
+	}
+	if result == nil {
+		return nil
+	}
+	out := make([]data.DoctorDiagnostic, 0, len(result.Diagnostics))
+	for _, d := range result.Diagnostics {
+		if d.Category == "" {
+			d.Category = CategoryBeads
+		}
+		out = append(out, d)
+	}
+	if len(out) == 0 {
+		out = append(out, data.DoctorDiagnostic{Name: "bd doctor", Status: StatusOK, Category: CategoryBeads, Explanation: result.Summary})
+	}
+	return out
+}
+
+func vitalsChecks(p Probes) []data.DoctorDiagnostic {
+	v, err := p.Vitals()
+	if err != nil {
+		return []data.DoctorDiagnostic{{
+			Name: "gt vitals", Status: StatusWarning, Category: CategoryGasTown,
+			Explanation: "Could not read Gas Town vitals", Observed: err.Error(), Commands: []string{"gt vitals"},
+		}}
+	}
+	if v == nil || (len(v.Servers) == 0 && v.Backups.LocalLabel == "" && v.Backups.JSONLLabel == "") {
+		return []data.DoctorDiagnostic{{
+			Name: "gt vitals", Status: StatusOK, Category: CategoryGasTown,
+			Explanation: "No structured vitals reported",
+		}}
+	}
+
+	var out []data.DoctorDiagnostic
+	for _, s := range v.Servers {
+		d := data.DoctorDiagnostic{Name: "dolt server " + s.Port, Category: CategoryGasTown, Status: StatusOK}
+		if s.Label != "" {
+			d.Name += " (" + s.Label + ")"
+		}
+		if s.Running {
+			d.Explanation = fmt.Sprintf("running, %s connections, latency %s", orDash(s.Connections), orDash(s.Latency))
+		} else {
+			d.Status = StatusError
+			d.Explanation = "Dolt server is not running"
+			d.Commands = []string{"gt vitals"}
+		}
+		out = append(out, d)
+	}
+	for _, b := range []struct {
+		name, label string
+		ok          bool
+	}{
+		{"local backup", v.Backups.LocalLabel, v.Backups.LocalOK},
+		{"jsonl backup", v.Backups.JSONLLabel, v.Backups.JSONLOK},
+	} {
+		if b.label == "" {
+			continue
+		}
+		d := data.DoctorDiagnostic{Name: b.name, Category: CategoryGasTown, Status: StatusOK, Observed: b.label}
+		if !b.ok {
+			d.Status = StatusWarning
+			d.Explanation = "Backup is stale or missing"
+			d.Commands = []string{"gt vitals"}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func patrolChecks(p Probes) []data.DoctorDiagnostic {
+	scan, err := p.Patrol()
+	if err != nil {
+		return []data.DoctorDiagnostic{{
+			Name: "gt patrol scan", Status: StatusWarning, Category: CategoryGasTown,
+			Explanation: "Patrol scan failed", Observed: err.Error(), Commands: []string{"gt patrol scan"},
+		}}
+	}
+	problems := gastown.PatrolScanProblems(scan)
+	if len(problems) == 0 {
+		return []data.DoctorDiagnostic{{
+			Name: "gt patrol scan", Status: StatusOK, Category: CategoryGasTown,
+			Explanation: "No zombies or stalls found",
+		}}
+	}
+	out := make([]data.DoctorDiagnostic, 0, len(problems))
+	for _, pr := range problems {
+		status := StatusWarning
+		if pr.Severity == "error" {
+			status = StatusError
+		}
+		out = append(out, data.DoctorDiagnostic{
+			Name:        pr.Type,
+			Status:      status,
+			Category:    CategoryGasTown,
+			Explanation: pr.Detail,
+			Observed:    pr.RigName,
+			Commands:    []string{"gt patrol scan"},
+		})
+	}
+	return out
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// statModTime returns the modification time of path.
+func statModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// WriteText prints the report grouped by category, with fix commands under
+// each failing check.
+func WriteText(w io.Writer, r *data.DoctorResult) {
+	var order []string
+	groups := make(map[string][]data.DoctorDiagnostic)
+	for _, d := range r.Diagnostics {
+		cat := d.Category
+		if cat == "" {
+			cat = CategoryBeads
+		}
+		if _, seen := groups[cat]; !seen {
+			order = append(order, cat)
+		}
+		groups[cat] = append(groups[cat], d)
+	}
+
+	for i, cat := range order {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, cat)
+		for _, d := range groups[cat] {
+			fmt.Fprintf(w, "  %s %-22s %s\n", statusMark(d.Status), d.Name, d.Explanation)
+			if d.Status == StatusOK {
+				continue
+			}
+			if d.Observed != "" {
+				fmt.Fprintf(w, "      observed: %s\n", d.Observed)
+			}
+			if d.Expected != "" {
+				fmt.Fprintf(w, "      expected: %s\n", d.Expected)
+			}
+			for _, c := range d.Commands {
+				fmt.Fprintf(w, "      $ %s\n", c)
+			}
+		}
+	}
+	fmt.Fprintf(w, "\n%s\n", r.Summary)
+}
+
+// statusMark returns the fixed-width marker for a diagnostic status.
+func statusMark(status string) string {
+	switch status {
+	case StatusError:
+		return "✗"
+	case StatusWarning:
+		return "!"
+	default:
+		return "✓"
+	}
+}
+
+// ExitCode maps a report to a process exit status: 0 when every check
+// passed, 1 when there are only warnings, 3 when any check failed. 2 is
+// left to the caller for usage errors.
+func ExitCode(r *data.DoctorResult) int {
+	errs, warns := Counts(r)
+	switch {
+	case errs > 0:
+		return 3
+	case warns > 0:
+		return 1
+	default:
+		return 0
+	}
+}
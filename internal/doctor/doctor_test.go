@@ -0,0 +1,225 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// healthyProbes returns probes where every check passes.
+func healthyProbes() Probes {
+	return Probes{
+		BdOnPath: func() bool { return true },
+		BdDoctor: func() (*data.DoctorResult, error) {
+			return &data.DoctorResult{OK: true, Summary: "All checks passed"}, nil
+		},
+		BdVersion: func() string { return "" },
+		BdContext: func() (*data.BeadsContext, error) {
+			return &data.BeadsContext{Database: "beads_mg", Backend: "dolt"}, nil
+		},
+		Source: func(string) error { return nil },
+		Vitals: func() (*gastown.Vitals, error) {
+			return &gastown.Vitals{Servers: []gastown.DoltServer{{Port: "3307", Running: true}}}, nil
+		},
+		Patrol: func() (*gastown.PatrolScanResult, error) { return &gastown.PatrolScanResult{}, nil },
+		Now:    time.Now,
+	}
+}
+
+// projectWithJSONL creates a project dir whose issues.jsonl has the given age.
+func projectWithJSONL(t *testing.T, age time.Duration) string {
+	t.Helper()
+	dir := t.TempDir()
+	beads := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beads, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beads, "issues.jsonl")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now().Add(-age)
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func findDiag(r *data.DoctorResult, name string) *data.DoctorDiagnostic {
+	for i := range r.Diagnostics {
+		if r.Diagnostics[i].Name == name {
+			return &r.Diagnostics[i]
+		}
+	}
+	return nil
+}
+
+func TestRunAllHealthy(t *testing.T) {
+	dir := projectWithJSONL(t, time.Minute)
+	r := Run(Options{ProjectDir: dir, Mode: data.SourceCLI, GasTown: true}, healthyProbes())
+	if !r.OK {
+		t.Fatalf("Run() not OK: %+v", r.Diagnostics)
+	}
+	if ExitCode(r) != 0 {
+		t.Errorf("ExitCode() = %d, want 0", ExitCode(r))
+	}
+	for _, name := range []string{"source", "jsonl fallback", "bd version", "bd context", "bd doctor", "dolt server 3307", "gt patrol scan"} {
+		if findDiag(r, name) == nil {
+			t.Errorf("missing %q check", name)
+		}
+	}
+}
+
+func TestRunOrderIsStable(t *testing.T) {
+	dir := projectWithJSONL(t, time.Minute)
+	r := Run(Options{ProjectDir: dir, Mode: data.SourceCLI, GasTown: true}, healthyProbes())
+	var cats []string
+	for _, d := range r.Diagnostics {
+		if len(cats) == 0 || cats[len(cats)-1] != d.Category {
+			cats = append(cats, d.Category)
+		}
+	}
+	want := []string{CategorySource, CategoryBd, CategoryBeads, CategoryGasTown}
+	if strings.Join(cats, ",") != strings.Join(want, ",") {
+		t.Errorf("category order = %v, want %v", cats, want)
+	}
+}
+
+func TestRunRunsChecksInParallel(t *testing.T) {
+	var inFlight, peak int32
+	slow := func() {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}
+	p := healthyProbes()
+	p.BdVersion = func() string { slow(); return "" }
+	p.BdContext = func() (*data.BeadsContext, error) { slow(); return &data.BeadsContext{}, nil }
+	p.BdDoctor = func() (*data.DoctorResult, error) { slow(); return &data.DoctorResult{}, nil }
+
+	Run(Options{ProjectDir: t.TempDir(), Mode: data.SourceCLI}, p)
+	if atomic.LoadInt32(&peak) < 2 {
+		t.Errorf("peak concurrency = %d, want >= 2", peak)
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	dir := projectWithJSONL(t, 3*time.Hour)
+	p := healthyProbes()
+	p.Source = func(string) error { return errors.New("connection refused") }
+	p.BdVersion = func() string { return "bd v0.59.0 has a known bug" }
+	p.Vitals = func() (*gastown.Vitals, error) {
+		return &gastown.Vitals{Servers: []gastown.DoltServer{{Port: "3307", Running: false}}}, nil
+	}
+	p.Patrol = func() (*gastown.PatrolScanResult, error) {
+		return &gastown.PatrolScanResult{Zombies: gastown.PatrolFinding{Checked: 3, Found: 1}}, nil
+	}
+
+	r := Run(Options{ProjectDir: dir, Mode: data.SourceCLI, GasTown: true}, p)
+	if r.OK {
+		t.Fatal("Run() OK = true, want failures")
+	}
+	if d := findDiag(r, "source"); d == nil || d.Status != StatusError || len(d.Commands) == 0 {
+		t.Errorf("source = %+v, want error with fix", d)
+	}
+	if d := findDiag(r, "jsonl fallback"); d == nil || d.Status != StatusWarning {
+		t.Errorf("jsonl fallback = %+v, want stale warning", d)
+	}
+	if d := findDiag(r, "bd version"); d == nil || d.Status != StatusWarning {
+		t.Errorf("bd version = %+v, want warning", d)
+	}
+	if d := findDiag(r, "dolt server 3307"); d == nil || d.Status != StatusError {
+		t.Errorf("dolt server = %+v, want error", d)
+	}
+	if d := findDiag(r, "patrol_zombie"); d == nil || d.Status != StatusError {
+		t.Errorf("patrol_zombie = %+v, want error", d)
+	}
+	if ExitCode(r) != 3 {
+		t.Errorf("ExitCode() = %d, want 3", ExitCode(r))
+	}
+}
+
+func TestRunUsesLiveHealth(t *testing.T) {
+	p := healthyProbes()
+	p.Source = func(string) error {
+		t.Error("Source probe should not run when Health is supplied")
+		return nil
+	}
+	health := data.SourceHealth{State: data.HealthFallback, LastError: errors.New("timeout")}
+	r := Run(Options{ProjectDir: t.TempDir(), Mode: data.SourceCLI, Health: &health}, p)
+	d := findDiag(r, "source")
+	if d == nil || d.Status != StatusError || !strings.Contains(d.Observed, "timeout") {
+		t.Errorf("source = %+v, want fallback error", d)
+	}
+}
+
+func TestRunWithoutBdOrGasTown(t *testing.T) {
+	p := healthyProbes()
+	p.BdOnPath = func() bool { return false }
+	p.BdDoctor = func() (*data.DoctorResult, error) {
+		t.Error("bd doctor should not run without bd")
+		return nil, nil
+	}
+	dir := projectWithJSONL(t, time.Minute)
+	r := Run(Options{ProjectDir: dir, Mode: data.SourceJSONL}, p)
+	if findDiag(r, "bd version") != nil {
+		t.Error("bd checks should be skipped without bd")
+	}
+	if d := findDiag(r, "gas town"); d == nil || d.Status != StatusOK {
+		t.Errorf("gas town = %+v, want skipped note", d)
+	}
+	if ExitCode(r) != 1 {
+		t.Errorf("ExitCode() = %d, want 1 (legacy JSONL warning)", ExitCode(r))
+	}
+}
+
+func TestRunNoProject(t *testing.T) {
+	r := Run(Options{}, healthyProbes())
+	if d := findDiag(r, "source"); d == nil || d.Status != StatusError {
+		t.Errorf("source = %+v, want missing-project error", d)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	r := &data.DoctorResult{Diagnostics: []data.DoctorDiagnostic{
+		{Name: "source", Status: StatusOK, Category: CategorySource, Explanation: "fine"},
+		{Name: "jsonl fallback", Status: StatusWarning, Category: CategorySource, Explanation: "stale", Observed: "3h", Commands: []string{"bd export -o .beads/issues.jsonl"}},
+		{Name: "dolt", Status: StatusError, Category: "Core System", Explanation: "down"},
+	}}
+	summarize(r)
+
+	var buf bytes.Buffer
+	WriteText(&buf, r)
+	out := buf.String()
+	for _, want := range []string{
+		"Data Source\n",
+		"  ✓ source",
+		"  ! jsonl fallback",
+		"      observed: 3h",
+		"      $ bd export -o .beads/issues.jsonl",
+		"\nCore System\n",
+		"  ✗ dolt",
+		"1 error(s), 1 warning(s)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteText() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "Data Source") != 1 {
+		t.Errorf("category header repeated:\n%s", out)
+	}
+}
@@ -4,10 +4,7 @@
 // vitals monitoring, and problem detection.
 package gastown
 
-import (
-	"os"
-	"os/exec"
-)
+import "os"
 
 // Env holds Gas Town environment context read once at startup.
 type Env struct {
@@ -27,7 +24,7 @@ func Detect() Env {
 		Scope: os.Getenv("GT_SCOPE"),
 	}
 
-	_, err := exec.LookPath("gt")
+	_, err := lookPath("gt")
 	env.Available = err == nil
 
 	env.Active = env.Role != "" || env.Rig != ""
@@ -91,3 +91,23 @@ var execWithTimeout = func(timeout time.Duration, name string, args ...string) e
 	defer cancel()
 	return exec.CommandContext(ctx, name, args...).Run()
 }
+
+// lookPath finds a binary on PATH; UseCommandRunner replaces it.
+var lookPath = exec.LookPath
+
+// UseCommandRunner routes every gt (and bd) call made by this package
+// through run instead of starting a process, and makes Detect report gt as
+// available. mg --demo uses it to serve a simulated town.
+//
+// SAFETY: like SetCmdTimeout, call it before tea.NewProgram.Run.
+func UseCommandRunner(run func(name string, args ...string) ([]byte, error)) {
+	runWithTimeout = func(_ time.Duration, name string, args ...string) ([]byte, error) {
+		return run(name, args...)
+	}
+	runCombinedWithTimeout = runWithTimeout
+	execWithTimeout = func(_ time.Duration, name string, args ...string) error {
+		_, err := run(name, args...)
+		return err
+	}
+	lookPath = func(file string) (string, error) { return file, nil }
+}
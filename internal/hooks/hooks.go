@@ -0,0 +1,180 @@
+// Package hooks runs user-configured shell commands when mg observes issue,
+// agent or data-source events. Each hook receives the event as JSON on stdin.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Event types that can be bound in the hooks config section.
+const (
+	IssueClosed         = "issue_closed"
+	IssueStalled        = "issue_stalled"
+	CurrentIssueChanged = "current_issue_changed"
+	AgentStuck          = "agent_stuck"
+	AgentBackoff        = "agent_backoff"
+	SourceFallback      = "source_fallback"
+)
+
+// EventTypes lists every supported hook event, in documentation order.
+var EventTypes = []string{
+	IssueClosed,
+	IssueStalled,
+	CurrentIssueChanged,
+	AgentStuck,
+	AgentBackoff,
+	SourceFallback,
+}
+
+// Event is the JSON payload written to a hook's stdin.
+type Event struct {
+	Type       string    `json:"event"`
+	IssueID    string    `json:"issue_id,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	From       string    `json:"from,omitempty"`
+	To         string    `json:"to,omitempty"`
+	Agent      string    `json:"agent,omitempty"`
+	Rig        string    `json:"rig,omitempty"`
+	Detail     string    `json:"detail,omitempty"`
+	ProjectDir string    `json:"project_dir,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// ResultMsg reports a hook that finished. Err is nil on success.
+type ResultMsg struct {
+	Event string
+	Err   error
+}
+
+// Default hook timeout, matching the medium exec tier in data and gastown.
+const defaultTimeout = 15 * time.Second
+
+// timeout is the runtime hook timeout, overridable via SetCmdTimeout.
+var timeout = defaultTimeout
+
+// SetCmdTimeout scales the hook timeout relative to a 30s baseline,
+// matching data.SetCmdTimeout and gastown.SetCmdTimeout.
+//
+// SAFETY: Must be called during program initialization, before any hook runs.
+func SetCmdTimeout(seconds int) {
+	if seconds <= 0 {
+		return
+	}
+	timeout = time.Duration(float64(defaultTimeout) * float64(seconds) / 30.0)
+}
+
+// runShell executes command via sh -c with stdin attached and returns its
+// combined output. Replaced in tests.
+var runShell = func(timeout time.Duration, dir, command string, stdin []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(stdin)
+	// Don't wait on grandchildren that inherited the output pipe after kill.
+	cmd.WaitDelay = time.Second
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return out, fmt.Errorf("timed out after %s", timeout)
+	}
+	return out, err
+}
+
+// Runner dispatches events to their configured commands.
+// The zero value has no hooks and fires nothing.
+type Runner struct {
+	commands   map[string]string
+	projectDir string
+}
+
+// NewRunner builds a Runner from the config hooks map. Unknown event names
+// and blank commands are dropped; see Unknown to report them.
+func NewRunner(commands map[string]string, projectDir string) Runner {
+	r := Runner{projectDir: projectDir}
+	for _, event := range EventTypes {
+		if cmd := strings.TrimSpace(commands[event]); cmd != "" {
+			if r.commands == nil {
+				r.commands = make(map[string]string)
+			}
+			r.commands[event] = cmd
+		}
+	}
+	return r
+}
+
+// Unknown returns the configured event names that mg does not emit, sorted.
+func Unknown(commands map[string]string) []string {
+	known := make(map[string]bool, len(EventTypes))
+	for _, e := range EventTypes {
+		known[e] = true
+	}
+	var out []string
+	for event := range commands {
+		if !known[event] {
+			out = append(out, event)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Enabled reports whether any hook is configured.
+func (r Runner) Enabled() bool {
+	return len(r.commands) > 0
+}
+
+// Has reports whether a hook is configured for the event type.
+func (r Runner) Has(event string) bool {
+	_, ok := r.commands[event]
+	return ok
+}
+
+// Fire returns a command that runs the hook for ev off the update loop, or
+// nil when no hook is bound to ev.Type.
+func (r Runner) Fire(ev Event) tea.Cmd {
+	command, ok := r.commands[ev.Type]
+	if !ok {
+		return nil
+	}
+	if ev.At.IsZero() {
+		ev.At = time.Now().UTC()
+	}
+	if ev.ProjectDir == "" {
+		ev.ProjectDir = r.projectDir
+	}
+	dir := r.projectDir
+	return func() tea.Msg {
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return ResultMsg{Event: ev.Type, Err: err}
+		}
+		out, err := runShell(timeout, dir, command, payload)
+		if err != nil {
+			if msg := firstLine(out); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		return ResultMsg{Event: ev.Type, Err: err}
+	}
+}
+
+// firstLine returns the first non-empty line of out, capped for toast display.
+func firstLine(out []byte) string {
+	s := strings.TrimSpace(string(out))
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		s = s[:idx]
+	}
+	if len(s) > 120 {
+		s = s[:120] + "..."
+	}
+	return s
+}
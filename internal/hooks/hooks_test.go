@@ -0,0 +1,125 @@
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockShell replaces runShell, capturing the command and stdin of each call.
+func mockShell(output []byte, err error) (calls *[][2]string, restore func()) {
+	var c [][2]string
+	orig := runShell
+	runShell = func(_ time.Duration, _ string, command string, stdin []byte) ([]byte, error) {
+		c = append(c, [2]string{command, string(stdin)})
+		return output, err
+	}
+	return &c, func() { runShell = orig }
+}
+
+func TestNewRunnerDropsUnknownAndBlank(t *testing.T) {
+	r := NewRunner(map[string]string{
+		IssueClosed:  "echo closed",
+		IssueStalled: "   ",
+		"bogus":      "echo nope",
+	}, "/proj")
+	if !r.Has(IssueClosed) {
+		t.Error("expected issue_closed hook")
+	}
+	if r.Has(IssueStalled) {
+		t.Error("blank command should be dropped")
+	}
+	if r.Has("bogus") {
+		t.Error("unknown event should be dropped")
+	}
+	if !r.Enabled() {
+		t.Error("Enabled() = false, want true")
+	}
+}
+
+func TestZeroRunnerFiresNothing(t *testing.T) {
+	var r Runner
+	if r.Enabled() {
+		t.Error("zero Runner Enabled() = true")
+	}
+	if cmd := r.Fire(Event{Type: IssueClosed}); cmd != nil {
+		t.Error("zero Runner Fire() returned a command")
+	}
+}
+
+func TestUnknown(t *testing.T) {
+	got := Unknown(map[string]string{IssueClosed: "x", "zeta": "y", "alpha": "z"})
+	if strings.Join(got, ",") != "alpha,zeta" {
+		t.Errorf("Unknown() = %v, want [alpha zeta]", got)
+	}
+}
+
+func TestFireWritesJSONPayload(t *testing.T) {
+	calls, restore := mockShell(nil, nil)
+	defer restore()
+
+	r := NewRunner(map[string]string{IssueClosed: "notify"}, "/proj")
+	cmd := r.Fire(Event{Type: IssueClosed, IssueID: "mg-1", Title: "Done"})
+	if cmd == nil {
+		t.Fatal("Fire() returned nil")
+	}
+	msg, ok := cmd().(ResultMsg)
+	if !ok {
+		t.Fatalf("Fire()() returned %T, want ResultMsg", cmd())
+	}
+	if msg.Err != nil || msg.Event != IssueClosed {
+		t.Errorf("ResultMsg = %+v, want success for issue_closed", msg)
+	}
+	if len(*calls) != 1 || (*calls)[0][0] != "notify" {
+		t.Fatalf("calls = %v, want one notify call", *calls)
+	}
+
+	var ev Event
+	if err := json.Unmarshal([]byte((*calls)[0][1]), &ev); err != nil {
+		t.Fatalf("stdin is not JSON: %v", err)
+	}
+	if ev.Type != IssueClosed || ev.IssueID != "mg-1" || ev.ProjectDir != "/proj" || ev.At.IsZero() {
+		t.Errorf("payload = %+v", ev)
+	}
+}
+
+func TestFireReportsFailureWithOutput(t *testing.T) {
+	_, restore := mockShell([]byte("permission denied\nmore"), errors.New("exit status 1"))
+	defer restore()
+
+	r := NewRunner(map[string]string{SourceFallback: "x"}, "")
+	msg := r.Fire(Event{Type: SourceFallback})().(ResultMsg)
+	if msg.Err == nil {
+		t.Fatal("expected error")
+	}
+	if got := msg.Err.Error(); got != "exit status 1: permission denied" {
+		t.Errorf("Err = %q", got)
+	}
+}
+
+func TestRunShellStdinAndTimeout(t *testing.T) {
+	out, err := runShell(time.Second, "", "cat", []byte("hello"))
+	if err != nil || string(out) != "hello" {
+		t.Errorf("runShell(cat) = %q, %v", out, err)
+	}
+	_, err = runShell(50*time.Millisecond, "", "sleep 5", nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("runShell(sleep) err = %v, want timeout", err)
+	}
+}
+
+func TestSetCmdTimeout(t *testing.T) {
+	orig := timeout
+	defer func() { timeout = orig }()
+
+	SetCmdTimeout(60)
+	if timeout != 30*time.Second {
+		t.Errorf("timeout = %v, want 30s", timeout)
+	}
+	SetCmdTimeout(0)
+	if timeout != 30*time.Second {
+		t.Errorf("SetCmdTimeout(0) changed timeout to %v", timeout)
+	}
+}
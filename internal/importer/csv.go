@@ -0,0 +1,181 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// CSV fields a Mapping can assign a column to.
+const (
+	FieldRef      = "ref"
+	FieldTitle    = "title"
+	FieldType     = "type"
+	FieldPriority = "priority"
+	FieldLabels   = "labels"
+	FieldParent   = "parent"
+	FieldDepends  = "depends"
+)
+
+// fieldAliases maps header names commonly found in exported sheets to fields.
+var fieldAliases = map[string]string{
+	"ref":        FieldRef,
+	"id":         FieldRef,
+	"key":        FieldRef,
+	"title":      FieldTitle,
+	"summary":    FieldTitle,
+	"name":       FieldTitle,
+	"type":       FieldType,
+	"issue type": FieldType,
+	"priority":   FieldPriority,
+	"labels":     FieldLabels,
+	"tags":       FieldLabels,
+	"parent":     FieldParent,
+	"depends":    FieldDepends,
+	"depends_on": FieldDepends,
+	"depends on": FieldDepends,
+	"blocked_by": FieldDepends,
+	"blocked by": FieldDepends,
+}
+
+// Mapping assigns CSV columns (by header name) to fields.
+type Mapping map[string]string
+
+// ParseMapping reads "field=Column,field=Column" as given to --map.
+func ParseMapping(s string) (Mapping, error) {
+	m := Mapping{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("bad mapping %q (want field=Column)", pair)
+		}
+		if fieldAliases[field] != field {
+			return nil, fmt.Errorf("unknown field %q (want ref, title, type, priority, labels, parent or depends)", field)
+		}
+		m[field] = strings.TrimSpace(column)
+	}
+	return m, nil
+}
+
+// ParseCSV reads a CSV file with a header row. Columns are matched to fields
+// by mapping, then by well-known header names ("Summary", "Tags", ...). Only
+// a title column is required. Refs default to the row number.
+func ParseCSV(r io.Reader, mapping Mapping, def Defaults) ([]Draft, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cols, err := resolveColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var drafts []Draft
+	for row := 2; ; row++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(field string) string {
+			if i, ok := cols[field]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(rec, "")) == "" {
+			continue
+		}
+		d := Draft{
+			Ref:       get(FieldRef),
+			Title:     get(FieldTitle),
+			Type:      def.Type,
+			Priority:  def.Priority,
+			Labels:    splitList(get(FieldLabels), ",;|"),
+			Parent:    get(FieldParent),
+			DependsOn: splitList(get(FieldDepends), ",; "),
+		}
+		if d.Ref == "" {
+			d.Ref = strconv.Itoa(row)
+		}
+		if v := get(FieldType); v != "" {
+			if d.Type, err = csvType(v); err != nil {
+				return nil, fmt.Errorf("row %d: %w", row, err)
+			}
+		}
+		if v := get(FieldPriority); v != "" {
+			p, ok := parsePriority(v)
+			if !ok {
+				return nil, fmt.Errorf("row %d: unknown priority %q", row, v)
+			}
+			d.Priority = p
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, nil
+}
+
+// resolveColumns maps each field to its column index.
+func resolveColumns(header []string, mapping Mapping) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	cols := make(map[string]int)
+	for field, column := range mapping {
+		i, ok := index[strings.ToLower(column)]
+		if !ok {
+			return nil, fmt.Errorf("no column %q for %s", column, field)
+		}
+		cols[field] = i
+	}
+	for i, h := range header {
+		if field, ok := fieldAliases[strings.ToLower(strings.TrimSpace(h))]; ok {
+			if _, mapped := cols[field]; !mapped {
+				cols[field] = i
+			}
+		}
+	}
+	if _, ok := cols[FieldTitle]; !ok {
+		return nil, errors.New("no title column (use --map title=Column)")
+	}
+	return cols, nil
+}
+
+func csvType(s string) (data.IssueType, error) {
+	t, ok := parseType(s)
+	if !ok {
+		return "", fmt.Errorf("unknown type %q", s)
+	}
+	return t, nil
+}
+
+// splitList splits s on any of seps, dropping blanks.
+func splitList(s, seps string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return strings.ContainsRune(seps, r) })
+	var out []string
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping("title=Summary, priority = Prio")
+	if err != nil {
+		t.Fatalf("ParseMapping() error = %v", err)
+	}
+	want := Mapping{FieldTitle: "Summary", FieldPriority: "Prio"}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("ParseMapping() = %v, want %v", m, want)
+	}
+
+	for _, bad := range []string{"title", "title=", "colour=Red", "summary=Title"} {
+		if _, err := ParseMapping(bad); err == nil {
+			t.Errorf("ParseMapping(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := `Key,Summary,Issue Type,Prio,Tags,Parent,Blocked By
+A,Set up CI,chore,P1,"infra, ci",,
+B,Add lint step,task,high,ci|lint,A,A
+,Fix flaky test,bug,,,,B mg-12
+,,,,,,
+`
+	drafts, err := ParseCSV(strings.NewReader(input), Mapping{FieldPriority: "Prio"}, DefaultDefaults)
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	want := []Draft{
+		{Ref: "A", Title: "Set up CI", Type: data.TypeChore, Priority: data.PriorityHigh, Labels: []string{"infra", "ci"}},
+		{Ref: "B", Title: "Add lint step", Type: data.TypeTask, Priority: data.PriorityHigh, Labels: []string{"ci", "lint"}, Parent: "A", DependsOn: []string{"A"}},
+		{Ref: "4", Title: "Fix flaky test", Type: data.TypeBug, Priority: data.PriorityMedium, DependsOn: []string{"B", "mg-12"}},
+	}
+	if !reflect.DeepEqual(drafts, want) {
+		t.Errorf("ParseCSV() =\n%+v\nwant\n%+v", drafts, want)
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		mapping Mapping
+		errSub  string
+	}{
+		{"no title column", "Foo,Bar\n1,2\n", nil, "no title column"},
+		{"missing mapped column", "Title\nx\n", Mapping{FieldPriority: "Prio"}, `no column "Prio"`},
+		{"bad type", "Title,Type\nx,widget\n", nil, `row 2: unknown type "widget"`},
+		{"bad priority", "Title,Priority\nx,\nY,urgent\n", nil, `row 3: unknown priority "urgent"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.input), tt.mapping, DefaultDefaults)
+			if err == nil || !strings.Contains(err.Error(), tt.errSub) {
+				t.Fatalf("ParseCSV() error = %v, want %q", err, tt.errSub)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ghIssue is one issue from `gh issue list --json number,title,body,labels,state`.
+type ghIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// ghDependsRe finds "depends on #12" and "blocked by #12" in an issue body.
+var ghDependsRe = regexp.MustCompile(`(?i)\b(?:depends on|blocked by)\s+#(\d+)`)
+
+// ParseGitHub reads a JSON array of GitHub issues as written by
+// `gh issue list --json number,title,body,labels,state`. Refs are "#N".
+// Labels naming a type ("bug", "enhancement") or priority ("p1",
+// "priority: high") set those fields; the rest are kept as labels. Closed
+// issues are imported closed, and "depends on #N" or "blocked by #N" in the
+// body becomes a dependency when #N is also in the export.
+func ParseGitHub(r io.Reader, def Defaults) ([]Draft, error) {
+	var issues []ghIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("parse GitHub issues: %w", err)
+	}
+	drafts := make([]Draft, 0, len(issues))
+	for _, gi := range issues {
+		d := Draft{
+			Ref:      "#" + strconv.Itoa(gi.Number),
+			Title:    strings.TrimSpace(gi.Title),
+			Type:     def.Type,
+			Priority: def.Priority,
+			Closed:   strings.EqualFold(gi.State, "closed"),
+		}
+		for _, l := range gi.Labels {
+			if t, ok := parseType(l.Name); ok {
+				d.Type = t
+			} else if p, ok := parsePriority(l.Name); ok {
+				d.Priority = p
+			} else {
+				d.Labels = append(d.Labels, l.Name)
+			}
+		}
+		for _, m := range ghDependsRe.FindAllStringSubmatch(gi.Body, -1) {
+			d.DependsOn = append(d.DependsOn, "#"+m[1])
+		}
+		drafts = append(drafts, d)
+	}
+	// Drop references to issues outside the export.
+	refs := make(map[string]bool, len(drafts))
+	for _, d := range drafts {
+		refs[d.Ref] = true
+	}
+	for i := range drafts {
+		drafts[i].DependsOn = slices.DeleteFunc(drafts[i].DependsOn, func(ref string) bool { return !refs[ref] })
+	}
+	return drafts, nil
+}
@@ -0,0 +1,37 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestParseGitHub(t *testing.T) {
+	input := `[
+  {"number": 12, "title": "Crash on empty config", "state": "OPEN",
+   "body": "Depends on #10. Blocked by #3 which is not exported.",
+   "labels": [{"name": "bug"}, {"name": "priority: high"}, {"name": "area/config"}]},
+  {"number": 10, "title": "Config loader", "state": "CLOSED", "body": "",
+   "labels": [{"name": "enhancement"}]}
+]`
+	drafts, err := ParseGitHub(strings.NewReader(input), DefaultDefaults)
+	if err != nil {
+		t.Fatalf("ParseGitHub() error = %v", err)
+	}
+	want := []Draft{
+		{Ref: "#12", Title: "Crash on empty config", Type: data.TypeBug, Priority: data.PriorityHigh,
+			Labels: []string{"area/config"}, DependsOn: []string{"#10"}},
+		{Ref: "#10", Title: "Config loader", Type: data.TypeFeature, Priority: data.PriorityMedium, Closed: true},
+	}
+	if !reflect.DeepEqual(drafts, want) {
+		t.Errorf("ParseGitHub() =\n%+v\nwant\n%+v", drafts, want)
+	}
+}
+
+func TestParseGitHubInvalid(t *testing.T) {
+	if _, err := ParseGitHub(strings.NewReader(`{"not": "an array"}`), DefaultDefaults); err == nil {
+		t.Error("expected error for non-array JSON")
+	}
+}
@@ -0,0 +1,317 @@
+// Package importer turns planning documents (Markdown task lists, CSV
+// sheets and GitHub issue exports) into Beads issues. Parsing yields Drafts
+// that can be previewed and checked for duplicates before Apply creates them.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// Formats accepted by Parse.
+const (
+	FormatMarkdown = "md"
+	FormatCSV      = "csv"
+	FormatGitHub   = "github"
+)
+
+// Draft is one issue waiting to be created.
+type Draft struct {
+	Ref       string         `json:"ref"` // key other drafts use to point here (list position, CSV id, GitHub #N)
+	Title     string         `json:"title"`
+	Type      data.IssueType `json:"type"`
+	Priority  data.Priority  `json:"priority"`
+	Labels    []string       `json:"labels,omitempty"`
+	Parent    string         `json:"parent,omitempty"`     // Ref of the parent draft
+	DependsOn []string       `json:"depends_on,omitempty"` // Refs of other drafts, or existing issue IDs
+	Closed    bool           `json:"closed,omitempty"`     // checked task item or closed GitHub issue
+	Duplicate string         `json:"duplicate,omitempty"`  // ID of an existing issue with a similar title
+	Skip      bool           `json:"skip,omitempty"`       // left out by Apply
+}
+
+// Defaults fill in fields a source does not specify.
+type Defaults struct {
+	Type     data.IssueType
+	Priority data.Priority
+}
+
+// DefaultDefaults are used when the caller has no preference.
+var DefaultDefaults = Defaults{Type: data.TypeTask, Priority: data.PriorityMedium}
+
+// DetectFormat guesses the format from a file extension.
+func DetectFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".txt":
+		return FormatMarkdown, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatGitHub, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s; pass md, csv or github", filepath.Base(path))
+}
+
+// Validate checks that every draft has a title, refs are unique and parent
+// refs resolve to another draft or an existing issue ID.
+func Validate(drafts []Draft) error {
+	refs := make(map[string]bool, len(drafts))
+	for _, d := range drafts {
+		if refs[d.Ref] {
+			return fmt.Errorf("duplicate ref %q", d.Ref)
+		}
+		refs[d.Ref] = true
+	}
+	for _, d := range drafts {
+		if strings.TrimSpace(d.Title) == "" {
+			return fmt.Errorf("%s: empty title", d.Ref)
+		}
+		if d.Parent != "" && !refs[d.Parent] && !isIssueID(d.Parent) {
+			return fmt.Errorf("%s: unknown parent %q", d.Ref, d.Parent)
+		}
+		if d.Parent == d.Ref && d.Ref != "" {
+			return fmt.Errorf("%s: issue cannot be its own parent", d.Ref)
+		}
+	}
+	return nil
+}
+
+// MarkDuplicates flags drafts whose title matches an existing issue: an
+// exact (case-insensitive) title first, else the best fuzzy match of similar
+// length from data.FilterIssues over titles only. Flagged drafts are skipped unless the
+// caller clears Skip.
+func MarkDuplicates(drafts []Draft, existing []data.Issue) {
+	titles := make([]data.Issue, len(existing))
+	byTitle := make(map[string]string, len(existing))
+	for i, iss := range existing {
+		titles[i] = data.Issue{ID: iss.ID, Title: iss.Title}
+		byTitle[normalizeTitle(iss.Title)] = iss.ID
+	}
+	for i := range drafts {
+		d := &drafts[i]
+		if id, ok := byTitle[normalizeTitle(d.Title)]; ok {
+			d.Duplicate = id
+		} else if matches := data.FilterIssues(titles, d.Title); len(matches) < len(titles) {
+			// A query that filtered nothing out (only structured tokens such
+			// as "p1") says nothing about similarity.
+			d.Duplicate = closeMatch(d.Title, matches)
+		}
+		if d.Duplicate != "" {
+			d.Skip = true
+		}
+	}
+}
+
+// closeMatch returns the first fuzzy match whose title is about as long as
+// title. The fuzzy matcher accepts any subsequence, so without the length
+// check a short title would match most long ones.
+func closeMatch(title string, matches []data.Issue) string {
+	n := len(normalizeTitle(title))
+	for _, m := range matches {
+		if k := len(normalizeTitle(m.Title)); 4*n >= 3*k && 4*k >= 3*n {
+			return m.ID
+		}
+	}
+	return ""
+}
+
+func normalizeTitle(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// Creator performs the bd mutations Apply needs. BD is the real one.
+type Creator interface {
+	CreateIssue(title string, issueType data.IssueType, priority data.Priority) (string, error)
+	AddLabel(issueID, label string) error
+	AddParent(childID, parentID string) error
+	AddDependency(issueID, dependsOnID string) error
+	CloseIssue(issueID string) error
+}
+
+// BD creates issues through the bd CLI.
+type BD struct{}
+
+func (BD) CreateIssue(title string, t data.IssueType, p data.Priority) (string, error) {
+	return data.CreateIssue(title, t, p)
+}
+func (BD) AddLabel(id, label string) error          { return data.AddLabel(id, label) }
+func (BD) AddParent(child, parent string) error     { return data.AddParent(child, parent) }
+func (BD) AddDependency(id, dependsOn string) error { return data.AddDependency(id, dependsOn) }
+func (BD) CloseIssue(id string) error               { return data.CloseIssue(id) }
+
+// Created records one issue made by Apply.
+type Created struct {
+	Ref   string `json:"ref"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Result summarizes an Apply run.
+type Result struct {
+	Created []Created
+	Errors  []error
+}
+
+// Err joins every error from the run, or nil.
+func (r Result) Err() error { return errors.Join(r.Errors...) }
+
+// Apply creates every draft not marked Skip, then adds labels, parent links
+// and dependencies, and closes drafts marked Closed. A skipped duplicate
+// still resolves references to it, pointing them at the existing issue.
+// Failures are collected and the run continues.
+func Apply(c Creator, drafts []Draft) Result {
+	var res Result
+	ids := make(map[string]string, len(drafts))
+	for _, d := range drafts {
+		if d.Skip {
+			if d.Duplicate != "" {
+				ids[d.Ref] = d.Duplicate
+			}
+			continue
+		}
+		id, err := c.CreateIssue(d.Title, d.Type, d.Priority)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Errorf("%s %q: %w", d.Ref, d.Title, err))
+			continue
+		}
+		ids[d.Ref] = id
+		res.Created = append(res.Created, Created{Ref: d.Ref, ID: id, Title: d.Title})
+	}
+
+	resolve := func(ref string) string {
+		if id, ok := ids[ref]; ok {
+			return id
+		}
+		if isIssueID(ref) {
+			return ref
+		}
+		return ""
+	}
+	fail := func(d Draft, err error) {
+		res.Errors = append(res.Errors, fmt.Errorf("%s: %w", ids[d.Ref], err))
+	}
+	for _, d := range drafts {
+		id, ok := ids[d.Ref]
+		if d.Skip || !ok {
+			continue
+		}
+		for _, label := range d.Labels {
+			if err := c.AddLabel(id, label); err != nil {
+				fail(d, err)
+			}
+		}
+		if d.Parent != "" {
+			parent := resolve(d.Parent)
+			if parent == "" {
+				fail(d, fmt.Errorf("unknown parent %q", d.Parent))
+			} else if err := c.AddParent(id, parent); err != nil {
+				fail(d, err)
+			}
+		}
+		for _, dep := range d.DependsOn {
+			target := resolve(dep)
+			if target == "" {
+				fail(d, fmt.Errorf("unknown dependency %q", dep))
+				continue
+			}
+			if err := c.AddDependency(id, target); err != nil {
+				fail(d, err)
+			}
+		}
+		if d.Closed {
+			if err := c.CloseIssue(id); err != nil {
+				fail(d, err)
+			}
+		}
+	}
+	return res
+}
+
+// parsePriority accepts 0-4, p0-p4 and the priority names, optionally
+// prefixed with "priority:" as GitHub labels often are.
+func parsePriority(s string) (data.Priority, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimSpace(strings.TrimPrefix(s, "priority:"))
+	if n, err := strconv.Atoi(strings.TrimPrefix(s, "p")); err == nil && n >= 0 && n <= 4 {
+		return data.Priority(n), true
+	}
+	for p := data.PriorityCritical; p <= data.PriorityBacklog; p++ {
+		if strings.ToLower(data.PriorityName(p)) == s {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// issueTypes lists the types parseType accepts.
+var issueTypes = []data.IssueType{
+	data.TypeTask, data.TypeBug, data.TypeFeature, data.TypeChore,
+	data.TypeEpic, data.TypeSpike, data.TypeStory, data.TypeMilestone,
+}
+
+// parseType accepts an issue type name, plus GitHub's "enhancement".
+func parseType(s string) (data.IssueType, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "enhancement" {
+		return data.TypeFeature, true
+	}
+	for _, t := range issueTypes {
+		if string(t) == s {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// isIssueID reports whether ref names an existing issue rather than a row
+// or GitHub number.
+func isIssueID(ref string) bool {
+	return data.ValidateIssueID(ref) == nil && !strings.HasPrefix(ref, "#") && !isNumber(ref)
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// Options control parsing.
+type Options struct {
+	Defaults Defaults
+	Mapping  Mapping // CSV column mapping; nil uses the header names
+}
+
+// Parse reads drafts in format from r and validates them.
+func Parse(r io.Reader, format string, opts Options) ([]Draft, error) {
+	if opts.Defaults.Type == "" {
+		opts.Defaults.Type = DefaultDefaults.Type
+	}
+	t, ok := parseType(string(opts.Defaults.Type))
+	if !ok {
+		return nil, fmt.Errorf("unknown issue type %q", opts.Defaults.Type)
+	}
+	opts.Defaults.Type = t
+	var drafts []Draft
+	var err error
+	switch format {
+	case FormatMarkdown:
+		drafts, err = ParseMarkdown(r, opts.Defaults)
+	case FormatCSV:
+		drafts, err = ParseCSV(r, opts.Mapping, opts.Defaults)
+	case FormatGitHub:
+		drafts, err = ParseGitHub(r, opts.Defaults)
+	default:
+		return nil, fmt.Errorf("unknown format %q (want md, csv or github)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return nil, errors.New("no issues found")
+	}
+	return drafts, Validate(drafts)
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// fakeCreator records calls and hands out sequential IDs.
+type fakeCreator struct {
+	next   int
+	calls  []string
+	failOn string // title whose CreateIssue fails
+}
+
+func (f *fakeCreator) CreateIssue(title string, t data.IssueType, p data.Priority) (string, error) {
+	if title == f.failOn {
+		return "", errors.New("bd exploded")
+	}
+	f.next++
+	id := fmt.Sprintf("mg-%d", f.next)
+	f.calls = append(f.calls, fmt.Sprintf("create %s %s %s p%d", id, title, t, p))
+	return id, nil
+}
+
+func (f *fakeCreator) AddLabel(id, label string) error {
+	f.calls = append(f.calls, "label "+id+" "+label)
+	return nil
+}
+
+func (f *fakeCreator) AddParent(child, parent string) error {
+	f.calls = append(f.calls, "parent "+child+" "+parent)
+	return nil
+}
+
+func (f *fakeCreator) AddDependency(id, dependsOn string) error {
+	f.calls = append(f.calls, "dep "+id+" "+dependsOn)
+	return nil
+}
+
+func (f *fakeCreator) CloseIssue(id string) error {
+	f.calls = append(f.calls, "close "+id)
+	return nil
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"plan.md", FormatMarkdown, false},
+		{"PLAN.MARKDOWN", FormatMarkdown, false},
+		{"sheet.csv", FormatCSV, false},
+		{"issues.json", FormatGitHub, false},
+		{"notes.docx", "", true},
+	}
+	for _, tt := range tests {
+		got, err := DetectFormat(tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("DetectFormat(%q) = %q, %v; want %q, err=%v", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		drafts []Draft
+		errSub string
+	}{
+		{"ok", []Draft{{Ref: "1", Title: "a"}, {Ref: "2", Title: "b", Parent: "1"}}, ""},
+		{"duplicate ref", []Draft{{Ref: "1", Title: "a"}, {Ref: "1", Title: "b"}}, "duplicate ref"},
+		{"empty title", []Draft{{Ref: "1", Title: " "}}, "empty title"},
+		{"unknown parent", []Draft{{Ref: "1", Title: "a", Parent: "9"}}, "unknown parent"},
+		{"existing parent", []Draft{{Ref: "1", Title: "a", Parent: "mg-7"}}, ""},
+		{"self parent", []Draft{{Ref: "1", Title: "a", Parent: "1"}}, "own parent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.drafts)
+			if tt.errSub == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSub) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.errSub)
+			}
+		})
+	}
+}
+
+func TestMarkDuplicates(t *testing.T) {
+	existing := []data.Issue{
+		{ID: "mg-1", Title: "Fix login redirect"},
+		{ID: "mg-2", Title: "Write onboarding documentation for new contributors"},
+	}
+	drafts := []Draft{
+		{Ref: "1", Title: "fix  LOGIN redirect"},
+		{Ref: "2", Title: "Fix login redir"},
+		{Ref: "3", Title: "Add dark mode"},
+		{Ref: "4", Title: "Write docs"},
+	}
+	MarkDuplicates(drafts, existing)
+
+	want := []string{"mg-1", "mg-1", "", ""}
+	for i, d := range drafts {
+		if d.Duplicate != want[i] {
+			t.Errorf("draft %s Duplicate = %q, want %q", d.Ref, d.Duplicate, want[i])
+		}
+		if d.Skip != (want[i] != "") {
+			t.Errorf("draft %s Skip = %v", d.Ref, d.Skip)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	drafts := []Draft{
+		{Ref: "1", Title: "Epic", Type: data.TypeEpic, Priority: data.PriorityHigh, Labels: []string{"q3"}},
+		{Ref: "2", Title: "Child", Type: data.TypeTask, Priority: data.PriorityMedium, Parent: "1", Closed: true},
+		{Ref: "3", Title: "Existing", Skip: true, Duplicate: "mg-99"},
+		{Ref: "4", Title: "Follow-up", Type: data.TypeTask, Priority: data.PriorityLow, DependsOn: []string{"2", "3", "mg-7"}},
+	}
+	c := &fakeCreator{}
+	res := Apply(c, drafts)
+	if err := res.Err(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := []string{
+		"create mg-1 Epic epic p1",
+		"create mg-2 Child task p2",
+		"create mg-3 Follow-up task p3",
+		"label mg-1 q3",
+		"parent mg-2 mg-1",
+		"close mg-2",
+		"dep mg-3 mg-2",
+		"dep mg-3 mg-99",
+		"dep mg-3 mg-7",
+	}
+	if !reflect.DeepEqual(c.calls, want) {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(c.calls, "\n"), strings.Join(want, "\n"))
+	}
+	if len(res.Created) != 3 || res.Created[2] != (Created{Ref: "4", ID: "mg-3", Title: "Follow-up"}) {
+		t.Errorf("Created = %+v", res.Created)
+	}
+}
+
+func TestApplyCollectsErrors(t *testing.T) {
+	drafts := []Draft{
+		{Ref: "1", Title: "Broken"},
+		{Ref: "2", Title: "Fine", DependsOn: []string{"1"}},
+		{Ref: "3", Title: "Orphan", Parent: "1"},
+	}
+	c := &fakeCreator{failOn: "Broken"}
+	res := Apply(c, drafts)
+	if len(res.Created) != 2 {
+		t.Fatalf("Created = %+v, want 1", res.Created)
+	}
+	err := res.Err()
+	if err == nil || !strings.Contains(err.Error(), "bd exploded") || !strings.Contains(err.Error(), `unknown dependency "1"`) ||
+		!strings.Contains(err.Error(), `unknown parent "1"`) {
+		t.Fatalf("Err() = %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	drafts, err := Parse(strings.NewReader("- [ ] One\n  - [ ] Two\n"), FormatMarkdown, Options{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(drafts) != 2 || drafts[0].Type != data.TypeTask || drafts[1].Parent != "1" {
+		t.Errorf("drafts = %+v", drafts)
+	}
+
+	if _, err := Parse(strings.NewReader("just prose\n"), FormatMarkdown, Options{}); err == nil {
+		t.Error("expected error for a file with no issues")
+	}
+	if _, err := Parse(strings.NewReader(""), "xml", Options{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		in   string
+		want data.Priority
+		ok   bool
+	}{
+		{"0", data.PriorityCritical, true},
+		{"P1", data.PriorityHigh, true},
+		{"medium", data.PriorityMedium, true},
+		{"priority: low", data.PriorityLow, true},
+		{"Backlog", data.PriorityBacklog, true},
+		{"7", 0, false},
+		{"urgent", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePriority(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parsePriority(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseMarkdown reads a Markdown task list. Every "- [ ]" item (also "*" and
+// "+" bullets) becomes a draft; an item indented under another becomes its
+// child, and checked items are imported closed. Other lines are ignored.
+// Refs are the items' 1-based positions.
+func ParseMarkdown(r io.Reader, def Defaults) ([]Draft, error) {
+	type level struct {
+		indent int
+		ref    string
+	}
+	var drafts []Draft
+	var stack []level
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		indent, title, checked, ok := parseTaskItem(sc.Text())
+		if !ok {
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		d := Draft{
+			Ref:      strconv.Itoa(len(drafts) + 1),
+			Title:    title,
+			Type:     def.Type,
+			Priority: def.Priority,
+			Closed:   checked,
+		}
+		if len(stack) > 0 {
+			d.Parent = stack[len(stack)-1].ref
+		}
+		stack = append(stack, level{indent: indent, ref: d.Ref})
+		drafts = append(drafts, d)
+	}
+	return drafts, sc.Err()
+}
+
+// parseTaskItem recognizes "<indent><bullet> [ ] title". A tab counts as
+// four spaces of indent.
+func parseTaskItem(line string) (indent int, title string, checked, ok bool) {
+	rest := strings.TrimLeft(line, " \t")
+	for _, c := range line[:len(line)-len(rest)] {
+		if c == '\t' {
+			indent += 4
+		} else {
+			indent++
+		}
+	}
+	if len(rest) < 2 || !strings.ContainsRune("-*+", rune(rest[0])) || rest[1] != ' ' {
+		return 0, "", false, false
+	}
+	rest = strings.TrimLeft(rest[2:], " ")
+	if len(rest) < 3 || rest[0] != '[' || rest[2] != ']' {
+		return 0, "", false, false
+	}
+	switch rest[1] {
+	case ' ':
+	case 'x', 'X':
+		checked = true
+	default:
+		return 0, "", false, false
+	}
+	title = strings.TrimSpace(rest[3:])
+	if title == "" {
+		return 0, "", false, false
+	}
+	return indent, title, checked, true
+}
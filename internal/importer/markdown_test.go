@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	input := `# Release plan
+
+Some intro text.
+
+- [ ] Ship importer
+  - [x] Markdown parser
+  - [ ] CSV parser
+    * [ ] Column mapping
+- [ ] Write docs
+	+ [X] README section
+- plain bullet, not a task
+- [?] not a task either
+- [ ]
+`
+	drafts, err := ParseMarkdown(strings.NewReader(input), Defaults{Type: data.TypeFeature, Priority: data.PriorityLow})
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	want := []struct {
+		title  string
+		parent string
+		closed bool
+	}{
+		{"Ship importer", "", false},
+		{"Markdown parser", "1", true},
+		{"CSV parser", "1", false},
+		{"Column mapping", "3", false},
+		{"Write docs", "", false},
+		{"README section", "5", true},
+	}
+	if len(drafts) != len(want) {
+		t.Fatalf("got %d drafts, want %d: %+v", len(drafts), len(want), drafts)
+	}
+	for i, w := range want {
+		d := drafts[i]
+		if d.Title != w.title || d.Parent != w.parent || d.Closed != w.closed {
+			t.Errorf("draft %d = {%q parent=%q closed=%v}, want {%q parent=%q closed=%v}",
+				i, d.Title, d.Parent, d.Closed, w.title, w.parent, w.closed)
+		}
+		if d.Type != data.TypeFeature || d.Priority != data.PriorityLow {
+			t.Errorf("draft %d defaults = %s/%d", i, d.Type, d.Priority)
+		}
+	}
+}
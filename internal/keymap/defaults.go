@@ -0,0 +1,247 @@
+package keymap
+
+// Actions. The same action name can be bound in several scopes.
+const (
+	// Global
+	Quit            Action = "quit"
+	SwitchPane      Action = "switch_pane"
+	Help            Action = "help"
+	Palette         Action = "palette"
+	Back            Action = "back"
+	Filter          Action = "filter"
+	FocusMode       Action = "focus_mode"
+	ToggleClosed    Action = "toggle_closed"
+	ToggleProblems  Action = "toggle_problems"
+	ToggleDoctor    Action = "toggle_doctor"
+	ToggleTimeline  Action = "toggle_timeline"
+	OpenCalendar    Action = "calendar"
+	ToggleCharts    Action = "toggle_charts"
+	GrowParade      Action = "grow_parade"
+	ShrinkParade    Action = "shrink_parade"
+	SetInProgress   Action = "set_in_progress"
+	SetOpen         Action = "set_open"
+	CloseIssue      Action = "close_issue"
+	PriorityHigh    Action = "priority_high"
+	PriorityMedium  Action = "priority_medium"
+	PriorityLow     Action = "priority_low"
+	PriorityBacklog Action = "priority_backlog"
+	CopyBranch      Action = "copy_branch"
+	CreateBranch    Action = "create_branch"
+	CreateWorktree  Action = "create_worktree"
+	OpenPR          Action = "open_pr"
+	NewIssue        Action = "new_issue"
+	EditIssue       Action = "edit_issue"
+	Comment         Action = "comment"
+	Assign          Action = "assign"
+	AddLabel        Action = "add_label"
+	Link            Action = "link"
+	LaunchAgent     Action = "launch_agent"
+	KillAgent       Action = "kill_agent"
+	SlingFormula    Action = "sling_formula"
+	Nudge           Action = "nudge"
+	ToggleGasTown   Action = "toggle_gastown"
+	CreateConvoy    Action = "create_convoy"
+
+	// Lists: parade, detail, Gas Town, problems and forms
+	Down   Action = "down"
+	Up     Action = "up"
+	Top    Action = "top"
+	Bottom Action = "bottom"
+
+	// Parade
+	Open           Action = "open"
+	ToggleSelect   Action = "toggle_select"
+	SelectDown     Action = "select_down"
+	SelectUp       Action = "select_up"
+	ClearSelection Action = "clear_selection"
+	BulkEdit       Action = "bulk_edit"
+	ToggleSection  Action = "toggle_section"
+	ExpandSections Action = "expand_sections"
+	ToggleRows     Action = "toggle_rows"
+
+	// Detail
+	PrevRef  Action = "prev_ref"
+	NextRef  Action = "next_ref"
+	OpenRef  Action = "open_ref"
+	StepDone Action = "step_done"
+	PrevTab  Action = "prev_tab"
+	NextTab  Action = "next_tab"
+
+	// Gas Town panel and problems
+	NextSection  Action = "next_section"
+	Expand       Action = "expand"
+	Handoff      Action = "handoff"
+	Decommission Action = "decommission"
+	Land         Action = "land"
+	CloseConvoy  Action = "close_convoy"
+	Reply        Action = "reply"
+	Archive      Action = "archive"
+	MarkAllRead  Action = "mark_all_read"
+	Watch        Action = "watch"
+	Unwatch      Action = "unwatch"
+	RecoverRig   Action = "recover_rig"
+
+	// Doctor, timeline, calendar and charts
+	Refresh    Action = "refresh"
+	Left       Action = "left"
+	Right      Action = "right"
+	ZoomIn     Action = "zoom_in"
+	ZoomOut    Action = "zoom_out"
+	Today      Action = "today"
+	PrevPeriod Action = "prev_period"
+	NextPeriod Action = "next_period"
+	ToggleWeek Action = "toggle_week"
+	MoveDate   Action = "move_date"
+	DayLater   Action = "day_later"
+	DayEarlier Action = "day_earlier"
+	WeekLater  Action = "week_later"
+	ClearDate  Action = "clear_date"
+	NextWindow Action = "next_window"
+	PrevWindow Action = "prev_window"
+
+	// Forms and dialogs
+	Cancel    Action = "cancel"
+	Submit    Action = "submit"
+	NextField Action = "next_field"
+	PrevField Action = "prev_field"
+	Toggle    Action = "toggle"
+	Close     Action = "close"
+	Yes       Action = "yes"
+	No        Action = "no"
+)
+
+// defaults are the built-in bindings, in help order.
+var defaults = []Binding{
+	{Global, Quit, []string{"q"}, "Quit application", "GLOBAL"},
+	{Global, SwitchPane, []string{"tab"}, "Switch active pane", "GLOBAL"},
+	{Global, Help, []string{"?"}, "Toggle help", "GLOBAL"},
+	{Global, Palette, []string{":", "ctrl+k"}, "Open command palette", "GLOBAL"},
+	{Global, Back, []string{"esc"}, "Leave focus mode, or back to parade pane", "GLOBAL"},
+	{Global, Filter, []string{"/"}, "Enter filter mode (fuzzy)", "GLOBAL"},
+	{Global, FocusMode, []string{"f"}, "Toggle focus mode (my work + top priority)", "GLOBAL"},
+	{Global, ToggleClosed, []string{"c"}, "Toggle closed issues", "GLOBAL"},
+	{Global, ToggleProblems, []string{"p"}, "Toggle problems view (gt)", "GLOBAL"},
+	{Global, ToggleDoctor, []string{"D"}, "Toggle doctor diagnostics", "GLOBAL"},
+	{Global, ToggleTimeline, []string{"T"}, "Toggle timeline view", "GLOBAL"},
+	{Global, OpenCalendar, []string{"M"}, "Open calendar (due/defer dates)", "GLOBAL"},
+	{Global, ToggleCharts, []string{"V"}, "Toggle flow charts", "GLOBAL"},
+	{Global, GrowParade, []string{">"}, "Grow the parade split", "GLOBAL"},
+	{Global, ShrinkParade, []string{"<"}, "Shrink the parade split", "GLOBAL"},
+
+	{Parade, Down, []string{"j", "down"}, "Move down", "PARADE"},
+	{Parade, Up, []string{"k", "up"}, "Move up", "PARADE"},
+	{Parade, Top, []string{"g"}, "Jump to top", "PARADE"},
+	{Parade, Bottom, []string{"G"}, "Jump to bottom", "PARADE"},
+	{Parade, Open, []string{"enter"}, "Focus detail pane", "PARADE"},
+	{Parade, ToggleSection, []string{"z"}, "Collapse/expand the cursor's section", "PARADE"},
+	{Parade, ExpandSections, []string{"Z"}, "Expand all collapsed sections", "PARADE"},
+	{Parade, ToggleRows, []string{"+"}, "Show all rows of a capped section, or cap it again", "PARADE"},
+
+	{Global, SetInProgress, []string{"1"}, "Set status: in_progress", "QUICK ACTIONS"},
+	{Global, SetOpen, []string{"2"}, "Set status: open", "QUICK ACTIONS"},
+	{Global, CloseIssue, []string{"3"}, "Close issue", "QUICK ACTIONS"},
+	{Global, PriorityHigh, []string{"!"}, "Set priority: P1 (high)", "QUICK ACTIONS"},
+	{Global, PriorityMedium, []string{"@"}, "Set priority: P2 (medium)", "QUICK ACTIONS"},
+	{Global, PriorityLow, []string{"#"}, "Set priority: P3 (low)", "QUICK ACTIONS"},
+	{Global, PriorityBacklog, []string{"$"}, "Set priority: P4 (backlog)", "QUICK ACTIONS"},
+	{Global, CopyBranch, []string{"b"}, "Copy branch name to clipboard", "QUICK ACTIONS"},
+	{Global, CreateBranch, []string{"B"}, "Create + checkout git branch", "QUICK ACTIONS"},
+	{Global, CreateWorktree, []string{"w"}, "Create git worktree on issue branch", "QUICK ACTIONS"},
+	{Global, OpenPR, []string{"O"}, "Open issue pull request (gh)", "QUICK ACTIONS"},
+	{Global, NewIssue, []string{"N"}, "Create new issue (from template)", "QUICK ACTIONS"},
+	{Global, EditIssue, []string{"e"}, "Edit title and priority", "QUICK ACTIONS"},
+	{Global, Comment, []string{"r"}, "Add a comment", "QUICK ACTIONS"},
+	{Global, Assign, []string{"y"}, "Assign the issue", "QUICK ACTIONS"},
+	{Global, AddLabel, []string{"t"}, "Add a label", "QUICK ACTIONS"},
+	{Global, Link, []string{"l"}, "Add a dependency", "QUICK ACTIONS"},
+
+	{Parade, ToggleSelect, []string{"space", "x"}, "Toggle select on cursor issue", "MULTI-SELECT"},
+	{Parade, SelectDown, []string{"J"}, "Select and move down", "MULTI-SELECT"},
+	{Parade, SelectUp, []string{"K"}, "Select and move up", "MULTI-SELECT"},
+	{Parade, ClearSelection, []string{"X"}, "Clear all selections", "MULTI-SELECT"},
+	{Parade, BulkEdit, []string{"E"}, "Bulk edit selected (priority, labels, dates...)", "MULTI-SELECT"},
+
+	{Global, LaunchAgent, []string{"a"}, "Launch agent, or sling to polecat (gt); slings the selection", "AGENTS"},
+	{Global, KillAgent, []string{"A"}, "Kill or unsling the issue's agent", "AGENTS"},
+	{Global, SlingFormula, []string{"s"}, "Pick formula and sling to polecat (gt)", "AGENTS"},
+	{Global, Nudge, []string{"n"}, "Nudge the issue's agent (gt)", "AGENTS"},
+	{Global, ToggleGasTown, []string{"ctrl+g"}, "Toggle Gas Town panel (gt)", "AGENTS"},
+	{Global, CreateConvoy, []string{"C"}, "Create convoy from selection or epic (gt)", "AGENTS"},
+
+	{Detail, Down, []string{"j", "down"}, "Scroll down", "DETAIL"},
+	{Detail, Up, []string{"k", "up"}, "Scroll up", "DETAIL"},
+	{Detail, PrevTab, []string{"H", "left"}, "Previous tab", "DETAIL"},
+	{Detail, NextTab, []string{"L", "right"}, "Next tab", "DETAIL"},
+	{Detail, PrevRef, []string{"["}, "Previous code reference", "DETAIL"},
+	{Detail, NextRef, []string{"]"}, "Next code reference", "DETAIL"},
+	{Detail, OpenRef, []string{"o"}, "Open code reference in $EDITOR", "DETAIL"},
+	{Detail, StepDone, []string{"m"}, "Mark active molecule step done", "DETAIL"},
+
+	{GasTown, NextSection, []string{"tab"}, "Switch section (agents/convoys/mail)", "GAS TOWN PANEL"},
+	{GasTown, Down, []string{"j", "down"}, "Move down", "GAS TOWN PANEL"},
+	{GasTown, Up, []string{"k", "up"}, "Move up", "GAS TOWN PANEL"},
+	{GasTown, Top, []string{"g"}, "Jump to first", "GAS TOWN PANEL"},
+	{GasTown, Bottom, []string{"G"}, "Jump to last", "GAS TOWN PANEL"},
+	{GasTown, Expand, []string{"enter"}, "Expand/collapse convoy or message", "GAS TOWN PANEL"},
+	{GasTown, Nudge, []string{"n"}, "Nudge selected agent", "GAS TOWN PANEL"},
+	{GasTown, Handoff, []string{"h"}, "Handoff work from agent", "GAS TOWN PANEL"},
+	{GasTown, Decommission, []string{"K"}, "Decommission polecat", "GAS TOWN PANEL"},
+	{GasTown, Land, []string{"l"}, "Land convoy", "GAS TOWN PANEL"},
+	{GasTown, CloseConvoy, []string{"x"}, "Close convoy", "GAS TOWN PANEL"},
+	{GasTown, Watch, []string{"w"}, "Watch convoy, or compose a message", "GAS TOWN PANEL"},
+	{GasTown, Unwatch, []string{"W"}, "Unwatch convoy", "GAS TOWN PANEL"},
+	{GasTown, Reply, []string{"r"}, "Reply to selected message", "GAS TOWN PANEL"},
+	{GasTown, Archive, []string{"d"}, "Archive selected message", "GAS TOWN PANEL"},
+	{GasTown, MarkAllRead, []string{"R"}, "Mark all mail read", "GAS TOWN PANEL"},
+
+	{Problems, Down, []string{"j", "down"}, "Move down", "PROBLEMS"},
+	{Problems, Up, []string{"k", "up"}, "Move up", "PROBLEMS"},
+	{Problems, Top, []string{"g"}, "Jump to first", "PROBLEMS"},
+	{Problems, Bottom, []string{"G"}, "Jump to last", "PROBLEMS"},
+	{Problems, Nudge, []string{"n"}, "Nudge agent on selected problem", "PROBLEMS"},
+	{Problems, Handoff, []string{"h"}, "Handoff from agent", "PROBLEMS"},
+	{Problems, Decommission, []string{"K"}, "Decommission polecat", "PROBLEMS"},
+	{Problems, RecoverRig, []string{"R"}, "Recover dead rig (opens confirmation)", "PROBLEMS"},
+
+	{Doctor, Down, []string{"j", "down"}, "Move down", "DOCTOR"},
+	{Doctor, Up, []string{"k", "up"}, "Move up", "DOCTOR"},
+	{Doctor, Top, []string{"g"}, "Jump to first", "DOCTOR"},
+	{Doctor, Bottom, []string{"G"}, "Jump to last", "DOCTOR"},
+	{Doctor, Refresh, []string{"R"}, "Rerun the checks", "DOCTOR"},
+
+	{Timeline, ZoomIn, []string{"+", "="}, "Zoom to day scale", "TIMELINE"},
+	{Timeline, ZoomOut, []string{"-"}, "Zoom to week scale", "TIMELINE"},
+	{Timeline, Left, []string{"h", "left"}, "Pan earlier", "TIMELINE"},
+	{Timeline, Right, []string{"l", "right"}, "Pan later", "TIMELINE"},
+	{Timeline, Today, []string{"."}, "Back to today", "TIMELINE"},
+
+	{Calendar, Left, []string{"h", "left"}, "Previous day", "CALENDAR"},
+	{Calendar, Right, []string{"l", "right"}, "Next day", "CALENDAR"},
+	{Calendar, Up, []string{"k", "up"}, "Previous week, or previous issue in a day", "CALENDAR"},
+	{Calendar, Down, []string{"j", "down"}, "Next week, or next issue in a day", "CALENDAR"},
+	{Calendar, PrevPeriod, []string{"["}, "Previous month (or week)", "CALENDAR"},
+	{Calendar, NextPeriod, []string{"]"}, "Next month (or week)", "CALENDAR"},
+	{Calendar, Today, []string{"t"}, "Back to today", "CALENDAR"},
+	{Calendar, ToggleWeek, []string{"w"}, "Toggle month/week view", "CALENDAR"},
+	{Calendar, Open, []string{"enter"}, "List the day's issues, jump to one, or confirm a move", "CALENDAR"},
+	{Calendar, MoveDate, []string{"m"}, "Move the issue to another day", "CALENDAR"},
+	{Calendar, DayLater, []string{"+", "="}, "Shift the issue a day later", "CALENDAR"},
+	{Calendar, DayEarlier, []string{"-"}, "Shift the issue a day earlier", "CALENDAR"},
+	{Calendar, WeekLater, []string{">"}, "Shift the issue a week later", "CALENDAR"},
+	{Calendar, ClearDate, []string{"x"}, "Clear the date", "CALENDAR"},
+	{Calendar, Close, []string{"esc", "q"}, "Back, or close the calendar", "CALENDAR"},
+
+	{Charts, NextWindow, []string{"w"}, "Next window (2w, 6w, quarter)", "CHARTS"},
+	{Charts, PrevWindow, []string{"W"}, "Previous window", "CHARTS"},
+
+	{Forms, Cancel, []string{"esc"}, "Cancel, or go back a step", "FORMS"},
+	{Forms, Submit, []string{"enter"}, "Next field, or submit on the last", "FORMS"},
+	{Forms, NextField, []string{"tab"}, "Next field", "FORMS"},
+	{Forms, PrevField, []string{"shift+tab"}, "Previous field", "FORMS"},
+	{Forms, Down, []string{"j", "down"}, "Next option", "FORMS"},
+	{Forms, Up, []string{"k", "up"}, "Previous option", "FORMS"},
+	{Forms, Toggle, []string{"space"}, "Skip or keep the draft (import)", "FORMS"},
+	{Forms, Close, []string{"q"}, "Close a list dialog", "FORMS"},
+	{Forms, Yes, []string{"y"}, "Confirm", "FORMS"},
+	{Forms, No, []string{"n"}, "Back from confirmation", "FORMS"},
+}
@@ -0,0 +1,265 @@
+// Package keymap is the registry of mg's key bindings. Each binding ties an
+// action in a scope (the pane or dialog that has focus) to its keys and help
+// text; key handlers look actions up here instead of matching keys, so a
+// remap from the keys config section reaches the handlers, the help overlay
+// and the footer alike.
+package keymap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Scope is the context a binding applies in.
+type Scope string
+
+// Binding scopes. Global bindings work whenever the parade or detail pane
+// has focus and win over Parade and Detail ones; the focused Gas Town,
+// problems, doctor, timeline and charts panels see their keys before
+// Global, and the calendar sees only its own.
+const (
+	Global   Scope = "global"
+	Parade   Scope = "parade"
+	Detail   Scope = "detail"
+	GasTown  Scope = "gastown"
+	Problems Scope = "problems"
+	Doctor   Scope = "doctor"
+	Timeline Scope = "timeline"
+	Calendar Scope = "calendar"
+	Charts   Scope = "charts"
+	Forms    Scope = "forms"
+)
+
+// Scopes lists every scope, in help order.
+var Scopes = []Scope{Global, Parade, Detail, GasTown, Problems, Doctor, Timeline, Calendar, Charts, Forms}
+
+// Action names what a key does within a scope. It is the name used in the
+// keys config section, after the scope: "parade.down".
+type Action string
+
+// Binding ties an action to its keys.
+type Binding struct {
+	Scope   Scope
+	Action  Action
+	Keys    []string // Bubble Tea key names: "j", "ctrl+g", "space", "shift+tab"
+	Help    string   // description in the help overlay
+	Section string   // help overlay section title
+}
+
+// Name is the binding's config name, "scope.action".
+func (b Binding) Name() string {
+	return string(b.Scope) + "." + string(b.Action)
+}
+
+// Keymap is a full set of bindings.
+type Keymap struct {
+	bindings []Binding
+}
+
+// Default returns the built-in bindings.
+func Default() Keymap {
+	bs := make([]Binding, len(defaults))
+	for i, b := range defaults {
+		b.Keys = slices.Clone(b.Keys)
+		bs[i] = b
+	}
+	return Keymap{bindings: bs}
+}
+
+// active is the keymap the package-level lookups use.
+var active = Default()
+
+// Use makes k the keymap for Lookup, Keys and the generated help.
+func Use(k Keymap) {
+	active = k
+}
+
+// Active returns the keymap in use.
+func Active() Keymap {
+	return active
+}
+
+// Lookup returns the action key triggers in scope, or "" when unbound.
+func Lookup(scope Scope, key string) Action {
+	return active.Lookup(scope, key)
+}
+
+// Keys returns the keys bound to action in scope.
+func Keys(scope Scope, action Action) []string {
+	return active.Keys(scope, action)
+}
+
+// Label is the short form of action's first key, for footers and the
+// palette: "ctrl+g" shows as "^g".
+func Label(scope Scope, action Action) string {
+	keys := active.Keys(scope, action)
+	if len(keys) == 0 {
+		return ""
+	}
+	return ShortKey(keys[0])
+}
+
+// Bindings returns every binding in the active keymap, in help order.
+func Bindings() []Binding {
+	return active.Bindings()
+}
+
+// Lookup returns the action key triggers in scope, or "" when unbound.
+func (k Keymap) Lookup(scope Scope, key string) Action {
+	for _, b := range k.bindings {
+		if b.Scope == scope && slices.Contains(b.Keys, key) {
+			return b.Action
+		}
+	}
+	return ""
+}
+
+// Keys returns the keys bound to action in scope.
+func (k Keymap) Keys(scope Scope, action Action) []string {
+	if i := k.index(scope, action); i >= 0 {
+		return k.bindings[i].Keys
+	}
+	return nil
+}
+
+// Bindings returns every binding, in help order.
+func (k Keymap) Bindings() []Binding {
+	return slices.Clone(k.bindings)
+}
+
+func (k Keymap) index(scope Scope, action Action) int {
+	return slices.IndexFunc(k.bindings, func(b Binding) bool {
+		return b.Scope == scope && b.Action == action
+	})
+}
+
+// Override rebinds actions from the keys config section, which maps
+// "scope.action" to a comma-separated key list:
+//
+//	keys:
+//	  global.quit: Q
+//	  parade.down: n, down
+//
+// Each override replaces all of the action's keys. Unknown names and keys
+// that would clash with another binding are skipped, and the first problem
+// is returned alongside the rest applied.
+func (k Keymap) Override(overrides map[string]string) (Keymap, error) {
+	out := Keymap{bindings: slices.Clone(k.bindings)}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var firstErr error
+	keep := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, name := range names {
+		scope, action, _ := strings.Cut(name, ".")
+		i := out.index(Scope(scope), Action(action))
+		if i < 0 {
+			keep(fmt.Errorf("keys: unknown binding %q", name))
+			continue
+		}
+		keys := ParseKeys(overrides[name])
+		if len(keys) == 0 {
+			keep(fmt.Errorf("keys: %s: no keys given", name))
+			continue
+		}
+		if clash := out.clash(i, keys); clash != "" {
+			keep(fmt.Errorf("keys: %s: %s", name, clash))
+			continue
+		}
+		out.bindings[i].Keys = keys
+	}
+	return out, firstErr
+}
+
+// clash describes the first binding that keys would collide with if given
+// to binding i: one in the same scope, or across Global and the pane
+// scopes it shadows.
+func (k Keymap) clash(i int, keys []string) string {
+	scope := k.bindings[i].Scope
+	for j, b := range k.bindings {
+		if j == i || !shadows(scope, b.Scope) {
+			continue
+		}
+		for _, key := range keys {
+			if slices.Contains(b.Keys, key) {
+				return fmt.Sprintf("%q is already bound to %s", key, b.Name())
+			}
+		}
+	}
+	return ""
+}
+
+// shadows reports whether bindings in scopes a and b can see the same key
+// press: the same scope, or Global with a pane it handles keys for.
+func shadows(a, b Scope) bool {
+	if a == b {
+		return true
+	}
+	pane := func(s Scope) bool { return s == Parade || s == Detail }
+	return (a == Global && pane(b)) || (b == Global && pane(a))
+}
+
+// ParseKeys splits a comma-separated key list, trimming spaces. A lone ","
+// is the comma key itself.
+func ParseKeys(s string) []string {
+	if strings.TrimSpace(s) == "," {
+		return []string{","}
+	}
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ShortKey abbreviates a key name for tight spaces: "ctrl+g" becomes "^g".
+func ShortKey(key string) string {
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "^" + rest
+	}
+	return key
+}
+
+// Hint is one "keys description" entry of a footer or hint bar.
+type Hint struct {
+	Scope   Scope
+	Desc    string
+	Actions []Action
+}
+
+// NewHint describes actions bound in scope.
+func NewHint(scope Scope, desc string, actions ...Action) Hint {
+	return Hint{Scope: scope, Desc: desc, Actions: actions}
+}
+
+// Key is the short form of each action's first key, joined by "/": "j/k".
+func (h Hint) Key() string {
+	keys := make([]string, 0, len(h.Actions))
+	for _, a := range h.Actions {
+		if k := Label(h.Scope, a); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return strings.Join(keys, "/")
+}
+
+// HintBar renders hints inline: "n nudge  j/k navigate".
+func HintBar(hints ...Hint) string {
+	parts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		parts = append(parts, h.Key()+" "+h.Desc)
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,109 @@
+package keymap
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultsDoNotClash(t *testing.T) {
+	k := Default()
+	for i, b := range k.bindings {
+		if clash := k.clash(i, b.Keys); clash != "" {
+			t.Errorf("%s: %s", b.Name(), clash)
+		}
+		if b.Help == "" || b.Section == "" {
+			t.Errorf("%s: missing help text or section", b.Name())
+		}
+	}
+}
+
+func TestLookupIsScoped(t *testing.T) {
+	k := Default()
+	if got := k.Lookup(Parade, "j"); got != Down {
+		t.Errorf("parade j = %q, want down", got)
+	}
+	if got := k.Lookup(GasTown, "l"); got != Land {
+		t.Errorf("gastown l = %q, want land", got)
+	}
+	if got := k.Lookup(Global, "l"); got != Link {
+		t.Errorf("global l = %q, want link", got)
+	}
+	if got := k.Lookup(Parade, "l"); got != "" {
+		t.Errorf("parade l = %q, want unbound", got)
+	}
+}
+
+func TestOverrideRebinds(t *testing.T) {
+	k, err := Default().Override(map[string]string{
+		"global.quit": "Q",
+		"parade.down": "ctrl+j, down",
+	})
+	if err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+	if k.Lookup(Global, "q") != "" || k.Lookup(Global, "Q") != Quit {
+		t.Error("quit not moved from q to Q")
+	}
+	if got := k.Keys(Parade, Down); !slices.Equal(got, []string{"ctrl+j", "down"}) {
+		t.Errorf("parade.down keys = %v, want [ctrl+j down]", got)
+	}
+	if got := Default().Keys(Global, Quit); !slices.Equal(got, []string{"q"}) {
+		t.Errorf("Override changed the defaults: quit = %v", got)
+	}
+}
+
+func TestOverrideSkipsBadEntries(t *testing.T) {
+	k, err := Default().Override(map[string]string{
+		"global.warp":   "z",
+		"parade.top":    "j", // clashes with parade.down
+		"parade.bottom": "b", // shadowed by global.copy_branch
+		"detail.up":     "K, u",
+	})
+	if err == nil {
+		t.Fatal("Override() error = nil, want the first problem")
+	}
+	if !strings.Contains(err.Error(), "global.warp") {
+		t.Errorf("error = %v, want the first name in sorted order", err)
+	}
+	if got := k.Keys(Parade, Top); !slices.Equal(got, []string{"g"}) {
+		t.Errorf("clashing override applied: parade.top = %v", got)
+	}
+	if got := k.Keys(Parade, Bottom); !slices.Equal(got, []string{"G"}) {
+		t.Errorf("shadowed override applied: parade.bottom = %v", got)
+	}
+	if got := k.Keys(Detail, Up); !slices.Equal(got, []string{"K", "u"}) {
+		t.Errorf("valid override skipped: detail.up = %v", got)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"q", []string{"q"}},
+		{" n , down ", []string{"n", "down"}},
+		{",", []string{","}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := ParseKeys(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("ParseKeys(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHintFollowsActiveKeymap(t *testing.T) {
+	t.Cleanup(func() { Use(Default()) })
+
+	h := NewHint(GasTown, "navigate", Down, Up)
+	if got := h.Key(); got != "j/k" {
+		t.Errorf("Key() = %q, want j/k", got)
+	}
+	k, _ := Default().Override(map[string]string{"gastown.down": "ctrl+n"})
+	Use(k)
+	if got := HintBar(h, NewHint(GasTown, "section", NextSection)); got != "^n/k navigate  tab section" {
+		t.Errorf("HintBar() = %q", got)
+	}
+}
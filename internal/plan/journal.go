@@ -0,0 +1,282 @@
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/gastown"
+)
+
+// Runner performs one resolved action and returns the ID it created, if
+// any. BD is the real one.
+type Runner interface {
+	Do(a Action) (string, error)
+}
+
+// BD runs actions through the bd and gt CLIs.
+type BD struct{}
+
+func (BD) Do(a Action) (string, error) {
+	switch a.Op {
+	case OpCreate:
+		return data.CreateIssue(a.Value, a.Type, a.Priority)
+	case OpStatus:
+		return "", data.SetStatus(a.Target, data.Status(a.Value))
+	case OpPriority:
+		return "", data.SetPriority(a.Target, a.Priority)
+	case OpAssignee:
+		return "", data.SetAssignee(a.Target, a.Value)
+	case OpAddLabel:
+		return "", data.AddLabel(a.Target, a.Value)
+	case OpRemoveLabel:
+		return "", data.RemoveLabel(a.Target, a.Value)
+	case OpDue:
+		return "", data.SetDueDate(a.Target, a.Value)
+	case OpDefer:
+		return "", data.SetDeferUntil(a.Target, a.Value)
+	case OpAddParent:
+		return "", data.AddParent(a.Target, a.Value)
+	case OpAddDep:
+		return "", data.AddDependency(a.Target, a.Value)
+	case OpRemoveDep:
+		return "", data.RemoveDependency(a.Target, a.Value)
+	case OpDelete:
+		return "", data.DeleteIssue(a.Target)
+	case OpConvoyCreate:
+		out, err := gastown.ConvoyCreate(a.Value, a.Refs)
+		return convoyID(out, a.Value, a.Refs), err
+	case OpConvoyAdd:
+		return "", gastown.ConvoyAdd(a.Target, a.Refs)
+	case OpConvoyClose:
+		return "", gastown.ConvoyClose(a.Target)
+	}
+	return "", fmt.Errorf("unknown op %q", a.Op)
+}
+
+// convoyID picks the convoy ID out of `gt convoy create` output, or "" when
+// none is recognisable. gt echoes the convoy name and its member issues
+// too, so tokens matching either are skipped: rollback closes whatever
+// this returns.
+func convoyID(out, name string, members []string) string {
+	for _, field := range strings.Fields(out) {
+		field = strings.Trim(field, ".:,()[]")
+		if field == name || slices.Contains(members, field) {
+			continue
+		}
+		if data.ValidateIssueID(field) == nil {
+			return field
+		}
+	}
+	return ""
+}
+
+// Journal records the compiled steps of a plan and how far a run got. It
+// is saved after every step so a failed run can be resumed or rolled back.
+type Journal struct {
+	Plan       string            `json:"plan"`
+	Hash       string            `json:"hash"` // of the plan file, to refuse resuming an edited plan
+	Started    time.Time         `json:"started"`
+	Aliases    map[string]string `json:"aliases"` // alias -> issue ID, "" until created
+	Steps      []Step            `json:"steps"`
+	RolledBack bool              `json:"rolled_back,omitempty"`
+}
+
+// JournalPath returns where the journal for planPath is kept: beside it,
+// with a .journal suffix.
+func JournalPath(planPath string) string {
+	return planPath + ".journal"
+}
+
+// HashPlan fingerprints plan file contents.
+func HashPlan(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewJournal starts a journal for the compiled steps of p.
+func NewJournal(planPath string, raw []byte, p *Plan, steps []Step) *Journal {
+	aliases := make(map[string]string, len(p.Create))
+	for _, c := range p.Create {
+		aliases[c.Alias] = ""
+	}
+	return &Journal{
+		Plan:    planPath,
+		Hash:    HashPlan(raw),
+		Started: time.Now().UTC(),
+		Aliases: aliases,
+		Steps:   steps,
+	}
+}
+
+// LoadJournal reads a journal. A missing file returns an error satisfying
+// errors.Is(err, os.ErrNotExist).
+func LoadJournal(path string) (*Journal, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var j Journal
+	if err := json.Unmarshal(raw, &j); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return &j, nil
+}
+
+// Save writes the journal atomically.
+func (j *Journal) Save(path string) error {
+	raw, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Pending counts steps that have not run yet.
+func (j *Journal) Pending() int {
+	n := 0
+	for _, s := range j.Steps {
+		if !s.Done {
+			n++
+		}
+	}
+	return n
+}
+
+// Complete reports whether every step ran.
+func (j *Journal) Complete() bool {
+	return j.Pending() == 0
+}
+
+// resolve swaps aliases in a for the issue IDs they were created as.
+func (j *Journal) resolve(a Action) (Action, error) {
+	ref := func(s string) (string, error) {
+		id, ok := j.Aliases[s]
+		if !ok {
+			return s, nil
+		}
+		if id == "" {
+			return "", fmt.Errorf("alias %q has not been created", s)
+		}
+		return id, nil
+	}
+	var err error
+	switch a.Op {
+	case OpCreate, OpConvoyAdd, OpConvoyClose:
+		// Target is the alias being created, or a convoy ID.
+	default:
+		if a.Target, err = ref(a.Target); err != nil {
+			return a, err
+		}
+	}
+	switch a.Op {
+	case OpAddParent, OpAddDep, OpRemoveDep:
+		if a.Value, err = ref(a.Value); err != nil {
+			return a, err
+		}
+	}
+	if len(a.Refs) > 0 {
+		refs := make([]string, len(a.Refs))
+		for i, r := range a.Refs {
+			if refs[i], err = ref(r); err != nil {
+				return a, err
+			}
+		}
+		a.Refs = refs
+	}
+	return a, nil
+}
+
+// Run executes the steps that have not run yet, in order, calling save after
+// each one. It stops at the first failure, which is recorded on the step,
+// so running again resumes there.
+func (j *Journal) Run(r Runner, save func(*Journal) error, progress func(Step)) error {
+	for i := range j.Steps {
+		s := &j.Steps[i]
+		if s.Done {
+			continue
+		}
+		a, err := j.resolve(s.Do)
+		var result string
+		if err == nil {
+			result, err = r.Do(a)
+		}
+		if err != nil {
+			s.Err = err.Error()
+			return errors.Join(fmt.Errorf("%s: %w", s.Diff, err), save(j))
+		}
+		s.Done, s.Err, s.Result = true, "", result
+		switch s.Do.Op {
+		case OpCreate:
+			j.Aliases[s.Do.Target] = result
+		case OpConvoyCreate:
+			if result != "" {
+				s.Undo = &Action{Op: OpConvoyClose, Target: result}
+			} else {
+				s.Irreversible = true
+			}
+		}
+		if progress != nil {
+			progress(*s)
+		}
+		if err := save(j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback undoes the steps that ran, newest first, saving after each. Steps
+// covered by deleting a created issue are skipped; irreversible ones are
+// returned as warnings. Failures are collected and leave their step marked
+// done, so rolling back again retries them.
+func (j *Journal) Rollback(r Runner, save func(*Journal) error, progress func(Step)) (warnings []string, err error) {
+	var errs []error
+	for i := len(j.Steps) - 1; i >= 0; i-- {
+		s := &j.Steps[i]
+		if !s.Done || s.Undone {
+			continue
+		}
+		if s.Irreversible {
+			warnings = append(warnings, "cannot undo: "+s.Diff)
+			continue
+		}
+		if s.Undo != nil {
+			a, rerr := j.resolve(*s.Undo)
+			if rerr == nil {
+				_, rerr = r.Do(a)
+			}
+			if rerr != nil {
+				errs = append(errs, fmt.Errorf("undo %s: %w", s.Diff, rerr))
+				continue
+			}
+		}
+		s.Undone = true
+		if s.Do.Op == OpCreate {
+			j.Aliases[s.Do.Target] = ""
+		}
+		if progress != nil {
+			progress(*s)
+		}
+		if err := save(j); err != nil {
+			return warnings, err
+		}
+	}
+	j.RolledBack = !slices.ContainsFunc(j.Steps, func(s Step) bool {
+		return s.Done && !s.Undone && !s.Irreversible
+	})
+	errs = append(errs, save(j))
+	return warnings, errors.Join(errs...)
+}
@@ -0,0 +1,151 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRunner records resolved actions and hands out sequential IDs.
+type fakeRunner struct {
+	next   int
+	calls  []string
+	failOn Op
+}
+
+func (f *fakeRunner) Do(a Action) (string, error) {
+	if a.Op == f.failOn {
+		return "", errors.New("bd exploded")
+	}
+	call := fmt.Sprintf("%s %s %s", a.Op, a.Target, a.Value)
+	if len(a.Refs) > 0 {
+		call += " " + strings.Join(a.Refs, ",")
+	}
+	f.calls = append(f.calls, strings.TrimSpace(call))
+	switch a.Op {
+	case OpCreate:
+		f.next++
+		return fmt.Sprintf("mg-%d", 10+f.next), nil
+	case OpConvoyCreate:
+		return "hq-cv-1", nil
+	}
+	return "", nil
+}
+
+func sampleJournal(t *testing.T) (*Journal, func(*Journal) error, string) {
+	t.Helper()
+	p, err := Parse(strings.NewReader(samplePlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "plan.yaml.journal")
+	j := NewJournal("plan.yaml", []byte(samplePlan), p, Compile(p, existingIssues()))
+	return j, func(j *Journal) error { return j.Save(path) }, path
+}
+
+func TestRunResolvesAliases(t *testing.T) {
+	j, save, path := sampleJournal(t)
+	r := &fakeRunner{}
+	if err := j.Run(r, save, nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{
+		"create api Build API",
+		"label-add mg-11 backend",
+		"create schema Design schema",
+		"dep-add mg-11 mg-12",
+		"parent-add mg-12 mg-1",
+		"status mg-2 in_progress",
+		"assignee mg-2 alice",
+		"label-add mg-2 sprint-12",
+		"dep-add mg-2 mg-11",
+		"convoy-create  Sprint 12 mg-11,mg-2",
+	}
+	if !reflect.DeepEqual(r.calls, want) {
+		t.Fatalf("calls =\n%s", strings.Join(r.calls, "\n"))
+	}
+	saved, err := LoadJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.Complete() || saved.Aliases["api"] != "mg-11" || saved.Steps[9].Undo.Target != "hq-cv-1" {
+		t.Errorf("saved journal = %+v", saved)
+	}
+}
+
+func TestRunFailureResumeAndRollback(t *testing.T) {
+	j, save, path := sampleJournal(t)
+	if err := j.Run(&fakeRunner{failOn: OpStatus}, save, nil); err == nil {
+		t.Fatal("expected the status step to fail")
+	}
+	saved, err := LoadJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Pending() != 5 || saved.Steps[5].Err != "bd exploded" {
+		t.Fatalf("pending = %d, step err = %q", saved.Pending(), saved.Steps[5].Err)
+	}
+
+	// Resuming picks up at the failed step with the recorded aliases.
+	r := &fakeRunner{next: 2}
+	if err := saved.Run(r, save, nil); err != nil {
+		t.Fatalf("resume error = %v", err)
+	}
+	if r.calls[0] != "status mg-2 in_progress" || r.calls[3] != "dep-add mg-2 mg-11" {
+		t.Errorf("resume calls = %v", r.calls)
+	}
+
+	r = &fakeRunner{}
+	warnings, err := saved.Rollback(r, save, nil)
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("Rollback() = %v, %v", warnings, err)
+	}
+	want := []string{
+		"convoy-close hq-cv-1",
+		"dep-remove mg-2 mg-11",
+		"label-remove mg-2 sprint-12",
+		"assignee mg-2",
+		"status mg-2 open",
+		"delete mg-12",
+		"delete mg-11",
+	}
+	if !reflect.DeepEqual(r.calls, want) {
+		t.Errorf("rollback calls =\n%s", strings.Join(r.calls, "\n"))
+	}
+	if !saved.RolledBack {
+		t.Error("journal should be marked rolled back")
+	}
+}
+
+func TestRollbackWarnsIrreversible(t *testing.T) {
+	p := &Plan{Convoys: []Convoy{{ID: "hq-cv-1", Issues: []string{"mg-1"}}}}
+	j := NewJournal("plan.yaml", nil, p, Compile(p, existingIssues()))
+	save := func(*Journal) error { return nil }
+	if err := j.Run(&fakeRunner{}, save, nil); err != nil {
+		t.Fatal(err)
+	}
+	warnings, err := j.Rollback(&fakeRunner{}, save, nil)
+	if err != nil || len(warnings) != 1 || !strings.Contains(warnings[0], "cannot undo") {
+		t.Errorf("Rollback() = %v, %v", warnings, err)
+	}
+}
+
+func TestConvoyID(t *testing.T) {
+	members := []string{"mg-1", "mg-2"}
+	tests := map[string]string{
+		"Created convoy hq-cv-abc (2 issues)\n": "hq-cv-abc",
+		"hq-cv-9":                               "hq-cv-9",
+		"done":                                  "",
+		"Tracking mg-1, mg-2\nCreated convoy hq-cv-7\n":                "hq-cv-7",
+		"Created convoy auth-rollout (hq-cv-8)\n  mg-1: Login\n":       "hq-cv-8",
+		"Created convoy auth-rollout with [mg-1 mg-2], no ID reported": "",
+	}
+	for out, want := range tests {
+		if got := convoyID(out, "auth-rollout", members); got != want {
+			t.Errorf("convoyID(%q) = %q, want %q", out, got, want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Package plan reads declarative change plans (YAML files that create,
+// update and link issues and build convoys) and applies them step by step
+// with a journal that lets a failed run be resumed or rolled back.
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"gopkg.in/yaml.v3"
+)
+
+// Plan is the file format read by `mg apply`. Issues created by the plan
+// get an alias that other entries use in place of an issue ID.
+type Plan struct {
+	Create  []NewIssue `yaml:"create"`
+	Update  []Change   `yaml:"update"`
+	Convoys []Convoy   `yaml:"convoys"`
+}
+
+// NewIssue is an issue the plan creates.
+type NewIssue struct {
+	Alias     string         `yaml:"alias"`
+	Title     string         `yaml:"title"`
+	Type      data.IssueType `yaml:"type"`     // defaults to task
+	Priority  *data.Priority `yaml:"priority"` // defaults to medium
+	Assignee  string         `yaml:"assignee"`
+	Labels    []string       `yaml:"labels"`
+	Parent    string         `yaml:"parent"`     // alias or issue ID
+	DependsOn []string       `yaml:"depends_on"` // aliases or issue IDs
+	Due       string         `yaml:"due"`        // YYYY-MM-DD
+	Defer     string         `yaml:"defer"`      // YYYY-MM-DD
+}
+
+// Change updates an existing issue, or one created earlier in the plan.
+// Nil pointer fields are left alone; an empty string clears the field.
+type Change struct {
+	ID           string         `yaml:"id"` // alias or issue ID
+	Status       data.Status    `yaml:"status"`
+	Priority     *data.Priority `yaml:"priority"`
+	Assignee     *string        `yaml:"assignee"`
+	AddLabels    []string       `yaml:"add_labels"`
+	RemoveLabels []string       `yaml:"remove_labels"`
+	Parent       string         `yaml:"parent"`
+	DependsOn    []string       `yaml:"depends_on"`
+	Due          *string        `yaml:"due"`
+	Defer        *string        `yaml:"defer"`
+}
+
+// Convoy creates a Gas Town convoy from Name, or adds to the existing
+// convoy ID.
+type Convoy struct {
+	Name   string   `yaml:"name"`
+	ID     string   `yaml:"id"`
+	Issues []string `yaml:"issues"` // aliases or issue IDs
+}
+
+// Parse reads a plan and rejects unknown keys, so a typo in a field name
+// fails instead of being ignored.
+func Parse(r io.Reader) (*Plan, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var p Plan
+	if err := dec.Decode(&p); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("plan is empty")
+		}
+		return nil, err
+	}
+	if len(p.Create)+len(p.Update)+len(p.Convoys) == 0 {
+		return nil, errors.New("plan is empty")
+	}
+	return &p, nil
+}
+
+// Validate checks the plan against the existing issues: aliases are unique
+// and do not shadow an issue ID, every reference is an alias or a known
+// issue, and statuses, priorities and dates are well formed. All problems
+// are reported together.
+func (p *Plan) Validate(existing map[string]*data.Issue) error {
+	var errs []error
+	fail := func(where, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", where, fmt.Sprintf(format, args...)))
+	}
+
+	aliases := make(map[string]bool, len(p.Create))
+	for i, c := range p.Create {
+		where := fmt.Sprintf("create[%d]", i)
+		switch {
+		case c.Alias == "":
+			fail(where, "alias is required")
+		case aliases[c.Alias]:
+			fail(where, "duplicate alias %q", c.Alias)
+		case existing[c.Alias] != nil:
+			fail(where, "alias %q is already an issue ID", c.Alias)
+		}
+		aliases[c.Alias] = true
+	}
+
+	ref := func(where, id string) {
+		if aliases[id] {
+			return
+		}
+		if err := data.ValidateIssueID(id); err != nil {
+			fail(where, "%v", err)
+			return
+		}
+		if existing[id] == nil {
+			fail(where, "unknown issue %s", id)
+		}
+	}
+	checkPriority := func(where string, prio *data.Priority) {
+		if prio != nil && (*prio < data.PriorityCritical || *prio > data.PriorityBacklog) {
+			fail(where, "priority %d out of range 0-4", *prio)
+		}
+	}
+	checkDate := func(where, field, date string) {
+		if date == "" {
+			return
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			fail(where, "%s must be YYYY-MM-DD, got %q", field, date)
+		}
+	}
+
+	for i, c := range p.Create {
+		where := fmt.Sprintf("create[%d] (%s)", i, c.Alias)
+		if strings.TrimSpace(c.Title) == "" {
+			fail(where, "title is required")
+		}
+		checkPriority(where, c.Priority)
+		checkDate(where, "due", c.Due)
+		checkDate(where, "defer", c.Defer)
+		if c.Parent != "" {
+			ref(where, c.Parent)
+		}
+		for _, dep := range c.DependsOn {
+			ref(where, dep)
+		}
+	}
+
+	for i, c := range p.Update {
+		where := fmt.Sprintf("update[%d] (%s)", i, c.ID)
+		if c.ID == "" {
+			fail(where, "id is required")
+		} else {
+			ref(where, c.ID)
+		}
+		switch c.Status {
+		case "", data.StatusOpen, data.StatusInProgress, data.StatusClosed:
+		default:
+			fail(where, "unknown status %q", c.Status)
+		}
+		checkPriority(where, c.Priority)
+		if c.Due != nil {
+			checkDate(where, "due", *c.Due)
+		}
+		if c.Defer != nil {
+			checkDate(where, "defer", *c.Defer)
+		}
+		if c.Parent != "" {
+			ref(where, c.Parent)
+		}
+		for _, dep := range c.DependsOn {
+			ref(where, dep)
+		}
+	}
+
+	for i, c := range p.Convoys {
+		where := fmt.Sprintf("convoys[%d]", i)
+		if (c.Name == "") == (c.ID == "") {
+			fail(where, "set exactly one of name (new convoy) or id (existing convoy)")
+		}
+		if len(c.Issues) == 0 {
+			fail(where, "issues is required")
+		}
+		for _, id := range c.Issues {
+			ref(where, id)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+const samplePlan = `
+create:
+  - alias: api
+    title: Build API
+    type: feature
+    priority: 1
+    labels: [backend]
+    depends_on: [schema]
+  - alias: schema
+    title: Design schema
+    parent: mg-1
+update:
+  - id: mg-2
+    status: in_progress
+    assignee: alice
+    add_labels: [sprint-12]
+    depends_on: [api]
+convoys:
+  - name: Sprint 12
+    issues: [api, mg-2]
+`
+
+func existingIssues() map[string]*data.Issue {
+	return map[string]*data.Issue{
+		"mg-1": {ID: "mg-1", Title: "Epic", Status: data.StatusOpen},
+		"mg-2": {ID: "mg-2", Title: "Client", Status: data.StatusOpen, Labels: []string{"later"}},
+	}
+}
+
+func TestParseAndValidate(t *testing.T) {
+	p, err := Parse(strings.NewReader(samplePlan))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(p.Create) != 2 || p.Create[0].DependsOn[0] != "schema" || *p.Update[0].Assignee != "alice" {
+		t.Fatalf("plan = %+v", p)
+	}
+	if err := p.Validate(existingIssues()); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, bad := range []string{"", "create:\n  - alias: a\n    titel: typo\n", "update: []\n"} {
+		if _, err := Parse(strings.NewReader(bad)); err == nil {
+			t.Errorf("Parse(%q) expected error", bad)
+		}
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	bad := `
+create:
+  - alias: mg-1
+    title: Shadows an issue
+  - alias: dup
+    title: One
+  - alias: dup
+    title: ""
+    priority: 7
+    due: soon
+update:
+  - id: mg-99
+    status: done
+  - id: "--rm"
+convoys:
+  - name: Both
+    id: hq-cv-1
+    issues: [nope]
+`
+	p, err := Parse(strings.NewReader(bad))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = p.Validate(existingIssues())
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		`alias "mg-1" is already an issue ID`,
+		`duplicate alias "dup"`,
+		"title is required",
+		"priority 7 out of range",
+		`due must be YYYY-MM-DD, got "soon"`,
+		"unknown issue mg-99",
+		`unknown status "done"`,
+		`invalid issue ID "--rm"`,
+		"set exactly one of name",
+		`invalid issue ID "nope"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("errors missing %q:\n%v", want, err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package plan
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+// Op names a single mutation.
+type Op string
+
+const (
+	OpCreate       Op = "create"
+	OpStatus       Op = "status"
+	OpPriority     Op = "priority"
+	OpAssignee     Op = "assignee"
+	OpAddLabel     Op = "label-add"
+	OpRemoveLabel  Op = "label-remove"
+	OpDue          Op = "due"
+	OpDefer        Op = "defer"
+	OpAddParent    Op = "parent-add"
+	OpAddDep       Op = "dep-add"
+	OpRemoveDep    Op = "dep-remove"
+	OpDelete       Op = "delete"
+	OpConvoyCreate Op = "convoy-create"
+	OpConvoyAdd    Op = "convoy-add"
+	OpConvoyClose  Op = "convoy-close"
+)
+
+// Action is one mutation. Target and Refs hold aliases or issue IDs until
+// the journal resolves them at run time.
+type Action struct {
+	Op       Op             `json:"op"`
+	Target   string         `json:"target,omitempty"` // issue, or convoy for convoy ops
+	Value    string         `json:"value,omitempty"`  // title, status, name, label, date or dependency
+	Refs     []string       `json:"refs,omitempty"`   // convoy members
+	Type     data.IssueType `json:"type,omitempty"`
+	Priority data.Priority  `json:"priority"`
+}
+
+// Step is an action plus how to undo it. Steps on issues the plan creates
+// have no Undo: rolling back deletes the issue. Irreversible steps cannot
+// be undone at all.
+type Step struct {
+	Do           Action  `json:"do"`
+	Undo         *Action `json:"undo,omitempty"`
+	Irreversible bool    `json:"irreversible,omitempty"`
+	Diff         string  `json:"diff"`
+	Done         bool    `json:"done,omitempty"`
+	Undone       bool    `json:"undone,omitempty"`
+	Result       string  `json:"result,omitempty"` // ID made by create steps
+	Err          string  `json:"error,omitempty"`
+}
+
+// Compile turns a validated plan into ordered steps: creates and their
+// fields, then their parent and dependency links (so aliases can point
+// forward), then updates, then convoys. Updates that would not change an
+// existing issue are dropped.
+func Compile(p *Plan, existing map[string]*data.Issue) []Step {
+	var steps []Step
+	add := func(do Action, undo *Action, diff string) {
+		steps = append(steps, Step{Do: do, Undo: undo, Diff: diff})
+	}
+
+	for _, c := range p.Create {
+		issueType := c.Type
+		if issueType == "" {
+			issueType = data.TypeTask
+		}
+		priority := data.PriorityMedium
+		if c.Priority != nil {
+			priority = *c.Priority
+		}
+		add(Action{Op: OpCreate, Target: c.Alias, Value: c.Title, Type: issueType, Priority: priority},
+			&Action{Op: OpDelete, Target: c.Alias},
+			fmt.Sprintf("+ create %s: %q (%s, P%d)", c.Alias, c.Title, issueType, priority))
+		if c.Assignee != "" {
+			add(Action{Op: OpAssignee, Target: c.Alias, Value: c.Assignee}, nil,
+				fmt.Sprintf("~ %s assignee: %s", c.Alias, c.Assignee))
+		}
+		for _, label := range c.Labels {
+			add(Action{Op: OpAddLabel, Target: c.Alias, Value: label}, nil,
+				fmt.Sprintf("+ %s label %s", c.Alias, label))
+		}
+		if c.Due != "" {
+			add(Action{Op: OpDue, Target: c.Alias, Value: c.Due}, nil,
+				fmt.Sprintf("~ %s due: %s", c.Alias, c.Due))
+		}
+		if c.Defer != "" {
+			add(Action{Op: OpDefer, Target: c.Alias, Value: c.Defer}, nil,
+				fmt.Sprintf("~ %s defer: %s", c.Alias, c.Defer))
+		}
+	}
+	for _, c := range p.Create {
+		if c.Parent != "" {
+			add(Action{Op: OpAddParent, Target: c.Alias, Value: c.Parent}, nil,
+				fmt.Sprintf("+ %s parent %s", c.Alias, c.Parent))
+		}
+		for _, dep := range c.DependsOn {
+			add(Action{Op: OpAddDep, Target: c.Alias, Value: dep}, nil,
+				fmt.Sprintf("+ %s depends on %s", c.Alias, dep))
+		}
+	}
+
+	for _, c := range p.Update {
+		steps = append(steps, compileChange(c, existing[c.ID])...)
+	}
+
+	for _, c := range p.Convoys {
+		members := strings.Join(c.Issues, ", ")
+		if c.ID != "" {
+			steps = append(steps, Step{
+				Do:           Action{Op: OpConvoyAdd, Target: c.ID, Refs: c.Issues},
+				Irreversible: true,
+				Diff:         fmt.Sprintf("+ convoy %s: %s", c.ID, members),
+			})
+			continue
+		}
+		// The undo needs the new convoy's ID, so Run fills it in.
+		add(Action{Op: OpConvoyCreate, Value: c.Name, Refs: c.Issues}, nil,
+			fmt.Sprintf("+ convoy %q: %s", c.Name, members))
+	}
+	return steps
+}
+
+// compileChange builds the steps for one update. issue is nil when the
+// target is created by the plan, in which case nothing is compared and no
+// undo is recorded.
+func compileChange(c Change, issue *data.Issue) []Step {
+	var steps []Step
+	id := c.ID
+	change := func(op Action, old, now string, undo Action) {
+		if issue != nil && old == now {
+			return
+		}
+		s := Step{Do: op, Diff: fmt.Sprintf("~ %s %s: %s", id, op.Op, now)}
+		if issue != nil {
+			s.Undo = &undo
+			s.Diff = fmt.Sprintf("~ %s %s: %s → %s", id, op.Op, orNone(old), orNone(now))
+		}
+		steps = append(steps, s)
+	}
+	link := func(op Action, diff string, exists bool) {
+		if issue == nil {
+			steps = append(steps, Step{Do: op, Diff: diff})
+		} else if !exists {
+			steps = append(steps, Step{Do: op, Undo: &Action{Op: OpRemoveDep, Target: id, Value: op.Value}, Diff: diff})
+		}
+	}
+
+	var cur data.Issue
+	if issue != nil {
+		cur = *issue
+	}
+	if c.Status != "" {
+		change(Action{Op: OpStatus, Target: id, Value: string(c.Status)},
+			string(cur.Status), string(c.Status),
+			Action{Op: OpStatus, Target: id, Value: string(cur.Status)})
+	}
+	if c.Priority != nil {
+		op := Action{Op: OpPriority, Target: id, Priority: *c.Priority}
+		change(op, "P"+strconv.Itoa(int(cur.Priority)), "P"+strconv.Itoa(int(*c.Priority)),
+			Action{Op: OpPriority, Target: id, Priority: cur.Priority})
+	}
+	if c.Assignee != nil {
+		change(Action{Op: OpAssignee, Target: id, Value: *c.Assignee},
+			cur.Assignee, *c.Assignee,
+			Action{Op: OpAssignee, Target: id, Value: cur.Assignee})
+	}
+	for _, label := range c.AddLabels {
+		if issue == nil || !slices.Contains(cur.Labels, label) {
+			s := Step{Do: Action{Op: OpAddLabel, Target: id, Value: label}, Diff: fmt.Sprintf("+ %s label %s", id, label)}
+			if issue != nil {
+				s.Undo = &Action{Op: OpRemoveLabel, Target: id, Value: label}
+			}
+			steps = append(steps, s)
+		}
+	}
+	for _, label := range c.RemoveLabels {
+		if issue == nil || slices.Contains(cur.Labels, label) {
+			s := Step{Do: Action{Op: OpRemoveLabel, Target: id, Value: label}, Diff: fmt.Sprintf("- %s label %s", id, label)}
+			if issue != nil {
+				s.Undo = &Action{Op: OpAddLabel, Target: id, Value: label}
+			}
+			steps = append(steps, s)
+		}
+	}
+	if c.Due != nil {
+		old := formatDate(cur.DueAt)
+		change(Action{Op: OpDue, Target: id, Value: *c.Due}, old, *c.Due,
+			Action{Op: OpDue, Target: id, Value: old})
+	}
+	if c.Defer != nil {
+		old := formatDate(cur.DeferUntil)
+		change(Action{Op: OpDefer, Target: id, Value: *c.Defer}, old, *c.Defer,
+			Action{Op: OpDefer, Target: id, Value: old})
+	}
+	if c.Parent != "" {
+		link(Action{Op: OpAddParent, Target: id, Value: c.Parent},
+			fmt.Sprintf("+ %s parent %s", id, c.Parent), hasDependency(cur, c.Parent))
+	}
+	for _, dep := range c.DependsOn {
+		link(Action{Op: OpAddDep, Target: id, Value: dep},
+			fmt.Sprintf("+ %s depends on %s", id, dep), hasDependency(cur, dep))
+	}
+	return steps
+}
+
+func hasDependency(issue data.Issue, id string) bool {
+	for _, d := range issue.Dependencies {
+		if d.DependsOnID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,90 @@
+package plan
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func diffs(steps []Step) []string {
+	out := make([]string, len(steps))
+	for i, s := range steps {
+		out[i] = s.Diff
+	}
+	return out
+}
+
+func TestCompile(t *testing.T) {
+	p, err := Parse(strings.NewReader(samplePlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := Compile(p, existingIssues())
+	want := []string{
+		`+ create api: "Build API" (feature, P1)`,
+		"+ api label backend",
+		`+ create schema: "Design schema" (task, P2)`,
+		"+ api depends on schema",
+		"+ schema parent mg-1",
+		"~ mg-2 status: open → in_progress",
+		"~ mg-2 assignee: (none) → alice",
+		"+ mg-2 label sprint-12",
+		"+ mg-2 depends on api",
+		`+ convoy "Sprint 12": api, mg-2`,
+	}
+	if got := diffs(steps); !reflect.DeepEqual(got, want) {
+		t.Fatalf("diff =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if u := steps[0].Undo; u == nil || u.Op != OpDelete || u.Target != "api" {
+		t.Errorf("create undo = %+v", u)
+	}
+	if steps[1].Undo != nil {
+		t.Error("steps on created issues should rely on the delete")
+	}
+	if u := steps[5].Undo; u == nil || u.Op != OpStatus || u.Value != "open" {
+		t.Errorf("status undo = %+v", u)
+	}
+	if u := steps[8].Undo; u == nil || u.Op != OpRemoveDep || u.Target != "mg-2" || u.Value != "api" {
+		t.Errorf("dependency undo = %+v", u)
+	}
+}
+
+func TestCompileSkipsNoops(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	issue := &data.Issue{
+		ID: "mg-2", Status: data.StatusOpen, Priority: data.PriorityHigh, Assignee: "alice",
+		Labels: []string{"ui"}, DueAt: &due,
+		Dependencies: []data.Dependency{{IssueID: "mg-2", DependsOnID: "mg-1"}},
+	}
+	high, same, cleared := data.PriorityHigh, "alice", ""
+	dueSame := "2026-11-01"
+	c := Change{
+		ID: "mg-2", Status: data.StatusOpen, Priority: &high, Assignee: &same,
+		AddLabels: []string{"ui"}, RemoveLabels: []string{"absent"},
+		Due: &dueSame, Defer: &cleared, DependsOn: []string{"mg-1"},
+	}
+	if steps := compileChange(c, issue); len(steps) != 0 {
+		t.Errorf("expected no steps, got %v", diffs(steps))
+	}
+
+	dueLater := "2026-12-01"
+	c = Change{ID: "mg-2", Due: &dueLater, RemoveLabels: []string{"ui"}}
+	steps := compileChange(c, issue)
+	if got := diffs(steps); !reflect.DeepEqual(got, []string{"- mg-2 label ui", "~ mg-2 due: 2026-11-01 → 2026-12-01"}) {
+		t.Errorf("diff = %v", got)
+	}
+	if steps[1].Undo.Value != "2026-11-01" || steps[0].Undo.Op != OpAddLabel {
+		t.Errorf("undos = %+v %+v", steps[0].Undo, steps[1].Undo)
+	}
+}
+
+func TestCompileConvoyAddIsIrreversible(t *testing.T) {
+	p := &Plan{Convoys: []Convoy{{ID: "hq-cv-1", Issues: []string{"mg-1"}}}}
+	steps := Compile(p, existingIssues())
+	if len(steps) != 1 || !steps[0].Irreversible || steps[0].Do.Op != OpConvoyAdd {
+		t.Errorf("steps = %+v", steps)
+	}
+}
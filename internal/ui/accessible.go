@@ -0,0 +1,62 @@
+package ui
+
+import "charm.land/lipgloss/v2"
+
+// accessible is set by SetAccessible.
+var accessible bool
+
+// Accessible reports whether accessible mode is on.
+func Accessible() bool {
+	return accessible
+}
+
+// SetAccessible switches accessible mode, for limited fonts and screen
+// readers: symbols and borders fall back to ASCII, gradients render flat,
+// and views add text labels where color alone would carry state. The styles
+// are rebuilt and the theme hooks run, as for a theme change.
+func SetAccessible(on bool) {
+	accessible = on
+	for _, s := range asciiSymbols {
+		if on {
+			*s.target = s.ascii
+		} else {
+			*s.target = s.unicode
+		}
+	}
+	if on {
+		sparkBlocks = asciiSparkBlocks
+	} else {
+		sparkBlocks = unicodeSparkBlocks
+	}
+	ApplyTheme(activeTheme)
+}
+
+// NoColor reports whether the NO_COLOR convention (https://no-color.org)
+// asks for output without color: any non-empty value counts.
+func NoColor(getenv func(string) string) bool {
+	return getenv("NO_COLOR") != ""
+}
+
+// Border returns b, or the ASCII border in accessible mode.
+func Border(b lipgloss.Border) lipgloss.Border {
+	if accessible {
+		return lipgloss.ASCIIBorder()
+	}
+	return b
+}
+
+// flat reports whether gradients and intensity colors are off: in
+// accessible mode, or under a theme without color.
+func flat() bool {
+	return accessible || activeTheme.NoColor
+}
+
+// AreaFill is the fill for series i of a stacked chart. Accessible mode
+// gives each series its own character so the chart reads without color.
+func AreaFill(i int) string {
+	if !accessible {
+		return "█"
+	}
+	fills := []string{"#", "=", "+", ":"}
+	return fills[i%len(fills)]
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestSetAccessibleSwapsSymbols(t *testing.T) {
+	t.Cleanup(func() { SetAccessible(false) })
+
+	unicode := SymRolling
+	SetAccessible(true)
+	for _, s := range asciiSymbols {
+		for _, r := range *s.target {
+			if r > 0x7f {
+				t.Errorf("%q is not ASCII in accessible mode", *s.target)
+				break
+			}
+		}
+	}
+	if got := Superscript(12); got != "(12)" {
+		t.Errorf("Superscript(12) = %q, want (12)", got)
+	}
+	if got := Border(lipgloss.RoundedBorder()); got != lipgloss.ASCIIBorder() {
+		t.Error("Border should fall back to ASCII")
+	}
+
+	SetAccessible(false)
+	if SymRolling != unicode {
+		t.Errorf("SymRolling = %q after switching off, want %q", SymRolling, unicode)
+	}
+	if got := Superscript(12); got != "¹²" {
+		t.Errorf("Superscript(12) = %q, want ¹²", got)
+	}
+}
+
+func TestAccessibleRendersFlat(t *testing.T) {
+	t.Cleanup(func() { SetAccessible(false) })
+
+	SetAccessible(true)
+	if got := ApplyMardiGrasGradient("Mardi Gras"); got != "Mardi Gras" {
+		t.Errorf("gradient = %q, want plain text", got)
+	}
+	if got := RenderSparkline([]int{0, 5, 10}, 3); strings.Contains(got, "\x1b") {
+		t.Errorf("sparkline = %q, want no escape codes", got)
+	}
+}
+
+func TestMonoThemeRendersFlat(t *testing.T) {
+	t.Cleanup(func() { ApplyTheme(DarkTheme()) })
+
+	ApplyTheme(MonoTheme())
+	if got := ApplyShimmerGradient("Mardi Gras", 3); got != "Mardi Gras" {
+		t.Errorf("shimmer = %q, want plain text", got)
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	env := func(v string) func(string) string {
+		return func(string) string { return v }
+	}
+	if NoColor(env("")) {
+		t.Error("empty NO_COLOR should not disable color")
+	}
+	if !NoColor(env("1")) {
+		t.Error("NO_COLOR=1 should disable color")
+	}
+}
@@ -41,8 +41,12 @@ func getCachedChar(r rune, hex string) string {
 	return res
 }
 
-// ApplyMardiGrasGradient applies a smooth Purple -> Gold -> Green gradient to the text.
+// ApplyMardiGrasGradient applies a smooth Purple -> Gold -> Green gradient to
+// the text. Flat gradients return it unstyled.
 func ApplyMardiGrasGradient(text string) string {
+	if flat() {
+		return text
+	}
 	runes := []rune(text)
 	width := len(runes)
 	if width == 0 {
@@ -73,6 +77,9 @@ func ApplyMardiGrasGradient(text string) string {
 
 // ApplyShimmerGradient applies the Mardi Gras gradient with a phase offset that shifts over time.
 func ApplyShimmerGradient(text string, offset float64) string {
+	if flat() {
+		return text
+	}
 	runes := []rune(text)
 	width := len(runes)
 	if width == 0 {
@@ -173,32 +180,56 @@ func GradientBar(pct float64, width int, g Gradient) string {
 	var b strings.Builder
 	for i := range filled {
 		step := i * 100 / width
-		b.WriteString(g.At(step).Render("█"))
+		b.WriteString(g.At(step).Render(SymProgress))
 	}
 	for range width - filled {
-		b.WriteString(dimStyle.Render("░"))
+		b.WriteString(dimStyle.Render(SymProgressEmpty))
 	}
 	return b.String()
 }
 
-// Pre-built gradients for common use cases.
+// Pre-built gradients for common use cases, rebuilt by buildGradients when
+// the theme changes.
 var (
 	// GradientProgress: green → gold → red (for progress bars, budgets).
-	GradientProgress = NewGradient(BrightGreen, BrightGold, lipgloss.Color("#E74C3C"))
+	GradientProgress Gradient
 
 	// GradientHeat: green → orange → red (for age/staleness).
-	GradientHeat = NewGradient(BrightGreen, lipgloss.Color("#E67E22"), lipgloss.Color("#E74C3C"))
+	GradientHeat Gradient
 
 	// GradientPurpleGold: purple → gold (Mardi Gras themed, for selection proximity).
-	GradientPurpleGold = NewGradient2(DimPurple, BrightGold)
+	GradientPurpleGold Gradient
 
 	// GradientFade: bright → dim (for list item positional fading).
-	GradientFade = NewGradient2(White, Dim)
+	GradientFade Gradient
 )
 
+// buildGradients derives the preset gradients from the current palette and
+// drops cached gradient characters rendered in the old one. Flat gradients
+// render every step unstyled.
+func buildGradients() {
+	styleCache = make(map[string]lipgloss.Style)
+	charCache = make(map[charKey]string)
+	if flat() {
+		var plain Gradient
+		for i := range plain {
+			plain[i] = lipgloss.NewStyle()
+		}
+		GradientProgress, GradientHeat, GradientPurpleGold, GradientFade = plain, plain, plain, plain
+		return
+	}
+	GradientProgress = NewGradient(BrightGreen, BrightGold, Red)
+	GradientHeat = NewGradient(BrightGreen, Orange, Red)
+	GradientPurpleGold = NewGradient2(DimPurple, BrightGold)
+	GradientFade = NewGradient2(White, Dim)
+}
+
 // ApplyPartialMardiGrasGradient applies the gradient as if the text was `totalLength` characters long,
 // ensuring a partial progress bar maps to the correct segment of the full color spectrum.
 func ApplyPartialMardiGrasGradient(text string, totalLength int) string {
+	if flat() {
+		return text
+	}
 	runes := []rune(text)
 	if totalLength == 0 {
 		return ""
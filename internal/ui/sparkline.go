@@ -2,14 +2,20 @@ package ui
 
 import (
 	"fmt"
+	"image/color"
 	"strings"
 
 	"charm.land/lipgloss/v2"
 	"github.com/lucasb-eyer/go-colorful"
 )
 
-// Block characters for sparkline rendering (8 levels, bottom to top).
-var sparkBlocks = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+// Block characters for sparkline rendering (8 levels, bottom to top), and
+// the ASCII ramp accessible mode swaps in.
+var (
+	unicodeSparkBlocks = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	asciiSparkBlocks   = []string{"_", ".", ",", "-", "~", "=", "*", "#"}
+	sparkBlocks        = unicodeSparkBlocks
+)
 
 // RenderSparkline renders a compact sparkline from integer values.
 // Each value maps to one block character (8 height levels).
@@ -28,7 +34,7 @@ func RenderSparkline(values []int, width int) string {
 	}
 	if maxVal == 0 {
 		return lipgloss.NewStyle().Foreground(Dim).Render(
-			strings.Repeat("▁", min(len(values), width)))
+			strings.Repeat(sparkBlocks[0], min(len(values), width)))
 	}
 
 	// Gradient: green (low activity) → gold (medium) → red (high)
@@ -55,6 +61,10 @@ func RenderSparkline(values []int, width int) string {
 			c = cMid.BlendLuv(cHigh, (t-0.5)*2)
 		}
 
+		if flat() {
+			b.WriteString(sparkBlocks[level])
+			continue
+		}
 		b.WriteString(getCachedChar([]rune(sparkBlocks[level])[0], c.Hex()))
 	}
 
@@ -65,7 +75,7 @@ func RenderSparkline(values []int, width int) string {
 // 0 events = dim dot, low = green, medium = gold, high = red.
 func HeatChar(eventCount, maxCount int) string {
 	if eventCount == 0 {
-		return lipgloss.NewStyle().Foreground(Dim).Render("·")
+		return lipgloss.NewStyle().Foreground(Dim).Render(SymNonBlocking)
 	}
 
 	cLow := toColorful(BrightGreen)
@@ -88,6 +98,10 @@ func HeatChar(eventCount, maxCount int) string {
 	if t > 0.7 {
 		sym = "▮"
 	}
+	if flat() {
+		// Without color, the level has to show in the character.
+		return sparkBlocks[min(int(t*7), 7)]
+	}
 
 	return getCachedChar([]rune(sym)[0], c.Hex())
 }
@@ -130,6 +144,10 @@ func BrailleSparkline(data []float64, width int, style lipgloss.Style) string {
 		}
 	}
 
+	if accessible {
+		return style.Render(asciiPairs(norm, width))
+	}
+
 	// Pair up values into braille chars (each char = 2 data points)
 	var b strings.Builder
 	chars := 0
@@ -151,6 +169,27 @@ func BrailleSparkline(data []float64, width int, style lipgloss.Style) string {
 	return style.Render(b.String())
 }
 
+// asciiPairs is the braille sparkline in ASCII: one ramp character per pair
+// of 0-4 values, showing the higher of the two.
+func asciiPairs(norm []int, width int) string {
+	var b strings.Builder
+	chars := 0
+	for i := 0; i < len(norm) && chars < width; i += 2 {
+		v := norm[i]
+		if i+1 < len(norm) {
+			v = max(v, norm[i+1])
+		}
+		level := 0
+		if v > 0 {
+			level = v * 7 / 4
+		}
+		b.WriteString(sparkBlocks[level])
+		chars++
+	}
+	b.WriteString(strings.Repeat(" ", width-chars))
+	return b.String()
+}
+
 // MiniSparkline renders a compact 3-character activity indicator using block elements.
 // Values should be recent activity counts (e.g. last 3 time periods).
 // Returns empty string if all values are zero.
@@ -179,6 +218,10 @@ func MiniSparkline(values [3]int) string {
 			level = min(v*7/maxVal, 7)
 		}
 		t := float64(v) / float64(maxVal)
+		if flat() {
+			b.WriteString(sparkBlocks[level])
+			continue
+		}
 		c := cLow.BlendLuv(cHigh, t)
 		style := lipgloss.NewStyle().Foreground(lipgloss.Color(c.Hex()))
 		b.WriteString(style.Render(sparkBlocks[level]))
@@ -217,6 +260,11 @@ func DualSparkline(top, bottom []float64, width int, topStyle, bottomStyle lipgl
 	normTop := normalize(top)
 	normBot := normalize(bottom)
 
+	both, upper, lower := "█", "▀", "▄"
+	if accessible {
+		both, upper, lower = "#", "^", "_"
+	}
+
 	var b strings.Builder
 	for i := 0; i < width; i++ {
 		t, bt := 0, 0
@@ -228,11 +276,11 @@ func DualSparkline(top, bottom []float64, width int, topStyle, bottomStyle lipgl
 		}
 		switch {
 		case t > 0 && bt > 0:
-			b.WriteString(topStyle.Render("█"))
+			b.WriteString(topStyle.Render(both))
 		case t > 0:
-			b.WriteString(topStyle.Render("▀"))
+			b.WriteString(topStyle.Render(upper))
 		case bt > 0:
-			b.WriteString(bottomStyle.Render("▄"))
+			b.WriteString(bottomStyle.Render(lower))
 		default:
 			b.WriteRune(' ')
 		}
@@ -256,8 +304,8 @@ func ConvoyPipeline(statuses []string, maxWidth int) string {
 	doneStyle := lipgloss.NewStyle().Foreground(BrightGreen)
 	activeStyle := lipgloss.NewStyle().Foreground(BrightGold)
 	openStyle := lipgloss.NewStyle().Foreground(Dim)
-	connDone := lipgloss.NewStyle().Foreground(DimGreen).Render("─")
-	connOpen := lipgloss.NewStyle().Foreground(Dim).Render("─")
+	connDone := lipgloss.NewStyle().Foreground(DimGreen).Render(BoxHorizontal)
+	connOpen := lipgloss.NewStyle().Foreground(Dim).Render(BoxHorizontal)
 
 	var b strings.Builder
 	for i := 0; i < n; i++ {
@@ -272,11 +320,11 @@ func ConvoyPipeline(statuses []string, maxWidth int) string {
 		}
 		switch statuses[i] {
 		case "closed":
-			b.WriteString(doneStyle.Render("●"))
+			b.WriteString(doneStyle.Render(SymWorking))
 		case "in_progress", "hooked":
-			b.WriteString(activeStyle.Render("◐"))
+			b.WriteString(activeStyle.Render(SymSpawning))
 		default:
-			b.WriteString(openStyle.Render("○"))
+			b.WriteString(openStyle.Render(SymIdle))
 		}
 	}
 
@@ -287,3 +335,62 @@ func ConvoyPipeline(statuses []string, maxWidth int) string {
 
 	return b.String()
 }
+
+// StackedArea renders series as a filled area chart of height rows, one
+// column per point with the series stacked bottom to top in order. When
+// there are more points than width, every column shows the last point it
+// covers. Each series is drawn in the matching color with AreaFill.
+func StackedArea(series [][]int, colors []color.Color, width, height int) []string {
+	rows := make([]string, height)
+	points := 0
+	for _, s := range series {
+		points = max(points, len(s))
+	}
+	if points == 0 || width <= 0 || height <= 0 {
+		for i := range rows {
+			rows[i] = strings.Repeat(" ", max(width, 0))
+		}
+		return rows
+	}
+
+	cols := min(points, width)
+	totals := make([]int, cols)
+	maxTotal := 0
+	at := func(s []int, col int) int {
+		idx := (col+1)*points/cols - 1
+		if idx < len(s) {
+			return s[idx]
+		}
+		return 0
+	}
+	for c := 0; c < cols; c++ {
+		for _, s := range series {
+			totals[c] += at(s, c)
+		}
+		maxTotal = max(maxTotal, totals[c])
+	}
+
+	for r := 0; r < height; r++ {
+		// Cell r (0 = top) covers the value at the middle of its band.
+		level := (float64(height-r) - 0.5) * float64(maxTotal) / float64(height)
+		var b strings.Builder
+		for c := 0; c < cols; c++ {
+			cum := 0.0
+			drawn := false
+			for i, s := range series {
+				cum += float64(at(s, c))
+				if level < cum {
+					b.WriteString(lipgloss.NewStyle().Foreground(colors[i%len(colors)]).Render(AreaFill(i)))
+					drawn = true
+					break
+				}
+			}
+			if !drawn {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(strings.Repeat(" ", width-cols))
+		rows[r] = b.String()
+	}
+	return rows
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"image/color"
 	"strings"
 	"testing"
 
@@ -76,3 +77,26 @@ func TestDualSparklineZeroWidth(t *testing.T) {
 		t.Error("zero width should return empty")
 	}
 }
+
+func TestStackedAreaStacksSeries(t *testing.T) {
+	rows := StackedArea([][]int{{1, 2}, {1, 0}}, []color.Color{lipgloss.Color("1"), lipgloss.Color("2")}, 4, 2)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	for i, row := range rows {
+		if w := lipgloss.Width(row); w != 4 {
+			t.Errorf("row %d width = %d, want 4", i, w)
+		}
+	}
+	// Both columns total 2, so both rows are filled in the first two columns.
+	if got := strings.Count(rows[0], "█") + strings.Count(rows[1], "█"); got != 4 {
+		t.Errorf("filled cells = %d, want 4", got)
+	}
+}
+
+func TestStackedAreaEmpty(t *testing.T) {
+	rows := StackedArea(nil, nil, 5, 3)
+	if len(rows) != 3 || rows[0] != "     " {
+		t.Errorf("empty chart = %q", rows)
+	}
+}
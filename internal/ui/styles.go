@@ -6,87 +6,154 @@ import (
 	"charm.land/lipgloss/v2"
 )
 
-// Pre-built styles for the Mardi Gras theme.
+// Pre-built styles for the active theme. buildStyles fills them in whenever
+// the theme changes.
 var (
+	// Header
+	HeaderStyle, HeaderCounts lipgloss.Style
+
+	// Bead string decorations
+	BeadStylePurple, BeadStyleGold, BeadStyleGreen lipgloss.Style
+
+	// Section headers in parade list (used for title text color within borders)
+	SectionRolling, SectionLinedUp, SectionStalled, SectionPassed lipgloss.Style
+
+	// Pre-rendered status indicators
+	StatusRollingStr, StatusLinedUpStr, StatusStalledStr, StatusPassedStr string
+
+	// Issue items in the list
+	ItemNormal, ItemSelected, ItemCursor, ItemSelectedBg lipgloss.Style
+
+	// Detail panel (right side)
+	DetailBorder, DetailTitle, DetailLabel, DetailValue, DetailSection lipgloss.Style
+
+	// Priority badge
+	BadgePriority lipgloss.Style
+
+	// Pre-rendered priority badges
+	BadgeP0, BadgeP1, BadgeP2, BadgeP3, BadgeP4 string
+
+	// Type badge
+	BadgeType lipgloss.Style
+
+	// Footer
+	FooterStyle, FooterKey, FooterDesc lipgloss.Style
+
+	// Dependency display
+	DepBlocked, DepBlocks, DepMissing, DepResolved, DepNonBlocking lipgloss.Style
+
+	// Due date badges
+	OverdueBadge, DueSoonBadge, DeferredStyle lipgloss.Style
+
+	// Rich dependency styles
+	DepRelated, DepDuplicates, DepSupersedes lipgloss.Style
+	AgentBadge, ConvoyBadge, GasTownTag      lipgloss.Style
+
+	// Gas Town panel
+	GasTownBorder, GasTownTitle, GasTownLabel, GasTownValue lipgloss.Style
+	GasTownAgentSelected, GasTownHint, FooterSource         lipgloss.Style
+
+	// Molecule step styles
+	MolStepDone, MolStepActive, MolStepReady, MolStepBlocked lipgloss.Style
+	MolTierLabel, MolDAGFlow, MolCritical                    lipgloss.Style
+
+	// Metadata fields
+	MetaFieldName, MetaFieldNameDim, MetaFieldType, MetaFieldValue lipgloss.Style
+	MetaRequired, MetaInvalid                                      lipgloss.Style
+
+	// Filter Input
+	InputPrompt, InputText, InputCursor lipgloss.Style
+
+	// Help Overlay
+	HelpOverlayBg, HelpTitle, HelpSubtitle, HelpSection, HelpKey, HelpDesc, HelpHint lipgloss.Style
+
+	// Toast notifications
+	ToastInfo, ToastSuccess, ToastWarn, ToastError lipgloss.Style
+
+	matchStyle lipgloss.Style
+)
+
+// buildStyles derives the styles from the current palette.
+func buildStyles() {
 	// Header
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Background(DimPurple).
-			Padding(0, 1)
+		Bold(true).
+		Background(DimPurple).
+		Padding(0, 1)
 
 	HeaderCounts = lipgloss.NewStyle().
-			Foreground(Light)
+		Foreground(Light)
 
 	// Bead string decorations
 	BeadStylePurple = lipgloss.NewStyle().Foreground(Purple)
-	BeadStyleGold   = lipgloss.NewStyle().Foreground(Gold)
-	BeadStyleGreen  = lipgloss.NewStyle().Foreground(Green)
+	BeadStyleGold = lipgloss.NewStyle().Foreground(Gold)
+	BeadStyleGreen = lipgloss.NewStyle().Foreground(Green)
 
 	// Section headers in parade list (used for title text color within borders)
 	SectionRolling = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(StatusRolling)
+		Bold(true).
+		Foreground(StatusRolling)
 
 	SectionLinedUp = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(StatusLinedUp)
+		Bold(true).
+		Foreground(StatusLinedUp)
 
 	SectionStalled = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(StatusStalled)
+		Bold(true).
+		Foreground(StatusStalled)
 
 	SectionPassed = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(StatusPassed)
+		Bold(true).
+		Foreground(StatusPassed)
 
 	// Pre-rendered status indicators
 	StatusRollingStr = lipgloss.NewStyle().Foreground(StatusRolling).Render(SymRolling)
 	StatusLinedUpStr = lipgloss.NewStyle().Foreground(StatusLinedUp).Render(SymLinedUp)
 	StatusStalledStr = lipgloss.NewStyle().Foreground(StatusStalled).Render(SymStalled)
-	StatusPassedStr  = lipgloss.NewStyle().Foreground(StatusPassed).Render(SymPassed)
+	StatusPassedStr = lipgloss.NewStyle().Foreground(StatusPassed).Render(SymPassed)
 
 	// Issue items in the list
 	ItemNormal = lipgloss.NewStyle().
-			PaddingLeft(3)
+		PaddingLeft(3)
 
 	ItemSelected = lipgloss.NewStyle().
-			PaddingLeft(1).
-			Bold(true).
-			Foreground(White)
+		PaddingLeft(1).
+		Bold(true).
+		Foreground(White)
 
 	ItemCursor = lipgloss.NewStyle().
-			Foreground(BrightGold).
-			Bold(true)
+		Foreground(BrightGold).
+		Bold(true)
 
 	ItemSelectedBg = lipgloss.NewStyle().
-			Background(DimPurple)
+		Background(DimPurple)
 
 	// Detail panel (right side)
 	DetailBorder = lipgloss.NewStyle().
-			BorderLeft(true).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(DimPurple).
-			PaddingLeft(1)
+		BorderLeft(true).
+		BorderStyle(Border(lipgloss.NormalBorder())).
+		BorderForeground(DimPurple).
+		PaddingLeft(1)
 
 	DetailTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(White)
+		Bold(true).
+		Foreground(White)
 
 	DetailLabel = lipgloss.NewStyle().
-			Foreground(Muted).
-			Width(12)
+		Foreground(Muted).
+		Width(12)
 
 	DetailValue = lipgloss.NewStyle().
-			Foreground(Light)
+		Foreground(Light)
 
 	DetailSection = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(BrightGold).
-			MarginTop(1)
+		Bold(true).
+		Foreground(BrightGold).
+		MarginTop(1)
 
 	// Priority badge
 	BadgePriority = lipgloss.NewStyle().
-			Bold(true)
+		Bold(true)
 
 	// Pre-rendered priority badges
 	BadgeP0 = BadgePriority.Foreground(PrioP0).Render("P0")
@@ -97,201 +164,207 @@ var (
 
 	// Type badge
 	BadgeType = lipgloss.NewStyle().
-			Italic(true)
+		Italic(true)
 
 	// Footer
 	FooterStyle = lipgloss.NewStyle().
-			Foreground(Light).
-			Background(DimPurple).
-			Padding(0, 1)
+		Foreground(Light).
+		Background(DimPurple).
+		Padding(0, 1)
 
 	FooterKey = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(BrightGold)
+		Bold(true).
+		Foreground(BrightGold)
 
 	FooterDesc = lipgloss.NewStyle().
-			Foreground(Light)
+		Foreground(Light)
 
 	// Dependency display
 	DepBlocked = lipgloss.NewStyle().
-			Foreground(StatusStalled)
+		Foreground(StatusStalled)
 
 	DepBlocks = lipgloss.NewStyle().
-			Foreground(StatusLinedUp)
+		Foreground(StatusLinedUp)
 
 	DepMissing = lipgloss.NewStyle().
-			Foreground(StatusStalled).
-			Bold(true)
+		Foreground(StatusStalled).
+		Bold(true)
 
 	DepResolved = lipgloss.NewStyle().
-			Foreground(StatusPassed)
+		Foreground(StatusPassed)
 
 	DepNonBlocking = lipgloss.NewStyle().
-			Foreground(Muted)
+		Foreground(Muted)
 
 	// Due date badges
 	OverdueBadge = lipgloss.NewStyle().
-			Foreground(StatusStalled).
-			Bold(true)
+		Foreground(StatusStalled).
+		Bold(true)
 
 	DueSoonBadge = lipgloss.NewStyle().
-			Foreground(PrioP1) // orange
+		Foreground(PrioP1) // orange
 
 	DeferredStyle = lipgloss.NewStyle().
-			Foreground(Dim)
+		Foreground(Dim)
 
 	// Rich dependency styles
 	DepRelated = lipgloss.NewStyle().
-			Foreground(BrightPurple)
+		Foreground(BrightPurple)
 
 	DepDuplicates = lipgloss.NewStyle().
-			Foreground(Muted).
-			Italic(true)
+		Foreground(Muted).
+		Italic(true)
 
 	DepSupersedes = lipgloss.NewStyle().
-			Foreground(BrightGold)
+		Foreground(BrightGold)
 
-	AgentBadge  = lipgloss.NewStyle().Foreground(StatusAgent).Bold(true)
+	AgentBadge = lipgloss.NewStyle().Foreground(StatusAgent).Bold(true)
 	ConvoyBadge = lipgloss.NewStyle().Foreground(StatusConvoy).Bold(true)
-	GasTownTag  = lipgloss.NewStyle().Foreground(BrightPurple).Italic(true)
+	GasTownTag = lipgloss.NewStyle().Foreground(BrightPurple).Italic(true)
 
 	// Gas Town panel
 	GasTownBorder = lipgloss.NewStyle().
-			BorderLeft(true).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(BrightGold).
-			PaddingLeft(1)
+		BorderLeft(true).
+		BorderStyle(Border(lipgloss.NormalBorder())).
+		BorderForeground(BrightGold).
+		PaddingLeft(1)
 
 	GasTownTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(BrightGold).
-			MarginTop(1)
+		Bold(true).
+		Foreground(BrightGold).
+		MarginTop(1)
 
 	GasTownLabel = lipgloss.NewStyle().
-			Foreground(Muted)
+		Foreground(Muted)
 
 	GasTownValue = lipgloss.NewStyle().
-			Foreground(Light)
+		Foreground(Light)
 
 	GasTownAgentSelected = lipgloss.NewStyle().
-				Background(DimPurple)
+		Background(DimPurple)
 
 	GasTownHint = lipgloss.NewStyle().
-			Foreground(Dim).
-			MarginTop(1)
+		Foreground(Dim).
+		MarginTop(1)
 
 	FooterSource = lipgloss.NewStyle().
-			Foreground(Muted)
+		Foreground(Muted)
 
 	// Molecule step styles
 	MolStepDone = lipgloss.NewStyle().
-			Foreground(BrightGreen)
+		Foreground(BrightGreen)
 
 	MolStepActive = lipgloss.NewStyle().
-			Foreground(BrightGold).
-			Bold(true)
+		Foreground(BrightGold).
+		Bold(true)
 
 	MolStepReady = lipgloss.NewStyle().
-			Foreground(Light)
+		Foreground(Light)
 
 	MolStepBlocked = lipgloss.NewStyle().
-			Foreground(StatusStalled)
+		Foreground(StatusStalled)
 
 	MolTierLabel = lipgloss.NewStyle().
-			Foreground(Dim).
-			Italic(true)
+		Foreground(Dim).
+		Italic(true)
 
 	MolDAGFlow = lipgloss.NewStyle().
-			Foreground(Dim)
+		Foreground(Dim)
 
 	MolCritical = lipgloss.NewStyle().
-			Foreground(BrightGold).
-			Bold(true)
+		Foreground(BrightGold).
+		Bold(true)
 
 	// Metadata fields
 	MetaFieldName = lipgloss.NewStyle().
-			Foreground(Light)
+		Foreground(Light)
 
 	MetaFieldNameDim = lipgloss.NewStyle().
-				Foreground(Muted)
+		Foreground(Muted)
 
 	MetaFieldType = lipgloss.NewStyle().
-			Foreground(Muted)
+		Foreground(Muted)
 
 	MetaFieldValue = lipgloss.NewStyle().
-			Foreground(BrightGreen)
+		Foreground(BrightGreen)
 
 	MetaRequired = lipgloss.NewStyle().
-			Foreground(StatusStalled)
+		Foreground(StatusStalled)
+
+	MetaInvalid = lipgloss.NewStyle().
+		Foreground(StatusStalled)
 
 	// Filter Input
 	InputPrompt = lipgloss.NewStyle().
-			Foreground(BrightGold).
-			Bold(true).
-			PaddingLeft(1)
+		Foreground(BrightGold).
+		Bold(true).
+		PaddingLeft(1)
 
 	InputText = lipgloss.NewStyle().
-			Foreground(White)
+		Foreground(White)
 
 	InputCursor = lipgloss.NewStyle().
-			Foreground(Purple)
+		Foreground(Purple)
 
 	// Help Overlay
 	HelpOverlayBg = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BrightPurple).
-			Background(lipgloss.Color("#121521")).
-			Padding(1, 2)
+		Border(Border(lipgloss.RoundedBorder())).
+		BorderForeground(BrightPurple).
+		Background(OverlayBg).
+		Padding(1, 2)
 
 	HelpTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(BrightGold).
-			Align(lipgloss.Center)
+		Bold(true).
+		Foreground(BrightGold).
+		Align(lipgloss.Center)
 
 	HelpSubtitle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#A9AFBF")).
-			Align(lipgloss.Center)
+		Foreground(OverlaySubtitle).
+		Align(lipgloss.Center)
 
 	HelpSection = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(BrightGreen).
-			Underline(true)
+		Bold(true).
+		Foreground(BrightGreen).
+		Underline(true)
 
 	HelpKey = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(Gold)
 
 	HelpDesc = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#D6D8DF"))
+		Foreground(OverlayText)
 
 	HelpHint = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8E94A6")).
-			Align(lipgloss.Center)
+		Foreground(OverlayHint).
+		Align(lipgloss.Center)
 
 	// Toast notifications
 	ToastInfo = lipgloss.NewStyle().
-			Foreground(Light).
-			Background(DimPurple).
-			Padding(0, 1)
+		Foreground(Light).
+		Background(DimPurple).
+		Padding(0, 1)
 
 	ToastSuccess = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#1A1A1A")).
-			Background(BrightGreen).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(Darkest).
+		Background(BrightGreen).
+		Bold(true).
+		Padding(0, 1)
 
 	ToastWarn = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#1A1A1A")).
-			Background(BrightGold).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(Darkest).
+		Background(BrightGold).
+		Bold(true).
+		Padding(0, 1)
 
 	ToastError = lipgloss.NewStyle().
-			Foreground(White).
-			Background(lipgloss.Color("#E74C3C")).
-			Bold(true).
-			Padding(0, 1)
-)
+		Foreground(White).
+		Background(Red).
+		Bold(true).
+		Padding(0, 1)
+
+	// Fuzzy match highlight
+	matchStyle = lipgloss.NewStyle().Foreground(BrightGold).Bold(true).Underline(true)
+}
 
 // RoleBadge returns a styled badge for a Gas Town role.
 func RoleBadge(role string) string {
@@ -356,8 +429,6 @@ func SectionDivider(title string, width int, focused bool) string {
 		ruleStyle.Render(trail)
 }
 
-var matchStyle = lipgloss.NewStyle().Foreground(BrightGold).Bold(true).Underline(true)
-
 // HighlightMatches renders a string with matched character positions highlighted.
 // Matched characters are rendered in bright gold bold; others use default style.
 func HighlightMatches(text string, indices []int, maxLen int) string {
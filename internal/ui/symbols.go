@@ -5,8 +5,9 @@ import (
 	"strings"
 )
 
-// Unicode symbols for the Mardi Gras theme.
-const (
+// Unicode symbols for the Mardi Gras theme. SetAccessible swaps them for
+// the ASCII set in asciiSymbols.
+var (
 	FleurDeLis = "⚜"
 
 	// Status indicators
@@ -39,6 +40,8 @@ const (
 	SymChanged     = "◈"
 	SymSelected    = "◉"
 	SymUnselected  = "○"
+	SymInvalid     = "✗" // metadata schema violation
+	SymWorktree    = "⎇" // issue has a git worktree
 
 	// Due dates
 	SymOverdue  = "▲"
@@ -101,16 +104,109 @@ const (
 
 	// Geometric indicators
 	SymDiamond = "◆"
+
+	// Timeline
+	SymToday     = "┊"
+	SymArrowTail = "╶"
+	SymArrowHead = "▸"
 )
 
+// symbol ties a symbol variable to its Unicode and ASCII forms.
+type symbol struct {
+	target         *string
+	unicode, ascii string
+}
+
+// asciiSymbols lists every symbol with its ASCII stand-in for accessible
+// mode.
+var asciiSymbols = []symbol{
+	{&FleurDeLis, FleurDeLis, "*"},
+	{&SymRolling, SymRolling, "*"},
+	{&SymLinedUp, SymLinedUp, "o"},
+	{&SymStalled, SymStalled, "!"},
+	{&SymPassed, SymPassed, "+"},
+	{&BeadRound, BeadRound, "o"},
+	{&BeadDiamond, BeadDiamond, "+"},
+	{&BeadDash, BeadDash, "-"},
+	{&Cursor, Cursor, ">"},
+	{&Expanded, Expanded, "v"},
+	{&Collapsed, Collapsed, ">"},
+	{&DepArrow, DepArrow, "->"},
+	{&DepTree, DepTree, "`-"},
+	{&SymMissing, SymMissing, "!"},
+	{&SymResolved, SymResolved, "+"},
+	{&SymNonBlocking, SymNonBlocking, "."},
+	{&SymNextArrow, SymNextArrow, "next ->"},
+	{&SymAgent, SymAgent, "@"},
+	{&SymConvoy, SymConvoy, "&"},
+	{&SymMail, SymMail, "M"},
+	{&SymSling, SymSling, "=>"},
+	{&SymChanged, SymChanged, "~"},
+	{&SymSelected, SymSelected, "x"},
+	{&SymUnselected, SymUnselected, "-"},
+	{&SymInvalid, SymInvalid, "X"},
+	{&SymWorktree, SymWorktree, "w"},
+	{&SymOverdue, SymOverdue, "!"},
+	{&SymDeferred, SymDeferred, "z"},
+	{&SymDueDate, SymDueDate, "d"},
+	{&SymRelated, SymRelated, "<>"},
+	{&SymDuplicates, SymDuplicates, "="},
+	{&SymSupersedes, SymSupersedes, "=>"},
+	{&BoxTopLeft, BoxTopLeft, "+"},
+	{&BoxTopRight, BoxTopRight, "+"},
+	{&BoxBottomLeft, BoxBottomLeft, "+"},
+	{&BoxBottomRight, BoxBottomRight, "+"},
+	{&BoxHorizontal, BoxHorizontal, "-"},
+	{&BoxVertical, BoxVertical, "|"},
+	{&DividerH, DividerH, "="},
+	{&DividerV, DividerV, "|"},
+	{&CornerTL, CornerTL, "+"},
+	{&CornerBL, CornerBL, "+"},
+	{&SymIdle, SymIdle, "o"},
+	{&SymWorking, SymWorking, "*"},
+	{&SymBackoff, SymBackoff, "~"},
+	{&SymStuck, SymStuck, "!"},
+	{&SymSpawning, SymSpawning, "+"},
+	{&SymGate, SymGate, "#"},
+	{&SymPaused, SymPaused, "="},
+	{&SymFixNeeded, SymFixNeeded, "F"},
+	{&SymPropelled, SymPropelled, ">"},
+	{&SymProgress, SymProgress, "#"},
+	{&SymProgressEmpty, SymProgressEmpty, "-"},
+	{&SymDog, SymDog, "d"},
+	{&SymTown, SymTown, "T"},
+	{&SymWarning, SymWarning, "!"},
+	{&SymDeadRig, SymDeadRig, "X"},
+	{&SymZombie, SymZombie, "Z"},
+	{&SymStepDone, SymStepDone, "+"},
+	{&SymStepActive, SymStepActive, "*"},
+	{&SymStepReady, SymStepReady, "o"},
+	{&SymStepBlocked, SymStepBlocked, "!"},
+	{&SymStepSkipped, SymStepSkipped, "-"},
+	{&SymTierLine, SymTierLine, "|"},
+	{&SymDAGFlow, SymDAGFlow, "|"},
+	{&SymDAGBranch, SymDAGBranch, "+"},
+	{&SymDAGFork, SymDAGFork, "+"},
+	{&SymDAGJoin, SymDAGJoin, "`"},
+	{&SymDAGArrow, SymDAGArrow, "v"},
+	{&SymDiamond, SymDiamond, "+"},
+	{&SymToday, SymToday, ":"},
+	{&SymArrowTail, SymArrowTail, "-"},
+	{&SymArrowHead, SymArrowHead, ">"},
+}
+
 // superscriptDigits maps 0-9 to their Unicode superscript equivalents.
 var superscriptDigits = [10]string{"⁰", "¹", "²", "³", "⁴", "⁵", "⁶", "⁷", "⁸", "⁹"}
 
-// Superscript converts a non-negative integer to superscript Unicode digits.
+// Superscript converts a non-negative integer to superscript Unicode digits,
+// or to "(n)" in accessible mode.
 func Superscript(n int) string {
 	if n < 0 {
 		n = 0
 	}
+	if accessible {
+		return fmt.Sprintf("(%d)", n)
+	}
 	if n < 10 {
 		return superscriptDigits[n]
 	}
@@ -3,85 +3,47 @@
 // renderers. It contains no business logic.
 package ui
 
-import (
-	"image/color"
+import "image/color"
 
-	"charm.land/lipgloss/v2"
-)
-
-// Mardi Gras palette.
+// Active palette. ApplyTheme assigns every color from a Theme; the built-in
+// dark theme is applied at startup.
 var (
 	// Core parade colors
-	Purple = lipgloss.Color("#7B2D8E")
-	Gold   = lipgloss.Color("#F5C518")
-	Green  = lipgloss.Color("#1D8348")
+	Purple, Gold, Green color.Color
 
 	// Brighter variants for emphasis
-	BrightPurple = lipgloss.Color("#9B59B6")
-	BrightGold   = lipgloss.Color("#FFD700")
-	BrightGreen  = lipgloss.Color("#2ECC71")
+	BrightPurple, BrightGold, BrightGreen color.Color
 
 	// Dimmed variants for backgrounds/borders
-	DimPurple = lipgloss.Color("#4A1259")
-	DimGold   = lipgloss.Color("#8B7D00")
-	DimGreen  = lipgloss.Color("#145A32")
-
-	// Neutrals
-	White   = lipgloss.Color("#FAFAFA")
-	Light   = lipgloss.Color("#CCCCCC")
-	Muted   = lipgloss.Color("#888888")
-	Dim     = lipgloss.Color("#555555")
-	Dark    = lipgloss.Color("#333333")
-	Darkest = lipgloss.Color("#1A1A1A")
+	DimPurple, DimGold, DimGreen color.Color
+
+	// Alert accents
+	Red, Orange color.Color
+
+	// Neutrals, from the strongest text to the faintest background
+	White, Light, Muted, Dim, Dark, Darkest, Silver color.Color
+
+	// Overlay surfaces (help, palette, dialogs)
+	OverlayBg, OverlayText, OverlaySubtitle, OverlayHint color.Color
 
 	// Semantic: parade status
-	StatusRolling = BrightGreen
-	StatusLinedUp = BrightGold
-	StatusStalled = lipgloss.Color("#E74C3C")
-	StatusPassed  = Muted
-	StatusAgent   = BrightPurple
-	StatusConvoy  = BrightGold
-	StatusMail    = BrightGreen
+	StatusRolling, StatusLinedUp, StatusStalled, StatusPassed color.Color
+	StatusAgent, StatusConvoy, StatusMail                     color.Color
 
 	// Priority colors (P0=critical red → P4=backlog gray)
-	PrioP0 = lipgloss.Color("#FF3333")
-	PrioP1 = lipgloss.Color("#FF8C00")
-	PrioP2 = BrightGold
-	PrioP3 = BrightGreen
-	PrioP4 = Muted
+	PrioP0, PrioP1, PrioP2, PrioP3, PrioP4 color.Color
 
 	// Issue type colors
-	ColorBug       = lipgloss.Color("#E74C3C")
-	ColorFeature   = BrightPurple
-	ColorTask      = BrightGold
-	ColorChore     = Muted
-	ColorEpic      = lipgloss.Color("#3498DB")
-	ColorSpike     = lipgloss.Color("#F39C12")
-	ColorStory     = lipgloss.Color("#A569BD")
-	ColorMilestone = lipgloss.Color("#00BCD4")
-
-	// Neutrals (extra)
-	Silver = lipgloss.Color("#AAAAAA")
+	ColorBug, ColorFeature, ColorTask, ColorChore     color.Color
+	ColorEpic, ColorSpike, ColorStory, ColorMilestone color.Color
 
 	// Gas Town role colors
-	RoleMayor    = BrightGold
-	RoleDeacon   = lipgloss.Color("#3498DB") // Blue — town health monitor
-	RolePolecat  = BrightGreen
-	RoleCrew     = BrightPurple
-	RoleWitness  = lipgloss.Color("#E67E22") // Orange — rig reviewer
-	RoleRefinery = lipgloss.Color("#1ABC9C") // Teal — merge processor
-	RoleDog      = lipgloss.Color("#8E44AD") // Deep purple — infrastructure worker
-	RoleDefault  = Silver
+	RoleMayor, RoleDeacon, RolePolecat, RoleCrew    color.Color
+	RoleWitness, RoleRefinery, RoleDog, RoleDefault color.Color
 
 	// Gas Town agent state colors
-	StateWorking   = BrightGreen
-	StateIdle      = Silver
-	StateBackoff   = lipgloss.Color("#E74C3C")
-	StateStuck     = lipgloss.Color("#FF8C00") // Amber — agent requesting help
-	StateSpawn     = lipgloss.Color("#3498DB") // Cyan — session starting
-	StateGate      = BrightGold                // Waiting on external trigger
-	StateFixNeeded = lipgloss.Color("#E056A0") // Pink — review feedback, needs rework
-	StatePropelled = lipgloss.Color("#00CED1") // Dark turquoise — ACP propulsion, output suppressed
+	StateWorking, StateIdle, StateBackoff, StateStuck     color.Color
+	StateSpawn, StateGate, StateFixNeeded, StatePropelled color.Color
 )
 
 // PriorityColor returns the theme color for a priority level.
@@ -171,3 +133,20 @@ func IssueTypeColor(t string) color.Color {
 		return Muted
 	}
 }
+
+// PRColor colors a pull request badge: red when CI fails, purple once merged,
+// dim when closed or draft, gold while checks run, green otherwise.
+func PRColor(label, checks string) color.Color {
+	switch {
+	case label == "merged":
+		return BrightPurple
+	case label == "closed", label == "draft":
+		return Dim
+	case checks == "failing":
+		return StatusStalled
+	case checks == "pending":
+		return BrightGold
+	default:
+		return BrightGreen
+	}
+}
@@ -162,3 +162,23 @@ func TestApplyPartialGradientNonEmpty(t *testing.T) {
 		t.Fatal("ApplyPartialMardiGrasGradient(\"hello\", 10) returned empty string")
 	}
 }
+
+func TestPRColor(t *testing.T) {
+	tests := []struct {
+		label, checks string
+		want          color.Color
+	}{
+		{"open", "failing", StatusStalled},
+		{"open", "pending", BrightGold},
+		{"open", "passing", BrightGreen},
+		{"open", "", BrightGreen},
+		{"draft", "failing", Dim},
+		{"merged", "failing", BrightPurple},
+		{"closed", "", Dim},
+	}
+	for _, tt := range tests {
+		if got := PRColor(tt.label, tt.checks); got != tt.want {
+			t.Errorf("PRColor(%q, %q) = %v, want %v", tt.label, tt.checks, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// themeFile is a parsed theme file: the theme it extends and its color
+// overrides keyed "section.key".
+type themeFile struct {
+	name    string
+	extends string
+	colors  map[string]string
+}
+
+// LoadThemeFile reads a YAML (.yaml, .yml) or TOML (.toml) theme file. The
+// file names a built-in theme to extend (default dark) and overrides colors
+// by section:
+//
+//	name: solarized
+//	extends: light
+//	status:
+//	  rolling: "#859900"
+//	priority:
+//	  p0: "1"
+//
+// Colors are "#RGB", "#RRGGBB" or an ANSI index 0-255. The theme is named
+// after the file unless it sets name.
+func LoadThemeFile(path string) (Theme, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	var tf themeFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		tf, err = parseThemeYAML(raw)
+	case ".toml":
+		tf, err = parseThemeTOML(raw)
+	default:
+		err = fmt.Errorf("unsupported theme format %q", ext)
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", filepath.Base(path), err)
+	}
+	if tf.name == "" {
+		tf.name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	t, err := tf.theme()
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", filepath.Base(path), err)
+	}
+	return t, nil
+}
+
+// LoadThemeDir loads every theme file in dir, sorted by file name. A
+// missing dir yields no themes; files that fail to load are skipped and
+// the first error is returned alongside the rest.
+func LoadThemeDir(dir string) ([]Theme, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var themes []Theme
+	var firstErr error
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml", ".toml":
+		default:
+			continue
+		}
+		t, err := LoadThemeFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		themes = append(themes, t)
+	}
+	return themes, firstErr
+}
+
+// theme applies the overrides to the extended built-in theme.
+func (tf themeFile) theme() (Theme, error) {
+	base := tf.extends
+	if base == "" {
+		base = ThemeDark
+	}
+	t, ok := BuiltinTheme(base)
+	if !ok {
+		return Theme{}, fmt.Errorf("extends unknown theme %q", base)
+	}
+	t.Name = tf.name
+
+	keys := make([]string, 0, len(tf.colors))
+	for k := range tf.colors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		i := themeColorIndex(key)
+		if i < 0 {
+			return Theme{}, fmt.Errorf("unknown color %q", key)
+		}
+		c, err := parseThemeColor(tf.colors[key])
+		if err != nil {
+			return Theme{}, fmt.Errorf("%s: %w", key, err)
+		}
+		*themeColors[i].field(&t) = c
+	}
+	return t, nil
+}
+
+func themeColorIndex(key string) int {
+	for i, c := range themeColors {
+		if c.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// parseThemeColor accepts a hex color or an ANSI index.
+func parseThemeColor(s string) (color.Color, error) {
+	s = strings.TrimSpace(s)
+	if hexColor.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return nil, fmt.Errorf("invalid color %q (want #RRGGBB or 0-255)", s)
+}
+
+// parseThemeYAML reads the top-level name and extends keys and one mapping
+// of colors per section.
+func parseThemeYAML(raw []byte) (themeFile, error) {
+	var doc map[string]yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return themeFile{}, err
+	}
+	tf := themeFile{colors: make(map[string]string)}
+	for key, node := range doc {
+		switch key {
+		case "name":
+			tf.name = node.Value
+		case "extends":
+			tf.extends = node.Value
+		default:
+			var section map[string]string
+			if err := node.Decode(&section); err != nil {
+				return themeFile{}, fmt.Errorf("section %s: %w", key, err)
+			}
+			for k, v := range section {
+				tf.colors[key+"."+k] = v
+			}
+		}
+	}
+	return tf, nil
+}
+
+// parseThemeTOML reads the top-level name and extends keys and one table of
+// colors per section. Colors are strings or integers.
+func parseThemeTOML(raw []byte) (themeFile, error) {
+	var doc map[string]any
+	if _, err := toml.Decode(string(raw), &doc); err != nil {
+		return themeFile{}, err
+	}
+	tf := themeFile{colors: make(map[string]string)}
+	for key, v := range doc {
+		switch key {
+		case "name", "extends":
+			s, ok := v.(string)
+			if !ok {
+				return themeFile{}, fmt.Errorf("%s: want a string", key)
+			}
+			if key == "name" {
+				tf.name = s
+			} else {
+				tf.extends = s
+			}
+			continue
+		}
+		section, ok := v.(map[string]any)
+		if !ok {
+			return themeFile{}, fmt.Errorf("color %q outside a section", key)
+		}
+		for k, c := range section {
+			switch c := c.(type) {
+			case string:
+				tf.colors[key+"."+k] = c
+			case int64:
+				tf.colors[key+"."+k] = strconv.FormatInt(c, 10)
+			default:
+				return themeFile{}, fmt.Errorf("%s.%s: want a quoted string or integer", key, k)
+			}
+		}
+	}
+	return tf, nil
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func writeTheme(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadThemeFileYAML(t *testing.T) {
+	path := writeTheme(t, t.TempDir(), "solar.yaml", `extends: light
+status:
+  rolling: "#859900"
+priority:
+  p0: 160
+role:
+  witness: "#CB4B16"
+`)
+	theme, err := LoadThemeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "solar" {
+		t.Errorf("Name = %q, want solar (from the file name)", theme.Name)
+	}
+	if !theme.LightBg {
+		t.Error("theme extending light should keep LightBg")
+	}
+	if theme.StatusRolling != lipgloss.Color("#859900") {
+		t.Errorf("StatusRolling = %v", theme.StatusRolling)
+	}
+	if theme.PrioP0 != lipgloss.Color("160") {
+		t.Errorf("PrioP0 = %v, want ANSI 160", theme.PrioP0)
+	}
+	if theme.RoleWitness != lipgloss.Color("#CB4B16") {
+		t.Errorf("RoleWitness = %v", theme.RoleWitness)
+	}
+	if theme.ColorEpic != LightTheme().ColorEpic {
+		t.Error("unset colors should come from the extended theme")
+	}
+}
+
+func TestLoadThemeFileTOML(t *testing.T) {
+	path := writeTheme(t, t.TempDir(), "x.toml", `# comment
+name = "night"
+role = { witness = "\u0023CB4B16" }
+priority.p0 = 160
+
+[state]
+stuck = "#FFAA00" # amber
+working = '#F0F'
+
+[type]
+bug = 9
+`)
+	theme, err := LoadThemeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "night" {
+		t.Errorf("Name = %q, want night", theme.Name)
+	}
+	if theme.LightBg {
+		t.Error("theme extends dark by default")
+	}
+	if theme.StateStuck != lipgloss.Color("#FFAA00") || theme.ColorBug != lipgloss.Color("9") {
+		t.Errorf("StateStuck = %v, ColorBug = %v", theme.StateStuck, theme.ColorBug)
+	}
+	if theme.RoleWitness != lipgloss.Color("#CB4B16") || theme.PrioP0 != lipgloss.Color("160") {
+		t.Errorf("inline table RoleWitness = %v, dotted key PrioP0 = %v", theme.RoleWitness, theme.PrioP0)
+	}
+}
+
+func TestLoadThemeFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name, file, content, want string
+	}{
+		{"unknown key", "a.yaml", "status:\n  rollin: \"#FFFFFF\"\n", `unknown color "status.rollin"`},
+		{"bad color", "b.yaml", "status:\n  rolling: red\n", "invalid color"},
+		{"unknown base", "c.yaml", "extends: sepia\n", `unknown theme "sepia"`},
+		{"toml key outside section", "d.toml", "rolling = \"#FFFFFF\"\n", "outside a section"},
+		{"toml bare word", "e.toml", "[status]\nrolling = red\n", "line 2"},
+		{"toml bool", "g.toml", "[status]\nrolling = true\n", "quoted string or integer"},
+		{"format", "f.json", "{}", "unsupported theme format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadThemeFile(writeTheme(t, dir, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadThemeDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTheme(t, dir, "b.toml", "name = \"bravo\"\n")
+	writeTheme(t, dir, "a.yml", "name: alpha\n")
+	writeTheme(t, dir, "bad.yaml", "status:\n  nope: \"#000000\"\n")
+	writeTheme(t, dir, "notes.txt", "ignored")
+
+	themes, err := LoadThemeDir(dir)
+	if err == nil {
+		t.Error("want the bad theme's error")
+	}
+	var names []string
+	for _, th := range themes {
+		names = append(names, th.Name)
+	}
+	if got := strings.Join(names, ","); got != "alpha,bravo" {
+		t.Errorf("themes = %s, want alpha,bravo", got)
+	}
+
+	themes, err = LoadThemeDir(filepath.Join(dir, "missing"))
+	if err != nil || themes != nil {
+		t.Errorf("missing dir = %v, %v; want nothing", themes, err)
+	}
+}
@@ -0,0 +1,396 @@
+package ui
+
+import (
+	"image/color"
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Theme is a complete set of colors for the palette variables. Theme files
+// override any subset of them on top of a built-in theme.
+type Theme struct {
+	Name    string
+	LightBg bool // designed for light terminal backgrounds
+	NoColor bool // renders without color; gradients are flat
+
+	Purple, Gold, Green                                  color.Color
+	BrightPurple, BrightGold, BrightGreen                color.Color
+	DimPurple, DimGold, DimGreen                         color.Color
+	Red, Orange                                          color.Color
+	White, Light, Muted, Dim, Dark, Darkest, Silver      color.Color
+	OverlayBg, OverlayText, OverlaySubtitle, OverlayHint color.Color
+
+	StatusRolling, StatusLinedUp, StatusStalled, StatusPassed color.Color
+	StatusAgent, StatusConvoy, StatusMail                     color.Color
+
+	PrioP0, PrioP1, PrioP2, PrioP3, PrioP4 color.Color
+
+	ColorBug, ColorFeature, ColorTask, ColorChore     color.Color
+	ColorEpic, ColorSpike, ColorStory, ColorMilestone color.Color
+
+	RoleMayor, RoleDeacon, RolePolecat, RoleCrew    color.Color
+	RoleWitness, RoleRefinery, RoleDog, RoleDefault color.Color
+
+	StateWorking, StateIdle, StateBackoff, StateStuck     color.Color
+	StateSpawn, StateGate, StateFixNeeded, StatePropelled color.Color
+}
+
+// themeColor ties a Theme field to its palette variable and its
+// "section.key" name in theme files.
+type themeColor struct {
+	key    string
+	field  func(*Theme) *color.Color
+	target *color.Color
+}
+
+// themeColors lists every themeable color, in theme file order.
+var themeColors = []themeColor{
+	{"palette.purple", func(t *Theme) *color.Color { return &t.Purple }, &Purple},
+	{"palette.gold", func(t *Theme) *color.Color { return &t.Gold }, &Gold},
+	{"palette.green", func(t *Theme) *color.Color { return &t.Green }, &Green},
+	{"palette.bright_purple", func(t *Theme) *color.Color { return &t.BrightPurple }, &BrightPurple},
+	{"palette.bright_gold", func(t *Theme) *color.Color { return &t.BrightGold }, &BrightGold},
+	{"palette.bright_green", func(t *Theme) *color.Color { return &t.BrightGreen }, &BrightGreen},
+	{"palette.dim_purple", func(t *Theme) *color.Color { return &t.DimPurple }, &DimPurple},
+	{"palette.dim_gold", func(t *Theme) *color.Color { return &t.DimGold }, &DimGold},
+	{"palette.dim_green", func(t *Theme) *color.Color { return &t.DimGreen }, &DimGreen},
+	{"palette.red", func(t *Theme) *color.Color { return &t.Red }, &Red},
+	{"palette.orange", func(t *Theme) *color.Color { return &t.Orange }, &Orange},
+	{"palette.white", func(t *Theme) *color.Color { return &t.White }, &White},
+	{"palette.light", func(t *Theme) *color.Color { return &t.Light }, &Light},
+	{"palette.muted", func(t *Theme) *color.Color { return &t.Muted }, &Muted},
+	{"palette.dim", func(t *Theme) *color.Color { return &t.Dim }, &Dim},
+	{"palette.dark", func(t *Theme) *color.Color { return &t.Dark }, &Dark},
+	{"palette.darkest", func(t *Theme) *color.Color { return &t.Darkest }, &Darkest},
+	{"palette.silver", func(t *Theme) *color.Color { return &t.Silver }, &Silver},
+
+	{"overlay.background", func(t *Theme) *color.Color { return &t.OverlayBg }, &OverlayBg},
+	{"overlay.text", func(t *Theme) *color.Color { return &t.OverlayText }, &OverlayText},
+	{"overlay.subtitle", func(t *Theme) *color.Color { return &t.OverlaySubtitle }, &OverlaySubtitle},
+	{"overlay.hint", func(t *Theme) *color.Color { return &t.OverlayHint }, &OverlayHint},
+
+	{"status.rolling", func(t *Theme) *color.Color { return &t.StatusRolling }, &StatusRolling},
+	{"status.lined_up", func(t *Theme) *color.Color { return &t.StatusLinedUp }, &StatusLinedUp},
+	{"status.stalled", func(t *Theme) *color.Color { return &t.StatusStalled }, &StatusStalled},
+	{"status.passed", func(t *Theme) *color.Color { return &t.StatusPassed }, &StatusPassed},
+	{"status.agent", func(t *Theme) *color.Color { return &t.StatusAgent }, &StatusAgent},
+	{"status.convoy", func(t *Theme) *color.Color { return &t.StatusConvoy }, &StatusConvoy},
+	{"status.mail", func(t *Theme) *color.Color { return &t.StatusMail }, &StatusMail},
+
+	{"priority.p0", func(t *Theme) *color.Color { return &t.PrioP0 }, &PrioP0},
+	{"priority.p1", func(t *Theme) *color.Color { return &t.PrioP1 }, &PrioP1},
+	{"priority.p2", func(t *Theme) *color.Color { return &t.PrioP2 }, &PrioP2},
+	{"priority.p3", func(t *Theme) *color.Color { return &t.PrioP3 }, &PrioP3},
+	{"priority.p4", func(t *Theme) *color.Color { return &t.PrioP4 }, &PrioP4},
+
+	{"type.bug", func(t *Theme) *color.Color { return &t.ColorBug }, &ColorBug},
+	{"type.feature", func(t *Theme) *color.Color { return &t.ColorFeature }, &ColorFeature},
+	{"type.task", func(t *Theme) *color.Color { return &t.ColorTask }, &ColorTask},
+	{"type.chore", func(t *Theme) *color.Color { return &t.ColorChore }, &ColorChore},
+	{"type.epic", func(t *Theme) *color.Color { return &t.ColorEpic }, &ColorEpic},
+	{"type.spike", func(t *Theme) *color.Color { return &t.ColorSpike }, &ColorSpike},
+	{"type.story", func(t *Theme) *color.Color { return &t.ColorStory }, &ColorStory},
+	{"type.milestone", func(t *Theme) *color.Color { return &t.ColorMilestone }, &ColorMilestone},
+
+	{"role.mayor", func(t *Theme) *color.Color { return &t.RoleMayor }, &RoleMayor},
+	{"role.deacon", func(t *Theme) *color.Color { return &t.RoleDeacon }, &RoleDeacon},
+	{"role.polecat", func(t *Theme) *color.Color { return &t.RolePolecat }, &RolePolecat},
+	{"role.crew", func(t *Theme) *color.Color { return &t.RoleCrew }, &RoleCrew},
+	{"role.witness", func(t *Theme) *color.Color { return &t.RoleWitness }, &RoleWitness},
+	{"role.refinery", func(t *Theme) *color.Color { return &t.RoleRefinery }, &RoleRefinery},
+	{"role.dog", func(t *Theme) *color.Color { return &t.RoleDog }, &RoleDog},
+	{"role.default", func(t *Theme) *color.Color { return &t.RoleDefault }, &RoleDefault},
+
+	{"state.working", func(t *Theme) *color.Color { return &t.StateWorking }, &StateWorking},
+	{"state.idle", func(t *Theme) *color.Color { return &t.StateIdle }, &StateIdle},
+	{"state.backoff", func(t *Theme) *color.Color { return &t.StateBackoff }, &StateBackoff},
+	{"state.stuck", func(t *Theme) *color.Color { return &t.StateStuck }, &StateStuck},
+	{"state.spawning", func(t *Theme) *color.Color { return &t.StateSpawn }, &StateSpawn},
+	{"state.awaiting_gate", func(t *Theme) *color.Color { return &t.StateGate }, &StateGate},
+	{"state.fix_needed", func(t *Theme) *color.Color { return &t.StateFixNeeded }, &StateFixNeeded},
+	{"state.propelled", func(t *Theme) *color.Color { return &t.StatePropelled }, &StatePropelled},
+}
+
+// Built-in theme names.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMono         = "mono"
+)
+
+var (
+	activeTheme Theme
+	themeHooks  []func()
+)
+
+func init() {
+	ApplyTheme(DarkTheme())
+}
+
+// ApplyTheme makes t the active theme: it assigns the palette variables,
+// rebuilds the styles and gradients derived from them, then runs the hooks
+// registered with OnThemeChange. Colors t leaves nil keep their current
+// value.
+func ApplyTheme(t Theme) {
+	for _, c := range themeColors {
+		if v := *c.field(&t); v != nil {
+			*c.target = v
+		}
+	}
+	activeTheme = t
+	buildStyles()
+	buildGradients()
+	for _, hook := range themeHooks {
+		hook()
+	}
+}
+
+// ActiveTheme returns the theme last applied.
+func ActiveTheme() Theme {
+	return activeTheme
+}
+
+// OnThemeChange registers fn to run after every ApplyTheme, for packages
+// that keep their own styles built from the palette.
+func OnThemeChange(fn func()) {
+	themeHooks = append(themeHooks, fn)
+}
+
+// BuiltinThemes returns the shipped themes: dark, light, high-contrast and
+// mono.
+func BuiltinThemes() []Theme {
+	return []Theme{DarkTheme(), LightTheme(), HighContrastTheme(), MonoTheme()}
+}
+
+// BuiltinTheme returns the shipped theme called name.
+func BuiltinTheme(name string) (Theme, bool) {
+	return FindTheme(BuiltinThemes(), name)
+}
+
+// FindTheme returns the theme called name from themes.
+func FindTheme(themes []Theme, name string) (Theme, bool) {
+	i := slices.IndexFunc(themes, func(t Theme) bool { return t.Name == name })
+	if i < 0 {
+		return Theme{}, false
+	}
+	return themes[i], true
+}
+
+// DefaultTheme picks the built-in theme for the terminal background.
+func DefaultTheme(darkBg bool) Theme {
+	if darkBg {
+		return DarkTheme()
+	}
+	return LightTheme()
+}
+
+// DarkTheme is the original Mardi Gras palette, for dark backgrounds.
+func DarkTheme() Theme {
+	t := Theme{
+		Name: ThemeDark,
+
+		Purple: lipgloss.Color("#7B2D8E"),
+		Gold:   lipgloss.Color("#F5C518"),
+		Green:  lipgloss.Color("#1D8348"),
+
+		BrightPurple: lipgloss.Color("#9B59B6"),
+		BrightGold:   lipgloss.Color("#FFD700"),
+		BrightGreen:  lipgloss.Color("#2ECC71"),
+
+		DimPurple: lipgloss.Color("#4A1259"),
+		DimGold:   lipgloss.Color("#8B7D00"),
+		DimGreen:  lipgloss.Color("#145A32"),
+
+		Red:    lipgloss.Color("#E74C3C"),
+		Orange: lipgloss.Color("#E67E22"),
+
+		White:   lipgloss.Color("#FAFAFA"),
+		Light:   lipgloss.Color("#CCCCCC"),
+		Muted:   lipgloss.Color("#888888"),
+		Dim:     lipgloss.Color("#555555"),
+		Dark:    lipgloss.Color("#333333"),
+		Darkest: lipgloss.Color("#1A1A1A"),
+		Silver:  lipgloss.Color("#AAAAAA"),
+
+		OverlayBg:       lipgloss.Color("#121521"),
+		OverlayText:     lipgloss.Color("#D6D8DF"),
+		OverlaySubtitle: lipgloss.Color("#A9AFBF"),
+		OverlayHint:     lipgloss.Color("#8E94A6"),
+
+		PrioP0: lipgloss.Color("#FF3333"),
+		PrioP1: lipgloss.Color("#FF8C00"),
+
+		ColorEpic:      lipgloss.Color("#3498DB"),
+		ColorSpike:     lipgloss.Color("#F39C12"),
+		ColorStory:     lipgloss.Color("#A569BD"),
+		ColorMilestone: lipgloss.Color("#00BCD4"),
+
+		RoleDeacon:   lipgloss.Color("#3498DB"), // Blue — town health monitor
+		RoleWitness:  lipgloss.Color("#E67E22"), // Orange — rig reviewer
+		RoleRefinery: lipgloss.Color("#1ABC9C"), // Teal — merge processor
+		RoleDog:      lipgloss.Color("#8E44AD"), // Deep purple — infrastructure worker
+
+		StateStuck:     lipgloss.Color("#FF8C00"), // Amber — agent requesting help
+		StateSpawn:     lipgloss.Color("#3498DB"), // Cyan — session starting
+		StateFixNeeded: lipgloss.Color("#E056A0"), // Pink — review feedback, needs rework
+		StatePropelled: lipgloss.Color("#00CED1"), // Dark turquoise — ACP propulsion, output suppressed
+	}
+	t.deriveSemantic()
+	return t
+}
+
+// LightTheme darkens the accents and inverts the neutrals so text stays
+// readable on light backgrounds.
+func LightTheme() Theme {
+	t := Theme{
+		Name:    ThemeLight,
+		LightBg: true,
+
+		Purple: lipgloss.Color("#6A1B9A"),
+		Gold:   lipgloss.Color("#A67C00"),
+		Green:  lipgloss.Color("#1B7A3A"),
+
+		BrightPurple: lipgloss.Color("#7B1FA2"),
+		BrightGold:   lipgloss.Color("#946200"),
+		BrightGreen:  lipgloss.Color("#137333"),
+
+		DimPurple: lipgloss.Color("#E8DCF0"),
+		DimGold:   lipgloss.Color("#C9A94A"),
+		DimGreen:  lipgloss.Color("#CDE8D3"),
+
+		Red:    lipgloss.Color("#C62828"),
+		Orange: lipgloss.Color("#C25100"),
+
+		White:   lipgloss.Color("#111111"),
+		Light:   lipgloss.Color("#333333"),
+		Muted:   lipgloss.Color("#666666"),
+		Dim:     lipgloss.Color("#999999"),
+		Dark:    lipgloss.Color("#CCCCCC"),
+		Darkest: lipgloss.Color("#F5F5F5"),
+		Silver:  lipgloss.Color("#707070"),
+
+		OverlayBg:       lipgloss.Color("#F6F3FA"),
+		OverlayText:     lipgloss.Color("#2B2B36"),
+		OverlaySubtitle: lipgloss.Color("#4F5566"),
+		OverlayHint:     lipgloss.Color("#6B7080"),
+
+		PrioP0: lipgloss.Color("#D50000"),
+		PrioP1: lipgloss.Color("#C25100"),
+
+		ColorEpic:      lipgloss.Color("#1565C0"),
+		ColorSpike:     lipgloss.Color("#B35C00"),
+		ColorStory:     lipgloss.Color("#8E24AA"),
+		ColorMilestone: lipgloss.Color("#00838F"),
+
+		RoleDeacon:   lipgloss.Color("#1565C0"),
+		RoleWitness:  lipgloss.Color("#C25100"),
+		RoleRefinery: lipgloss.Color("#00796B"),
+		RoleDog:      lipgloss.Color("#6A1B9A"),
+
+		StateStuck:     lipgloss.Color("#C25100"),
+		StateSpawn:     lipgloss.Color("#1565C0"),
+		StateFixNeeded: lipgloss.Color("#AD1457"),
+		StatePropelled: lipgloss.Color("#00838F"),
+	}
+	t.deriveSemantic()
+	return t
+}
+
+// HighContrastTheme uses saturated accents and pure neutrals on a black
+// background.
+func HighContrastTheme() Theme {
+	t := Theme{
+		Name: ThemeHighContrast,
+
+		Purple: lipgloss.Color("#D787FF"),
+		Gold:   lipgloss.Color("#FFFF00"),
+		Green:  lipgloss.Color("#00FF00"),
+
+		BrightPurple: lipgloss.Color("#FF87FF"),
+		BrightGold:   lipgloss.Color("#FFFF00"),
+		BrightGreen:  lipgloss.Color("#00FF00"),
+
+		DimPurple: lipgloss.Color("#5F005F"),
+		DimGold:   lipgloss.Color("#AFAF00"),
+		DimGreen:  lipgloss.Color("#005F00"),
+
+		Red:    lipgloss.Color("#FF0000"),
+		Orange: lipgloss.Color("#FF8700"),
+
+		White:   lipgloss.Color("#FFFFFF"),
+		Light:   lipgloss.Color("#FFFFFF"),
+		Muted:   lipgloss.Color("#D0D0D0"),
+		Dim:     lipgloss.Color("#A8A8A8"),
+		Dark:    lipgloss.Color("#444444"),
+		Darkest: lipgloss.Color("#000000"),
+		Silver:  lipgloss.Color("#D0D0D0"),
+
+		OverlayBg:       lipgloss.Color("#000000"),
+		OverlayText:     lipgloss.Color("#FFFFFF"),
+		OverlaySubtitle: lipgloss.Color("#FFFFFF"),
+		OverlayHint:     lipgloss.Color("#D0D0D0"),
+
+		PrioP0: lipgloss.Color("#FF0000"),
+		PrioP1: lipgloss.Color("#FF8700"),
+
+		ColorEpic:      lipgloss.Color("#5FAFFF"),
+		ColorSpike:     lipgloss.Color("#FF8700"),
+		ColorStory:     lipgloss.Color("#D787FF"),
+		ColorMilestone: lipgloss.Color("#00FFFF"),
+
+		RoleDeacon:   lipgloss.Color("#5FAFFF"),
+		RoleWitness:  lipgloss.Color("#FF8700"),
+		RoleRefinery: lipgloss.Color("#00FFD7"),
+		RoleDog:      lipgloss.Color("#D787FF"),
+
+		StateStuck:     lipgloss.Color("#FF8700"),
+		StateSpawn:     lipgloss.Color("#5FAFFF"),
+		StateFixNeeded: lipgloss.Color("#FF5FD7"),
+		StatePropelled: lipgloss.Color("#00FFFF"),
+	}
+	t.deriveSemantic()
+	return t
+}
+
+// MonoTheme sets no colors at all, leaving text in the terminal's own
+// foreground, for NO_COLOR.
+func MonoTheme() Theme {
+	t := Theme{Name: ThemeMono, NoColor: true}
+	for _, c := range themeColors {
+		*c.field(&t) = lipgloss.NoColor{}
+	}
+	return t
+}
+
+// deriveSemantic fills the semantic colors the built-in themes share with
+// the palette.
+func (t *Theme) deriveSemantic() {
+	t.StatusRolling = t.BrightGreen
+	t.StatusLinedUp = t.BrightGold
+	t.StatusStalled = t.Red
+	t.StatusPassed = t.Muted
+	t.StatusAgent = t.BrightPurple
+	t.StatusConvoy = t.BrightGold
+	t.StatusMail = t.BrightGreen
+
+	t.PrioP2 = t.BrightGold
+	t.PrioP3 = t.BrightGreen
+	t.PrioP4 = t.Muted
+
+	t.ColorBug = t.Red
+	t.ColorFeature = t.BrightPurple
+	t.ColorTask = t.BrightGold
+	t.ColorChore = t.Muted
+
+	t.RoleMayor = t.BrightGold
+	t.RolePolecat = t.BrightGreen
+	t.RoleCrew = t.BrightPurple
+	t.RoleDefault = t.Silver
+
+	t.StateWorking = t.BrightGreen
+	t.StateIdle = t.Silver
+	t.StateBackoff = t.Red
+	t.StateGate = t.BrightGold
+}
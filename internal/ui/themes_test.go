@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestBuiltinThemesSetEveryColor(t *testing.T) {
+	for _, theme := range BuiltinThemes() {
+		for _, c := range themeColors {
+			if *c.field(&theme) == nil {
+				t.Errorf("%s: %s is not set", theme.Name, c.key)
+			}
+		}
+	}
+}
+
+func TestApplyThemeRebuildsStylesAndGradients(t *testing.T) {
+	t.Cleanup(func() { ApplyTheme(DarkTheme()) })
+
+	hooked := 0
+	OnThemeChange(func() { hooked++ })
+	t.Cleanup(func() { themeHooks = themeHooks[:len(themeHooks)-1] })
+
+	light := LightTheme()
+	ApplyTheme(light)
+
+	if StatusRolling != light.StatusRolling || PrioP0 != light.PrioP0 || RoleDeacon != light.RoleDeacon {
+		t.Error("palette variables not reassigned from the light theme")
+	}
+	if got := SectionRolling.GetForeground(); got != light.StatusRolling {
+		t.Errorf("SectionRolling foreground = %v, want %v", got, light.StatusRolling)
+	}
+	if got := HelpOverlayBg.GetBackground(); got != light.OverlayBg {
+		t.Errorf("HelpOverlayBg background = %v, want %v", got, light.OverlayBg)
+	}
+	want := NewGradient2(light.White, light.Dim)
+	if GradientFade.At(0).GetForeground() != want.At(0).GetForeground() {
+		t.Error("GradientFade not rebuilt from the light palette")
+	}
+	if hooked != 1 {
+		t.Errorf("theme hook ran %d times, want 1", hooked)
+	}
+	if ActiveTheme().Name != ThemeLight {
+		t.Errorf("ActiveTheme() = %q, want light", ActiveTheme().Name)
+	}
+}
+
+func TestApplyThemeKeepsUnsetColors(t *testing.T) {
+	t.Cleanup(func() { ApplyTheme(DarkTheme()) })
+
+	before := StatusRolling
+	ApplyTheme(Theme{Name: "partial", PrioP0: lipgloss.Color("#010203")})
+	if StatusRolling != before {
+		t.Error("unset color was cleared")
+	}
+	if PrioP0 != lipgloss.Color("#010203") {
+		t.Errorf("PrioP0 = %v, want #010203", PrioP0)
+	}
+}
+
+func TestDefaultTheme(t *testing.T) {
+	if got := DefaultTheme(true).Name; got != ThemeDark {
+		t.Errorf("DefaultTheme(dark) = %q, want dark", got)
+	}
+	if got := DefaultTheme(false).Name; got != ThemeLight {
+		t.Errorf("DefaultTheme(light) = %q, want light", got)
+	}
+}
+
+func TestFindTheme(t *testing.T) {
+	if _, ok := BuiltinTheme(ThemeHighContrast); !ok {
+		t.Error("high-contrast not found")
+	}
+	if _, ok := BuiltinTheme("solarized"); ok {
+		t.Error("unknown theme found")
+	}
+}
@@ -0,0 +1,287 @@
+package views
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// ChartWindow is how far back the charts reach.
+type ChartWindow int
+
+const (
+	ChartTwoWeeks ChartWindow = iota
+	ChartSixWeeks
+	ChartQuarter
+	chartWindowCount
+)
+
+// Days returns the window length in days.
+func (w ChartWindow) Days() int {
+	switch w {
+	case ChartSixWeeks:
+		return 42
+	case ChartQuarter:
+		return 91
+	default:
+		return 14
+	}
+}
+
+// String returns the window label shown in the panel header.
+func (w ChartWindow) String() string {
+	switch w {
+	case ChartSixWeeks:
+		return "6w"
+	case ChartQuarter:
+		return "quarter"
+	default:
+		return "2w"
+	}
+}
+
+type flowBand struct {
+	status data.ParadeStatus
+	title  string
+	color  color.Color
+}
+
+// flowBands returns the cumulative flow bands, bottom to top, in the
+// current theme's colors.
+func flowBands() []flowBand {
+	return []flowBand{
+		{data.ParadePastTheStand, "Past the Stand", ui.StatusPassed},
+		{data.ParadeRolling, "Rolling", ui.StatusRolling},
+		{data.ParadeStalled, "Stalled", ui.StatusStalled},
+		{data.ParadeLinedUp, "Lined Up", ui.StatusLinedUp},
+	}
+}
+
+// Charts renders flow charts from issue timestamps alone, so it works for
+// plain Beads projects: a cumulative flow diagram of the parade sections, a
+// burndown for an epic or convoy, and lead and cycle time histograms.
+type Charts struct {
+	width         int
+	height        int
+	window        ChartWindow
+	issues        []data.Issue
+	blockingTypes map[string]bool
+	scopeTitle    string
+	scope         map[string]bool
+
+	// Now is the end of the window; zero means time.Now.
+	Now time.Time
+}
+
+// NewCharts creates a Charts panel over the last two weeks.
+func NewCharts(width, height int) Charts {
+	return Charts{width: width, height: height}
+}
+
+// SetSize updates dimensions.
+func (c *Charts) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// SetIssues updates the issues charted.
+func (c *Charts) SetIssues(issues []data.Issue, blockingTypes map[string]bool) {
+	c.issues = issues
+	c.blockingTypes = blockingTypes
+}
+
+// SetScope sets the issues the burndown tracks and its title.
+func (c *Charts) SetScope(title string, ids map[string]bool) {
+	c.scopeTitle = title
+	c.scope = ids
+}
+
+// Window returns the selected window.
+func (c *Charts) Window() ChartWindow {
+	return c.window
+}
+
+// Update handles key events for the charts view.
+func (c Charts) Update(msg tea.Msg) (Charts, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return c, nil
+	}
+	switch keymap.Lookup(keymap.Charts, keyMsg.String()) {
+	case keymap.NextWindow:
+		c.window = (c.window + 1) % chartWindowCount
+	case keymap.PrevWindow:
+		c.window = (c.window + chartWindowCount - 1) % chartWindowCount
+	}
+	return c, nil
+}
+
+func (c Charts) now() time.Time {
+	if c.Now.IsZero() {
+		return time.Now()
+	}
+	return c.Now
+}
+
+// View renders the charts panel.
+func (c Charts) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.BrightGold)
+	sectionStyle := lipgloss.NewStyle().Foreground(ui.Light).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+
+	now := c.now()
+	days := c.window.Days()
+	inner := max(c.width-3, 20)
+	flow := data.CumulativeFlow(c.issues, c.blockingTypes, days, now)
+
+	lines := []string{
+		headerStyle.Render("CHARTS") + dimStyle.Render(" · last "+c.window.String()),
+		"",
+		sectionStyle.Render("Cumulative flow"),
+	}
+	lines = append(lines, c.renderFlow(flow, inner)...)
+	lines = append(lines, "", sectionStyle.Render("Burndown")+dimStyle.Render(" · "+c.scopeTitle))
+	lines = append(lines, c.renderBurndown(days, now, inner))
+	lines = append(lines, "")
+	lines = append(lines, c.renderTimes(days, now, inner)...)
+
+	for len(lines) < c.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, dimStyle.Render("  "+keymap.HintBar(
+		keymap.NewHint(keymap.Charts, "window (2w, 6w, quarter)", keymap.NextWindow, keymap.PrevWindow),
+		keymap.NewHint(keymap.Global, "close", keymap.ToggleCharts),
+	)))
+
+	return ui.DetailBorder.
+		Width(c.width).
+		Height(c.height).
+		Render(strings.Join(lines, "\n"))
+}
+
+// chartHeight is the number of rows given to the cumulative flow diagram.
+func (c Charts) chartHeight() int {
+	return min(max(c.height-24, 3), 12)
+}
+
+// renderFlow draws the stacked bands, a date axis, the legend with today's
+// counts, and a sparkline of issues closed per day.
+func (c Charts) renderFlow(flow []data.FlowPoint, width int) []string {
+	bands := flowBands()
+	series := make([][]int, len(bands))
+	colors := make([]color.Color, len(bands))
+	for i, band := range bands {
+		colors[i] = band.color
+		for _, p := range flow {
+			series[i] = append(series[i], p.Counts[band.status])
+		}
+	}
+	lines := ui.StackedArea(series, colors, width, c.chartHeight())
+
+	muted := lipgloss.NewStyle().Foreground(ui.Muted)
+	first, last := flow[0].Day.Format("Jan 2"), flow[len(flow)-1].Day.Format("Jan 2")
+	cols := min(len(flow), width)
+	lines = append(lines, muted.Render(first+strings.Repeat(" ", max(cols-len(first)-len(last), 1))+last))
+
+	today := flow[len(flow)-1]
+	var legend []string
+	for i := len(bands) - 1; i >= 0; i-- {
+		band := bands[i]
+		swatch := lipgloss.NewStyle().Foreground(band.color).Render(ui.AreaFill(i))
+		legend = append(legend, fmt.Sprintf("%s %s %d", swatch, band.title, today.Counts[band.status]))
+	}
+	lines = append(lines, ansi.Truncate(strings.Join(legend, "  "), width, "…"))
+
+	closed := make([]int, len(flow))
+	for i := 1; i < len(flow); i++ {
+		closed[i] = max(flow[i].Counts[data.ParadePastTheStand]-flow[i-1].Counts[data.ParadePastTheStand], 0)
+	}
+	label := "closed/day "
+	sparkW := max(width-len(label), 1)
+	closed = closed[max(len(closed)-sparkW, 0):]
+	lines = append(lines, muted.Render(label)+ui.RenderSparkline(closed, sparkW))
+	return lines
+}
+
+// renderBurndown draws the scope's remaining open issues as a braille line,
+// stretched to fill the width, followed by start and current counts.
+func (c Charts) renderBurndown(days int, now time.Time, width int) string {
+	if len(c.scope) == 0 {
+		return lipgloss.NewStyle().Foreground(ui.Dim).Render("  No issues in scope")
+	}
+	remaining := data.Burndown(c.issues, c.scope, days, now)
+	summary := fmt.Sprintf(" %d → %d open", remaining[0], remaining[len(remaining)-1])
+	sparkW := max(width-len(summary)-1, 4)
+
+	repeat := max(sparkW*2/len(remaining), 1)
+	values := make([]float64, 0, len(remaining)*repeat)
+	for _, r := range remaining {
+		for range repeat {
+			values = append(values, float64(r))
+		}
+	}
+	style := lipgloss.NewStyle().Foreground(ui.BrightPurple)
+	return ui.BrailleSparkline(values, sparkW, style) + lipgloss.NewStyle().Foreground(ui.Light).Render(summary)
+}
+
+// renderTimes draws the lead and cycle time histograms side by side.
+func (c Charts) renderTimes(days int, now time.Time, width int) []string {
+	lead, cycle := data.FlowTimes(c.issues, days, now)
+	colW := width / 2
+	left := histogram("Lead time", lead, colW, ui.BrightGold)
+	right := histogram("Cycle time", cycle, colW, ui.BrightGreen)
+	lines := make([]string, len(left))
+	for i := range left {
+		lines[i] = padVisible(left[i], colW) + right[i]
+	}
+	return lines
+}
+
+// histogram renders a titled bar per data.DurationBuckets entry.
+func histogram(title string, durations []time.Duration, width int, clr color.Color) []string {
+	sectionStyle := lipgloss.NewStyle().Foreground(ui.Light).Bold(true)
+	muted := lipgloss.NewStyle().Foreground(ui.Muted)
+	bar := lipgloss.NewStyle().Foreground(clr)
+
+	header := sectionStyle.Render(title)
+	if len(durations) > 0 {
+		median := data.MedianDuration(durations)
+		header += muted.Render(fmt.Sprintf(" · median %.1fd, n=%d", median.Hours()/24, len(durations)))
+	}
+	lines := []string{ansi.Truncate(header, width-1, "…")}
+
+	counts := data.Histogram(durations)
+	peak := 0
+	for _, n := range counts {
+		peak = max(peak, n)
+	}
+	barW := max(width-12, 1)
+	for i, b := range data.DurationBuckets {
+		n := 0
+		if peak > 0 {
+			n = (counts[i]*barW + peak - 1) / peak
+		}
+		line := muted.Render(fmt.Sprintf("%-5s", b.Label)) + bar.Render(strings.Repeat(ui.SymProgress, n))
+		if counts[i] > 0 {
+			line += muted.Render(fmt.Sprintf(" %d", counts[i]))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// padVisible pads s with spaces to width visible cells.
+func padVisible(s string, width int) string {
+	if w := ansi.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,56 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func TestChartsView(t *testing.T) {
+	now := time.Now()
+	c := NewCharts(80, 40)
+	c.Now = now
+	c.SetIssues(timelineFixture(now), data.DefaultBlockingTypes)
+	c.SetScope("epic tl-0 Plan", map[string]bool{"tl-1": true, "tl-2": true})
+	view := ansi.Strip(c.View())
+
+	for _, want := range []string{"CHARTS", "last 2w", "Cumulative flow", "Burndown", "epic tl-0 Plan", "Lead time", "Cycle time", "closed/day", "Past the Stand 1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	// Both issues were created inside the window, so the line starts at 0.
+	if !strings.Contains(view, "0 → 1 open") {
+		t.Error("burndown should go from 0 to 1 open")
+	}
+}
+
+func TestChartsEmptyScope(t *testing.T) {
+	c := NewCharts(60, 30)
+	c.SetScope("all issues", nil)
+	if !strings.Contains(ansi.Strip(c.View()), "No issues in scope") {
+		t.Error("empty scope should say so")
+	}
+}
+
+func TestChartsWindowCycles(t *testing.T) {
+	c := NewCharts(60, 30)
+	w := tea.KeyPressMsg{Code: 'w', Text: "w"}
+	c, _ = c.Update(w)
+	if c.Window() != ChartSixWeeks {
+		t.Fatalf("w should select 6w, got %v", c.Window())
+	}
+	c, _ = c.Update(w)
+	c, _ = c.Update(w)
+	if c.Window() != ChartTwoWeeks {
+		t.Errorf("w should wrap to 2w, got %v", c.Window())
+	}
+	c, _ = c.Update(tea.KeyPressMsg{Code: 'W', Text: "W"})
+	if c.Window() != ChartQuarter || c.Window().Days() != 91 {
+		t.Errorf("W should step back to the quarter, got %v", c.Window())
+	}
+}
@@ -35,12 +35,17 @@ type Detail struct {
 	MetadataSchema   *data.MetadataSchema
 	AgentOutput      []string // live captured lines from agent's tmux pane
 	AgentOutputID    string   // which issue the agent output belongs to
+	CodeRefs         *data.CodeRefs
+	PullRequests     map[string]*data.PullRequest // issueID -> matched pull request
+	RefCursor        int                          // selected file hit in the references section
+	Tab              DetailTab                    // active tab
+	scroll           [detailTabCount]int          // each tab's scroll offset for the current issue
 	mdRenderer       *glamour.TermRenderer
 }
 
 // NewDetail creates a detail panel.
 func NewDetail(width, height int, issues []data.Issue) Detail {
-	vp := viewport.New(viewport.WithWidth(width-2), viewport.WithHeight(height))
+	vp := viewport.New(viewport.WithWidth(width-2), viewport.WithHeight(max(height-tabBarHeight, 1)))
 	return Detail{
 		AllIssues: issues,
 		IssueMap:  data.BuildIssueMap(issues),
@@ -64,10 +69,20 @@ func (d *Detail) SetIssue(issue *data.Issue) {
 		d.Comments = nil
 		d.CommentsIssueID = ""
 	}
+	// Clear stale code references when switching issues
+	if issue == nil || d.CodeRefs == nil || issue.ID != d.CodeRefs.IssueID {
+		d.CodeRefs = nil
+		d.RefCursor = 0
+	}
 	// Clear stale rich detail when switching issues
 	if issue == nil || issue.ID != d.RichIssueID {
 		d.RichIssueID = ""
 	}
+	// Keep the tab when the new issue has it; every tab starts at the top
+	if !d.hasTab(d.Tab) {
+		d.Tab = TabOverview
+	}
+	d.scroll = [detailTabCount]int{}
 	d.Viewport.SetContent(d.renderContent())
 	d.Viewport.GotoTop()
 }
@@ -91,6 +106,41 @@ func (d *Detail) SetComments(issueID string, comments []gastown.Comment) {
 	}
 }
 
+// SetCodeRefs updates the source-code references for the current issue. A
+// rescan of the same issue keeps the references cursor where it was.
+func (d *Detail) SetCodeRefs(refs *data.CodeRefs) {
+	if refs == nil || d.CodeRefs == nil || refs.IssueID != d.CodeRefs.IssueID {
+		d.RefCursor = 0
+	} else {
+		d.RefCursor = max(0, min(d.RefCursor, len(refs.Hits)-1))
+	}
+	d.CodeRefs = refs
+	if d.Issue != nil {
+		d.Viewport.SetContent(d.renderContent())
+	}
+}
+
+// SelectedHit returns the file hit under the references cursor, or nil.
+func (d *Detail) SelectedHit() *data.CodeHit {
+	if !d.hasCodeRefs() || len(d.CodeRefs.Hits) == 0 {
+		return nil
+	}
+	return &d.CodeRefs.Hits[d.RefCursor]
+}
+
+// MoveRefCursor moves the references cursor by delta, clamped to the hits.
+func (d *Detail) MoveRefCursor(delta int) {
+	if !d.hasCodeRefs() || len(d.CodeRefs.Hits) == 0 {
+		return
+	}
+	d.RefCursor = max(0, min(d.RefCursor+delta, len(d.CodeRefs.Hits)-1))
+	d.Viewport.SetContent(d.renderContent())
+}
+
+func (d *Detail) hasCodeRefs() bool {
+	return d.Issue != nil && d.CodeRefs != nil && d.CodeRefs.IssueID == d.Issue.ID
+}
+
 // SetRichDetail enriches the current issue with fields from bd show (notes, design, acceptance_criteria).
 func (d *Detail) SetRichDetail(issueID string, rich *data.Issue) {
 	d.RichIssueID = issueID
@@ -113,7 +163,7 @@ func (d *Detail) SetSize(width, height int) {
 	d.Width = width
 	d.Height = height
 	d.Viewport.SetWidth(width - 2)
-	d.Viewport.SetHeight(height)
+	d.Viewport.SetHeight(max(height-tabBarHeight, 1))
 	d.mdRenderer = nil // re-create with new width
 	if d.Issue != nil {
 		d.Viewport.SetContent(d.renderContent())
@@ -132,11 +182,21 @@ func (d *Detail) View() string {
 		return ui.DetailBorder.Height(d.Height).Render(empty)
 	}
 
-	content := d.Viewport.View()
+	content := d.renderTabBar() + "\n" + d.Viewport.View()
 	return ui.DetailBorder.Height(d.Height).Render(content)
 }
 
-// renderMarkdown renders markdown text using glamour with dark theme.
+// Restyle re-renders the content after a theme change.
+func (d *Detail) Restyle() {
+	d.mdRenderer = nil
+	if d.Issue != nil {
+		d.Viewport.SetContent(d.renderContent())
+	}
+}
+
+// renderMarkdown renders markdown text using glamour, in its light style
+// under a light theme and its plain ASCII style in accessible mode or
+// without color.
 func (d *Detail) renderMarkdown(text string) string {
 	if text == "" {
 		return ""
@@ -148,8 +208,15 @@ func (d *Detail) renderMarkdown(text string) string {
 	}
 
 	if d.mdRenderer == nil {
+		style := glamour.WithAutoStyle()
+		switch {
+		case ui.Accessible() || ui.ActiveTheme().NoColor:
+			style = glamour.WithStandardStyle("ascii")
+		case ui.ActiveTheme().LightBg:
+			style = glamour.WithStandardStyle("light")
+		}
 		r, err := glamour.NewTermRenderer(
-			glamour.WithAutoStyle(),
+			style,
 			glamour.WithWordWrap(contentWidth),
 		)
 		if err != nil {
@@ -165,7 +232,10 @@ func (d *Detail) renderMarkdown(text string) string {
 	return strings.TrimRight(rendered, "\n")
 }
 
-func (d *Detail) renderContent() string {
+// renderOverview renders the Overview tab: fields, formula suggestion,
+// description and the other long-form text, pull request and code
+// references.
+func (d *Detail) renderOverview() string {
 	issue := d.Issue
 	if issue == nil {
 		return ""
@@ -296,12 +366,6 @@ func (d *Detail) renderContent() string {
 		lines = append(lines, d.renderMarkdown(issue.Description))
 	}
 
-	// Metadata (from issue data, rendered against schema)
-	if metaSection := d.renderMetadata(); metaSection != "" {
-		lines = append(lines, "")
-		lines = append(lines, metaSection)
-	}
-
 	// Close reason
 	if issue.CloseReason != "" {
 		lines = append(lines, "")
@@ -330,12 +394,37 @@ func (d *Detail) renderContent() string {
 		lines = append(lines, d.renderMarkdown(issue.Design))
 	}
 
+	// Pull request section
+	if pr := d.PullRequests[issue.ID]; pr != nil {
+		lines = append(lines, "")
+		lines = append(lines, d.renderPullRequest(pr))
+	}
+
+	// Source-code references section
+	if d.hasCodeRefs() && !d.CodeRefs.Empty() {
+		lines = append(lines, "")
+		lines = append(lines, d.renderCodeRefs())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderDependencies renders the Dependencies tab: local dependency edges
+// and cross-rig references.
+func (d *Detail) renderDependencies() string {
+	issue := d.Issue
+	bt := d.BlockingTypes
+	if bt == nil {
+		bt = data.DefaultBlockingTypes
+	}
+
+	var lines []string
+
 	// Dependencies
 	eval := issue.EvaluateDependencies(d.IssueMap, bt)
 	blocks := issue.BlocksIDs(d.AllIssues, bt)
 	hasDeps := len(eval.Edges) > 0 || len(blocks) > 0
 	if hasDeps {
-		lines = append(lines, "")
 		lines = append(lines, ui.DetailSection.Render("DEPENDENCIES"))
 
 		for _, id := range eval.BlockingIDs {
@@ -389,7 +478,9 @@ func (d *Detail) renderContent() string {
 	// Cross-rig dependencies (external references)
 	crossRigRefs := data.CrossRigDeps(issue)
 	if len(crossRigRefs) > 0 {
-		lines = append(lines, "")
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
 		lines = append(lines, ui.DetailSection.Render("CROSS-RIG"))
 		for _, ref := range crossRigRefs {
 			rigStyle := lipgloss.NewStyle().Foreground(ui.BrightPurple).Bold(true)
@@ -402,35 +493,17 @@ func (d *Detail) renderContent() string {
 		}
 	}
 
-	// Gate status (when agent is awaiting-gate)
-	if gateSection := d.renderGateStatus(); gateSection != "" {
-		lines = append(lines, "")
-		lines = append(lines, gateSection)
-	}
-
-	// Molecule DAG section
-	if d.MoleculeDAG != nil && d.MoleculeIssueID == issue.ID {
-		lines = append(lines, "")
-		lines = append(lines, d.renderMolecule())
-	}
-
-	// Activity section (timestamps + agent info)
-	lines = append(lines, "")
-	lines = append(lines, d.renderActivity())
-
-	// Comments section
-	if len(d.Comments) > 0 && d.CommentsIssueID == issue.ID {
-		lines = append(lines, "")
-		lines = append(lines, d.renderComments())
-	}
+	return strings.Join(lines, "\n")
+}
 
-	// Agent output section (live tail from tmux pane)
-	if len(d.AgentOutput) > 0 && d.AgentOutputID == issue.ID {
-		lines = append(lines, "")
-		lines = append(lines, d.renderAgentOutput())
+// hasDependencies reports whether the Dependencies tab has anything to show.
+func (d *Detail) hasDependencies() bool {
+	bt := d.BlockingTypes
+	if bt == nil {
+		bt = data.DefaultBlockingTypes
 	}
-
-	return strings.Join(lines, "\n")
+	eval := d.Issue.EvaluateDependencies(d.IssueMap, bt)
+	return len(eval.Edges) > 0 || len(d.Issue.BlocksIDs(d.AllIssues, bt)) > 0 || len(data.CrossRigDeps(d.Issue)) > 0
 }
 
 // renderMolecule renders the molecule DAG with visual flow connectors and branching.
@@ -478,11 +551,11 @@ func (d *Detail) renderMolecule() string {
 					var prefix string
 					switch {
 					case i == 0:
-						prefix = ui.SymDAGBranch + "─"
+						prefix = ui.SymDAGBranch + ui.BoxHorizontal
 					case i == len(row.Nodes)-1:
-						prefix = ui.SymDAGJoin + "─"
+						prefix = ui.SymDAGJoin + ui.BoxHorizontal
 					default:
-						prefix = ui.SymDAGFork + "─"
+						prefix = ui.SymDAGFork + ui.BoxHorizontal
 					}
 					critical := criticalSet[node.ID]
 					lines = append(lines, d.renderDAGNode(node, prefix, critical))
@@ -674,6 +747,82 @@ func (d *Detail) renderComments() string {
 	return strings.Join(lines, "\n")
 }
 
+// renderPullRequest renders the PR matched to the issue.
+func (d *Detail) renderPullRequest(pr *data.PullRequest) string {
+	var lines []string
+	lines = append(lines, ui.DetailSection.Render("PULL REQUEST"))
+
+	stateStyle := lipgloss.NewStyle().Foreground(ui.PRColor(pr.Label(), pr.Checks)).Bold(true)
+	lines = append(lines, d.row("PR:", stateStyle.Render(fmt.Sprintf("#%d", pr.Number))+" "+
+		truncate(pr.Title, max(d.Width-24, 20))))
+	lines = append(lines, d.row("State:", stateStyle.Render(pr.Label())))
+	if review := pr.ReviewLabel(); review != "" {
+		lines = append(lines, d.row("Review:", review))
+	}
+	if pr.Checks != "" {
+		lines = append(lines, d.row("Checks:", lipgloss.NewStyle().Foreground(ui.PRColor("open", pr.Checks)).Render(pr.Checks)))
+	}
+	if pr.HeadRefName != "" {
+		lines = append(lines, d.row("Branch:", lipgloss.NewStyle().Foreground(ui.Muted).Render(pr.HeadRefName)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCodeRefs renders file hits, commits and branches that mention the issue.
+func (d *Detail) renderCodeRefs() string {
+	refs := d.CodeRefs
+	total := len(refs.Hits) + len(refs.Commits) + len(refs.Branches)
+	var lines []string
+	lines = append(lines, ui.DetailSection.Render(fmt.Sprintf("REFERENCES (%d)", total)))
+
+	pathStyle := lipgloss.NewStyle().Foreground(ui.Light)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.Muted)
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.BrightGold)
+	textWidth := max(d.Width-12, 20)
+
+	for i, hit := range refs.Hits {
+		marker := " "
+		if i == d.RefCursor {
+			marker = cursorStyle.Render(ui.Cursor)
+		}
+		lines = append(lines, fmt.Sprintf(" %s %s", marker,
+			pathStyle.Render(fmt.Sprintf("%s:%d", hit.Path, hit.Line))))
+		lines = append(lines, "    "+dimStyle.Render(truncate(hit.Text, textWidth)))
+	}
+	if refs.Truncated {
+		lines = append(lines, "  "+mutedStyle.Render("… more matches not shown"))
+	}
+
+	for _, c := range refs.Commits {
+		date := ""
+		if !c.Date.IsZero() {
+			date = c.Date.Format("Jan 02")
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s",
+			lipgloss.NewStyle().Foreground(ui.BrightGold).Render(c.Hash),
+			pathStyle.Render(truncate(c.Subject, textWidth-20)),
+			mutedStyle.Render(fmt.Sprintf("%s %s", truncate(c.Author, 16), date))))
+	}
+
+	for _, b := range refs.Branches {
+		var tags []string
+		if b.Current {
+			tags = append(tags, "current")
+		}
+		if b.Generated {
+			tags = append(tags, "mg")
+		}
+		label := fmt.Sprintf("  %s %s", ui.DepArrow, pathStyle.Render(b.Name))
+		if len(tags) > 0 {
+			label += " " + mutedStyle.Render("("+strings.Join(tags, ", ")+")")
+		}
+		lines = append(lines, label)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // renderAgentOutput renders live agent output captured from a tmux pane.
 func (d *Detail) renderAgentOutput() string {
 	var lines []string
@@ -714,10 +863,21 @@ func (d *Detail) renderMetadata() string {
 		}
 		lines = append(lines, ui.DetailSection.Render(header))
 
+		problems := make(map[string]string)
+		if issue != nil {
+			for _, v := range schema.ValidateIssue(*issue) {
+				problems[v.Field] = v.Message
+			}
+		}
+
 		fieldNames := schema.SortedFieldNames()
 		for _, name := range fieldNames {
 			field := schema.Fields[name]
-			lines = append(lines, d.renderMetadataField(name, field, issue))
+			line := d.renderMetadataField(name, field, issue)
+			if msg, bad := problems[name]; bad {
+				line += " " + ui.MetaInvalid.Render(ui.SymInvalid+" "+msg)
+			}
+			lines = append(lines, line)
 		}
 
 		// Show any extra metadata values not in the schema
@@ -829,7 +989,12 @@ func formatTime(t time.Time) string {
 	return t.Format("Jan 02 15:04")
 }
 
+// row renders a label and value. Accessible mode drops the padded label
+// column so each line reads "Label: value".
 func (d *Detail) row(label, value string) string {
+	if ui.Accessible() {
+		return ui.DetailLabel.UnsetWidth().Render(label) + " " + value
+	}
 	return ui.DetailLabel.Render(label) + " " + value
 }
 
@@ -0,0 +1,197 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// DetailTab is one page of the detail pane.
+type DetailTab int
+
+const (
+	TabOverview DetailTab = iota
+	TabDependencies
+	TabMolecule
+	TabActivity
+	TabComments
+	TabAgent
+	TabMetadata
+	detailTabCount
+)
+
+// tabBarHeight is the rows the tab bar takes above the viewport.
+const tabBarHeight = 1
+
+// String returns the tab's title.
+func (t DetailTab) String() string {
+	return [...]string{"Overview", "Dependencies", "Molecule", "Activity", "Comments", "Agent", "Metadata"}[t]
+}
+
+// renderContent renders the active tab.
+func (d *Detail) renderContent() string {
+	if d.Issue == nil {
+		return ""
+	}
+	return d.renderTab(d.Tab)
+}
+
+// renderTab renders tab t for the current issue.
+func (d *Detail) renderTab(t DetailTab) string {
+	switch t {
+	case TabDependencies:
+		return d.renderDependencies()
+	case TabMolecule:
+		return d.renderMoleculeTab()
+	case TabActivity:
+		return d.renderActivity()
+	case TabComments:
+		return d.renderCommentsTab()
+	case TabAgent:
+		return d.renderAgentTab()
+	case TabMetadata:
+		return d.renderMetadata()
+	}
+	return d.renderOverview()
+}
+
+// hasTab reports whether tab t has anything to show for the current issue.
+// Molecule and Comments count until their lazy fetch comes back empty.
+func (d *Detail) hasTab(t DetailTab) bool {
+	issue := d.Issue
+	if issue == nil {
+		return t == TabOverview
+	}
+	if t == TabOverview || t == TabActivity {
+		return true
+	}
+	switch t {
+	case TabDependencies:
+		return d.hasDependencies()
+	case TabMolecule:
+		if d.MoleculeIssueID == issue.ID {
+			return d.MoleculeDAG != nil
+		}
+		_, active := d.ActiveAgents[issue.ID]
+		return active
+	case TabComments:
+		return d.CommentsIssueID != issue.ID || len(d.Comments) > 0
+	case TabAgent:
+		return d.renderGateStatus() != "" || (len(d.AgentOutput) > 0 && d.AgentOutputID == issue.ID)
+	case TabMetadata:
+		return d.renderMetadata() != ""
+	}
+	return false
+}
+
+// Tabs returns the tabs shown for the current issue, in order. Empty tabs
+// are hidden, except the active one so a fetch that comes back empty
+// doesn't pull the tab out from under the cursor.
+func (d *Detail) Tabs() []DetailTab {
+	var tabs []DetailTab
+	for t := TabOverview; t < detailTabCount; t++ {
+		if t == d.Tab || d.hasTab(t) {
+			tabs = append(tabs, t)
+		}
+	}
+	return tabs
+}
+
+// SetTab switches to tab t, keeping each tab's scroll position.
+func (d *Detail) SetTab(t DetailTab) {
+	if t == d.Tab || t < 0 || t >= detailTabCount {
+		return
+	}
+	d.scroll[d.Tab] = d.Viewport.YOffset()
+	d.Tab = t
+	d.Viewport.SetContent(d.renderContent())
+	d.Viewport.SetYOffset(d.scroll[t])
+}
+
+// CycleTab moves delta tabs along the shown ones, wrapping around.
+func (d *Detail) CycleTab(delta int) {
+	tabs := d.Tabs()
+	i := 0
+	for j, t := range tabs {
+		if t == d.Tab {
+			i = j
+		}
+	}
+	n := len(tabs)
+	d.SetTab(tabs[((i+delta)%n+n)%n])
+}
+
+// renderTabBar renders the shown tabs on one line, the active one
+// highlighted (bracketed in accessible mode).
+func (d *Detail) renderTabBar() string {
+	active := lipgloss.NewStyle().Bold(true).Underline(true).Foreground(ui.BrightGold)
+	inactive := lipgloss.NewStyle().Foreground(ui.Muted)
+
+	var parts []string
+	for _, t := range d.Tabs() {
+		title := t.String()
+		if t == TabComments && d.CommentsIssueID == d.Issue.ID && len(d.Comments) > 0 {
+			title += fmt.Sprintf(" (%d)", len(d.Comments))
+		}
+		switch {
+		case t == d.Tab && ui.Accessible():
+			parts = append(parts, "["+title+"]")
+		case t == d.Tab:
+			parts = append(parts, active.Render(title))
+		default:
+			parts = append(parts, inactive.Render(title))
+		}
+	}
+	return ansi.Truncate(strings.Join(parts, "  "), max(d.Width-3, 1), "…")
+}
+
+// renderMoleculeTab renders the Molecule tab: the molecule DAG, or a note
+// while it loads or when none is attached.
+func (d *Detail) renderMoleculeTab() string {
+	if d.MoleculeDAG != nil && d.MoleculeIssueID == d.Issue.ID {
+		return d.renderMolecule()
+	}
+	if d.MoleculeIssueID == d.Issue.ID {
+		return d.placeholder("No molecule attached")
+	}
+	return d.pending("molecule")
+}
+
+// renderCommentsTab renders the Comments tab, or a note while the comments
+// load or when there are none.
+func (d *Detail) renderCommentsTab() string {
+	if d.CommentsIssueID != d.Issue.ID {
+		return d.pending("comments")
+	}
+	if len(d.Comments) == 0 {
+		return d.placeholder("No comments")
+	}
+	return d.renderComments()
+}
+
+// renderAgentTab renders the Agent tab: gate status and live agent output.
+func (d *Detail) renderAgentTab() string {
+	var sections []string
+	if gate := d.renderGateStatus(); gate != "" {
+		sections = append(sections, gate)
+	}
+	if len(d.AgentOutput) > 0 && d.AgentOutputID == d.Issue.ID {
+		sections = append(sections, d.renderAgentOutput())
+	}
+	if len(sections) == 0 {
+		return d.placeholder("No agent on this issue")
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// pending is the note a lazily fetched tab shows until its data arrives.
+func (d *Detail) pending(what string) string {
+	return d.placeholder("Loading " + what + "…")
+}
+
+func (d *Detail) placeholder(text string) string {
+	return "\n" + lipgloss.NewStyle().Foreground(ui.Muted).Render("  "+text)
+}
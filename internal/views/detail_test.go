@@ -1,10 +1,12 @@
 package views
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/matt-wright86/mardi-gras/internal/data"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
@@ -121,8 +123,8 @@ func TestSetSizeUpdatesDimensions(t *testing.T) {
 	if d.Viewport.Width() != 98 {
 		t.Fatalf("Viewport.Width = %d, want 98 (width-2)", d.Viewport.Width())
 	}
-	if d.Viewport.Height() != 30 {
-		t.Fatalf("Viewport.Height = %d, want 30", d.Viewport.Height())
+	if d.Viewport.Height() != 29 {
+		t.Fatalf("Viewport.Height = %d, want 29 (height less the tab bar)", d.Viewport.Height())
 	}
 }
 
@@ -250,7 +252,7 @@ func TestMoleculeRenderingInContent(t *testing.T) {
 	}
 	d.SetMolecule("mg-001", dag, progress)
 
-	content := d.renderContent()
+	content := d.renderTab(TabMolecule)
 
 	if !strings.Contains(content, "MOLECULE") {
 		t.Error("content should contain MOLECULE section")
@@ -294,7 +296,7 @@ func TestMoleculeDAGParallelBranching(t *testing.T) {
 		TierGroups: [][]string{{"s1"}, {"s2", "s3"}, {"s4"}, {"s5"}},
 	}
 	d.SetMolecule("mg-001", dag, nil)
-	content := d.renderContent()
+	content := d.renderTab(TabMolecule)
 
 	// Parallel branch connectors
 	if !strings.Contains(content, ui.SymDAGBranch) {
@@ -333,7 +335,7 @@ func TestMoleculeDAGFiveWayParallel(t *testing.T) {
 		TierGroups: [][]string{{"s1"}, {"r1", "r2", "r3", "r4", "r5"}, {"s2"}},
 	}
 	d.SetMolecule("mg-001", dag, nil)
-	content := d.renderContent()
+	content := d.renderTab(TabMolecule)
 
 	// Should have branch start, middle forks, and join
 	if !strings.Contains(content, ui.SymDAGBranch) {
@@ -372,7 +374,7 @@ func TestMoleculeDAGCriticalPathTitles(t *testing.T) {
 		CriticalPath: []string{"s1", "s2", "s3"},
 	}
 	d.SetMolecule("mg-001", dag, nil)
-	content := d.renderContent()
+	content := d.renderTab(TabMolecule)
 
 	// Critical path shows titles, not IDs
 	if !strings.Contains(content, "critical:") {
@@ -401,7 +403,7 @@ func TestActivityRenderingInContent(t *testing.T) {
 	d := NewDetail(80, 40, issues)
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabActivity)
 
 	if !strings.Contains(content, "ACTIVITY") {
 		t.Error("content should contain ACTIVITY section")
@@ -429,7 +431,7 @@ func TestActivityWithAgent(t *testing.T) {
 	}
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabActivity)
 
 	if !strings.Contains(content, "polecat-1") {
 		t.Error("content should show agent name in activity")
@@ -447,7 +449,7 @@ func TestActivityWithClosedIssue(t *testing.T) {
 	d := NewDetail(80, 40, issues)
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabActivity)
 
 	if !strings.Contains(content, "Closed") {
 		t.Error("content should contain 'Closed' event")
@@ -499,7 +501,7 @@ func TestGateStatusRendering(t *testing.T) {
 	d.ActiveAgents = map[string]string{"mg-001": "Toast"}
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabAgent)
 
 	if !strings.Contains(content, "GATE") {
 		t.Error("content should contain GATE section when agent is awaiting-gate")
@@ -562,7 +564,7 @@ func TestCommentsRendering(t *testing.T) {
 	}
 	d.SetComments("mg-001", comments)
 
-	content := d.renderContent()
+	content := d.renderTab(TabComments)
 
 	if !strings.Contains(content, "COMMENTS (2)") {
 		t.Error("content should contain 'COMMENTS (2)' section header")
@@ -624,6 +626,91 @@ func TestCommentsClearedOnIssueSwitch(t *testing.T) {
 	}
 }
 
+func TestCodeRefsRendering(t *testing.T) {
+	issues := []data.Issue{
+		{ID: "mg-001", Title: "Referenced Issue", Status: data.StatusInProgress,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+	}
+	d := NewDetail(80, 40, issues)
+	d.SetIssue(&issues[0])
+	d.SetCodeRefs(&data.CodeRefs{
+		IssueID: "mg-001",
+		Hits: []data.CodeHit{
+			{Path: "main.go", Line: 12, Text: "// TODO(mg-001): retry"},
+			{Path: "docs/notes.md", Line: 3, Text: "see mg-001"},
+		},
+		Commits:  []data.CommitRef{{Hash: "abc1234", Subject: "Start mg-001", Author: "ada"}},
+		Branches: []data.BranchRef{{Name: "feat/mg-001-referenced-issue", Generated: true, Current: true}},
+	})
+
+	content := d.renderContent()
+	for _, want := range []string{"REFERENCES (4)", "main.go:12", "TODO(mg-001): retry", "abc1234", "Start mg-001", "feat/mg-001-referenced-issue", "(current, mg)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q", want)
+		}
+	}
+
+	if hit := d.SelectedHit(); hit == nil || hit.Path != "main.go" {
+		t.Fatalf("SelectedHit() = %+v, want main.go", hit)
+	}
+	d.MoveRefCursor(5)
+	if hit := d.SelectedHit(); hit == nil || hit.Path != "docs/notes.md" {
+		t.Errorf("after MoveRefCursor(5) SelectedHit() = %+v, want clamped to last hit", hit)
+	}
+	d.MoveRefCursor(-5)
+	if d.RefCursor != 0 {
+		t.Errorf("RefCursor = %d, want clamped to 0", d.RefCursor)
+	}
+}
+
+func TestPullRequestRendering(t *testing.T) {
+	issues := []data.Issue{
+		{ID: "mg-001", Title: "Reviewed Issue", Status: data.StatusInProgress,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+		{ID: "mg-002", Title: "No PR", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+	}
+	d := NewDetail(80, 40, issues)
+	d.PullRequests = map[string]*data.PullRequest{
+		"mg-001": {Number: 42, Title: "Add retries", State: data.PRStateOpen, IsDraft: true,
+			ReviewDecision: "CHANGES_REQUESTED", Checks: data.ChecksFailing, HeadRefName: "task/mg-001-reviewed-issue"},
+	}
+	d.SetIssue(&issues[0])
+
+	content := d.renderContent()
+	for _, want := range []string{"PULL REQUEST", "#42", "Add retries", "draft", "changes requested", "failing", "task/mg-001-reviewed-issue"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q", want)
+		}
+	}
+
+	d.SetIssue(&issues[1])
+	if strings.Contains(d.renderContent(), "PULL REQUEST") {
+		t.Error("issue without a PR should not render the section")
+	}
+}
+
+func TestCodeRefsClearedOnIssueSwitch(t *testing.T) {
+	issues := []data.Issue{
+		{ID: "mg-001", Title: "Issue 1", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+		{ID: "mg-002", Title: "Issue 2", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+	}
+	d := NewDetail(80, 40, issues)
+	d.SetIssue(&issues[0])
+	d.SetCodeRefs(&data.CodeRefs{IssueID: "mg-001", Hits: []data.CodeHit{{Path: "a.go", Line: 1}}})
+
+	d.SetIssue(&issues[1])
+
+	if d.CodeRefs != nil || d.SelectedHit() != nil {
+		t.Error("code references should be cleared when switching issues")
+	}
+	if strings.Contains(d.renderContent(), "REFERENCES") {
+		t.Error("content should not contain REFERENCES after switching issues")
+	}
+}
+
 func TestFormulaRecommendationRendered(t *testing.T) {
 	issues := []data.Issue{
 		{ID: "bd-001", Title: "Add authentication middleware", Status: data.StatusOpen,
@@ -655,7 +742,7 @@ func TestCrossRigDepsRendered(t *testing.T) {
 	d := NewDetail(80, 40, issues)
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabDependencies)
 
 	if !strings.Contains(content, "CROSS-RIG") {
 		t.Error("content should contain CROSS-RIG section")
@@ -754,7 +841,7 @@ func TestMetadataSchemaRendered(t *testing.T) {
 	}
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabMetadata)
 
 	if !strings.Contains(content, "METADATA") {
 		t.Error("content should contain METADATA section")
@@ -782,13 +869,36 @@ func TestMetadataNotRenderedWithoutSchema(t *testing.T) {
 	d := NewDetail(80, 40, issues)
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabMetadata)
 
 	if strings.Contains(content, "METADATA") {
 		t.Error("content should not contain METADATA section without schema")
 	}
 }
 
+func TestMetadataViolationRendered(t *testing.T) {
+	issues := []data.Issue{
+		{ID: "mg-001", Title: "Bad Metadata", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask,
+			CreatedAt: time.Now(),
+			Metadata:  map[string]interface{}{"team": "qa"},
+		},
+	}
+	d := NewDetail(80, 40, issues)
+	d.MetadataSchema = &data.MetadataSchema{
+		Mode: "error",
+		Fields: map[string]data.MetadataFieldSchema{
+			"team": {Type: data.MetaEnum, Values: []string{"platform", "frontend"}},
+		},
+	}
+	d.SetIssue(&issues[0])
+
+	content := ansi.Strip(d.renderTab(TabMetadata))
+	if !strings.Contains(content, ui.SymInvalid+` "qa" not in enum[platform|frontend]`) {
+		t.Errorf("content should flag the invalid enum value, got:\n%s", content)
+	}
+}
+
 func TestMetadataWithIssueValues(t *testing.T) {
 	issues := []data.Issue{
 		{ID: "mg-001", Title: "With Metadata", Status: data.StatusOpen,
@@ -816,7 +926,7 @@ func TestMetadataWithIssueValues(t *testing.T) {
 	}
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabMetadata)
 
 	if !strings.Contains(content, "METADATA") {
 		t.Error("content should contain METADATA section")
@@ -842,7 +952,7 @@ func TestMetadataRawValuesWithoutSchema(t *testing.T) {
 	d := NewDetail(80, 40, issues)
 	d.SetIssue(&issues[0])
 
-	content := d.renderContent()
+	content := d.renderTab(TabMetadata)
 
 	if !strings.Contains(content, "METADATA") {
 		t.Error("content should contain METADATA section for raw metadata")
@@ -854,3 +964,59 @@ func TestMetadataRawValuesWithoutSchema(t *testing.T) {
 		t.Error("content should contain raw metadata value")
 	}
 }
+
+func TestDetailTabsHideEmpty(t *testing.T) {
+	issues := []data.Issue{
+		{ID: "mg-001", Title: "Blocker", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+		{ID: "mg-002", Title: "Blocked", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now(),
+			Dependencies: []data.Dependency{{IssueID: "mg-002", DependsOnID: "mg-001", Type: "blocks"}}},
+		{ID: "mg-003", Title: "Plain", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now()},
+	}
+	d := NewDetail(80, 40, issues)
+	d.SetIssue(&issues[2])
+
+	want := []DetailTab{TabOverview, TabActivity, TabComments}
+	if got := d.Tabs(); !slices.Equal(got, want) {
+		t.Errorf("tabs = %v, want %v", got, want)
+	}
+
+	// An empty fetch hides Comments, except while it is the open tab.
+	d.SetComments("mg-003", nil)
+	if got := d.Tabs(); slices.Contains(got, TabComments) {
+		t.Errorf("tabs = %v, want Comments hidden with no comments", got)
+	}
+	d.SetTab(TabComments)
+	if got := d.Tabs(); !slices.Contains(got, TabComments) {
+		t.Errorf("active Comments tab hidden: %v", got)
+	}
+
+	d.SetIssue(&issues[1])
+	d.SetTab(TabDependencies)
+	d.SetIssue(&issues[2])
+	if d.Tab != TabOverview {
+		t.Errorf("tab = %v, want Overview when the new issue has no dependencies", d.Tab)
+	}
+}
+
+func TestDetailTabsScrollIndependently(t *testing.T) {
+	issues := []data.Issue{
+		{ID: "mg-001", Title: "Long", Status: data.StatusOpen,
+			Priority: data.PriorityMedium, IssueType: data.TypeTask, CreatedAt: time.Now(),
+			Description: strings.Repeat("line\n\n", 40)},
+	}
+	d := NewDetail(80, 10, issues)
+	d.SetIssue(&issues[0])
+	d.Viewport.SetYOffset(5)
+
+	d.SetTab(TabActivity)
+	if d.Viewport.YOffset() != 0 {
+		t.Errorf("Activity offset = %d, want 0", d.Viewport.YOffset())
+	}
+	d.SetTab(TabOverview)
+	if d.Viewport.YOffset() != 5 {
+		t.Errorf("Overview offset = %d, want 5 restored", d.Viewport.YOffset())
+	}
+}
@@ -7,10 +7,12 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
-// Doctor renders the bd doctor diagnostics overlay in place of the detail pane.
+// Doctor renders the aggregated health report (bd doctor plus mg's own
+// source, bd and Gas Town checks) in place of the detail pane.
 type Doctor struct {
 	width  int
 	height int
@@ -51,18 +53,18 @@ func (d Doctor) Update(msg tea.Msg) (Doctor, tea.Cmd) {
 	}
 
 	last := len(d.result.Diagnostics) - 1
-	switch keyMsg.String() {
-	case "j", "down":
+	switch keymap.Lookup(keymap.Doctor, keyMsg.String()) {
+	case keymap.Down:
 		if d.cursor < last {
 			d.cursor++
 		}
-	case "k", "up":
+	case keymap.Up:
 		if d.cursor > 0 {
 			d.cursor--
 		}
-	case "g":
+	case keymap.Top:
 		d.cursor = 0
-	case "G":
+	case keymap.Bottom:
 		d.cursor = last
 	}
 
@@ -80,7 +82,7 @@ func (d Doctor) View() string {
 		lines = append(lines, headerStyle.Render("DIAGNOSTICS"))
 		lines = append(lines, "")
 		loadingStyle := lipgloss.NewStyle().Foreground(ui.Dim)
-		lines = append(lines, loadingStyle.Render("  Running bd doctor..."))
+		lines = append(lines, loadingStyle.Render("  Running health checks..."))
 	case d.result.OK:
 		lines = append(lines, headerStyle.Render("DIAGNOSTICS"))
 		lines = append(lines, "")
@@ -88,25 +90,21 @@ func (d Doctor) View() string {
 		lines = append(lines, okStyle.Render("  "+ui.SymResolved+" "+d.result.Summary))
 		lines = append(lines, "")
 		// Still show all checks even when OK
-		for i, diag := range d.result.Diagnostics {
-			lines = append(lines, d.renderDiagnostic(i, diag)...)
-			lines = append(lines, "")
-		}
+		lines = append(lines, d.renderDiagnostics()...)
 	default:
 		warnStyle := lipgloss.NewStyle().Foreground(ui.StatusStalled).Bold(true)
 		header := fmt.Sprintf("DIAGNOSTICS — %s", d.result.Summary)
 		lines = append(lines, warnStyle.Render(header))
 		lines = append(lines, "")
-
-		for i, diag := range d.result.Diagnostics {
-			lines = append(lines, d.renderDiagnostic(i, diag)...)
-			lines = append(lines, "")
-		}
+		lines = append(lines, d.renderDiagnostics()...)
 	}
 
 	// Hint bar
 	hintStyle := lipgloss.NewStyle().Foreground(ui.Dim)
-	lines = append(lines, hintStyle.Render("  D close  R refresh"))
+	lines = append(lines, hintStyle.Render("  "+keymap.HintBar(
+		keymap.NewHint(keymap.Global, "close", keymap.ToggleDoctor),
+		keymap.NewHint(keymap.Doctor, "refresh", keymap.Refresh),
+	)))
 
 	content := strings.Join(lines, "\n")
 
@@ -116,6 +114,23 @@ func (d Doctor) View() string {
 		Render(content)
 }
 
+// renderDiagnostics renders every diagnostic, with a header line each time
+// the category changes.
+func (d Doctor) renderDiagnostics() []string {
+	var lines []string
+	categoryStyle := lipgloss.NewStyle().Foreground(ui.Muted).Bold(true)
+	prev := ""
+	for i, diag := range d.result.Diagnostics {
+		if diag.Category != "" && diag.Category != prev {
+			lines = append(lines, categoryStyle.Render(diag.Category))
+			prev = diag.Category
+		}
+		lines = append(lines, d.renderDiagnostic(i, diag)...)
+		lines = append(lines, "")
+	}
+	return lines
+}
+
 func (d Doctor) renderDiagnostic(idx int, diag data.DoctorDiagnostic) []string {
 	var lines []string
 
@@ -139,18 +154,14 @@ func (d Doctor) renderDiagnostic(idx int, diag data.DoctorDiagnostic) []string {
 		prefix = ui.ItemCursor.Render(ui.Cursor) + " "
 	}
 
-	// Name + status + category
+	// Name + status (category is shown as a group header)
 	nameStyle := lipgloss.NewStyle().Foreground(ui.Light).Bold(true)
-	categoryStyle := lipgloss.NewStyle().Foreground(ui.Muted)
 
 	line1 := fmt.Sprintf("%s%s %s",
 		prefix,
 		statusStyle.Render(sym+" "+diag.Status),
 		nameStyle.Render(diag.Name),
 	)
-	if diag.Category != "" {
-		line1 += "  " + categoryStyle.Render(diag.Category)
-	}
 	lines = append(lines, line1)
 
 	// Explanation
@@ -9,6 +9,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
@@ -21,6 +22,27 @@ const (
 	SectionMail
 )
 
+// String returns the section's name: agents, convoys or mail.
+func (s GasTownSection) String() string {
+	switch s {
+	case SectionConvoys:
+		return "convoys"
+	case SectionMail:
+		return "mail"
+	}
+	return "agents"
+}
+
+// ParseGasTownSection maps a section name back to the section.
+func ParseGasTownSection(name string) (GasTownSection, bool) {
+	for _, s := range []GasTownSection{SectionAgents, SectionConvoys, SectionMail} {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return SectionAgents, false
+}
+
 // ActionType identifies a user action from the Gas Town panel.
 type ActionType string
 
@@ -275,6 +297,11 @@ func (g *GasTown) Section() GasTownSection {
 	return g.section
 }
 
+// SetSection focuses section s.
+func (g *GasTown) SetSection(s GasTownSection) {
+	g.section = s
+}
+
 // Update handles key messages for the Gas Town panel.
 // Returns a tea.Cmd when the panel wants to emit an action back to app.go.
 func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
@@ -283,8 +310,8 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 		return g, nil
 	}
 
-	switch km.String() {
-	case "tab":
+	switch keymap.Lookup(keymap.GasTown, km.String()) {
+	case keymap.NextSection:
 		// Cycle through sections: Agents → Convoys → Mail → Agents
 		switch g.section {
 		case SectionAgents:
@@ -304,23 +331,23 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 		}
 		return g, nil
 
-	case "j", "down":
+	case keymap.Down:
 		g.moveCursorDown()
 		return g, nil
 
-	case "k", "up":
+	case keymap.Up:
 		g.moveCursorUp()
 		return g, nil
 
-	case "g":
+	case keymap.Top:
 		g.jumpTop()
 		return g, nil
 
-	case "G":
+	case keymap.Bottom:
 		g.jumpBottom()
 		return g, nil
 
-	case "enter":
+	case keymap.Expand:
 		switch g.section {
 		case SectionConvoys:
 			if g.expandedConvoy == g.convoyCursor {
@@ -344,7 +371,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 		}
 		return g, nil
 
-	case "n":
+	case keymap.Nudge:
 		if g.section == SectionAgents {
 			if a := g.SelectedAgent(); a != nil {
 				agent := *a
@@ -354,7 +381,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "h":
+	case keymap.Handoff:
 		if g.section == SectionAgents {
 			if a := g.SelectedAgent(); a != nil {
 				agent := *a
@@ -364,7 +391,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "K":
+	case keymap.Decommission:
 		if g.section == SectionAgents {
 			if a := g.SelectedAgent(); a != nil && a.Role == "polecat" {
 				agent := *a
@@ -374,7 +401,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "l":
+	case keymap.Land:
 		if g.section == SectionConvoys {
 			if c := g.SelectedConvoy(); c != nil {
 				convoyID := c.ID
@@ -384,7 +411,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "x":
+	case keymap.CloseConvoy:
 		if g.section == SectionConvoys {
 			if c := g.SelectedConvoy(); c != nil {
 				convoyID := c.ID
@@ -394,7 +421,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "r":
+	case keymap.Reply:
 		if g.section == SectionMail {
 			if m := g.SelectedMail(); m != nil {
 				mail := *m
@@ -404,7 +431,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "d":
+	case keymap.Archive:
 		if g.section == SectionMail {
 			if m := g.SelectedMail(); m != nil {
 				mail := *m
@@ -414,14 +441,14 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "R":
+	case keymap.MarkAllRead:
 		if g.section == SectionMail {
 			return g, func() tea.Msg {
 				return GasTownActionMsg{Type: ActionMailMarkAllRead}
 			}
 		}
 
-	case "W":
+	case keymap.Unwatch:
 		if g.section == SectionConvoys {
 			if c := g.SelectedConvoy(); c != nil {
 				convoyID := c.ID
@@ -431,7 +458,7 @@ func (g GasTown) Update(msg tea.Msg) (GasTown, tea.Cmd) {
 			}
 		}
 
-	case "w":
+	case keymap.Watch:
 		switch g.section {
 		case SectionConvoys:
 			if c := g.SelectedConvoy(); c != nil {
@@ -536,6 +563,32 @@ func (g *GasTown) jumpBottom() {
 	}
 }
 
+// AgentAt returns the roster index of the agent drawn on panel row, or -1
+// when the row is not an agent.
+func (g *GasTown) AgentAt(row int) int {
+	if g.status == nil {
+		return -1
+	}
+	// Roster rows follow the town header, the AGENTS divider, the column
+	// headings and their rule.
+	header := renderTownHeader(g.env, g.status) + "\n" + ui.SectionDivider("AGENTS", g.width, false)
+	first := strings.Count(header, "\n") + 1 + 2
+	i := row + g.scrollOff - first
+	if row < 0 || i < 0 || i >= len(g.status.Agents) {
+		return -1
+	}
+	return i
+}
+
+// SelectAgent focuses the roster and moves its cursor to agent i.
+func (g *GasTown) SelectAgent(i int) {
+	if i < 0 || i >= g.AgentCount() {
+		return
+	}
+	g.section = SectionAgents
+	g.agentCursor = i
+}
+
 // ensureVisible adjusts scroll offset so the cursor row is on screen.
 func (g *GasTown) ensureVisible() {
 	visibleRows := max(g.height-12, 3)
@@ -678,7 +731,7 @@ func (g *GasTown) renderAgentRoster(width int) string {
 		nameW, "Name", roleW, "Role", stateW, "State", "Work")
 	lines = append(lines, headerStyle.Render(header))
 	lines = append(lines, lipgloss.NewStyle().Foreground(ui.Dim).Render(
-		"  "+strings.Repeat("─", width-4)))
+		"  "+strings.Repeat(ui.BoxHorizontal, width-4)))
 
 	for i, a := range agents {
 		isSelected := g.section == SectionAgents && i == g.agentCursor
@@ -1442,16 +1495,20 @@ func (g *GasTown) renderScorecards(width int) string {
 }
 
 func (g *GasTown) renderHints() string {
-	var hint string
+	h := func(desc string, actions ...keymap.Action) keymap.Hint {
+		return keymap.NewHint(keymap.GasTown, desc, actions...)
+	}
+	nav := []keymap.Hint{h("navigate", keymap.Down, keymap.Up), h("section", keymap.NextSection)}
+	var hints []keymap.Hint
 	switch g.section {
 	case SectionAgents:
-		hint = "n nudge  w mail  h handoff  K decommission  j/k navigate  tab section"
+		hints = []keymap.Hint{h("nudge", keymap.Nudge), h("mail", keymap.Watch), h("handoff", keymap.Handoff), h("decommission", keymap.Decommission)}
 	case SectionConvoys:
-		hint = "enter expand  l land  x close  w watch  W unwatch  j/k navigate  tab section"
+		hints = []keymap.Hint{h("expand", keymap.Expand), h("land", keymap.Land), h("close", keymap.CloseConvoy), h("watch", keymap.Watch), h("unwatch", keymap.Unwatch)}
 	case SectionMail:
-		hint = "enter read  r reply  w compose  d archive  R mark-all-read  j/k navigate  tab section"
+		hints = []keymap.Hint{h("read", keymap.Expand), h("reply", keymap.Reply), h("compose", keymap.Watch), h("archive", keymap.Archive), h("mark-all-read", keymap.MarkAllRead)}
 	}
-	return "\n" + ui.GasTownHint.Render(hint)
+	return "\n" + ui.GasTownHint.Render(keymap.HintBar(append(hints, nav...)...))
 }
 
 // progressBar renders a unicode block progress bar with per-block gradient coloring.
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
 )
 
@@ -1346,3 +1347,30 @@ func TestGasTownMailMarkAllReadInMailSection(t *testing.T) {
 		t.Fatalf("expected mail_mark_all_read, got %s", msg.Type)
 	}
 }
+
+func TestGasTownAgentAtMatchesView(t *testing.T) {
+	g := NewGasTown(100, 30)
+	status := &gastown.TownStatus{Agents: []gastown.AgentRuntime{
+		{Name: "alpha", Role: "polecat", State: "working"},
+		{Name: "bravo", Role: "polecat", State: "idle"},
+	}}
+	g.SetStatus(status, gastown.Env{Available: true, Role: "mayor"})
+
+	for row, line := range strings.Split(ansi.Strip(g.View()), "\n") {
+		if !strings.Contains(line, "bravo") {
+			continue
+		}
+		if got := g.AgentAt(row); got != 1 {
+			t.Fatalf("AgentAt(%d) = %d, want 1", row, got)
+		}
+		g.SelectAgent(1)
+		if a := g.SelectedAgent(); a == nil || a.Name != "bravo" {
+			t.Errorf("SelectAgent(1) selected %v", a)
+		}
+		if g.AgentAt(0) != -1 {
+			t.Error("header row should not be an agent")
+		}
+		return
+	}
+	t.Fatal("bravo not rendered")
+}
@@ -12,9 +12,13 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/matt-wright86/mardi-gras/internal/data"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
+// staleAge is the age at which an issue ID's heat color is fully stale.
+const staleAge = 30 * 24 * time.Hour
+
 // paradeSection defines how each parade group renders.
 type paradeSection struct {
 	Title          string
@@ -25,17 +29,31 @@ type paradeSection struct {
 	BorderVertical string
 }
 
-var sections = []paradeSection{
-	{Title: "Rolling", Symbol: ui.SymRolling, Style: ui.SectionRolling, Color: ui.StatusRolling, Status: data.ParadeRolling, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusRolling).Render(ui.BoxVertical)},
-	{Title: "Lined Up", Symbol: ui.SymLinedUp, Style: ui.SectionLinedUp, Color: ui.StatusLinedUp, Status: data.ParadeLinedUp, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusLinedUp).Render(ui.BoxVertical)},
-	{Title: "Stalled", Symbol: ui.SymStalled, Style: ui.SectionStalled, Color: ui.StatusStalled, Status: data.ParadeStalled, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusStalled).Render(ui.BoxVertical)},
-	{Title: "Past the Stand", Symbol: ui.SymPassed, Style: ui.SectionPassed, Color: ui.StatusPassed, Status: data.ParadePastTheStand, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusPassed).Render(ui.BoxVertical)},
+// sections are the parade sections in display order, rebuilt from the
+// palette whenever the theme changes.
+var sections []paradeSection
+
+func init() {
+	buildSections()
+	ui.OnThemeChange(buildSections)
+}
+
+func buildSections() {
+	sections = []paradeSection{
+		{Title: "Rolling", Symbol: ui.SymRolling, Style: ui.SectionRolling, Color: ui.StatusRolling, Status: data.ParadeRolling, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusRolling).Render(ui.BoxVertical)},
+		{Title: "Lined Up", Symbol: ui.SymLinedUp, Style: ui.SectionLinedUp, Color: ui.StatusLinedUp, Status: data.ParadeLinedUp, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusLinedUp).Render(ui.BoxVertical)},
+		{Title: "Stalled", Symbol: ui.SymStalled, Style: ui.SectionStalled, Color: ui.StatusStalled, Status: data.ParadeStalled, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusStalled).Render(ui.BoxVertical)},
+		{Title: "Past the Stand", Symbol: ui.SymPassed, Style: ui.SectionPassed, Color: ui.StatusPassed, Status: data.ParadePastTheStand, BorderVertical: lipgloss.NewStyle().Foreground(ui.StatusPassed).Render(ui.BoxVertical)},
+	}
 }
 
-// ParadeItem is a renderable entry — a section header, footer, or issue.
+// ParadeItem is a renderable entry — a section header, footer, or issue, or
+// the "N more" row of a section cut short by the row cap.
 type ParadeItem struct {
 	IsHeader   bool
 	IsFooter   bool
+	IsMore     bool
+	Hidden     int // issues behind an IsMore row
 	Section    paradeSection
 	Issue      *data.Issue
 	Eval       *data.DepEval
@@ -44,7 +62,7 @@ type ParadeItem struct {
 
 // isSelectable returns true if this item can receive the cursor.
 func (item ParadeItem) isSelectable() bool {
-	return !item.IsHeader && !item.IsFooter
+	return !item.IsHeader && !item.IsFooter && !item.IsMore
 }
 
 // Parade is the grouped issue list view.
@@ -52,6 +70,9 @@ type Parade struct {
 	Items           []ParadeItem
 	Cursor          int
 	ShowClosed      bool
+	Collapsed       map[data.ParadeStatus]bool // open sections folded to their header
+	RowCap          int                        // most issue rows per section; 0 is no cap
+	Revealed        map[data.ParadeStatus]bool // sections shown past RowCap
 	Width           int
 	Height          int
 	ScrollOffset    int
@@ -62,11 +83,14 @@ type Parade struct {
 	SelectedIssue   *data.Issue
 	ActiveAgents    map[string]string // issueID -> tmux window name
 	TownStatus      *gastown.TownStatus
-	ChangedIDs      map[string]bool  // recently changed issues (change indicator dot)
-	OrphanedIDs     map[string]bool  // orphaned issues from dead rigs
-	ZombieIDs       map[string]bool  // issues with dead agent sessions (zombie polecats)
-	Selected        map[string]bool  // multi-selected issue IDs
-	MatchHighlights map[string][]int // issueID -> matched char indices in title (fuzzy search)
+	ChangedIDs      map[string]bool              // recently changed issues (change indicator dot)
+	OrphanedIDs     map[string]bool              // orphaned issues from dead rigs
+	ZombieIDs       map[string]bool              // issues with dead agent sessions (zombie polecats)
+	InvalidIDs      map[string]string            // issues failing the metadata schema → severity
+	WorktreeIDs     map[string]bool              // issues with a git worktree → dirty
+	PullRequests    map[string]*data.PullRequest // issueID -> matched pull request
+	Selected        map[string]bool              // multi-selected issue IDs
+	MatchHighlights map[string][]int             // issueID -> matched char indices in title (fuzzy search)
 }
 
 // NewParade creates a parade view from a set of issues.
@@ -114,7 +138,10 @@ func NewParadeWithData(
 	return p
 }
 
-// rebuildItems flattens groups into the renderable item list.
+// rebuildItems flattens groups into the renderable item list. Collapsed
+// sections, and Past the Stand while closed issues are hidden, keep only
+// their header and footer; a section longer than RowCap ends in a "N more"
+// row until it is revealed.
 func (p *Parade) rebuildItems() {
 	p.Items = nil
 	for _, sec := range sections {
@@ -126,24 +153,12 @@ func (p *Parade) rebuildItems() {
 		// Header (top border)
 		p.Items = append(p.Items, ParadeItem{IsHeader: true, Section: sec})
 
-		// Closed section: show collapsed count or expanded list
-		if sec.Status == data.ParadePastTheStand {
-			if p.ShowClosed {
-				for i := range issues {
-					eval := issues[i].EvaluateDependencies(p.issueMap, p.blockingTypes)
-					ageDays := int(issues[i].Age().Hours() / 24)
-					agePct := min(ageDays*100/30, 100)
-					idStyle := ui.GradientHeat.At(agePct)
-					p.Items = append(p.Items, ParadeItem{
-						Issue:      &issues[i],
-						Section:    sec,
-						Eval:       &eval,
-						RenderedID: idStyle.Render(issues[i].ID),
-					})
-				}
+		if !p.folded(sec.Status) {
+			shown := issues
+			if p.capped(sec.Status) {
+				shown = issues[:p.RowCap]
 			}
-		} else {
-			for i := range issues {
+			for i := range shown {
 				eval := issues[i].EvaluateDependencies(p.issueMap, p.blockingTypes)
 				ageDays := int(issues[i].Age().Hours() / 24)
 				agePct := min(ageDays*100/30, 100)
@@ -155,6 +170,9 @@ func (p *Parade) rebuildItems() {
 					RenderedID: idStyle.Render(issues[i].ID),
 				})
 			}
+			if hidden := len(issues) - len(shown); hidden > 0 {
+				p.Items = append(p.Items, ParadeItem{IsMore: true, Hidden: hidden, Section: sec})
+			}
 		}
 
 		// Footer (bottom border)
@@ -162,6 +180,20 @@ func (p *Parade) rebuildItems() {
 	}
 }
 
+// folded reports whether a section shows only its header: Past the Stand
+// while closed issues are hidden, or a collapsed section.
+func (p *Parade) folded(status data.ParadeStatus) bool {
+	if status == data.ParadePastTheStand {
+		return !p.ShowClosed
+	}
+	return p.Collapsed[status]
+}
+
+// capped reports whether a section is cut short at RowCap.
+func (p *Parade) capped(status data.ParadeStatus) bool {
+	return p.RowCap > 0 && len(p.Groups[status]) > p.RowCap && !p.Revealed[status]
+}
+
 // MoveUp moves the cursor up, skipping headers and footers.
 func (p *Parade) MoveUp() {
 	for i := p.Cursor - 1; i >= 0; i-- {
@@ -189,10 +221,107 @@ func (p *Parade) MoveDown() {
 // ToggleClosed shows or hides closed issues.
 func (p *Parade) ToggleClosed() {
 	p.ShowClosed = !p.ShowClosed
+	p.rebuildKeepingCursor()
+}
+
+// ToggleSection collapses or expands a section. Past the Stand follows
+// ShowClosed, so it toggles the same way as ToggleClosed.
+func (p *Parade) ToggleSection(status data.ParadeStatus) {
+	if status == data.ParadePastTheStand {
+		p.ToggleClosed()
+		return
+	}
+	if p.Collapsed == nil {
+		p.Collapsed = make(map[data.ParadeStatus]bool)
+	}
+	p.Collapsed[status] = !p.Collapsed[status]
+	p.rebuildKeepingCursor()
+}
+
+// ExpandAll expands every collapsed section. Past the Stand still follows
+// ShowClosed.
+func (p *Parade) ExpandAll() {
+	p.Collapsed = nil
+	p.rebuildKeepingCursor()
+}
+
+// SetCollapsed replaces the set of collapsed sections.
+func (p *Parade) SetCollapsed(collapsed map[data.ParadeStatus]bool) {
+	p.SetFolding(collapsed, p.Revealed, p.RowCap)
+}
+
+// SetFolding replaces the collapsed and revealed sections and the row cap
+// in one rebuild.
+func (p *Parade) SetFolding(collapsed, revealed map[data.ParadeStatus]bool, rowCap int) {
+	p.Collapsed = collapsed
+	p.Revealed = revealed
+	p.RowCap = rowCap
+	p.rebuildKeepingCursor()
+}
+
+// ToggleRows shows every row of a section cut short by the row cap, or
+// caps it again. It reports false when the section is not long enough to
+// be capped.
+func (p *Parade) ToggleRows(status data.ParadeStatus) bool {
+	if p.RowCap <= 0 || len(p.Groups[status]) <= p.RowCap {
+		return false
+	}
+	if p.Revealed == nil {
+		p.Revealed = make(map[data.ParadeStatus]bool)
+	}
+	p.Revealed[status] = !p.Revealed[status]
+	p.rebuildKeepingCursor()
+	return true
+}
+
+// CursorSection returns the section the cursor is in, and false when the
+// parade is empty.
+func (p *Parade) CursorSection() (data.ParadeStatus, bool) {
+	if p.Cursor < 0 || p.Cursor >= len(p.Items) {
+		return 0, false
+	}
+	return p.Items[p.Cursor].Section.Status, true
+}
+
+// Reveal makes issueID's row visible when the row cap or a collapsed
+// section hides it, reporting whether it did. Closed issues stay hidden
+// while ShowClosed is off.
+func (p *Parade) Reveal(issueID string) bool {
+	for _, sec := range sections {
+		if sec.Status == data.ParadePastTheStand && !p.ShowClosed {
+			continue
+		}
+		for i, iss := range p.Groups[sec.Status] {
+			if iss.ID != issueID {
+				continue
+			}
+			if !p.Collapsed[sec.Status] && (p.RowCap <= 0 || i < p.RowCap || p.Revealed[sec.Status]) {
+				return false
+			}
+			if p.Collapsed[sec.Status] {
+				delete(p.Collapsed, sec.Status)
+			}
+			if p.RowCap > 0 && i >= p.RowCap {
+				if p.Revealed == nil {
+					p.Revealed = make(map[data.ParadeStatus]bool)
+				}
+				p.Revealed[sec.Status] = true
+			}
+			p.rebuildKeepingCursor()
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildKeepingCursor rebuilds the item list and keeps the cursor on the
+// selected issue, or the first selectable item when it is no longer shown.
+func (p *Parade) rebuildKeepingCursor() {
 	selectedID := ""
 	if p.SelectedIssue != nil {
 		selectedID = p.SelectedIssue.ID
 	}
+	oldCursor := p.Cursor
 	p.rebuildItems()
 	p.clampScroll()
 	// Restore cursor to the same issue if possible
@@ -204,16 +333,36 @@ func (p *Parade) ToggleClosed() {
 			return
 		}
 	}
-	// Fallback to first selectable item
-	for i, item := range p.Items {
-		if item.isSelectable() {
-			p.Cursor = i
-			p.SelectedIssue = item.Issue
-			p.ensureVisible()
-			return
+	// The issue was folded away: fall back to the nearest row
+	p.SelectNearest(oldCursor)
+}
+
+// MoveTop moves the cursor to the first issue row, scrolled to the top.
+func (p *Parade) MoveTop() {
+	p.ScrollOffset = 0
+	p.SelectNearest(0)
+}
+
+// MoveBottom moves the cursor to the last issue row.
+func (p *Parade) MoveBottom() {
+	p.SelectNearest(len(p.Items) - 1)
+}
+
+// SelectNearest moves the cursor to the issue row nearest to item hint,
+// looking up before down at each distance. With no issue rows at all the
+// selection is cleared.
+func (p *Parade) SelectNearest(hint int) {
+	hint = max(min(hint, len(p.Items)-1), 0)
+	for delta := 0; delta < len(p.Items); delta++ {
+		for _, i := range []int{hint - delta, hint + delta} {
+			if i >= 0 && i < len(p.Items) && p.Items[i].isSelectable() {
+				p.Cursor = i
+				p.SelectedIssue = p.Items[i].Issue
+				p.ensureVisible()
+				return
+			}
 		}
 	}
-	// No selectable items at all
 	p.Cursor = 0
 	p.ScrollOffset = 0
 	p.SelectedIssue = nil
@@ -233,6 +382,33 @@ func (p *Parade) clampScroll() {
 	}
 }
 
+// ItemAt returns the index of the item drawn on visible row, or -1 when the
+// row is past the end of the list.
+func (p *Parade) ItemAt(row int) int {
+	idx := p.ScrollOffset + row
+	if row < 0 || row >= p.Height || idx >= len(p.Items) {
+		return -1
+	}
+	return idx
+}
+
+// Select moves the cursor to item idx if it is an issue row.
+func (p *Parade) Select(idx int) bool {
+	if idx < 0 || idx >= len(p.Items) || !p.Items[idx].isSelectable() {
+		return false
+	}
+	p.Cursor = idx
+	p.SelectedIssue = p.Items[idx].Issue
+	p.ensureVisible()
+	return true
+}
+
+// ScrollBy scrolls the list by delta rows without moving the cursor.
+func (p *Parade) ScrollBy(delta int) {
+	p.ScrollOffset += delta
+	p.clampScroll()
+}
+
 // ensureVisible adjusts scroll offset so cursor is visible.
 func (p *Parade) ensureVisible() {
 	if p.Cursor < p.ScrollOffset {
@@ -319,6 +495,8 @@ func (p *Parade) View() string {
 			lines = append(lines, p.renderBorderTop(item.Section))
 		case item.IsFooter:
 			lines = append(lines, p.renderBorderBottom(item.Section))
+		case item.IsMore:
+			lines = append(lines, p.renderMore(item))
 		default:
 			dist := globalIdx - p.Cursor
 			if dist < 0 {
@@ -353,8 +531,11 @@ func (p *Parade) renderBorderTop(sec paradeSection) string {
 		}
 		titleText = fmt.Sprintf("%s %s %s%s", toggle, sec.Symbol, sec.Title, ui.Superscript(count))
 		if !p.ShowClosed {
-			titleText += " press c"
+			titleText += " press " + keymap.Label(keymap.Global, keymap.ToggleClosed)
 		}
+	} else if p.Collapsed[sec.Status] {
+		titleText = fmt.Sprintf("%s %s %s%s press %s", ui.Collapsed, sec.Symbol, sec.Title, ui.Superscript(count),
+			keymap.Label(keymap.Parade, keymap.ExpandSections))
 	} else {
 		titleText = fmt.Sprintf("%s %s%s", sec.Symbol, sec.Title, ui.Superscript(count))
 	}
@@ -404,6 +585,19 @@ func (p *Parade) renderBorderBottom(sec paradeSection) string {
 	return cornerL + fill + cornerR
 }
 
+// renderMore renders the row standing in for issues past the row cap:
+// │   + 37 more  press + │
+func (p *Parade) renderMore(item ParadeItem) string {
+	innerWidth := p.Width - 4
+	text := fmt.Sprintf("    + %d more  press %s", item.Hidden, keymap.Label(keymap.Parade, keymap.ToggleRows))
+	row := ansi.Truncate(text, innerWidth, "")
+	if padLen := innerWidth - lipgloss.Width(row); padLen > 0 {
+		row += strings.Repeat(" ", padLen)
+	}
+	content := lipgloss.NewStyle().Foreground(ui.Dim).Render(row)
+	return item.Section.BorderVertical + " " + content + " " + item.Section.BorderVertical
+}
+
 // renderIssue renders an issue row wrapped in │ section borders.
 // distFromCursor controls positional fading (btop-style depth effect).
 func (p *Parade) renderIssue(item ParadeItem, selected bool, distFromCursor int) string {
@@ -540,6 +734,58 @@ func (p *Parade) renderIssue(item ParadeItem, selected bool, distFromCursor int)
 		deferWidth = 2
 	}
 
+	// Metadata schema violation badge
+	invalidBadge := ""
+	invalidWidth := 0
+	if sev, ok := p.InvalidIDs[issue.ID]; ok {
+		color := ui.BrightGold
+		if sev == data.SeverityError {
+			color = ui.StatusStalled
+		}
+		label := ui.SymInvalid
+		if ui.Accessible() {
+			label += " " + sev
+		}
+		invalidBadge = " " + lipgloss.NewStyle().Foreground(color).Render(label)
+		invalidWidth = lipgloss.Width(invalidBadge)
+	}
+
+	// Worktree badge (gold when the worktree has uncommitted changes)
+	worktreeBadge := ""
+	worktreeWidth := 0
+	if dirty, ok := p.WorktreeIDs[issue.ID]; ok {
+		color := ui.Muted
+		if dirty {
+			color = ui.BrightGold
+		}
+		label := ui.SymWorktree
+		if dirty && ui.Accessible() {
+			label += " dirty"
+		}
+		worktreeBadge = " " + lipgloss.NewStyle().Foreground(color).Render(label)
+		worktreeWidth = lipgloss.Width(worktreeBadge)
+	}
+
+	// Pull request badge (#123, colored by state and CI)
+	prBadge := ""
+	prWidth := 0
+	if pr := p.PullRequests[issue.ID]; pr != nil {
+		label := fmt.Sprintf("#%d", pr.Number)
+		if ui.Accessible() {
+			label += prStateText(pr)
+		}
+		prBadge = " " + lipgloss.NewStyle().Foreground(ui.PRColor(pr.Label(), pr.Checks)).Render(label)
+		prWidth = lipgloss.Width(prBadge)
+	}
+
+	// Stale badge: in accessible mode the ID's heat color is lost, so say it
+	staleBadge := ""
+	staleWidth := 0
+	if ui.Accessible() && issue.Status != data.StatusClosed && issue.Age() >= staleAge {
+		staleBadge = " " + lipgloss.NewStyle().Foreground(ui.Muted).Render("stale")
+		staleWidth = lipgloss.Width(staleBadge)
+	}
+
 	// Build the "next blocker" hint for stalled issues
 	var rawHint string
 	hintStyle := lipgloss.NewStyle().Foreground(ui.Muted)
@@ -555,7 +801,7 @@ func (p *Parade) renderIssue(item ParadeItem, selected bool, distFromCursor int)
 	innerWidth := p.Width - 4 // │ + space + content + space + │
 
 	// First, constrain the hint length if the terminal is very narrow
-	maxHint := innerWidth - 16 - agentWidth - indentWidth - dueWidth - deferWidth - orphanWidth - zombieWidth
+	maxHint := innerWidth - 16 - agentWidth - indentWidth - dueWidth - deferWidth - invalidWidth - worktreeWidth - prWidth - staleWidth - orphanWidth - zombieWidth
 	if maxHint < 0 {
 		maxHint = 0
 	}
@@ -572,7 +818,7 @@ func (p *Parade) renderIssue(item ParadeItem, selected bool, distFromCursor int)
 	}
 
 	hintLen := lipgloss.Width(hint)
-	maxTitle := innerWidth - 16 - hintLen - agentWidth - changeWidth - selectWidth - indentWidth - dueWidth - deferWidth - orphanWidth - zombieWidth
+	maxTitle := innerWidth - 16 - hintLen - agentWidth - changeWidth - selectWidth - indentWidth - dueWidth - deferWidth - invalidWidth - worktreeWidth - prWidth - staleWidth - orphanWidth - zombieWidth
 	if maxTitle < 0 {
 		maxTitle = 0
 	}
@@ -611,7 +857,7 @@ func (p *Parade) renderIssue(item ParadeItem, selected bool, distFromCursor int)
 		renderedTitle,
 		prioStr,
 	)
-	line += dueBadge + deferBadge + hint
+	line += dueBadge + deferBadge + invalidBadge + worktreeBadge + prBadge + staleBadge + hint
 
 	leftBorder := sec.BorderVertical
 	rightBorder := sec.BorderVertical
@@ -676,3 +922,15 @@ func statusColor(issue *data.Issue, isBlocked bool) color.Color {
 		return ui.StatusLinedUp
 	}
 }
+
+// prStateText spells out what a pull request badge's color shows: the state
+// once it is no longer open, else the CI result.
+func prStateText(pr *data.PullRequest) string {
+	if label := pr.Label(); label != "open" {
+		return " " + label
+	}
+	if pr.Checks != "" {
+		return " " + pr.Checks
+	}
+	return ""
+}
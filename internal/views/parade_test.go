@@ -1,6 +1,7 @@
 package views
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/matt-wright86/mardi-gras/internal/data"
@@ -234,3 +235,148 @@ func TestEnsureVisible(t *testing.T) {
 		t.Fatalf("cursor %d not visible in viewport [%d, %d)", p.Cursor, p.ScrollOffset, p.ScrollOffset+p.Height)
 	}
 }
+
+func TestToggleSectionCollapses(t *testing.T) {
+	p := newTestParade()
+	before := len(p.Items)
+
+	p.ToggleSection(data.ParadeLinedUp)
+	if len(p.Items) != before-2 {
+		t.Fatalf("collapsing Lined Up should hide its 2 rows, got %d -> %d items", before, len(p.Items))
+	}
+	for _, item := range p.Items {
+		if item.Issue != nil && item.Section.Status == data.ParadeLinedUp {
+			t.Fatalf("collapsed section still shows %s", item.Issue.ID)
+		}
+	}
+	if p.SelectedIssue == nil || p.SelectedIssue.ID != "mg-001" {
+		t.Errorf("cursor should stay on mg-001, got %v", p.SelectedIssue)
+	}
+
+	p.ToggleSection(data.ParadeLinedUp)
+	if len(p.Items) != before {
+		t.Errorf("expanding should restore %d items, got %d", before, len(p.Items))
+	}
+
+	p.ToggleSection(data.ParadePastTheStand)
+	if !p.ShowClosed {
+		t.Error("Past the Stand header should toggle closed issues")
+	}
+}
+
+func TestRowCapAddsMoreRow(t *testing.T) {
+	p := newTestParade()
+	p.SetFolding(nil, nil, 1)
+
+	var lined []string
+	more := -1
+	for i, item := range p.Items {
+		if item.Section.Status != data.ParadeLinedUp {
+			continue
+		}
+		if item.Issue != nil {
+			lined = append(lined, item.Issue.ID)
+		}
+		if item.IsMore {
+			more = i
+			if item.Hidden != 1 {
+				t.Errorf("more row hides %d, want 1", item.Hidden)
+			}
+		}
+	}
+	if len(lined) != 1 || more < 0 {
+		t.Fatalf("capped Lined Up shows %v, more row at %d", lined, more)
+	}
+	if !strings.Contains(p.View(), "1 more") {
+		t.Error("view should show the more row")
+	}
+
+	// The cursor skips the more row; Past the Stand is folded, so the
+	// capped row is the last stop.
+	p.MoveDown()
+	p.MoveDown()
+	if p.Items[p.Cursor].IsMore || p.SelectedIssue.ID != lined[0] {
+		t.Errorf("cursor on item %d (%v), want %s", p.Cursor, p.SelectedIssue, lined[0])
+	}
+
+	if !p.ToggleRows(data.ParadeLinedUp) {
+		t.Fatal("ToggleRows should reveal the capped section")
+	}
+	for _, item := range p.Items {
+		if item.IsMore {
+			t.Fatal("revealed section still has a more row")
+		}
+	}
+	if p.ToggleRows(data.ParadeRolling) {
+		t.Error("Rolling has one issue and should not be capped")
+	}
+}
+
+func TestRevealShowsHiddenIssue(t *testing.T) {
+	p := newTestParade()
+	p.SetFolding(nil, nil, 1)
+	hidden := p.Groups[data.ParadeLinedUp][1].ID
+
+	if !p.Reveal(hidden) {
+		t.Fatalf("Reveal(%s) should lift the row cap", hidden)
+	}
+	if !p.Revealed[data.ParadeLinedUp] {
+		t.Error("Lined Up should be revealed")
+	}
+	if p.Reveal(hidden) {
+		t.Error("Reveal of a shown issue should report false")
+	}
+
+	p.ToggleSection(data.ParadeLinedUp)
+	if !p.Reveal(hidden) || p.Collapsed[data.ParadeLinedUp] {
+		t.Error("Reveal should expand the collapsed section")
+	}
+	if p.Reveal("mg-004") {
+		t.Error("closed issues stay hidden while ShowClosed is off")
+	}
+}
+
+func TestCollapseMovesCursorToNearestRow(t *testing.T) {
+	p := newTestParade()
+	p.MoveDown()
+	if p.SelectedIssue.Status != data.StatusOpen {
+		t.Fatalf("cursor on %s, want a Lined Up issue", p.SelectedIssue.ID)
+	}
+
+	p.ToggleSection(data.ParadeLinedUp)
+	if !p.Items[p.Cursor].isSelectable() || p.SelectedIssue.ID != "mg-001" {
+		t.Errorf("cursor on item %d (%v), want mg-001", p.Cursor, p.SelectedIssue)
+	}
+
+	p.ToggleSection(data.ParadeLinedUp)
+	p.MoveBottom()
+	if last := p.Groups[data.ParadeLinedUp][1].ID; p.SelectedIssue.ID != last {
+		t.Errorf("MoveBottom selected %s, want %s", p.SelectedIssue.ID, last)
+	}
+	p.MoveTop()
+	if p.SelectedIssue.ID != "mg-001" || p.ScrollOffset != 0 {
+		t.Errorf("MoveTop selected %s at offset %d", p.SelectedIssue.ID, p.ScrollOffset)
+	}
+}
+
+func TestItemAtAndSelect(t *testing.T) {
+	p := newTestParade()
+	p.Height = 4
+	p.ScrollBy(2)
+	if p.ScrollOffset != 2 {
+		t.Fatalf("ScrollOffset = %d, want 2", p.ScrollOffset)
+	}
+	idx := p.ItemAt(2)
+	if idx != 4 {
+		t.Fatalf("ItemAt(2) = %d, want 4", idx)
+	}
+	if p.ItemAt(4) != -1 {
+		t.Error("rows past the pane height should miss")
+	}
+	if !p.Select(idx) || p.SelectedIssue != p.Items[idx].Issue {
+		t.Error("Select should move the cursor to an issue row")
+	}
+	if p.Select(0) {
+		t.Error("headers are not selectable")
+	}
+}
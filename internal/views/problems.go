@@ -7,6 +7,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/matt-wright86/mardi-gras/internal/gastown"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
 	"github.com/matt-wright86/mardi-gras/internal/ui"
 )
 
@@ -58,32 +59,32 @@ func (p Problems) Update(msg tea.Msg) (Problems, tea.Cmd) {
 		return p, nil
 	}
 
-	switch keyMsg.String() {
-	case "j", "down":
+	switch keymap.Lookup(keymap.Problems, keyMsg.String()) {
+	case keymap.Down:
 		if p.cursor < len(p.problems)-1 {
 			p.cursor++
 		}
-	case "k", "up":
+	case keymap.Up:
 		if p.cursor > 0 {
 			p.cursor--
 		}
-	case "g":
+	case keymap.Top:
 		p.cursor = 0
-	case "G":
+	case keymap.Bottom:
 		p.cursor = len(p.problems) - 1
 
 	// Actions on selected problem's agent
-	case "n":
+	case keymap.Nudge:
 		a := p.problems[p.cursor].Agent
 		return p, func() tea.Msg {
 			return GasTownActionMsg{Type: "nudge", Agent: a}
 		}
-	case "h":
+	case keymap.Handoff:
 		a := p.problems[p.cursor].Agent
 		return p, func() tea.Msg {
 			return GasTownActionMsg{Type: "handoff", Agent: a}
 		}
-	case "K":
+	case keymap.Decommission:
 		a := p.problems[p.cursor].Agent
 		if a.Role != "polecat" {
 			return p, nil
@@ -91,7 +92,7 @@ func (p Problems) Update(msg tea.Msg) (Problems, tea.Cmd) {
 		return p, func() tea.Msg {
 			return GasTownActionMsg{Type: "decommission", Agent: a}
 		}
-	case "R":
+	case keymap.RecoverRig:
 		prob := p.problems[p.cursor]
 		if prob.Type != "dead_rig" {
 			return p, nil
@@ -135,10 +136,15 @@ func (p Problems) View() string {
 				break
 			}
 		}
-		hint := "  n nudge  h handoff  K decommission"
+		hints := []keymap.Hint{
+			keymap.NewHint(keymap.Problems, "nudge", keymap.Nudge),
+			keymap.NewHint(keymap.Problems, "handoff", keymap.Handoff),
+			keymap.NewHint(keymap.Problems, "decommission", keymap.Decommission),
+		}
 		if hasDeadRig {
-			hint += "  R recover rig"
+			hints = append(hints, keymap.NewHint(keymap.Problems, "recover rig", keymap.RecoverRig))
 		}
+		hint := "  " + keymap.HintBar(hints...)
 		lines = append(lines, hintStyle.Render(hint))
 	}
 
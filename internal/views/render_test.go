@@ -273,6 +273,32 @@ func TestRenderIssueChangedDot(t *testing.T) {
 	}
 }
 
+func TestRenderIssueInvalidBadge(t *testing.T) {
+	issues := []data.Issue{
+		testIssue("inv-1", data.StatusOpen),
+	}
+	p := NewParade(issues, 80, 20, data.DefaultBlockingTypes)
+
+	var item ParadeItem
+	for _, it := range p.Items {
+		if it.Issue != nil {
+			item = it
+			break
+		}
+	}
+	if item.Issue == nil {
+		t.Fatal("no selectable item found")
+	}
+
+	if out := p.renderIssue(item, false, 0); strings.Contains(out, ui.SymInvalid) {
+		t.Fatalf("renderIssue without InvalidIDs should not contain %q", ui.SymInvalid)
+	}
+	p.InvalidIDs = map[string]string{"inv-1": data.SeverityError}
+	if out := p.renderIssue(item, false, 0); !strings.Contains(out, ui.SymInvalid) {
+		t.Fatalf("renderIssue with InvalidIDs should contain %q, got: %s", ui.SymInvalid, out)
+	}
+}
+
 func TestRenderIssueOrphanBadge(t *testing.T) {
 	issues := []data.Issue{
 		testIssue("orph-1", data.StatusInProgress),
@@ -414,7 +440,7 @@ func TestDetailRenderContentDependencies(t *testing.T) {
 		IssueMap:      issueMap,
 		AllIssues:     allIssues,
 	}
-	out := d.renderContent()
+	out := d.renderTab(TabDependencies)
 
 	if !strings.Contains(out, "DEPENDENCIES") {
 		t.Fatalf("renderContent for blocked issue should contain 'DEPENDENCIES', got: %s", out)
@@ -569,7 +595,7 @@ func TestDetailRenderContentRichDeps(t *testing.T) {
 		IssueMap:      issueMap,
 		AllIssues:     allIssues,
 	}
-	out := d.renderContent()
+	out := d.renderTab(TabDependencies)
 	if !strings.Contains(out, ui.SymRelated) {
 		t.Fatalf("renderContent with related dep should contain %q, got: %s", ui.SymRelated, out)
 	}
@@ -627,3 +653,55 @@ func TestDetailRenderContentOwnerAssignee(t *testing.T) {
 		t.Fatalf("renderContent should contain 'Assignee:', got: %s", out)
 	}
 }
+
+func TestRenderIssueWorktreeBadge(t *testing.T) {
+	issues := []data.Issue{
+		testIssue("wt-1", data.StatusOpen),
+	}
+	p := NewParade(issues, 80, 20, data.DefaultBlockingTypes)
+
+	var item ParadeItem
+	for _, it := range p.Items {
+		if it.Issue != nil {
+			item = it
+			break
+		}
+	}
+	if item.Issue == nil {
+		t.Fatal("no selectable item found")
+	}
+
+	if out := p.renderIssue(item, false, 0); strings.Contains(out, ui.SymWorktree) {
+		t.Fatalf("renderIssue without WorktreeIDs should not contain %q", ui.SymWorktree)
+	}
+	p.WorktreeIDs = map[string]bool{"wt-1": true}
+	if out := p.renderIssue(item, false, 0); !strings.Contains(out, ui.SymWorktree) {
+		t.Fatalf("renderIssue with WorktreeIDs should contain %q, got: %s", ui.SymWorktree, out)
+	}
+}
+
+func TestRenderIssuePRBadge(t *testing.T) {
+	issues := []data.Issue{
+		testIssue("pr-1", data.StatusOpen),
+	}
+	p := NewParade(issues, 80, 20, data.DefaultBlockingTypes)
+
+	var item ParadeItem
+	for _, it := range p.Items {
+		if it.Issue != nil {
+			item = it
+			break
+		}
+	}
+	if item.Issue == nil {
+		t.Fatal("no selectable item found")
+	}
+
+	if out := p.renderIssue(item, false, 0); strings.Contains(out, "#42") {
+		t.Fatal("renderIssue without PullRequests should not contain a PR badge")
+	}
+	p.PullRequests = map[string]*data.PullRequest{"pr-1": {Number: 42, State: data.PRStateOpen}}
+	if out := p.renderIssue(item, false, 0); !strings.Contains(out, "#42") {
+		t.Fatalf("renderIssue with PullRequests should contain #42, got: %s", out)
+	}
+}
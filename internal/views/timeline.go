@@ -0,0 +1,326 @@
+package views
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+	"github.com/matt-wright86/mardi-gras/internal/keymap"
+	"github.com/matt-wright86/mardi-gras/internal/ui"
+)
+
+// TimelineScale is the time span of one timeline column.
+type TimelineScale int
+
+const (
+	TimelineDays  TimelineScale = iota // one column per day
+	TimelineWeeks                      // one column per week
+)
+
+// String returns the scale name shown in the panel header.
+func (s TimelineScale) String() string {
+	if s == TimelineWeeks {
+		return "week"
+	}
+	return "day"
+}
+
+// timelineLabelW is the width of the issue ID column left of the bars.
+const timelineLabelW = 12
+
+// Timeline renders the parade's rows as bars on a day or week axis: each
+// issue runs from its start (or creation) to its close (or now), with due
+// markers, shaded defer windows and arrows from blockers that end earlier.
+// It shares the parade's filter, grouping and cursor.
+type Timeline struct {
+	width    int
+	height   int
+	scale    TimelineScale
+	pan      int // columns panned from the default window, negative is earlier
+	items    []ParadeItem
+	cursor   int
+	issueMap map[string]*data.Issue
+
+	// Now is the reference time for open bars; zero means time.Now.
+	Now time.Time
+}
+
+// NewTimeline creates a Timeline panel at day scale.
+func NewTimeline(width, height int) Timeline {
+	return Timeline{width: width, height: height}
+}
+
+// SetSize updates dimensions.
+func (t *Timeline) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+}
+
+// SetParade copies the parade's rows and cursor.
+func (t *Timeline) SetParade(p *Parade) {
+	t.items = p.Items
+	t.cursor = p.Cursor
+	t.issueMap = p.issueMap
+}
+
+// Scale returns the current zoom level.
+func (t *Timeline) Scale() TimelineScale {
+	return t.scale
+}
+
+// ZoomIn switches to the day scale.
+func (t *Timeline) ZoomIn() {
+	t.scale = TimelineDays
+	t.pan = 0
+}
+
+// ZoomOut switches to the week scale.
+func (t *Timeline) ZoomOut() {
+	t.scale = TimelineWeeks
+	t.pan = 0
+}
+
+// Pan shifts the window by a quarter of its width per step; negative steps
+// move earlier in time.
+func (t *Timeline) Pan(steps int) {
+	t.pan += steps * max(t.barWidth()/4, 1)
+}
+
+// Today recenters the window on the current date.
+func (t *Timeline) Today() {
+	t.pan = 0
+}
+
+func (t Timeline) now() time.Time {
+	if t.Now.IsZero() {
+		return time.Now()
+	}
+	return t.Now
+}
+
+// barWidth is the number of time columns right of the label column.
+func (t Timeline) barWidth() int {
+	return max(t.width-3-timelineLabelW, 10)
+}
+
+// unitStart truncates ts to the start of its column: local midnight, or the
+// Monday of its week.
+func (t Timeline) unitStart(ts time.Time) time.Time {
+	ts = ts.In(time.Local)
+	day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.Local)
+	if t.scale == TimelineWeeks {
+		day = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+// origin is the date of the first column. Today sits two thirds of the way
+// across before panning.
+func (t Timeline) origin() time.Time {
+	back := t.barWidth()*2/3 - t.pan
+	if t.scale == TimelineWeeks {
+		return t.unitStart(t.now()).AddDate(0, 0, -7*back)
+	}
+	return t.unitStart(t.now()).AddDate(0, 0, -back)
+}
+
+// col returns the column of ts, which may fall outside [0, barWidth).
+func (t Timeline) col(origin, ts time.Time) int {
+	days := int(t.unitStart(ts).Sub(origin).Round(24*time.Hour) / (24 * time.Hour))
+	if t.scale == TimelineWeeks {
+		return days / 7
+	}
+	return days
+}
+
+// timelineCell is one column of a row.
+type timelineCell struct {
+	sym   string
+	color color.Color
+}
+
+// View renders the timeline panel.
+func (t Timeline) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.BrightGold)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.Dim)
+
+	origin := t.origin()
+	lines := []string{
+		headerStyle.Render("TIMELINE") + dimStyle.Render(" · "+t.scale.String()+" scale · "+origin.Format("Jan 2")+" onward"),
+		strings.Repeat(" ", timelineLabelW) + t.renderAxis(origin),
+	}
+
+	// Footers carry no information here; headers become section titles.
+	var rows []int
+	cursorRow := 0
+	for i, item := range t.items {
+		if item.IsFooter {
+			continue
+		}
+		if i == t.cursor {
+			cursorRow = len(rows)
+		}
+		rows = append(rows, i)
+	}
+
+	visible := max(t.height-4, 1)
+	offset := 0
+	if cursorRow >= visible {
+		offset = cursorRow - visible + 1
+	}
+	if len(rows) == 0 {
+		lines = append(lines, dimStyle.Render("  No issues to plot"))
+	}
+	for _, i := range rows[offset:min(offset+visible, len(rows))] {
+		item := t.items[i]
+		if item.IsHeader {
+			lines = append(lines, item.Section.Style.Render(item.Section.Symbol+" "+item.Section.Title))
+			continue
+		}
+		lines = append(lines, t.renderRow(origin, item, i == t.cursor))
+	}
+
+	for len(lines) < t.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, dimStyle.Render("  "+keymap.HintBar(
+		keymap.NewHint(keymap.Timeline, "zoom", keymap.ZoomIn, keymap.ZoomOut),
+		keymap.NewHint(keymap.Timeline, "pan", keymap.Left, keymap.Right),
+		keymap.NewHint(keymap.Timeline, "today", keymap.Today),
+		keymap.NewHint(keymap.Global, "close", keymap.ToggleTimeline),
+	)))
+
+	return ui.DetailBorder.
+		Width(t.width).
+		Height(t.height).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderAxis labels the first column of every week (day scale) or month
+// (week scale), skipping labels that would overlap.
+func (t Timeline) renderAxis(origin time.Time) string {
+	w := t.barWidth()
+	axis := []rune(strings.Repeat(" ", w))
+	next := 0
+	for c := 0; c < w; c++ {
+		var label string
+		if t.scale == TimelineWeeks {
+			start := origin.AddDate(0, 0, 7*c)
+			if start.AddDate(0, 0, 6).Month() != start.Month() || c == 0 {
+				label = start.AddDate(0, 0, 6).Format("Jan")
+			}
+		} else {
+			day := origin.AddDate(0, 0, c)
+			if day.Weekday() == time.Monday {
+				label = day.Format("Jan 2")
+			}
+		}
+		if label == "" || c < next || c+len(label) > w {
+			continue
+		}
+		copy(axis[c:], []rune(label))
+		next = c + len(label) + 1
+	}
+	return lipgloss.NewStyle().Foreground(ui.Muted).Render(string(axis))
+}
+
+// renderRow draws one issue: its label, bar, defer window, due marker,
+// blocker arrows and the today rule.
+func (t Timeline) renderRow(origin time.Time, item ParadeItem, selected bool) string {
+	issue := item.Issue
+	now := t.now()
+	w := t.barWidth()
+	cells := make([]timelineCell, w)
+	set := func(c int, sym string, clr color.Color) {
+		if c >= 0 && c < w {
+			cells[c] = timelineCell{sym: sym, color: clr}
+		}
+	}
+
+	start := issue.CreatedAt
+	if issue.StartedAt != nil {
+		start = *issue.StartedAt
+	}
+	end := now
+	if issue.ClosedAt != nil {
+		end = *issue.ClosedAt
+	}
+	barColor := item.Section.Color
+	if issue.IsOverdue() {
+		barColor = ui.StatusStalled
+	}
+	startCol, endCol := t.col(origin, start), t.col(origin, end)
+	for c := max(startCol, 0); c <= min(endCol, w-1); c++ {
+		set(c, ui.DividerH, barColor)
+	}
+
+	if issue.DeferUntil != nil && issue.DeferUntil.After(now) && issue.Status != data.StatusClosed {
+		for c := max(endCol+1, 0); c <= min(t.col(origin, *issue.DeferUntil), w-1); c++ {
+			set(c, ui.SymProgressEmpty, ui.Dim)
+		}
+	}
+	if issue.DueAt != nil {
+		dueColor := ui.BrightGold
+		if issue.IsOverdue() {
+			dueColor = ui.StatusStalled
+		}
+		set(t.col(origin, *issue.DueAt), ui.SymDiamond, dueColor)
+	}
+	if item.Eval != nil {
+		t.drawArrows(origin, cells, item.Eval, startCol)
+	}
+	if today := t.col(origin, now); today >= 0 && today < w && cells[today].sym == "" {
+		cells[today] = timelineCell{sym: ui.SymToday, color: ui.DimPurple}
+	}
+
+	var b strings.Builder
+	for _, cell := range cells {
+		if cell.sym == "" {
+			b.WriteByte(' ')
+			continue
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(cell.color).Render(cell.sym))
+	}
+
+	label := "  " + ansi.Truncate(issue.ID, timelineLabelW-3, "…")
+	labelStyle := lipgloss.NewStyle().Foreground(ui.Light)
+	if selected {
+		label = ui.Cursor + " " + ansi.Truncate(issue.ID, timelineLabelW-3, "…")
+		labelStyle = ui.ItemCursor
+	}
+	return labelStyle.Render(fmt.Sprintf("%-*s", timelineLabelW, label)) + b.String()
+}
+
+// drawArrows connects each blocker that ends before this bar starts with a
+// ╶──▸ run across the gap, when the gap is empty and at least two columns
+// wide.
+func (t Timeline) drawArrows(origin time.Time, cells []timelineCell, eval *data.DepEval, startCol int) {
+	blockers := append(append([]string(nil), eval.BlockingIDs...), eval.ResolvedIDs...)
+	for _, id := range blockers {
+		blocker := t.issueMap[id]
+		if blocker == nil || blocker.ClosedAt == nil {
+			continue
+		}
+		from := max(t.col(origin, *blocker.ClosedAt)+1, 0)
+		to := min(startCol-1, len(cells)-1)
+		if to-from < 1 {
+			continue
+		}
+		free := true
+		for c := from; c <= to; c++ {
+			free = free && cells[c].sym == ""
+		}
+		if !free {
+			continue
+		}
+		for c := from; c <= to; c++ {
+			cells[c] = timelineCell{sym: ui.BoxHorizontal, color: ui.Muted}
+		}
+		cells[from].sym = ui.SymArrowTail
+		cells[to].sym = ui.SymArrowHead
+	}
+}
@@ -0,0 +1,108 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/matt-wright86/mardi-gras/internal/data"
+)
+
+func timelineFixture(now time.Time) []data.Issue {
+	day := 24 * time.Hour
+	ago := func(days int) *time.Time {
+		t := now.Add(-time.Duration(days) * day)
+		return &t
+	}
+	return []data.Issue{
+		{ID: "tl-1", Title: "Blocker", Status: data.StatusClosed, CreatedAt: *ago(12), ClosedAt: ago(9)},
+		{ID: "tl-2", Title: "Dependent", Status: data.StatusInProgress, CreatedAt: *ago(12), StartedAt: ago(4),
+			Dependencies: []data.Dependency{{IssueID: "tl-2", DependsOnID: "tl-1", Type: "blocks"}}},
+		{ID: "tl-3", Title: "Late", Status: data.StatusOpen, CreatedAt: *ago(6), DueAt: ago(2)},
+		{ID: "tl-4", Title: "Parked", Status: data.StatusOpen, CreatedAt: *ago(3), DeferUntil: ago(-5)},
+	}
+}
+
+func newTestTimeline(t *testing.T) (Timeline, *Parade) {
+	t.Helper()
+	now := time.Now()
+	p := NewParade(timelineFixture(now), 40, 20, data.DefaultBlockingTypes)
+	p.ToggleClosed()
+	tl := NewTimeline(80, 20)
+	tl.Now = now
+	tl.SetParade(&p)
+	return tl, &p
+}
+
+// timelineRow returns the plain text of the row for id.
+func timelineRow(t *testing.T, view, id string) string {
+	t.Helper()
+	for _, line := range strings.Split(ansi.Strip(view), "\n") {
+		if strings.Contains(line, id) {
+			return line
+		}
+	}
+	t.Fatalf("no row for %s in:\n%s", id, ansi.Strip(view))
+	return ""
+}
+
+func TestTimelineBars(t *testing.T) {
+	tl, _ := newTestTimeline(t)
+	view := tl.View()
+
+	if !strings.Contains(view, "TIMELINE") || !strings.Contains(view, "day scale") {
+		t.Fatal("header should name the view and scale")
+	}
+	if got := strings.Count(timelineRow(t, view, "tl-1"), "━"); got != 4 {
+		t.Errorf("tl-1 bar = %d days, want 4 (created to closed)", got)
+	}
+	if row := timelineRow(t, view, "tl-3"); !strings.Contains(row, "◆") {
+		t.Errorf("tl-3 should show a due marker: %q", row)
+	}
+	if row := timelineRow(t, view, "tl-4"); strings.Count(row, "░") != 5 {
+		t.Errorf("tl-4 should shade 5 deferred days: %q", row)
+	}
+	if row := timelineRow(t, view, "tl-2"); !strings.Contains(row, "▸━") {
+		t.Errorf("tl-2 should have an arrow from its closed blocker: %q", row)
+	}
+}
+
+func TestTimelineFollowsParadeCursor(t *testing.T) {
+	tl, p := newTestTimeline(t)
+	p.MoveDown()
+	tl.SetParade(p)
+	row := timelineRow(t, tl.View(), p.SelectedIssue.ID)
+	if !strings.HasPrefix(strings.TrimLeft(row, "│ "), ">") {
+		t.Errorf("selected row should carry the cursor: %q", row)
+	}
+}
+
+func TestTimelineZoomAndPan(t *testing.T) {
+	tl, _ := newTestTimeline(t)
+	day := tl.origin()
+
+	tl.Pan(-1)
+	if !tl.origin().Before(day) {
+		t.Error("panning left should move the window earlier")
+	}
+	tl.Today()
+	if !tl.origin().Equal(day) {
+		t.Error("Today should restore the default window")
+	}
+
+	tl.ZoomOut()
+	if tl.Scale() != TimelineWeeks {
+		t.Fatalf("scale = %v, want week", tl.Scale())
+	}
+	if tl.origin().Weekday() != time.Monday {
+		t.Errorf("week columns should start on Monday, got %v", tl.origin().Weekday())
+	}
+	if !strings.Contains(tl.View(), "week scale") {
+		t.Error("header should show the week scale")
+	}
+	tl.ZoomIn()
+	if tl.Scale() != TimelineDays {
+		t.Errorf("scale = %v, want day", tl.Scale())
+	}
+}